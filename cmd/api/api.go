@@ -0,0 +1,383 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package api sends the requests of the generated commands of the dcim, circuits, core, vpn and
+// wireless palettes. A Request is read from the flags of a command every time it runs, so nothing
+// carries over from one command to the next when several run in one process, as in the shell, and
+// every failure is returned as an error instead of ending the process.
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/decassidy/abc-netbox-cli/cmd/refs"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/go-resty/resty/v2"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Request is one run of a generated command: the values of its flags and the Netbox server they select.
+type Request struct {
+	// Env is the environment ('development' or 'production') or profile of --env.
+	Env string
+	// ID is the object of a by-ID command. Without --id it is resolved from Name, Slug, Serial or Lookup.
+	ID int
+	// Name, Slug, Serial and Lookup identify the object of a by-ID command instead of --id.
+	Name   string
+	Slug   string
+	Serial string
+	Lookup string
+	// Data is the JSON payload of --data.
+	Data string
+	// Query is the search term of --query.
+	Query string
+	// DryRun prints the resolved payload of a create or update instead of sending it.
+	DryRun bool
+	// CustomFields are the --cf name=value pairs merged into the custom_fields of the payload.
+	CustomFields []string
+	// CustomFieldFilters are the --cf-filter name=value pairs a list is filtered by.
+	CustomFieldFilters []string
+
+	// ctx is the context of the command; the requests stop when it is done.
+	ctx     context.Context
+	config  *viper.Viper
+	rootURL string
+}
+
+// NewRequest reads the flags of c and the configuration of the Netbox server --env selects.
+// Flags c does not have are left at their zero value.
+func NewRequest(c *cobra.Command) (*Request, error) {
+	flags := c.Flags()
+	r := &Request{ctx: c.Context()}
+	if r.ctx == nil {
+		r.ctx = context.Background()
+	}
+	r.Env, _ = flags.GetString("env")
+	if r.Env == "" {
+		// A few commands have no --env flag and always use the default environment.
+		r.Env = "development"
+	}
+	r.ID, _ = flags.GetInt("id")
+	r.Name, _ = flags.GetString("name")
+	r.Slug, _ = flags.GetString("slug")
+	r.Serial, _ = flags.GetString("serial")
+	r.Lookup, _ = flags.GetString("lookup")
+	r.Data, _ = flags.GetString("data")
+	r.Query, _ = flags.GetString("query")
+	r.DryRun, _ = flags.GetBool("dry-run")
+	r.CustomFields, _ = flags.GetStringArray("cf")
+	r.CustomFieldFilters, _ = flags.GetStringArray("cf-filter")
+
+	config, err := session.Config()
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %s", err)
+	}
+	r.config = config
+	r.rootURL, err = session.RootURL(r.Env)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RootURL returns the root URL of the Netbox API the request is sent to.
+func (r *Request) RootURL() string {
+	return r.rootURL
+}
+
+// token returns the value of the Authorization header.
+func (r *Request) token() string {
+	return r.config.GetString("cmd.token_key")
+}
+
+// objectURL returns the URL of the object of a by-ID command on the endpoint with config key suffix,
+// resolving its ID first when it was identified by --name, --slug, --serial or --lookup.
+func (r *Request) objectURL(suffix string) (string, error) {
+	if err := r.resolveLookup(suffix); err != nil {
+		return "", err
+	}
+	return r.rootURL + r.config.GetString(suffix) + strconv.Itoa(r.ID) + "/", nil
+}
+
+// Get gets the object of a by-ID command into object.
+func (r *Request) Get(object interface{}, suffix string) error {
+	fullAPIPath, err := r.objectURL(suffix)
+	if err != nil {
+		return err
+	}
+	color.Yellow("\n  Getting Netbox API object from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.get(fullAPIPath, object)
+}
+
+// List gets the first page of the endpoint with config key suffix into object, filtered by --cf-filter.
+func (r *Request) List(object interface{}, suffix string) error {
+	fullAPIPath, err := customfields.AddFilters(r.rootURL+r.config.GetString(suffix), r.CustomFieldFilters)
+	if err != nil {
+		return fmt.Errorf("invalid --cf-filter: %s", err)
+	}
+	color.Yellow("\n  Getting Netbox API objects from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.get(fullAPIPath, object)
+}
+
+// NextPage gets the page of a list at next, the URL Netbox returned for it, into object.
+func (r *Request) NextPage(object interface{}, next string) error {
+	color.Yellow("\n  Getting Netbox API objects from %s\n", next)
+	r.checkSSL()
+	return r.get(next, object)
+}
+
+// BySerial gets the objects with the serial number of --serial into object.
+func (r *Request) BySerial(object interface{}, suffix string) error {
+	fullAPIPath := r.rootURL + r.config.GetString(suffix) + r.Serial
+	color.Yellow("\n  Getting Netbox API objects from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.get(fullAPIPath, object)
+}
+
+// Search gets the objects matching --query into object.
+func (r *Request) Search(object interface{}, suffix string) error {
+	fullAPIPath := r.rootURL + r.config.GetString(suffix) + "?q=" + r.Query
+	color.Yellow("\n  Getting Netbox API objects from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.get(fullAPIPath, object)
+}
+
+// Post creates the objects of --data on the endpoint with config key suffix.
+func (r *Request) Post(suffix string) error {
+	fullAPIPath := r.rootURL + r.config.GetString(suffix)
+	color.Yellow("\n  Posting Netbox API objects in %s\n", fullAPIPath)
+	r.checkSSL()
+
+	if done, err := r.prepareData(suffix, "POST", fullAPIPath); done || err != nil {
+		return err
+	}
+	resp, err := r.send("POST", fullAPIPath, r.Data)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 201 {
+		return unhandledStatus(resp)
+	}
+	fmt.Println(color.GreenString("  Successfully Posted data for: " + color.YellowString("%s\n", fullAPIPath)))
+	return nil
+}
+
+// Patch updates the objects of --data, each with its id, on the endpoint with config key suffix.
+func (r *Request) Patch(suffix string) error {
+	fullAPIPath := r.rootURL + r.config.GetString(suffix)
+	color.Yellow("\n  Patching Netbox API objects in %s\n", fullAPIPath)
+	r.checkSSL()
+
+	if done, err := r.prepareData(suffix, "PATCH", fullAPIPath); done || err != nil {
+		return err
+	}
+	resp, err := r.send("PATCH", fullAPIPath, r.Data)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return unhandledStatus(resp)
+	}
+	fmt.Println(color.GreenString("  Successfully Patched data for: " + color.YellowString("%s\n", fullAPIPath)))
+	return nil
+}
+
+// PatchID updates the object of a by-ID command with --data.
+func (r *Request) PatchID(suffix string) error {
+	fullAPIPath, err := r.objectURL(suffix)
+	if err != nil {
+		return err
+	}
+	color.Yellow("\n  Patching Netbox API object from %s\n", fullAPIPath)
+	r.checkSSL()
+
+	if done, err := r.prepareData(suffix, "PATCH", fullAPIPath); done || err != nil {
+		return err
+	}
+	resp, err := r.send("PATCH", fullAPIPath, r.Data)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return unhandledStatus(resp)
+	}
+	fmt.Println(color.GreenString("  Successfully patched ID: " + color.YellowString("%d\n", r.ID)))
+	return nil
+}
+
+// Delete deletes the objects of --data, each given by its id, from the endpoint with config key suffix.
+func (r *Request) Delete(suffix string) error {
+	fullAPIPath := r.rootURL + r.config.GetString(suffix)
+	color.Yellow("\n  Deleting Netbox API object from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.delete(fullAPIPath, r.Data)
+}
+
+// DeleteID deletes the object of a by-ID command.
+func (r *Request) DeleteID(suffix string) error {
+	fullAPIPath, err := r.objectURL(suffix)
+	if err != nil {
+		return err
+	}
+	color.Yellow("\n  Deleting Netbox API object from %s\n", fullAPIPath)
+	r.checkSSL()
+	return r.delete(fullAPIPath, "")
+}
+
+func (r *Request) delete(fullAPIPath string, body string) error {
+	resp, err := r.send("DELETE", fullAPIPath, body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode() {
+	case 204:
+		fmt.Println(color.GreenString("  Successfully deleted."))
+	case 404:
+		fmt.Println(color.BlueString("  No such object on Netbox server."))
+	case 409:
+		if body != "" {
+			fmt.Printf(color.RedString("  Dependency Error: there is a conflict with the objects - HTTP Status Code: "+color.YellowString("%v\n")), resp.Status())
+		} else {
+			fmt.Printf(color.RedString("  Dependency Error: there is a conflict with ID: "+color.YellowString("%d - HTTP Status Code: %v\n")), r.ID, resp.Status())
+		}
+	default:
+		return unhandledStatus(resp)
+	}
+	fmt.Println(resp)
+	return nil
+}
+
+// get gets fullAPIPath and decodes the response into object.
+func (r *Request) get(fullAPIPath string, object interface{}) error {
+	resp, err := r.send("GET", fullAPIPath, "")
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp.Body(), object); err != nil {
+		return fmt.Errorf("parsing the response bytes: %s", err)
+	}
+	return nil
+}
+
+// send sends a request with body, if not empty, to fullAPIPath.
+func (r *Request) send(method string, fullAPIPath string, body string) (*resty.Response, error) {
+	request := session.Client().R().
+		SetContext(r.ctx).
+		SetHeaders(map[string]string{
+			"Authorization": r.token(),
+			"Content-Type":  "application/json",
+		})
+	if body != "" {
+		request.SetBody(body)
+	}
+	resp, err := request.Execute(method, fullAPIPath)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func unhandledStatus(resp *resty.Response) error {
+	return fmt.Errorf("unhandled response status code: %d - %s", resp.StatusCode(), resp.Status())
+}
+
+// checkSSL reports whether the Netbox server serves a valid certificate.
+func (r *Request) checkSSL() {
+	err := session.CheckSSL(r.rootURL)
+	if err != nil {
+		fmt.Println("  SSL certificate is not valid: ", err)
+	} else {
+		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", r.rootURL))
+	}
+}
+
+// prepareData resolves Data for a write to the endpoint with config key suffix, see
+// refs.ResolveReferences. It returns true when --dry-run is set, after printing the request that
+// would have been sent.
+func (r *Request) prepareData(suffix string, method string, fullAPIPath string) (bool, error) {
+	data, expanded, err := refs.ResolveReferences(r.ctx, r.rootURL, suffix, method, r.Data, r.CustomFields)
+	if err != nil {
+		return false, err
+	}
+	if expanded > 0 {
+		color.Cyan("  Expanded name ranges into " + color.YellowString("%d objects", expanded))
+	}
+	r.Data = data
+
+	if !r.DryRun {
+		return false, nil
+	}
+	color.Cyan("  Dry run, not sending: " + color.YellowString("%s %s", method, fullAPIPath))
+	fmt.Println(refs.Indent(r.Data))
+	return true, nil
+}
+
+// promptReader reads the answers to the next-page prompts. Unlike the flags it is shared by every
+// command of the process: answers piped on stdin must not be lost in the buffer of an earlier
+// prompt.
+var promptReader = bufio.NewReader(os.Stdin)
+
+var (
+	promptMu sync.Mutex
+	// pendingAnswer receives the line being read for a prompt that was abandoned when its command
+	// stopped. The next prompt takes it instead of starting a second read of stdin.
+	pendingAnswer chan string
+)
+
+// ReadAnswer reads the answer to a prompt, a line of stdin. Once stdin is exhausted, or when ctx
+// is done before a line is read, it reads "no".
+func ReadAnswer(ctx context.Context) string {
+	promptMu.Lock()
+	defer promptMu.Unlock()
+	if pendingAnswer == nil {
+		answer := make(chan string, 1)
+		go func() {
+			input, err := promptReader.ReadString('\n')
+			if err != nil && input == "" {
+				input = "no"
+			}
+			answer <- strings.TrimSpace(input)
+		}()
+		pendingAnswer = answer
+	}
+	select {
+	case input := <-pendingAnswer:
+		pendingAnswer = nil
+		return input
+	case <-ctx.Done():
+		return "no"
+	}
+}
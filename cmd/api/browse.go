@@ -0,0 +1,91 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+)
+
+// BrowsePageSize is the page size used when the terminal browser loads a list.
+const BrowsePageSize = 250
+
+// BrowseMaxPages caps how many pages the terminal browser loads for a single list.
+const BrowseMaxPages = 20
+
+// BrowsePages loads the list endpoint with config key suffix of env page by page for the terminal
+// browser, filtered by the Netbox query string query. newPage returns an empty model for every page
+// and collect consumes it and returns the URL of the next page. It returns the number of objects
+// the server has, which is more than were collected when the list has over BrowseMaxPages pages.
+// Unlike List it prints nothing, since the screen belongs to the browser.
+func BrowsePages[T any](ctx context.Context, env string, suffix string, query string, newPage func() T, collect func(T) string) (int, error) {
+	config, err := session.Config()
+	if err != nil {
+		return 0, err
+	}
+	rootURL, err := session.RootURL(env)
+	if err != nil {
+		return 0, err
+	}
+
+	apiSuffix := strings.SplitN(config.GetString(suffix), "?", 2)[0]
+	fullAPIPath := rootURL + apiSuffix + "?limit=" + strconv.Itoa(BrowsePageSize)
+	if query != "" {
+		fullAPIPath += "&" + query
+	}
+	token := config.GetString("cmd.token_key")
+
+	count := 0
+	for pages := 0; fullAPIPath != "" && pages < BrowseMaxPages; pages++ {
+		page := newPage()
+		resp, err := session.Client().R().
+			SetContext(ctx).
+			SetHeaders(map[string]string{
+				"Authorization": token,
+				"Accept":        "application/json",
+			}).
+			Get(fullAPIPath)
+		if err != nil {
+			return count, err
+		}
+		if resp.StatusCode() != 200 {
+			return count, fmt.Errorf("%s returned %s", fullAPIPath, resp.Status())
+		}
+		if err := json.Unmarshal(resp.Body(), page); err != nil {
+			return count, fmt.Errorf("error while parsing the response bytes: %s", err)
+		}
+		if pages == 0 {
+			var total struct {
+				Count int `json:"count"`
+			}
+			_ = json.Unmarshal(resp.Body(), &total)
+			count = total.Count
+		}
+		fullAPIPath = collect(page)
+	}
+	return count, nil
+}
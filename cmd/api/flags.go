@@ -0,0 +1,222 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// lookupFlags lists, per config key of an endpoint, the fields its objects can be identified by
+// instead of --id. Netbox ignores filters on fields a model lacks, so a --slug on a model without
+// slugs would match every object; endpoints not listed only have names.
+var lookupFlags = map[string][]string{
+	"cmd.circuits.circuits_api_url.circuit_types_id":         {"name", "slug"},
+	"cmd.circuits.circuits_api_url.circuit_terminations_id":  {},
+	"cmd.circuits.circuits_api_url.circuits_id":              {},
+	"cmd.circuits.circuits_api_url.circuits_terminations_id": {},
+	"cmd.circuits.circuits_api_url.providers_id":             {"name", "slug"},
+	"cmd.core.core_api_url.data_files_id":                    {},
+	"cmd.dcim.dcim_api_url.cable_terminations_id":            {},
+	"cmd.dcim.dcim_api_url.cables_id":                        {},
+	"cmd.dcim.dcim_api_url.device_roles_id":                  {"name", "slug"},
+	"cmd.dcim.dcim_api_url.device_types_id":                  {"slug"},
+	"cmd.dcim.dcim_api_url.devices_id":                       {"name", "serial"},
+	"cmd.dcim.dcim_api_url.inventory_item_roles_id":          {"name", "slug"},
+	"cmd.dcim.dcim_api_url.inventory_items_id":               {"name", "serial"},
+	"cmd.dcim.dcim_api_url.locations_id":                     {"name", "slug"},
+	"cmd.dcim.dcim_api_url.manufacturers_id":                 {"name", "slug"},
+	"cmd.dcim.dcim_api_url.module_types_id":                  {},
+	"cmd.dcim.dcim_api_url.modules_id":                       {"serial"},
+	"cmd.dcim.dcim_api_url.platforms_id":                     {"name", "slug"},
+	"cmd.dcim.dcim_api_url.rack_reservations_id":             {},
+	"cmd.dcim.dcim_api_url.rack_roles_id":                    {"name", "slug"},
+	"cmd.dcim.dcim_api_url.racks_id":                         {"name", "serial"},
+	"cmd.dcim.dcim_api_url.regions_id":                       {"name", "slug"},
+	"cmd.dcim.dcim_api_url.site_groups_id":                   {"name", "slug"},
+	"cmd.dcim.dcim_api_url.sites_id":                         {"name", "slug"},
+	"cmd.ipam.ipam_api_url.aggregates_id":                    {},
+	"cmd.ipam.ipam_api_url.asns_id":                          {},
+	"cmd.ipam.ipam_api_url.fhrp_group_assignments_id":        {},
+	"cmd.ipam.ipam_api_url.ip_addresses_id":                  {},
+	"cmd.ipam.ipam_api_url.ip_ranges_id":                     {},
+	"cmd.ipam.ipam_api_url.prefixes_id":                      {},
+	"cmd.vpn.vpn_api_url.l2vpn_terminations":                 {},
+	"cmd.vpn.vpn_api_url.l2vpns":                             {"name", "slug"},
+	"cmd.vpn.vpn_api_url.tunnel-groups":                      {"name", "slug"},
+	"cmd.vpn.vpn_api_url.tunnel-terminations":                {},
+	"cmd.wireless.wireless_api_url.wireless_lan_groups":      {"name", "slug"},
+	"cmd.wireless.wireless_api_url.wireless_lans":            {},
+	"cmd.wireless.wireless_api_url.wireless_links":           {},
+}
+
+// LookupFields returns the fields besides the ID that the objects of the endpoint with config key
+// suffix can be identified by.
+func LookupFields(suffix string) []string {
+	if fields, ok := lookupFlags[suffix]; ok {
+		return fields
+	}
+	return []string{"name"}
+}
+
+// NameField returns the field a name given for an object of the endpoint with config key suffix
+// is matched against, the first of name, slug and serial its model has. It returns false for
+// models without any, such as cables or IP addresses.
+func NameField(suffix string) (string, bool) {
+	fields := LookupFields(suffix)
+	if len(fields) == 0 {
+		return "", false
+	}
+	return fields[0], true
+}
+
+// AddLookupFlags lets a by-ID command of the endpoint with config key suffix identify its object
+// by the name, slug or serial number its model has, or by a natural key expression, instead of a
+// numeric --id.
+func AddLookupFlags(c *cobra.Command, suffix string) {
+	fields := LookupFields(suffix)
+	usage := map[string]string{
+		"name":   "Name of the object (instead of --id)",
+		"slug":   "Slug of the object (instead of --id)",
+		"serial": "Serial number of the object (instead of --id)",
+	}
+	for _, field := range fields {
+		c.Flags().StringP(field, "", "", usage[field])
+	}
+	c.Flags().StringP("lookup", "", "", "Natural key of the object as key=value pairs, e.g. device=core1,name=Ethernet1 (instead of --id)")
+	selectors := append(append([]string{"id"}, fields...), "lookup")
+	c.MarkFlagsOneRequired(selectors...)
+	c.MarkFlagsMutuallyExclusive(selectors...)
+}
+
+// AddDryRunFlag adds --dry-run to a command that writes --data to the Netbox server.
+func AddDryRunFlag(c *cobra.Command) {
+	c.Flags().BoolP("dry-run", "", false, "Print the resolved --data payload instead of sending it")
+}
+
+// AddCustomFieldFlag adds --cf to a command that writes --data to the Netbox server. With --cf,
+// --data may be left out: the payload then only sets the custom fields.
+func AddCustomFieldFlag(c *cobra.Command) {
+	c.Flags().StringArrayP("cf", "", nil, "Set a custom field as name=value, typed after its definition in Netbox (repeatable; an empty value clears it)")
+	if flag := c.Flags().Lookup("data"); flag != nil {
+		delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+		c.MarkFlagsOneRequired("data", "cf")
+	}
+}
+
+// AddCustomFieldFilterFlag adds --cf-filter to a command that lists objects.
+func AddCustomFieldFilterFlag(c *cobra.Command) {
+	c.Flags().StringArrayP("cf-filter", "", nil, "Only list objects whose custom field matches, as name=value (repeatable)")
+}
+
+// DisplayCustomFields prints the custom fields of an object by name. Objects without custom fields print nothing.
+func DisplayCustomFields(values map[string]interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	color.Cyan("\tCustom Fields: ")
+	for _, name := range customfields.Names(values) {
+		color.Cyan("\t  %s: %s", name, color.YellowString("%s", customfields.Format(values[name])))
+	}
+}
+
+// lookupMatches is the brief list returned when resolving a lookup to an ID.
+type lookupMatches struct {
+	Count   int `json:"count"`
+	Results []struct {
+		Id      uint   `json:"id"`
+		Display string `json:"display"`
+	} `json:"results"`
+}
+
+// resolveLookup sets ID from --name, --slug, --serial or --lookup when no --id was given.
+// The identifier has to match exactly one object on the Netbox server; when it matches none or
+// several, the candidates are listed and an error is returned.
+func (r *Request) resolveLookup(suffix string) error {
+	if r.ID != 0 {
+		return nil
+	}
+
+	values := url.Values{}
+	if r.Name != "" {
+		values.Set("name", r.Name)
+	}
+	if r.Slug != "" {
+		values.Set("slug", r.Slug)
+	}
+	if r.Serial != "" {
+		values.Set("serial", r.Serial)
+	}
+	if r.Lookup != "" {
+		for _, pair := range strings.Split(r.Lookup, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("invalid lookup expression %q: expected key=value pairs", pair)
+			}
+			values.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("one of --id, --lookup or a name, slug or serial number is required")
+	}
+	identifier := values.Encode()
+	values.Set("brief", "true")
+	values.Set("limit", "50")
+
+	apiSuffix := strings.SplitN(r.config.GetString(suffix), "?", 2)[0]
+	fullAPIPath := r.rootURL + apiSuffix + "?" + values.Encode()
+
+	resp, err := r.send("GET", fullAPIPath, "")
+	if err != nil {
+		return fmt.Errorf("resolving %s: %s", identifier, err)
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("resolving %s: %s - %s", identifier, resp.Status(), resp.String())
+	}
+
+	matches := new(lookupMatches)
+	if err := json.Unmarshal(resp.Body(), matches); err != nil {
+		return fmt.Errorf("parsing the response bytes: %s", err)
+	}
+
+	switch matches.Count {
+	case 1:
+		r.ID = int(matches.Results[0].Id)
+		color.Cyan("  Resolved " + color.YellowString("%s", identifier) + color.CyanString(" to ID: ") + color.YellowString("%d (%s)", r.ID, matches.Results[0].Display))
+		return nil
+	case 0:
+		return fmt.Errorf("no object found on Netbox server for: %s", identifier)
+	}
+	for _, match := range matches.Results {
+		color.Cyan("\tID: " + color.YellowString("%d", match.Id) + color.CyanString("  Display: ") + color.YellowString("%s", match.Display))
+	}
+	if matches.Count > len(matches.Results) {
+		color.Cyan("\t... and %d more", matches.Count-len(matches.Results))
+	}
+	return fmt.Errorf("%d objects match %s, use --id or a more specific --lookup", matches.Count, identifier)
+}
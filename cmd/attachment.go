@@ -0,0 +1,559 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// attachmentsKey is the config key of the extras image attachments endpoint.
+const attachmentsKey = "cmd.extras.extras_api_url.image_attachments"
+
+// attachmentLabelSeparator separates the object from the attachment name in the file names of
+// attachment upload --dir, as in nyc1-leaf1+front.jpg.
+const attachmentLabelSeparator = "+"
+
+// imageExtensions are the file extensions attachment upload --dir uploads; Netbox only accepts images.
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true, ".bmp": true, ".tif": true, ".tiff": true}
+
+var attachmentEnv string
+
+var attachmentFiles []string
+
+var attachmentUploadDir string
+
+var attachmentDownloadDir string
+
+var attachmentName string
+
+var attachmentDryRun bool
+
+var attachmentForce bool
+
+var attachmentOutput string
+
+var attachmentObjects objectSelector
+
+// AttachmentCmd represents the attachment command
+var AttachmentCmd = &cobra.Command{
+	Use:   "attachment",
+	Short: "Upload, list, download and delete the image attachments of Netbox objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Upload, list, download and delete the image attachments of any kind of Netbox object, such as
+  photos of racks, devices and sites:
+
+    abc-netbox.cli attachment upload dcim racks nyc1-r01 --file front.jpg --file rear.jpg --env production
+    abc-netbox.cli attachment upload dcim devices --dir ./photos --env production
+    abc-netbox.cli attachment list dcim devices nyc1-leaf1 --env production
+    abc-netbox.cli attachment download dcim sites nyc1 --dir ./nyc1 --env production
+    abc-netbox.cli attachment delete dcim devices nyc1-leaf1 --name label --env production`,
+}
+
+// AttachmentUploadCmd represents the attachment upload command
+var AttachmentUploadCmd = &cobra.Command{
+	Use:   "upload <domain> <resource> [id|name]...",
+	Short: "Attach images to objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Attach the images given with --file to objects given by ID or name as arguments, with --id, or
+  with --filter to select every object matching Netbox filters. The attachment is named --name,
+  or after the file.
+
+  With --dir, every image of a directory is attached to the object its file name maps to: the
+  file name without extension is the name or the asset tag of the object, optionally followed by
+  "+" and the name of the attachment, e.g. nyc1-leaf1.jpg or FA4421+label.png.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := uploadAttachments(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("uploading attachments: %s", err)
+		}
+		return nil
+	},
+}
+
+// AttachmentListCmd represents the attachment list command
+var AttachmentListCmd = &cobra.Command{
+	Use:   "list <domain> <resource> [id|name]...",
+	Short: "List the image attachments of objects",
+	Long: `
+ABC Netbox Automation Tools:
+  List the image attachments of objects given by ID or name as arguments, with --id or with
+  --filter. Without objects, the attachments of every object of the resource are listed.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listAttachments(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("listing attachments: %s", err)
+		}
+		return nil
+	},
+}
+
+// AttachmentDownloadCmd represents the attachment download command
+var AttachmentDownloadCmd = &cobra.Command{
+	Use:   "download <domain> <resource> [id|name]...",
+	Short: "Download the image attachments of objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Download the image attachments of objects given by ID or name as arguments, with --id or with
+  --filter, to --dir, keeping the file names Netbox stores them under. Existing files are kept
+  unless --force is given.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := downloadAttachments(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("downloading attachments: %s", err)
+		}
+		return nil
+	},
+}
+
+// AttachmentDeleteCmd represents the attachment delete command
+var AttachmentDeleteCmd = &cobra.Command{
+	Use:   "delete <domain> <resource> [id|name]...",
+	Short: "Delete the image attachments of objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Delete the image attachments of objects given by ID or name as arguments, with --id or with
+  --filter, or only those called --name.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := deleteAttachments(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("deleting attachments: %s", err)
+		}
+		return nil
+	},
+}
+
+// attachmentTarget is the Netbox instance and endpoint the attachment commands work on.
+type attachmentTarget struct {
+	rootURL  string
+	endpoint *objectEndpoint
+	// path is the API path of the image attachments.
+	path string
+	// typeField is the field holding the model of the attached object: content_type before
+	// Netbox 4.0, object_type since.
+	typeField string
+}
+
+func newAttachmentTarget(domain string, resource string) (*attachmentTarget, error) {
+	endpoint, err := lookupEndpoint(domain, resource)
+	if err != nil {
+		return nil, err
+	}
+	rootURL, err := session.RootURL(attachmentEnv)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized environment: %s", attachmentEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return nil, fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	path := strings.SplitN(config.GetString(attachmentsKey), "?", 2)[0]
+	if path == "" {
+		return nil, fmt.Errorf("no endpoint configured for %s", attachmentsKey)
+	}
+	typeField := "object_type"
+	if version, err := session.ServerVersion(rootURL); err == nil && session.CompareVersions(version, "4.0") < 0 {
+		typeField = "content_type"
+	}
+	return &attachmentTarget{rootURL: rootURL, endpoint: endpoint, path: path, typeField: typeField}, nil
+}
+
+// attachments returns the image attachments of the selected objects, or of every object of the
+// endpoint when none is selected, with name, if set, as only attachment name.
+func (t *attachmentTarget) attachments(ctx context.Context, names []string, name string) ([]*output.Object, error) {
+	filters := url.Values{t.typeField: {t.endpoint.objectType}}
+	if name != "" {
+		filters.Set("name", name)
+	}
+	var found []interface{}
+	if !attachmentObjects.selected(names) {
+		list, err := listObjects(ctx, attachmentEnv, t.rootURL+t.path, filters)
+		if err != nil {
+			return nil, err
+		}
+		found = list
+	} else {
+		objects, err := attachmentObjects.objects(ctx, attachmentEnv, t.rootURL, t.endpoint, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range objects {
+			filters.Set("object_id", fmt.Sprint(o.Get("id")))
+			list, err := listObjects(ctx, attachmentEnv, t.rootURL+t.path, filters)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, list...)
+		}
+	}
+	var attachments []*output.Object
+	for _, item := range found {
+		if a, ok := item.(*output.Object); ok {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+// upload attaches file, named name, to the object with ID id.
+func (t *attachmentTarget) upload(ctx context.Context, id interface{}, name string, file string) error {
+	resp, err := session.Client().R().
+		SetContext(ctx).
+		SetHeaders(map[string]string{
+			"Authorization": session.ProfileToken(attachmentEnv),
+			"Accept":        "application/json",
+		}).
+		SetFormData(map[string]string{
+			t.typeField: t.endpoint.objectType,
+			"object_id": fmt.Sprint(id),
+			"name":      name,
+		}).
+		SetFile("image", file).
+		Post(t.rootURL + t.path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 201 {
+		return fmt.Errorf("POST %s%s returned %s: %s", t.rootURL, t.path, resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+	return nil
+}
+
+// attachmentUpload is a file to attach to an object.
+type attachmentUpload struct {
+	object *output.Object
+	name   string
+	file   string
+}
+
+// uploadAttachments attaches the --file images to the selected objects, or the images of --dir to
+// the objects their file names map to.
+func uploadAttachments(ctx context.Context, domain string, resource string, names []string) error {
+	if attachmentUploadDir != "" && attachmentObjects.selected(names) {
+		return fmt.Errorf("--dir maps files to objects by their names, do not select objects")
+	}
+	if attachmentUploadDir == "" && !attachmentObjects.selected(names) {
+		return fmt.Errorf("no objects selected: give IDs or names, --id or --filter")
+	}
+	target, err := newAttachmentTarget(domain, resource)
+	if err != nil {
+		return err
+	}
+
+	var uploads []attachmentUpload
+	failed := 0
+	if attachmentUploadDir != "" {
+		uploads, failed, err = target.directoryUploads(ctx, attachmentUploadDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, file := range attachmentFiles {
+			if _, err := os.Stat(file); err != nil {
+				return err
+			}
+		}
+		objects, err := attachmentObjects.objects(ctx, attachmentEnv, target.rootURL, target.endpoint, names)
+		if err != nil {
+			return err
+		}
+		for _, o := range objects {
+			for _, file := range attachmentFiles {
+				name := attachmentName
+				if name == "" {
+					name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+				}
+				uploads = append(uploads, attachmentUpload{object: o, name: name, file: file})
+			}
+		}
+	}
+
+	for _, u := range uploads {
+		if attachmentDryRun {
+			color.Cyan("  Would attach %s to "+color.YellowString("%s", objectName(u.object))+" as "+color.YellowString("%s", u.name), u.file)
+			continue
+		}
+		if err := target.upload(ctx, u.object.Get("id"), u.name, u.file); err != nil {
+			color.Red("  Error attaching %s to %s: %s", u.file, objectName(u.object), err)
+			failed++
+			continue
+		}
+		color.Cyan("  Attached %s to "+color.YellowString("%s", objectName(u.object))+" as "+color.YellowString("%s", u.name), u.file)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files not attached", failed, len(uploads)+failed)
+	}
+	if !attachmentDryRun {
+		color.Green("\n  Attached %d files to %s.", len(uploads), target.endpoint.resource)
+	}
+	return nil
+}
+
+// directoryUploads maps the images of dir to the objects named in their file names, and returns
+// them with the number of images mapped to no object.
+func (t *attachmentTarget) directoryUploads(ctx context.Context, dir string) ([]attachmentUpload, int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	var uploads []attachmentUpload
+	unmatched := 0
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !imageExtensions[ext] {
+			continue
+		}
+		stem := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		key, name, ok := strings.Cut(stem, attachmentLabelSeparator)
+		if !ok || name == "" {
+			name = key
+		}
+		object, err := t.objectByNameOrAssetTag(ctx, key)
+		if err != nil {
+			color.Red("  Skipping %s: %s", entry.Name(), err)
+			unmatched++
+			continue
+		}
+		uploads = append(uploads, attachmentUpload{object: object, name: name, file: filepath.Join(dir, entry.Name())})
+	}
+	if len(uploads) == 0 && unmatched == 0 {
+		return nil, 0, fmt.Errorf("no images (%s) in %s", strings.Join(sortedExtensions(), ", "), dir)
+	}
+	return uploads, unmatched, nil
+}
+
+// objectByNameOrAssetTag returns the object of the endpoint named key, or else with asset tag key.
+// The asset tag is checked on the objects returned, as Netbox ignores filters a resource lacks.
+func (t *attachmentTarget) objectByNameOrAssetTag(ctx context.Context, key string) (*output.Object, error) {
+	for _, field := range []string{"name", "asset_tag"} {
+		found, err := listObjects(ctx, attachmentEnv, t.rootURL+t.endpoint.path, url.Values{field: {key}})
+		if err != nil {
+			return nil, err
+		}
+		var matches []*output.Object
+		for _, item := range found {
+			if o, ok := item.(*output.Object); ok && o.Get(field) == key {
+				matches = append(matches, o)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return nil, fmt.Errorf("%d %s have the %s %q", len(matches), t.endpoint.resource, strings.ReplaceAll(field, "_", " "), key)
+		}
+	}
+	return nil, fmt.Errorf("no %s has the name or asset tag %q", t.endpoint.resource, key)
+}
+
+func sortedExtensions() []string {
+	var extensions []string
+	for ext := range imageExtensions {
+		extensions = append(extensions, ext)
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// listAttachments prints the image attachments of the selected objects, or of every object.
+func listAttachments(ctx context.Context, domain string, resource string, names []string) error {
+	if attachmentOutput == "" {
+		attachmentOutput = output.Default()
+	}
+	target, err := newAttachmentTarget(domain, resource)
+	if err != nil {
+		return err
+	}
+	attachments, err := target.attachments(ctx, names, attachmentName)
+	if err != nil {
+		return err
+	}
+	if attachmentOutput != output.Text {
+		list := make([]interface{}, len(attachments))
+		for i, a := range attachments {
+			list[i] = a
+		}
+		return output.Render(os.Stdout, list, attachmentOutput)
+	}
+	if len(attachments) == 0 {
+		color.Cyan("  No attachments found.")
+		return nil
+	}
+	for _, a := range attachments {
+		object := fmt.Sprintf("%s #%v", target.endpoint.objectType, a.Get("object_id"))
+		if parent, ok := a.Get("parent").(*output.Object); ok {
+			object = objectName(parent)
+		}
+		fmt.Println(color.CyanString("  %s", object) + "  " + color.YellowString("#%v %v", a.Get("id"), a.Get("name")) + "  " +
+			color.CyanString("%vx%v", a.Get("image_width"), a.Get("image_height")) + "  " + color.YellowString("%v", a.Get("image")))
+	}
+	return nil
+}
+
+// downloadAttachments saves the image attachments of the selected objects to --dir.
+func downloadAttachments(ctx context.Context, domain string, resource string, names []string) error {
+	if !attachmentObjects.selected(names) {
+		return fmt.Errorf("no objects selected: give IDs or names, --id or --filter")
+	}
+	target, err := newAttachmentTarget(domain, resource)
+	if err != nil {
+		return err
+	}
+	attachments, err := target.attachments(ctx, names, attachmentName)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		color.Cyan("  No attachments found.")
+		return nil
+	}
+	if err := os.MkdirAll(attachmentDownloadDir, 0o755); err != nil {
+		return err
+	}
+
+	saved := 0
+	for _, a := range attachments {
+		// The image URL is made absolute by Netbox from the host it was asked on, which behind a
+		// proxy may not be reachable, so only its path is kept.
+		image, err := url.Parse(fmt.Sprint(a.Get("image")))
+		if err != nil || image.Path == "" {
+			return fmt.Errorf("attachment #%v has no image", a.Get("id"))
+		}
+		file := filepath.Join(attachmentDownloadDir, filepath.Base(image.Path))
+		if _, err := os.Stat(file); err == nil && !attachmentForce {
+			color.Yellow("  Skipping %s: the file exists, use --force to overwrite it", file)
+			continue
+		}
+		resp, err := session.Client().R().
+			SetContext(ctx).
+			SetHeader("Authorization", session.ProfileToken(attachmentEnv)).
+			Get(target.rootURL + image.Path)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != 200 {
+			return fmt.Errorf("%s%s returned %s", target.rootURL, image.Path, resp.Status())
+		}
+		if err := os.WriteFile(file, resp.Body(), 0o644); err != nil {
+			return err
+		}
+		color.Cyan("  Saved "+color.YellowString("%v", a.Get("name"))+" to %s", file)
+		saved++
+	}
+	color.Green("\n  Downloaded %d of %d attachments.", saved, len(attachments))
+	return nil
+}
+
+// deleteAttachments deletes the image attachments of the selected objects in bulk.
+func deleteAttachments(ctx context.Context, domain string, resource string, names []string) error {
+	if !attachmentObjects.selected(names) {
+		return fmt.Errorf("no objects selected: give IDs or names, --id or --filter")
+	}
+	target, err := newAttachmentTarget(domain, resource)
+	if err != nil {
+		return err
+	}
+	attachments, err := target.attachments(ctx, names, attachmentName)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		color.Cyan("  No attachments found.")
+		return nil
+	}
+
+	ids := make([]interface{}, len(attachments))
+	for i, a := range attachments {
+		ids[i] = &output.Object{Keys: []string{"id"}, Values: map[string]interface{}{"id": a.Get("id")}}
+		verb := "Deleting"
+		if attachmentDryRun {
+			verb = "Would delete"
+		}
+		color.Cyan("  %s "+color.YellowString("#%v %v", a.Get("id"), a.Get("name"))+" of "+color.YellowString("%s", attachmentParent(a)), verb)
+	}
+	if attachmentDryRun {
+		return nil
+	}
+	if _, err := sendJSON(ctx, attachmentEnv, "DELETE", target.rootURL+target.path, ids); err != nil {
+		return err
+	}
+	color.Green("\n  Deleted %d attachments.", len(attachments))
+	return nil
+}
+
+func attachmentParent(a *output.Object) string {
+	if parent, ok := a.Get("parent").(*output.Object); ok {
+		return objectName(parent)
+	}
+	return fmt.Sprintf("#%v", a.Get("object_id"))
+}
+
+func init() {
+	for _, c := range []*cobra.Command{AttachmentUploadCmd, AttachmentListCmd, AttachmentDownloadCmd, AttachmentDeleteCmd} {
+		c.Flags().StringVarP(&attachmentEnv, "env", "", "development", "Environment ('development' or 'production')")
+		_ = c.MarkFlagRequired("env")
+		attachmentObjects.addFlags(c)
+		AttachmentCmd.AddCommand(c)
+	}
+	AttachmentUploadCmd.Flags().StringArrayVar(&attachmentFiles, "file", nil, "Image to attach (repeatable)")
+	AttachmentUploadCmd.Flags().StringVar(&attachmentUploadDir, "dir", "", "Attach every image of a directory to the object its file name maps to")
+	AttachmentUploadCmd.Flags().StringVar(&attachmentName, "name", "", "Name of the attachments, default the file name without extension")
+	AttachmentUploadCmd.MarkFlagsOneRequired("file", "dir")
+	AttachmentUploadCmd.MarkFlagsMutuallyExclusive("file", "dir")
+	_ = AttachmentUploadCmd.MarkFlagDirname("dir")
+
+	AttachmentListCmd.Flags().StringVar(&attachmentName, "name", "", "Only list the attachments with this name")
+	AttachmentListCmd.Flags().StringVarP(&attachmentOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	_ = AttachmentListCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+
+	AttachmentDownloadCmd.Flags().StringVar(&attachmentDownloadDir, "dir", ".", "Directory to save the images to")
+	AttachmentDownloadCmd.Flags().StringVar(&attachmentName, "name", "", "Only download the attachments with this name")
+	AttachmentDownloadCmd.Flags().BoolVar(&attachmentForce, "force", false, "Overwrite existing files")
+	_ = AttachmentDownloadCmd.MarkFlagDirname("dir")
+
+	AttachmentDeleteCmd.Flags().StringVar(&attachmentName, "name", "", "Only delete the attachments with this name")
+	for _, c := range []*cobra.Command{AttachmentUploadCmd, AttachmentDeleteCmd} {
+		c.Flags().BoolVar(&attachmentDryRun, "dry-run", false, "Print the changes instead of sending them")
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestAttachment(t *testing.T) {
+	// Commands run in a directory of their own, so files are given by absolute path and shown
+	// relative in the golden files.
+	images, err := filepath.Abs(filepath.Join("testdata", "attachments"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloads := t.TempDir()
+
+	// uploaded checks the attachments created, as object ID, name and image URL.
+	uploaded := func(want ...string) func(t *testing.T, srv *netboxtest.Server) {
+		return func(t *testing.T, srv *netboxtest.Server) {
+			var got []string
+			for _, a := range srv.Objects("/api/extras/image-attachments/")[3:] {
+				got = append(got, strings.Join([]string{a["object_type"].(string), a["object_id"].(json.Number).String(), a["name"].(string), a["image"].(string)}, " "))
+			}
+			if strings.Join(got, "\n") != strings.Join(want, "\n") {
+				t.Errorf("uploaded:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+			}
+		}
+	}
+	noWrites := func(t *testing.T, srv *netboxtest.Server) {
+		for _, r := range srv.Requests() {
+			if r.Method != "GET" {
+				t.Errorf("sent %s %s", r.Method, r.Path)
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name:  "upload_file",
+			args:  []string{"attachment", "upload", "dcim", "devices", "nyc1-leaf1", "--file", filepath.Join(images, "nyc1-leaf2.png"), "--name", "front", "--env", "development"},
+			check: uploaded("dcim.device 1 front http://netbox.test/media/image-attachments/nyc1-leaf2.png"),
+		},
+		{
+			name:  "upload_dir",
+			args:  []string{"attachment", "upload", "dcim", "devices", "--dir", images, "--env", "development"},
+			check: uploaded("dcim.device 3 label http://netbox.test/media/image-attachments/FA4421+label.png", "dcim.device 2 nyc1-leaf2 http://netbox.test/media/image-attachments/nyc1-leaf2.png"),
+		},
+		{
+			name:  "upload_dry_run",
+			args:  []string{"attachment", "upload", "dcim", "devices", "--dir", images, "--dry-run", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "upload_dir_and_objects",
+			args:  []string{"attachment", "upload", "dcim", "devices", "nyc1-leaf1", "--dir", images, "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "list",
+			args:  []string{"attachment", "list", "dcim", "devices", "nyc1-leaf1", "--env", "development", "-o", "text"},
+			check: noWrites,
+		},
+		{
+			name:  "list_all_table",
+			args:  []string{"attachment", "list", "dcim", "devices", "--env", "development", "-o", "table"},
+			check: noWrites,
+		},
+		{
+			name: "download",
+			args: []string{"attachment", "download", "dcim", "devices", "nyc1-leaf1", "--name", "label", "--dir", downloads, "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				if _, err := os.Stat(filepath.Join(downloads, "dcim_device_1_label.png")); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name: "delete",
+			args: []string{"attachment", "delete", "dcim", "devices", "nyc1-leaf1", "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				if left := srv.Objects("/api/extras/image-attachments/"); len(left) != 1 || left[0]["name"] != "rear" {
+					t.Errorf("attachments left: %v", left)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fixtures := netboxtest.DefaultFixtures()
+			fixtures["/api/dcim/devices/"].Results[2]["asset_tag"] = "FA4421"
+			srv := netboxtest.NewServer(t, fixtures)
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			out := strings.NewReplacer(images, "testdata/attachments", downloads, "<downloads>").Replace(result.String())
+			netboxtest.Golden(t, "attachment/"+tt.name, out)
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package browse
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Link points from one object to a list of related objects, e.g. from a device to its interfaces.
+// Query holds the Netbox filter parameters applied to the target resource, e.g. "device_id=12".
+type Link struct {
+	Label    string
+	Domain   string
+	Resource string
+	Query    string
+}
+
+// Field is a single labelled value shown in the detail pane.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Object is the browser's view of a single Netbox object decoded with the models of its API package.
+type Object struct {
+	Id      uint
+	Display string
+	ApiUrl  string
+	Fields  []Field
+	Related []Link
+}
+
+// WebUrl returns the URL of the object in the Netbox web UI.
+func (o Object) WebUrl() string {
+	return strings.Replace(o.ApiUrl, "/api/", "/", 1)
+}
+
+// List is the result of a Fetch: the objects loaded and the number of objects the server has,
+// which is larger when the list was too long to load completely.
+type List struct {
+	Objects []Object
+	Count   int
+}
+
+// Truncated reports whether the server has more objects than were loaded.
+func (l List) Truncated() bool {
+	return l.Count > len(l.Objects)
+}
+
+// Resource is a list endpoint the browser can display. Fetch receives the environment
+// ('development' or 'production') and the Netbox filter query string, and returns the objects it
+// matches. It stops when ctx is done.
+type Resource struct {
+	Domain string
+	Name   string
+	Fetch  func(ctx context.Context, env string, query string) (List, error)
+}
+
+var (
+	mu        sync.Mutex
+	resources = map[string]Resource{}
+)
+
+// Register makes a resource available to the browser. The API packages call it from init.
+func Register(r Resource) {
+	mu.Lock()
+	defer mu.Unlock()
+	resources[r.Domain+"/"+r.Name] = r
+}
+
+// Lookup returns the resource registered for domain and name.
+func Lookup(domain, name string) (Resource, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	r, ok := resources[domain+"/"+name]
+	return r, ok
+}
+
+// Resources returns every registered resource ordered by domain and name.
+func Resources() []Resource {
+	mu.Lock()
+	defer mu.Unlock()
+	var list []Resource
+	for _, r := range resources {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Domain != list[j].Domain {
+			return list[i].Domain < list[j].Domain
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// FuzzyMatch reports whether every character of pattern appears in s in order, ignoring case,
+// and returns a score that favours consecutive and early matches.
+func FuzzyMatch(pattern, s string) (bool, int) {
+	if pattern == "" {
+		return true, 0
+	}
+	p := []rune(strings.ToLower(pattern))
+	score, run, pi := 0, 0, 0
+	for i, r := range strings.ToLower(s) {
+		if pi < len(p) && r == p[pi] {
+			run++
+			score += run * 2
+			if i == 0 {
+				score += 3
+			}
+			pi++
+		} else {
+			run = 0
+		}
+	}
+	return pi == len(p), score
+}
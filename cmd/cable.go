@@ -0,0 +1,470 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/refs"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// cableResources are the resources whose objects terminate cables, by domain, in the order a
+// cable end given as device:port is looked up in.
+var cableResources = []struct{ domain, resource string }{
+	{"dcim", "interfaces"},
+	{"dcim", "front-ports"},
+	{"dcim", "rear-ports"},
+	{"dcim", "console-ports"},
+	{"dcim", "console-server-ports"},
+	{"dcim", "power-ports"},
+	{"dcim", "power-outlets"},
+	{"circuits", "circuit-terminations"},
+}
+
+// cableCompatible lists the object types each termination type may be cabled to, as Netbox
+// checks them.
+var cableCompatible = map[string][]string{
+	"dcim.interface":              {"dcim.interface", "circuits.circuittermination", "dcim.frontport", "dcim.rearport"},
+	"dcim.frontport":              {"dcim.consoleport", "dcim.consoleserverport", "dcim.interface", "dcim.frontport", "dcim.rearport", "circuits.circuittermination"},
+	"dcim.rearport":               {"dcim.consoleport", "dcim.consoleserverport", "dcim.interface", "dcim.frontport", "dcim.rearport", "circuits.circuittermination"},
+	"dcim.consoleport":            {"dcim.consoleserverport", "dcim.frontport", "dcim.rearport"},
+	"dcim.consoleserverport":      {"dcim.consoleport", "dcim.frontport", "dcim.rearport"},
+	"dcim.powerport":              {"dcim.poweroutlet", "dcim.powerfeed"},
+	"dcim.poweroutlet":            {"dcim.powerport"},
+	"circuits.circuittermination": {"dcim.interface", "dcim.frontport", "dcim.rearport", "circuits.circuittermination"},
+}
+
+// cableTypes are the cable types of Netbox, offered for completion.
+var cableTypes = []string{
+	"cat3", "cat5", "cat5e", "cat6", "cat6a", "cat7", "cat7a", "cat8", "dac-active", "dac-passive", "mrj21-trunk",
+	"coaxial", "mmf", "mmf-om1", "mmf-om2", "mmf-om3", "mmf-om4", "mmf-om5", "smf", "smf-os1", "smf-os2", "aoc", "power", "usb",
+}
+
+var cableLengthPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(km|m|cm|mi|ft|in)$`)
+
+var cableEnv string
+
+var cableTo string
+
+var cableType string
+
+var cableLength string
+
+var cableLabel string
+
+var cableColor string
+
+var cableStatus string
+
+var cableDescription string
+
+var cableDryRun bool
+
+// CableCmd represents the cable command
+var CableCmd = &cobra.Command{
+	Use:   "cable",
+	Short: "Connect and disconnect cables by device and port names",
+	Long: `
+ABC Netbox Automation Tools:
+  Connect and disconnect cables by naming the ports at their ends, instead of building the
+  terminations of postDcimCables by hand.
+
+  A cable end is given as device:port, e.g. core1:Ethernet1 or srv42:eth0, and looked up among
+  the interfaces, front and rear ports, console and console server ports, power ports and power
+  outlets of the device. A circuit termination is given as circuit:side, e.g. ZYO-100234:A.
+  When a name matches ports of several kinds, prefix it with the resource, as in
+  rear-ports/nyc1-pp1:1.`,
+}
+
+// CableConnectCmd represents the cable connect command
+var CableConnectCmd = &cobra.Command{
+	Use:   "connect <device:port> --to <device:port>",
+	Short: "Cable two ports together",
+	Long: `
+ABC Netbox Automation Tools:
+  Create a cable between two ports, after checking that neither is cabled yet and that their
+  kinds may be cabled together:
+
+    abc-netbox.cli cable connect core1:Ethernet1 --to srv42:eth0 --type cat6 --length 3m --label C-1042 --env production
+    abc-netbox.cli cable connect nyc1-leaf1:Ethernet2 --to ZYO-100234:A --type smf --env production --dry-run
+
+  --length takes a number and a unit: km, m, cm, mi, ft or in.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runCableConnect(cmd.Context(), args[0], cableTo); err != nil {
+			return fmt.Errorf("connecting cable: %s", err)
+		}
+		return nil
+	},
+}
+
+// CableDisconnectCmd represents the cable disconnect command
+var CableDisconnectCmd = &cobra.Command{
+	Use:   "disconnect <device:port>...",
+	Short: "Delete the cables of ports",
+	Long: `
+ABC Netbox Automation Tools:
+  Delete the cables attached to the ports given, which may be either end of a cable:
+
+    abc-netbox.cli cable disconnect core1:Ethernet1 --env production
+    abc-netbox.cli cable disconnect srv42:eth0 srv42:eth1 --env production --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runCableDisconnect(cmd.Context(), args); err != nil {
+			return fmt.Errorf("disconnecting cable: %s", err)
+		}
+		return nil
+	},
+}
+
+// cableEnd is a port or circuit termination a cable is attached to.
+type cableEnd struct {
+	endpoint *objectEndpoint
+	object   *output.Object
+}
+
+func (e *cableEnd) name() string {
+	return terminationName(e.object)
+}
+
+// cableID returns the ID of the cable attached to the end, 0 if there is none.
+func (e *cableEnd) cableID() int64 {
+	switch cable := e.object.Get("cable").(type) {
+	case *output.Object:
+		id, _ := toInt64(cable.Get("id"))
+		return id
+	default:
+		id, _ := toInt64(cable)
+		return id
+	}
+}
+
+func (e *cableEnd) termination() *output.Object {
+	return &output.Object{
+		Keys:   []string{"object_type", "object_id"},
+		Values: map[string]interface{}{"object_type": e.endpoint.objectType, "object_id": e.object.Get("id")},
+	}
+}
+
+// cableSession returns the root URL of --env after checking its certificate.
+func cableSession() (string, error) {
+	rootURL, err := session.RootURL(cableEnv)
+	if err != nil {
+		return "", fmt.Errorf("unrecognized environment: %s", cableEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return "", fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+	return rootURL, nil
+}
+
+func runCableConnect(ctx context.Context, a string, b string) error {
+	body := &output.Object{Values: map[string]interface{}{}}
+	set := func(key string, value interface{}) {
+		body.Keys = append(body.Keys, key)
+		body.Values[key] = value
+	}
+	var length interface{}
+	var lengthUnit string
+	if cableLength != "" {
+		match := cableLengthPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(cableLength)))
+		if match == nil {
+			return fmt.Errorf("--length %q is not a number and a unit such as 3m, 1.5m or 10ft", cableLength)
+		}
+		n, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return fmt.Errorf("--length %q: %s", cableLength, err)
+		}
+		length, lengthUnit = n, match[2]
+	}
+
+	rootURL, err := cableSession()
+	if err != nil {
+		return err
+	}
+	ends := make([]*cableEnd, 2)
+	for i, ref := range []string{a, b} {
+		if ends[i], err = resolveCableEnd(ctx, rootURL, ref); err != nil {
+			return err
+		}
+		if id := ends[i].cableID(); id != 0 {
+			return fmt.Errorf("%s is already cabled (cable #%d), disconnect it first", ends[i].name(), id)
+		}
+		if ends[i].object.Get("mark_connected") == true {
+			return fmt.Errorf("%s is marked as connected", ends[i].name())
+		}
+	}
+	if !cableTypesCompatible(ends[0].endpoint.objectType, ends[1].endpoint.objectType) {
+		return fmt.Errorf("%s (%s) cannot be cabled to %s (%s)", ends[0].name(), ends[0].endpoint.objectType, ends[1].name(), ends[1].endpoint.objectType)
+	}
+
+	set("a_terminations", []interface{}{ends[0].termination()})
+	set("b_terminations", []interface{}{ends[1].termination()})
+	if cableType != "" {
+		set("type", cableType)
+	}
+	if cableStatus != "" {
+		set("status", cableStatus)
+	}
+	if cableLabel != "" {
+		set("label", cableLabel)
+	}
+	if cableColor != "" {
+		set("color", strings.ToLower(strings.TrimPrefix(cableColor, "#")))
+	}
+	if length != nil {
+		set("length", length)
+		set("length_unit", lengthUnit)
+	}
+	if cableDescription != "" {
+		set("description", cableDescription)
+	}
+
+	cables, err := lookupEndpoint("dcim", "cables")
+	if err != nil {
+		return err
+	}
+	if cableDryRun {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		color.Cyan("  Dry run, not sending: " + color.YellowString("POST %s%s", rootURL, cables.path))
+		fmt.Println(refs.Indent(string(payload)))
+		return nil
+	}
+	created, err := sendJSON(ctx, cableEnv, "POST", rootURL+cables.path, body)
+	if err != nil {
+		return err
+	}
+	cable, ok := created.(*output.Object)
+	if !ok {
+		return fmt.Errorf("unexpected response creating the cable")
+	}
+	color.Green("  Connected "+color.YellowString("%s", ends[0].name())+color.CyanString(" ─[%s]─ ", cableSummary(cable))+color.YellowString("%s", ends[1].name())+" as cable #%v", cable.Get("id"))
+	return nil
+}
+
+func cableTypesCompatible(a string, b string) bool {
+	for _, t := range cableCompatible[a] {
+		if t == b {
+			return true
+		}
+	}
+	return false
+}
+
+func runCableDisconnect(ctx context.Context, names []string) error {
+	rootURL, err := cableSession()
+	if err != nil {
+		return err
+	}
+	cables, err := lookupEndpoint("dcim", "cables")
+	if err != nil {
+		return err
+	}
+
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, ref := range names {
+		end, err := resolveCableEnd(ctx, rootURL, ref)
+		if err != nil {
+			return err
+		}
+		id := end.cableID()
+		if id == 0 {
+			color.Yellow("  %s is not cabled.", end.name())
+			continue
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("none of the ports given is cabled")
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		v, _, err := getJSON(ctx, cableEnv, fmt.Sprintf("%s%s%d/", rootURL, cables.path, id))
+		if err != nil {
+			return err
+		}
+		cable, ok := v.(*output.Object)
+		if !ok {
+			return fmt.Errorf("unexpected response for cable #%d", id)
+		}
+		verb := "Disconnecting"
+		if cableDryRun {
+			verb = "Would disconnect"
+		}
+		color.Cyan("  %s "+color.YellowString("%s", cableTerminations(cable.Get("a_terminations")))+" ─[%s]─ "+color.YellowString("%s", cableTerminations(cable.Get("b_terminations")))+" (cable #%d)", verb, cableSummary(cable), id)
+	}
+	if cableDryRun {
+		return nil
+	}
+	deleted := make([]interface{}, len(ids))
+	for i, id := range ids {
+		deleted[i] = &output.Object{Keys: []string{"id"}, Values: map[string]interface{}{"id": id}}
+	}
+	if _, err := sendJSON(ctx, cableEnv, "DELETE", rootURL+cables.path, deleted); err != nil {
+		return err
+	}
+	noun := "cables"
+	if len(ids) == 1 {
+		noun = "cable"
+	}
+	color.Green("\n  Deleted %d %s.", len(ids), noun)
+	return nil
+}
+
+// cableTerminations returns the names of the terminations at one end of a cable, given as
+// {object_type, object_id, object}.
+func cableTerminations(v interface{}) string {
+	var objects []*output.Object
+	for _, t := range objectList(v) {
+		if o, ok := t.Get("object").(*output.Object); ok {
+			objects = append(objects, o)
+		}
+	}
+	if len(objects) == 0 {
+		return "?"
+	}
+	return terminationNames(objects)
+}
+
+// resolveCableEnd finds the port or circuit termination named by ref, given as device:port or
+// circuit:side, optionally prefixed with its resource as in rear-ports/nyc1-pp1:1.
+func resolveCableEnd(ctx context.Context, rootURL string, ref string) (*cableEnd, error) {
+	resources := cableResources
+	name := ref
+	if prefix, rest, ok := strings.Cut(ref, "/"); ok {
+		for _, r := range cableResources {
+			if r.resource == prefix {
+				resources = []struct{ domain, resource string }{r}
+				name = rest
+			}
+		}
+	}
+	parent, port, ok := strings.Cut(name, ":")
+	if !ok || parent == "" || port == "" {
+		return nil, fmt.Errorf("%q is not given as device:port or circuit:side", ref)
+	}
+
+	var found []*cableEnd
+	for _, r := range resources {
+		endpoint, err := lookupEndpoint(r.domain, r.resource)
+		if err != nil {
+			return nil, err
+		}
+		var filters url.Values
+		if r.resource == "circuit-terminations" {
+			if filters, err = circuitTerminationFilters(ctx, cableEnv, rootURL, parent, port); err != nil {
+				return nil, err
+			}
+			if filters == nil {
+				continue
+			}
+		} else {
+			filters = url.Values{"device": {parent}, "name": {port}}
+		}
+		objects, err := listObjects(ctx, cableEnv, rootURL+endpoint.path, filters)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range objects {
+			if o, ok := item.(*output.Object); ok {
+				found = append(found, &cableEnd{endpoint: endpoint, object: o})
+			}
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, fmt.Errorf("no port or circuit termination is named %s", ref)
+	case len(found) > 1:
+		var kinds []string
+		for _, end := range found {
+			kinds = append(kinds, end.endpoint.resource+"/"+name)
+		}
+		return nil, fmt.Errorf("%s matches %d ports, give one of %s", ref, len(found), strings.Join(kinds, ", "))
+	}
+	return found[0], nil
+}
+
+// circuitTerminationFilters returns the filters of the termination on side of the circuit with
+// the circuit ID cid on the instance of profile, or nil when side is not A or Z or there is no
+// such circuit.
+func circuitTerminationFilters(ctx context.Context, profile string, rootURL string, cid string, side string) (url.Values, error) {
+	side = strings.ToUpper(side)
+	if side != "A" && side != "Z" {
+		return nil, nil
+	}
+	circuits, err := lookupEndpoint("circuits", "circuits")
+	if err != nil {
+		return nil, err
+	}
+	found, err := listObjects(ctx, profile, rootURL+circuits.path, url.Values{"cid": {cid}})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	var ids []string
+	for _, item := range found {
+		if o, ok := item.(*output.Object); ok {
+			ids = append(ids, fmt.Sprint(o.Get("id")))
+		}
+	}
+	return url.Values{"circuit_id": ids, "term_side": {side}}, nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{CableConnectCmd, CableDisconnectCmd} {
+		c.Flags().StringVarP(&cableEnv, "env", "", "development", "Environment ('development' or 'production')")
+		_ = c.MarkFlagRequired("env")
+		c.Flags().BoolVar(&cableDryRun, "dry-run", false, "Print the changes instead of sending them")
+		CableCmd.AddCommand(c)
+	}
+
+	CableConnectCmd.Flags().StringVar(&cableTo, "to", "", "Port at the other end of the cable, as device:port or circuit:side (required)")
+	_ = CableConnectCmd.MarkFlagRequired("to")
+	CableConnectCmd.Flags().StringVar(&cableType, "type", "", "Cable type, e.g. cat6 or smf")
+	CableConnectCmd.Flags().StringVar(&cableLength, "length", "", "Cable length with its unit, e.g. 3m or 10ft")
+	CableConnectCmd.Flags().StringVar(&cableLabel, "label", "", "Cable label")
+	CableConnectCmd.Flags().StringVar(&cableColor, "color", "", "Cable color as a hex RGB code, e.g. ff0000")
+	CableConnectCmd.Flags().StringVar(&cableStatus, "status", "", "Cable status: connected, planned or decommissioning (Netbox defaults to connected)")
+	CableConnectCmd.Flags().StringVar(&cableDescription, "description", "", "Cable description")
+	_ = CableConnectCmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(cableTypes, cobra.ShellCompDirectiveNoFileComp))
+	_ = CableConnectCmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"connected", "planned", "decommissioning"}, cobra.ShellCompDirectiveNoFileComp))
+}
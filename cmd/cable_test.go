@@ -0,0 +1,84 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestCable(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name: "connect",
+			args: []string{"cable", "connect", "nyc1-leaf1:Ethernet2", "--to", "ZYO-100234:A", "--type", "smf", "--length", "3m", "--label", "C-1042", "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				cables := srv.Objects("/api/dcim/cables/")
+				if len(cables) != 2 {
+					t.Fatalf("%d cables, want 2", len(cables))
+				}
+				got, _ := json.Marshal(cables[1]["a_terminations"])
+				if string(got) != `[{"object_id":2,"object_type":"dcim.interface"}]` {
+					t.Errorf("a_terminations = %s", got)
+				}
+				got, _ = json.Marshal(cables[1]["b_terminations"])
+				if string(got) != `[{"object_id":1,"object_type":"circuits.circuittermination"}]` {
+					t.Errorf("b_terminations = %s", got)
+				}
+			},
+		},
+		{name: "connect_dry_run", args: []string{"cable", "connect", "interfaces/nyc1-leaf1:Management1", "--to", "circuit-terminations/ZYO-100235:a", "--color", "#FF0000", "--length", "1.5 ft", "--env", "development", "--dry-run"}},
+		{name: "connect_cabled", args: []string{"cable", "connect", "nyc1-leaf1:Ethernet1", "--to", "nyc1-leaf1:Ethernet2", "--env", "development"}},
+		{name: "connect_incompatible", args: []string{"cable", "connect", "nyc1-leaf1:Ethernet2", "--to", "nyc1-leaf1:PSU1", "--env", "development"}},
+		{name: "connect_unknown_port", args: []string{"cable", "connect", "nyc1-leaf1:Ethernet9", "--to", "nyc1-leaf1:Ethernet2", "--env", "development"}},
+		{name: "connect_bad_length", args: []string{"cable", "connect", "nyc1-leaf1:Ethernet2", "--to", "ZYO-100234:A", "--length", "3", "--env", "development"}},
+		{
+			name: "disconnect",
+			args: []string{"cable", "disconnect", "nyc1-leaf1:Ethernet1", "nyc1-leaf1:Ethernet2", "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				if n := len(srv.Objects("/api/dcim/cables/")); n != 0 {
+					t.Errorf("%d cables left, want 0", n)
+				}
+			},
+		},
+		{name: "disconnect_not_cabled", args: []string{"cable", "disconnect", "nyc1-leaf1:Ethernet2", "--env", "development"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "cable/"+tt.name, result.String())
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package circuits
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
+	"github.com/decassidy/abc-netbox-cli/cmd/browse"
+)
+
+func init() {
+	browse.Register(browse.Resource{Domain: "circuits", Name: "circuits", Fetch: browseCircuits})
+	browse.Register(browse.Resource{Domain: "circuits", Name: "circuit-terminations", Fetch: browseCircuitTerminations})
+	browse.Register(browse.Resource{Domain: "circuits", Name: "providers", Fetch: browseProviders})
+}
+
+func browseCircuits(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.circuits.circuits_api_url.circuits", query, func() *circuit { return new(circuit) }, func(page *circuit) string {
+		for _, c := range page.Results {
+			objects = append(objects, browse.Object{
+				Id:      c.Id,
+				Display: c.Display,
+				ApiUrl:  c.Url,
+				Fields: []browse.Field{
+					{Label: "Circuit ID", Value: c.Cid},
+					{Label: "Provider", Value: c.Provider.Name},
+					{Label: "Provider Account", Value: c.ProviderAccount.Account},
+					{Label: "Type", Value: c.Type.Name},
+					{Label: "Status", Value: c.Status.Label},
+					{Label: "Tenant", Value: c.Tenant.Name},
+					{Label: "Commit Rate", Value: fmt.Sprintf("%d", c.CommitRate)},
+					{Label: "Termination A", Value: c.TerminationA.Display},
+					{Label: "Termination Z", Value: c.TerminationZ.Display},
+					{Label: "Install Date", Value: c.InstallDate},
+					{Label: "Description", Value: c.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Terminations", Domain: "circuits", Resource: "circuit-terminations", Query: fmt.Sprintf("circuit_id=%d", c.Id)},
+					{Label: "Provider", Domain: "circuits", Resource: "providers", Query: fmt.Sprintf("id=%d", c.Provider.Id)},
+				},
+			})
+		}
+		return page.Next
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseCircuitTerminations(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.circuits.circuits_api_url.circuits_terminations", query, func() *terminations { return new(terminations) }, func(page *terminations) string {
+		for _, t := range page.Results {
+			object := browse.Object{
+				Id:      uint(t.Id),
+				Display: t.Display,
+				ApiUrl:  t.Url,
+				Fields: []browse.Field{
+					{Label: "Circuit", Value: t.Circuit.Cid},
+					{Label: "Term Side", Value: t.TermSide},
+					{Label: "Site", Value: t.Site.Display},
+					{Label: "Provider Network", Value: t.ProviderNetwork.Display},
+					{Label: "Port Speed", Value: fmt.Sprintf("%d", t.PortSpeed)},
+					{Label: "Cross Connect", Value: t.XconnectId},
+					{Label: "Cable", Value: t.Cable.Display},
+					{Label: "Description", Value: t.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Circuit", Domain: "circuits", Resource: "circuits", Query: fmt.Sprintf("id=%d", t.Circuit.Id)},
+				},
+			}
+			if t.Cable.Id != 0 {
+				object.Related = append(object.Related, browse.Link{Label: "Cable", Domain: "dcim", Resource: "cables", Query: fmt.Sprintf("id=%d", t.Cable.Id)})
+			}
+			objects = append(objects, object)
+		}
+		return page.Next
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseProviders(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.circuits.circuits_api_url.providers", query, func() *providers { return new(providers) }, func(page *providers) string {
+		for _, p := range page.Results {
+			objects = append(objects, browse.Object{
+				Id:      p.Id,
+				Display: p.Display,
+				ApiUrl:  p.Url,
+				Fields: []browse.Field{
+					{Label: "Name", Value: p.Name},
+					{Label: "Slug", Value: p.Slug},
+					{Label: "Circuit Count", Value: strconv.Itoa(p.CircuitCount)},
+					{Label: "Description", Value: p.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Circuits", Domain: "circuits", Resource: "circuits", Query: fmt.Sprintf("provider_id=%d", p.Id)},
+				},
+			})
+		}
+		return page.Next
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
@@ -34,8 +34,9 @@ var DeleteCircuitsCircuitTerminationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of circuit termination objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("deleteCircuitsCircuitTerminations called")
+		return nil
 	},
 }
 
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteCircuitsCircuitTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete a circuit termination object.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.circuits.circuits_api_url.circuits_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.circuits.circuits_api_url.circuits_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsCircuitTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsCircuitTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteCircuitsCircuitTerminationsByIdCmd", err)
 	}
 
-	DeleteCircuitsCircuitTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit termination object to be deleted")
-	err = DeleteCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteCircuitsCircuitTerminationsByIdCmd", err)
-	}
+	DeleteCircuitsCircuitTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit termination object to be deleted")
+	api.AddLookupFlags(DeleteCircuitsCircuitTerminationsByIdCmd, "cmd.circuits.circuits_api_url.circuits_terminations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
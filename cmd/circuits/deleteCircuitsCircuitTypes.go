@@ -35,8 +35,9 @@ var DeleteCircuitsCircuitTypesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete a list of circuit type objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("deleteCircuitsCircuitTypes called")
+		return nil
 	},
 }
 
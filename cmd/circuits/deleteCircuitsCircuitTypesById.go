@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteCircuitsCircuitTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete a circuit type object.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.circuits.circuits_api_url.circuit_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.circuits.circuits_api_url.circuit_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsCircuitTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsCircuitTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsCircuitTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag env as required: %s - for DeleteCircuitsCircuitTypesByIdCmd", err)
 	}
 
-	DeleteCircuitsCircuitTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit type to be deleted")
-	err = DeleteCircuitsCircuitTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag id as required: %s - for DeleteCircuitsCircuitTypesByIdCmd", err)
-	}
+	DeleteCircuitsCircuitTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit type to be deleted")
+	api.AddLookupFlags(DeleteCircuitsCircuitTypesByIdCmd, "cmd.circuits.circuits_api_url.circuit_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
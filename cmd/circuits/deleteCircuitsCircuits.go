@@ -34,21 +34,24 @@ var DeleteCircuitsCircuitsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of circuit objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.circuits.circuits_api_url.circuits_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.circuits.circuits_api_url.circuits_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsCircuitsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsCircuitsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsCircuitsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteCircuitsCircuitsCmd", err)
 	}
 
-	DeleteCircuitsCircuitsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data of objects to delete")
+	DeleteCircuitsCircuitsCmd.Flags().StringP("data", "", "", "JSON data of objects to delete")
 	err = DeleteCircuitsCircuitsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteCircuitsCircuitsCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteCircuitsCircuitsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete a circuit object.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.circuits.circuits_api_url.circuits_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.circuits.circuits_api_url.circuits_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsCircuitsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsCircuitsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsCircuitsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteCircuitsCircuitsByIdCmd", err)
 	}
 
-	DeleteCircuitsCircuitsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit to be deleted")
-	err = DeleteCircuitsCircuitsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteCircuitsCircuitsByIdCmd", err)
-	}
+	DeleteCircuitsCircuitsByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit to be deleted")
+	api.AddLookupFlags(DeleteCircuitsCircuitsByIdCmd, "cmd.circuits.circuits_api_url.circuits_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteCircuitsProviderNetworksByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete an provider network object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.circuits.circuits_api_url.provider_networks_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.circuits.circuits_api_url.provider_networks_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsProviderNetworksByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsProviderNetworksByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsProviderNetworksByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag env as required: %s - for DeleteCircuitsProviderNetworksByIdCmd", err)
 	}
 
-	DeleteCircuitsProviderNetworksByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider network object to be deleted")
-	err = DeleteCircuitsProviderNetworksByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag id as required: %s - for DeleteCircuitsProviderNetworksByIdCmd", err)
-	}
+	DeleteCircuitsProviderNetworksByIdCmd.Flags().IntP("id", "", 0, "ID of the provider network object to be deleted")
+	api.AddLookupFlags(DeleteCircuitsProviderNetworksByIdCmd, "cmd.circuits.circuits_api_url.provider_networks_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
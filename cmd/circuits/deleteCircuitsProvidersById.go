@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteCircuitsProvidersByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Delete an provider object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.circuits.circuits_api_url.providers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.circuits.circuits_api_url.providers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteCircuitsProvidersByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteCircuitsProvidersByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteCircuitsProvidersByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag env as required: %s - for DeleteCircuitsProvidersByIdCmd", err)
 	}
 
-	DeleteCircuitsProvidersByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider object to be deleted")
-	err = DeleteCircuitsProvidersByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag id as required: %s - for DeleteCircuitsProvidersByIdCmd", err)
-	}
+	DeleteCircuitsProvidersByIdCmd.Flags().IntP("id", "", 0, "ID of the provider object to be deleted")
+	api.AddLookupFlags(DeleteCircuitsProvidersByIdCmd, "cmd.circuits.circuits_api_url.providers_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -80,9 +81,10 @@ type terminations struct {
 			Slug    string `json:"slug,omitempty"`
 			Color   string `json:"color,omitempty"`
 		} `json:"tags,omitempty"`
-		Created     string `json:"created,omitempty"`
-		LastUpdated string `json:"last_updated,omitempty"`
-		Occupied    bool   `json:"_occupied,omitempty"`
+		CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+		Created      string                 `json:"created,omitempty"`
+		LastUpdated  string                 `json:"last_updated,omitempty"`
+		Occupied     bool                   `json:"_occupied,omitempty"`
 	} `json:"results,omitempty"`
 }
 
@@ -96,9 +98,11 @@ and usage of using your command. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(terminations)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuits_terminations")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuits_terminations"); err != nil {
+			return err
+		}
 
 		color.Cyan("\nTerminations Count: %d\n", responseObject.Count)
 		for _, result := range responseObject.Results {
@@ -202,22 +206,26 @@ to quickly create a Cobra application.`,
 					color.Cyan("\tTags: %s\n", color.RedString("No tags found"))
 				}
 			}
+			api.DisplayCustomFields(result.CustomFields)
 			color.Cyan("\tCreated: %s\n", color.YellowString(result.Created))
 			color.Cyan("\tLast Updated: %s\n", color.YellowString(result.LastUpdated))
 			color.Cyan("\tOccupied: %t\n\n", result.Occupied)
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitTerminationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment from which the server is running")
+	GetCircuitsCircuitTerminationsCmd.Flags().StringP("env", "", "development", "Environment from which the server is running")
 	err := GetCircuitsCircuitTerminationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsCircuitTerminationsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetCircuitsCircuitTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getCircuitsCircuitTerminationsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -76,9 +77,10 @@ type TerminationsByID struct {
 		Slug    string `json:"slug,omitempty"`
 		Color   string `json:"color,omitempty"`
 	} `json:"tags,omitempty"`
-	Created     string `json:"created,omitempty"`
-	LastUpdated string `json:"last_updated,omitempty"`
-	Occupied    bool   `json:"_occupied,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	Created      string                 `json:"created,omitempty"`
+	LastUpdated  string                 `json:"last_updated,omitempty"`
+	Occupied     bool                   `json:"_occupied,omitempty"`
 }
 
 // GetCircuitsCircuitTerminationsByIdCmd represents the circuitTerminationsByID command
@@ -88,9 +90,11 @@ var GetCircuitsCircuitTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a Circuit Termination object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(TerminationsByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuits_terminations_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuits_terminations_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("==========================================================\n")
@@ -193,29 +197,28 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: %s\n", color.RedString("No tags found"))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: %s\n", color.YellowString(responseObject.Created))
 			color.Cyan("\tLast Updated: %s\n", color.YellowString(responseObject.LastUpdated))
 			color.Cyan("\tOccupied: %t\n\n", responseObject.Occupied)
 		} else {
-			color.Red("  Doh! No circuit termination object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No circuit termination object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsCircuitTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag env as required: %s - for GetCircuitsCircuitTerminationsByIdCmd", err)
 	}
 
-	GetCircuitsCircuitTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit termination object")
-	err = GetCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag id as required: %s - for GetCircuitsCircuitTerminationsByIdCmd", err)
-	}
+	GetCircuitsCircuitTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit termination object")
+	api.AddLookupFlags(GetCircuitsCircuitTerminationsByIdCmd, "cmd.circuits.circuits_api_url.circuits_terminations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -41,9 +42,10 @@ type circuitTypes struct {
 			dcim.CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created      string `json:"created"`
-		LastUpdated  string `json:"last_updated"`
-		CircuitCount int    `json:"circuit_count"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		CircuitCount int                    `json:"circuit_count"`
 	} `json:"results"`
 }
 
@@ -54,9 +56,11 @@ var GetCircuitsCircuitTypesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of Circuit Type objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(circuitTypes)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuit_types")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuit_types"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\nABC Total Circuit Types in Netbox: "+color.YellowString("%d"), responseObject.Count)
@@ -92,24 +96,28 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for type: %s", color.YellowString("%s", types.Display)))
 					}
 				}
+				api.DisplayCustomFields(types.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), types.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), types.LastUpdated)
 			}
 		} else {
 			color.Cyan("\tCircuit Types: " + color.RedString("No circuit types found on the server: "))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitTypesCmd.Flags().StringVarP(&serverEnv, "env", "", "", "Environment ('development' or 'production')")
+	GetCircuitsCircuitTypesCmd.Flags().StringP("env", "", "", "Environment ('development' or 'production')")
 	err := GetCircuitsCircuitTypesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env as required: %s - for GetCircuitsCircuitTerminationsByIDCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetCircuitsCircuitTypesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// circuitTypesCmd.PersistentFlags().String("foo", "", "A help for foo")
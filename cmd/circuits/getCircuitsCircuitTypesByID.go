@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -37,9 +38,10 @@ type circuitTypesByID struct {
 		dcim.CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created      string `json:"created"`
-	LastUpdated  string `json:"last_updated"`
-	CircuitCount int    `json:"circuit_count"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	CircuitCount int                    `json:"circuit_count"`
 }
 
 // GetCircuitsCircuitTypesByIdCmd represents the getCircuitsCircuitTypesById command
@@ -49,9 +51,11 @@ var GetCircuitsCircuitTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Get a circuit type object.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(circuitTypesByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuit_types_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuit_types_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("\n============================================================================")
@@ -85,28 +89,27 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags found for type: %s", color.YellowString("%s", responseObject.Display)))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 		} else {
-			color.Red("  Doh! No circuit type object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No circuit type object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsCircuitTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsCircuitTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsCircuitTypesByIdCmd", err)
 	}
 
-	GetCircuitsCircuitTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit type object")
-	err = GetCircuitsCircuitTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %v", err)
-	}
+	GetCircuitsCircuitTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit type object")
+	api.AddLookupFlags(GetCircuitsCircuitTypesByIdCmd, "cmd.circuits.circuits_api_url.circuit_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
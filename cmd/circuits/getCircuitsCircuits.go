@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -89,8 +90,9 @@ type circuit struct {
 			dcim.CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -101,9 +103,11 @@ var GetCircuitsCircuitsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Get a list of Circuits objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(circuit)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuits")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuits"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\nABC Total Circuits in Netbox: "+color.YellowString("%d"), responseObject.Count)
@@ -294,23 +298,27 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for circuit: ") + color.YellowString("%s", circuit.Display))
 					}
 				}
+				api.DisplayCustomFields(circuit.CustomFields)
 				color.Cyan("\tABC Circuit Provider Created: "+color.YellowString("%s"), circuit.Created)
 				color.Cyan("\tABC Circuit Provider Last Updated: "+color.YellowString("%s"), circuit.LastUpdated)
 			}
 		} else {
 			color.Cyan("\tCircuits: " + color.RedString("No circuits found on the server: "))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "environment ('development' or 'production')")
+	GetCircuitsCircuitsCmd.Flags().StringP("env", "", "development", "environment ('development' or 'production')")
 	err := GetCircuitsCircuitsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("%s - for GetCircuitsCircuitsCmd", err)
 	}
+
+	api.AddCustomFieldFilterFlag(GetCircuitsCircuitsCmd)
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// allCircuitsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -85,8 +86,9 @@ type circuitsByID struct {
 		dcim.CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetCircuitsCircuitsByIDCmd represents the circuitByID command
@@ -96,9 +98,11 @@ var GetCircuitsCircuitsByIDCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Get a ABC Circuit object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(circuitsByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.circuits_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.circuits_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			color.Cyan("\n============================================================================")
@@ -287,28 +291,27 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags found for circuit: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tABC Circuit Provider Created: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tABC Circuit Provider Last Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 		} else {
-			color.Red("  Doh! No circuit object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No circuit object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsCircuitsByIDCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment from which the server is running")
+	GetCircuitsCircuitsByIDCmd.Flags().StringP("env", "", "development", "Environment from which the server is running")
 	err := GetCircuitsCircuitsByIDCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Could not mark 'env' flag as required %s - for GetCircuitsCircuitsByIDCmd", err)
 	}
 
-	GetCircuitsCircuitsByIDCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit object to get")
-	err = GetCircuitsCircuitsByIDCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Could not mark 'id' flag as required %s - for GetCircuitsCircuitsByIDCmd", err)
-	}
+	GetCircuitsCircuitsByIDCmd.Flags().IntP("id", "", 0, "ID of the circuit object to get")
+	api.AddLookupFlags(GetCircuitsCircuitsByIDCmd, "cmd.circuits.circuits_api_url.circuits_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -52,8 +53,9 @@ type providerAccounts struct {
 			Slug    string `json:"slug"`
 			Color   string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -64,9 +66,11 @@ var GetCircuitsProviderAccountsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of Provider Account objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providerAccounts)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.provider_accounts")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.provider_accounts"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\nABC Total Provider Accounts in Netbox: "+color.YellowString("%d"), responseObject.Count)
@@ -119,24 +123,28 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for provider account: %s", color.YellowString("%s", account.Display)))
 					}
 				}
+				api.DisplayCustomFields(account.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), account.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), account.LastUpdated)
 			}
 		} else {
 			color.Cyan("\nABC Total Provider Accounts in Netbox: " + color.RedString("No provider accounts found on the server"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProviderAccountsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProviderAccountsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProviderAccountsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProviderAccountsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetCircuitsProviderAccountsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// circuitProviderAccountsCmd.PersistentFlags().String("foo", "", "A help for foo")
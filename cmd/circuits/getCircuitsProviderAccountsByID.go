@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -48,8 +49,9 @@ type providerAccountsByID struct {
 		Slug    string `json:"slug"`
 		Color   string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetCircuitsProviderAccountsByIDCmd represents the circuitProviderAccountsByID command
@@ -59,9 +61,11 @@ var GetCircuitsProviderAccountsByIDCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a Provider Account object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providerAccountsByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.provider_accounts_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.provider_accounts_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("\n============================================================================")
@@ -112,29 +116,28 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags found for provider account: %s", color.YellowString("%s", responseObject.Display)))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s\n"), responseObject.LastUpdated)
 		} else {
-			color.Red("  Doh! No provider account object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No provider account object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
 
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProviderAccountsByIDCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProviderAccountsByIDCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProviderAccountsByIDCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProviderAccountsByIDCmd", err)
 	}
 
-	GetCircuitsProviderAccountsByIDCmd.Flags().IntVarP(&id, "id", "", 0, "Provider Account ID")
-	err = GetCircuitsProviderAccountsByIDCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProviderAccountsByIDCmd", err)
-	}
+	GetCircuitsProviderAccountsByIDCmd.Flags().IntP("id", "", 0, "Provider Account ID")
+	api.AddLookupFlags(GetCircuitsProviderAccountsByIDCmd, "cmd.circuits.circuits_api_url.provider_accounts_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
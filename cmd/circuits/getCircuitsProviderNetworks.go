@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -48,8 +49,9 @@ type providerNetworks struct {
 			dcim.CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -60,9 +62,11 @@ var GetCircuitsProviderNetworksCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of Provider Network objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providerNetworks)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.provider_networks")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.provider_networks"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\nABC Total Provider Networks in Netbox: "+color.YellowString("%d"), responseObject.Count)
@@ -115,24 +119,28 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for provider account: %s", color.YellowString("%s", network.Display)))
 					}
 				}
+				api.DisplayCustomFields(network.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), network.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), network.LastUpdated)
 			}
 		} else {
 			color.Cyan("  ABC Total Provider Networks in Netbox: " + color.RedString("No provider networks found on the server\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProviderNetworksCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProviderNetworksCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProviderNetworksCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProviderNetworksCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetCircuitsProviderNetworksCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// circuitProviderNetworksCmd.PersistentFlags().String("foo", "", "A help for foo")
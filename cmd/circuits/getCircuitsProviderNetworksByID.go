@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -44,8 +45,9 @@ type providerNetworksByID struct {
 		dcim.CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetCircuitsProviderNetworksByIDCmd represents the circuitProviderNetworksByID command
@@ -53,9 +55,11 @@ var GetCircuitsProviderNetworksByIDCmd = &cobra.Command{
 	Use:   "getCircuitsProviderNetworksById",
 	Short: "GET a Provider Network object by ID.",
 	Long:  `GET a Provider Network object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providerNetworksByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.provider_networks_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.provider_networks_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("\n============================================================================")
@@ -106,28 +110,27 @@ var GetCircuitsProviderNetworksByIDCmd = &cobra.Command{
 					color.Cyan("\tTags: " + color.RedString("No tags found for provider account: %s", color.YellowString("%s", responseObject.Display)))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 		} else {
-			color.Red("  Doh! No provider network object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No provider network object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProviderNetworksByIDCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProviderNetworksByIDCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProviderNetworksByIDCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProviderNetworksByIDCmd", err)
 	}
 
-	GetCircuitsProviderNetworksByIDCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider network object")
-	err = GetCircuitsProviderNetworksByIDCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err.Error())
-	}
+	GetCircuitsProviderNetworksByIDCmd.Flags().IntP("id", "", 0, "ID of the provider network object")
+	api.AddLookupFlags(GetCircuitsProviderNetworksByIDCmd, "cmd.circuits.circuits_api_url.provider_networks_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -46,9 +47,10 @@ type providers struct {
 			dcim.CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created      string `json:"created"`
-		LastUpdated  string `json:"last_updated"`
-		CircuitCount int    `json:"circuit_count"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		CircuitCount int                    `json:"circuit_count"`
 	} `json:"results"`
 }
 
@@ -62,9 +64,11 @@ and usage of using your command. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providers)
-		ApiConnectionNonID(responseObject, "GET", "cmd.circuits.circuits_api_url.providers")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.circuits.circuits_api_url.providers"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\nABC Total Providers in Netbox: "+color.YellowString("%d"), responseObject.Count)
@@ -118,23 +122,27 @@ to quickly create a Cobra application.`,
 						color.Cyan("\tTags: " + color.RedString("No tags found for provider: %s", color.YellowString("%s", provider.Display)))
 					}
 				}
+				api.DisplayCustomFields(provider.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), provider.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), provider.LastUpdated)
 				color.Cyan("\tCircuit Count: "+color.YellowString("%d"), provider.CircuitCount)
 			}
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProvidersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProvidersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProvidersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProvidersCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetCircuitsProvidersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getCircuitsProvidersCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -42,9 +43,10 @@ type providersByID struct {
 		dcim.CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created      string `json:"created"`
-	LastUpdated  string `json:"last_updated"`
-	CircuitCount int    `json:"circuit_count"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	CircuitCount int                    `json:"circuit_count"`
 }
 
 // GetCircuitsProvidersByIdCmd represents the getCircuitsProvidersById command
@@ -57,9 +59,11 @@ and usage of using your command. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(providersByID)
-		apiConnectionID(responseObject, "GET", "cmd.circuits.circuits_api_url.providers_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.circuits.circuits_api_url.providers_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("\n============================================================================")
@@ -111,29 +115,28 @@ to quickly create a Cobra application.`,
 					color.Cyan("\tTags: " + color.RedString("No tags found for provider: %s", color.YellowString("%s", responseObject.Display)))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 			color.Cyan("\tCircuit Count: "+color.YellowString("%d\n"), responseObject.CircuitCount)
 		} else {
-			color.Red("  Doh! No provider object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No provider object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetCircuitsProvidersByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetCircuitsProvidersByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetCircuitsProvidersByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProvidersByIdCmd", err)
 	}
 
-	GetCircuitsProvidersByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Provider ID")
-	err = GetCircuitsProvidersByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetCircuitsProvidersByIdCmd", err)
-	}
+	GetCircuitsProvidersByIdCmd.Flags().IntP("id", "", 0, "Provider ID")
+	api.AddLookupFlags(GetCircuitsProvidersByIdCmd, "cmd.circuits.circuits_api_url.providers_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
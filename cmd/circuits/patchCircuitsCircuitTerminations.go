@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -36,26 +37,32 @@ ABC Netbox Automation Tools:
   PATCH a list of circuit termination objects.
 
   Example: "[{\"id\": 65, \"circuits\": {\"cid\": \"MyFakeCircuit654\"}, \"term_side\": \"Z\", \"site\": {\"name\": \"Your Site Name]\"}}]"`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.circuits_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.circuits_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 	// Here you will define your flags and configuration settings.
 
-	PatchCircuitsCircuitTerminationsCmd.PersistentFlags().StringVarP(&serverEnv, "env", "e", "development", "Environment ('production' or 'development')")
+	PatchCircuitsCircuitTerminationsCmd.PersistentFlags().StringP("env", "e", "development", "Environment ('production' or 'development')")
 	err := PatchCircuitsCircuitTerminationsCmd.MarkPersistentFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsCircuitTerminationsCmd", err)
 	}
 
-	PatchCircuitsCircuitTerminationsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsCircuitTerminationsCmd.Flags().StringP("data", "", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsCircuitTerminationsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsCircuitTerminationsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsCircuitTerminationsCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsCircuitTerminationsCmd.PersistentFlags().String("foo", "", "A help for foo")
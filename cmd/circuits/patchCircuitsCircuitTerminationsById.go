@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchCircuitsCircuitTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Patch an circuit termination object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.circuits_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.circuits_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsCircuitTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsCircuitTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsCircuitTerminationsByIdCmd", err)
 	}
 
-	PatchCircuitsCircuitTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit termination object")
-	err = PatchCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchCircuitsCircuitTerminationsByIdCmd", err)
-	}
+	PatchCircuitsCircuitTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit termination object")
+	api.AddLookupFlags(PatchCircuitsCircuitTerminationsByIdCmd, "cmd.circuits.circuits_api_url.circuits_terminations_id")
 
-	PatchCircuitsCircuitTerminationsByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data fields to be patched (changed)")
+	PatchCircuitsCircuitTerminationsByIdCmd.Flags().StringP("data", "", "", "JSON data fields to be patched (changed)")
 	err = PatchCircuitsCircuitTerminationsByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsCircuitTerminationsByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsCircuitTerminationsByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitTerminationsByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsCircuitTerminationsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,31 @@ var PatchCircuitsCircuitTypesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a List of Circuit Type objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.circuit_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.circuit_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsCircuitTypesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsCircuitTypesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsCircuitTypesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsCircuitTypesCmd", err)
 	}
 
-	PatchCircuitsCircuitTypesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsCircuitTypesCmd.Flags().StringP("data", "", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsCircuitTypesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsCircuitTypesCmd", err)
 	}
+
+	api.AddDryRunFlag(PatchCircuitsCircuitTypesCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitTypesCmd)
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitTypeCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package circuits
 
 import (
 	_ "bytes"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -35,32 +36,35 @@ var PatchCircuitsCircuitTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Patch an circuit type object by Netbox ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.circuit_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.circuit_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsCircuitTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsCircuitTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsCircuitTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsCircuitTypesByIdCmd", err)
 	}
 
-	PatchCircuitsCircuitTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit type to be patched")
-	err = PatchCircuitsCircuitTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchCircuitsCircuitTypesByIdCmd", err)
-	}
+	PatchCircuitsCircuitTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit type to be patched")
+	api.AddLookupFlags(PatchCircuitsCircuitTypesByIdCmd, "cmd.circuits.circuits_api_url.circuit_types_id")
 
-	PatchCircuitsCircuitTypesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsCircuitTypesByIdCmd.Flags().StringP("data", "", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsCircuitTypesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsCircuitTypesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsCircuitTypesByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitTypesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsCircuitTypesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -35,25 +36,31 @@ var PatchCircuitsCircuitsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of Circuit objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.circuits")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.circuits"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsCircuitsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsCircuitsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsCircuitsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for PatchCircuitsCircuitsCmd", err)
 	}
 
-	PatchCircuitsCircuitsCmd.Flags().StringVarP(&data, "data", "d", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsCircuitsCmd.Flags().StringP("data", "d", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsCircuitsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for PatchCircuitsCircuitsCmd", err)
 	}
+
+	api.AddDryRunFlag(PatchCircuitsCircuitsCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitsCmd)
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchCircuitsCircuitsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Patch a circuit object.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.circuits_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.circuits_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsCircuitsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsCircuitsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsCircuitsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsCircuitsByIdCmd", err)
 	}
 
-	PatchCircuitsCircuitsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the circuit object you want to patch")
-	err = PatchCircuitsCircuitsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchCircuitsCircuitsByIdCmd", err)
-	}
+	PatchCircuitsCircuitsByIdCmd.Flags().IntP("id", "", 0, "ID of the circuit object you want to patch")
+	api.AddLookupFlags(PatchCircuitsCircuitsByIdCmd, "cmd.circuits.circuits_api_url.circuits_id")
 
-	PatchCircuitsCircuitsByIdCmd.Flags().StringVarP(&data, "data", "", "", "PATCH JSON data for object changes")
+	PatchCircuitsCircuitsByIdCmd.Flags().StringP("data", "", "", "PATCH JSON data for object changes")
 	err = PatchCircuitsCircuitsByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsCircuitsByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsCircuitsByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsCircuitsByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsCircuitsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
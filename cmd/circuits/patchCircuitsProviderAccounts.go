@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/decassidy/abc-netbox-cli/cmd/dcim"
 	"github.com/spf13/cobra"
 	"log"
@@ -58,26 +59,32 @@ var PatchCircuitsProviderAccountsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of Provider Account objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.provider_accounts_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.provider_accounts_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsProviderAccountsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "\"Environment ('development' or 'production')")
+	PatchCircuitsProviderAccountsCmd.Flags().StringP("env", "", "development", "\"Environment ('development' or 'production')")
 	err := PatchCircuitsProviderAccountsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsProviderAccountsCmd", err)
 	}
 
-	PatchCircuitsProviderAccountsCmd.Flags().StringVarP(&data, "data", "d", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsProviderAccountsCmd.Flags().StringP("data", "d", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsProviderAccountsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsProviderAccountsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProviderAccountsCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProviderAccountsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProviderAccountsCmd.PersistentFlags().String("foo", "", "A help for foo")
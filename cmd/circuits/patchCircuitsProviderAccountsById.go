@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchCircuitsProviderAccountsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an provider account object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.provider_accounts_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.provider_accounts_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsProviderAccountsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsProviderAccountsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsProviderAccountsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsProviderAccountsByIdCmd", err)
 	}
 
-	PatchCircuitsProviderAccountsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider account to be patched (changed)")
-	err = PatchCircuitsProviderAccountsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchCircuitsProviderAccountsByIdCmd", err)
-	}
+	PatchCircuitsProviderAccountsByIdCmd.Flags().IntP("id", "", 0, "ID of the provider account to be patched (changed)")
+	api.AddLookupFlags(PatchCircuitsProviderAccountsByIdCmd, "cmd.circuits.circuits_api_url.provider_accounts_id")
 
-	PatchCircuitsProviderAccountsByIdCmd.Flags().StringVarP(&data, "data", "", "", "PATCH JSON data for object changes")
+	PatchCircuitsProviderAccountsByIdCmd.Flags().StringP("data", "", "", "PATCH JSON data for object changes")
 	err = PatchCircuitsProviderAccountsByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsProviderAccountsByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProviderAccountsByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProviderAccountsByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProviderAccountsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
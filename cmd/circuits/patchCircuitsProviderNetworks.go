@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchCircuitsProviderNetworksCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of provider network objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.provider_networks_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.provider_networks_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsProviderNetworksCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsProviderNetworksCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsProviderNetworksCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsProviderNetworksCmd", err)
 	}
 
-	PatchCircuitsProviderNetworksCmd.Flags().StringVarP(&data, "data", "d", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsProviderNetworksCmd.Flags().StringP("data", "d", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsProviderNetworksCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsProviderNetworksCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProviderNetworksCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProviderNetworksCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProviderNetworksCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchCircuitsProviderNetworksByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Patch an provider network object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.provider_networks_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.provider_networks_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsProviderNetworksByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsProviderNetworksByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsProviderNetworksByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env as required: %s - for PatchCircuitsProviderNetworksByIdCmd", err)
 	}
 
-	PatchCircuitsProviderNetworksByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider network object to be patched (changed)")
-	err = PatchCircuitsProviderNetworksByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id as required: %s - for PatchCircuitsProviderNetworksByIdCmd", err)
-	}
+	PatchCircuitsProviderNetworksByIdCmd.Flags().IntP("id", "", 0, "ID of the provider network object to be patched (changed)")
+	api.AddLookupFlags(PatchCircuitsProviderNetworksByIdCmd, "cmd.circuits.circuits_api_url.provider_networks_id")
 
-	PatchCircuitsProviderNetworksByIdCmd.Flags().StringVarP(&data, "data", "d", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsProviderNetworksByIdCmd.Flags().StringP("data", "d", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsProviderNetworksByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data as required: %s - for PatchCircuitsProviderNetworksByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProviderNetworksByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProviderNetworksByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProviderNetworksByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchCircuitsProvidersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of provider objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.circuits.circuits_api_url.providers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.circuits.circuits_api_url.providers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchCircuitsProvidersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsProvidersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsProvidersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchCircuitsProvidersCmd", err)
 	}
 
-	PatchCircuitsProvidersCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsProvidersCmd.Flags().StringP("data", "", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsProvidersCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchCircuitsProvidersCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProvidersCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProvidersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProvidersCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,8 +35,11 @@ var PatchCircuitsProvidersByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Patch an provider object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.circuits.circuits_api_url.providers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.circuits.circuits_api_url.providers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
@@ -43,24 +47,24 @@ func init() {
 
 	// Here you will define your flags and configuration settings.
 
-	PatchCircuitsProvidersByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchCircuitsProvidersByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchCircuitsProvidersByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env as required: %s - for PatchCircuitsProvidersByIdCmd", err)
 	}
 
-	PatchCircuitsProvidersByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the provider network object to be patched (changed)")
-	err = PatchCircuitsProvidersByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id as required: %s - for PatchCircuitsProvidersByIdCmd", err)
-	}
+	PatchCircuitsProvidersByIdCmd.Flags().IntP("id", "", 0, "ID of the provider network object to be patched (changed)")
+	api.AddLookupFlags(PatchCircuitsProvidersByIdCmd, "cmd.circuits.circuits_api_url.providers_id")
 
-	PatchCircuitsProvidersByIdCmd.Flags().StringVarP(&data, "data", "d", "", "JSON data to be sent in PATCH request")
+	PatchCircuitsProvidersByIdCmd.Flags().StringP("data", "d", "", "JSON data to be sent in PATCH request")
 	err = PatchCircuitsProvidersByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data as required: %s - for PatchCircuitsProvidersByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchCircuitsProvidersByIdCmd)
+	api.AddCustomFieldFlag(PatchCircuitsProvidersByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchCircuitsProvidersByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -36,26 +37,32 @@ ABC Netbox Automation Tools:
   POST a list of circuit termination objects.
 
 See Netbox API documentation for more information.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.circuits.circuits_api_url.circuit_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.circuits.circuits_api_url.circuits_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsCircuitTerminationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostCircuitsCircuitTerminationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostCircuitsCircuitTerminationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsTerminationsCmd", err)
 	}
 
-	PostCircuitsCircuitTerminationsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be posted (required)")
+	PostCircuitsCircuitTerminationsCmd.Flags().StringP("data", "", "", "JSON data to be posted (required)")
 	err = PostCircuitsCircuitTerminationsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsTerminationsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostCircuitsCircuitTerminationsCmd)
+	api.AddCustomFieldFlag(PostCircuitsCircuitTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -32,8 +33,11 @@ var PostCircuitsCircuitTypeCmd = &cobra.Command{
 	Use:   "postCircuitType",
 	Short: "POST a list of ABC Circuit Type objects.",
 	Long:  `POST a list of ABC Circuit Type objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.circuits.circuits_api_url.circuit_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.circuits.circuits_api_url.circuit_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
@@ -44,18 +48,21 @@ var PostCircuitsCircuitTypeCmd = &cobra.Command{
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsCircuitTypeCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostCircuitsCircuitTypeCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostCircuitsCircuitTypeCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsCircuitTypeCmd", err)
 	}
 
-	PostCircuitsCircuitTypeCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be posted (required)")
+	PostCircuitsCircuitTypeCmd.Flags().StringP("data", "", "", "JSON data to be posted (required)")
 	err = PostCircuitsCircuitTypeCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsCircuitTypeCmd", err)
 	}
 
+	api.AddDryRunFlag(PostCircuitsCircuitTypeCmd)
+	api.AddCustomFieldFlag(PostCircuitsCircuitTypeCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitTypeCmd.PersistentFlags().String("foo", "", "A help for foo")
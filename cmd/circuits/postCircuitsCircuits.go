@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -36,26 +37,32 @@ ABC Netbox Automation Tools:
   POST a list of Circuit objects.
 
 See Netbox API documentation for more information.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.circuits.circuits_api_url.circuits_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.circuits.circuits_api_url.circuits_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsCircuitsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostCircuitsCircuitsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostCircuitsCircuitsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsCircuitsCmd", err)
 	}
 
-	PostCircuitsCircuitsCmd.Flags().StringVarP(&data, "data", "d", "", "JSON Data to be posted")
+	PostCircuitsCircuitsCmd.Flags().StringP("data", "d", "", "JSON Data to be posted")
 	err = PostCircuitsCircuitsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsCircuitsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostCircuitsCircuitsCmd)
+	api.AddCustomFieldFlag(PostCircuitsCircuitsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitsCmd.PersistentFlags().String("foo", "", "A help for foo")
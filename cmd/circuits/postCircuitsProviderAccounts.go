@@ -33,21 +33,22 @@ var PostCircuitsProviderAccountsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Post a list of provider account objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsProviderAccountsCmd.Flags().StringVarP(&serverEnv, "env", "", "", "Environment ('development' or 'production')")
+	PostCircuitsProviderAccountsCmd.Flags().StringP("env", "", "", "Environment ('development' or 'production')")
 	err := PostCircuitsProviderAccountsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsProviderAccountsCmd", err)
 	}
 
-	PostCircuitsProviderAccountsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be posted (required)")
+	PostCircuitsProviderAccountsCmd.Flags().StringP("data", "", "", "JSON data to be posted (required)")
 	err = PostCircuitsProviderAccountsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsProviderAccountsCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostCircuitsProviderNetworksCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Post a list of provider network objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.circuits.circuits_api_url.provider_networks_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.circuits.circuits_api_url.provider_networks_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsProviderNetworksCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostCircuitsProviderNetworksCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostCircuitsProviderNetworksCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsProviderNetworksCmd", err)
 	}
 
-	PostCircuitsProviderNetworksCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be posted (required)")
+	PostCircuitsProviderNetworksCmd.Flags().StringP("data", "", "", "JSON data to be posted (required)")
 	err = PostCircuitsProviderNetworksCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsProviderNetworksCmd", err)
 	}
 
+	api.AddDryRunFlag(PostCircuitsProviderNetworksCmd)
+	api.AddCustomFieldFlag(PostCircuitsProviderNetworksCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitsProviderNetworksCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package circuits
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostCircuitsProvidersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Post a list of provider objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.circuits.circuits_api_url.providers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.circuits.circuits_api_url.providers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostCircuitsProvidersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostCircuitsProvidersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostCircuitsProvidersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostCircuitsProvidersCmd", err)
 	}
 
-	PostCircuitsProvidersCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be posted (required)")
+	PostCircuitsProvidersCmd.Flags().StringP("data", "", "", "JSON data to be posted (required)")
 	err = PostCircuitsProvidersCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostCircuitsProvidersCmd", err)
 	}
 
+	api.AddDryRunFlag(PostCircuitsProvidersCmd)
+	api.AddCustomFieldFlag(PostCircuitsProvidersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postCircuitsProvidersCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -0,0 +1,350 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultCompletionCacheTTL is how long completion candidates fetched from the Netbox server are
+// reused before the server is asked again, unless cmd.completion_cache_ttl in netbox_config.yaml
+// says otherwise.
+const defaultCompletionCacheTTL = 2 * time.Minute
+
+// completionTimeout bounds every request made while the shell is waiting for candidates.
+var completionTimeout = 5 * time.Second
+
+// commandNamePattern splits a command name such as getDcimDevicesById into its verb, domain and resource.
+var commandNamePattern = regexp.MustCompile(`^(get|post|patch|put|delete)(Circuits|Core|Dcim|Extras|Ipam|Tenancy|Users|Virtualization|Vpn|Wireless)([A-Za-z0-9]+?)(ById|ByID)?$`)
+
+// slugFlagEndpoints maps reference flags that take a slug to the endpoint listing their candidates.
+var slugFlagEndpoints = map[string]string{
+	"site":     "cmd.dcim.dcim_api_url.sites_id",
+	"role":     "cmd.dcim.dcim_api_url.device_roles_id",
+	"tag":      "cmd.extras.extras_api_url.tags",
+	"tenant":   "cmd.tenancy.tenancy_api_url.tenants",
+	"platform": "cmd.dcim.dcim_api_url.platforms_id",
+}
+
+// enumFlagFields lists flags whose values are choices of the Netbox field with the same name.
+var enumFlagFields = []string{"status", "type", "face", "airflow", "mode", "kind"}
+
+// completionItem is a single completion candidate as stored in the on-disk cache.
+type completionItem struct {
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// completionCache is the on-disk representation of the candidates for a single URL.
+type completionCache struct {
+	Fetched time.Time        `json:"fetched"`
+	Items   []completionItem `json:"items"`
+}
+
+// briefList is the subset of a Netbox list response needed to offer IDs, names, slugs and serials.
+type briefList struct {
+	Results []struct {
+		Id      uint   `json:"id"`
+		Display string `json:"display"`
+		Name    string `json:"name"`
+		Slug    string `json:"slug"`
+		Serial  string `json:"serial"`
+	} `json:"results"`
+}
+
+// fieldChoices is the subset of a Netbox OPTIONS response needed to offer enum values.
+type fieldChoices struct {
+	Actions struct {
+		POST map[string]struct {
+			Choices []struct {
+				Value       interface{} `json:"value"`
+				DisplayName string      `json:"display_name"`
+			} `json:"choices"`
+		} `json:"POST"`
+	} `json:"actions"`
+}
+
+// registerDynamicCompletions walks the command tree below c and attaches completion functions
+// to the flags that reference Netbox objects, so that IDs, slugs and enum values are offered live
+// from the server selected with --env.
+func registerDynamicCompletions(c *cobra.Command) {
+	for _, child := range c.Commands() {
+		registerDynamicCompletions(child)
+	}
+
+	if c.Flags().Lookup("env") != nil {
+		_ = c.RegisterFlagCompletionFunc("env", cobra.FixedCompletions([]string{
+			"development\tNetbox development server",
+			"production\tNetbox production server",
+		}, cobra.ShellCompDirectiveNoFileComp))
+	}
+
+	endpoint, ok := endpointKeyForCommand(c.Name())
+	if !ok {
+		endpoint = c.Annotations[endpointAnnotation]
+	}
+	if endpoint == "" {
+		return
+	}
+
+	for _, field := range []string{"id", "name", "slug", "serial"} {
+		if c.Flags().Lookup(field) != nil {
+			_ = c.RegisterFlagCompletionFunc(field, completeObjects(endpoint, field))
+		}
+	}
+	for flag, key := range slugFlagEndpoints {
+		if c.Flags().Lookup(flag) != nil {
+			_ = c.RegisterFlagCompletionFunc(flag, completeObjects(key, "slug"))
+		}
+	}
+	for _, field := range enumFlagFields {
+		if c.Flags().Lookup(field) != nil {
+			_ = c.RegisterFlagCompletionFunc(field, completeChoices(endpoint, field))
+		}
+	}
+}
+
+// endpointKeyForCommand derives the netbox_config.yaml key of the object endpoint a command talks to
+// from its name, e.g. getDcimDevicesById -> cmd.dcim.dcim_api_url.devices_id.
+func endpointKeyForCommand(name string) (string, bool) {
+	m := commandNamePattern.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	domain := strings.ToLower(m[2])
+	resource := m[3]
+	// getDcimDeviceIdBySerialNumber, getDcimDeviceByQuery and friends are searches, not objects.
+	if strings.HasPrefix(resource, "DeviceId") || strings.HasSuffix(resource, "ByQuery") || strings.HasSuffix(resource, "BySerialNumber") {
+		return "", false
+	}
+	return fmt.Sprintf("cmd.%s.%s_api_url.%s_id", domain, domain, toSnakeCase(resource)), true
+}
+
+// toSnakeCase converts a CamelCase resource name such as ConsolePortTemplates to console_port_templates.
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// completeObjects returns a completion function offering the objects of an endpoint by field,
+// which is one of "id", "name", "slug" or "serial", with the display name as description.
+func completeObjects(endpointKey string, field string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		config, root, ttl, err := loadCompletionConfig(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		path := config.GetString(endpointKey)
+		if path == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		url := root + strings.SplitN(path, "?", 2)[0] + "?limit=1000"
+		if field == "id" || field == "slug" {
+			url += "&brief=1"
+		}
+		key := field + " " + url
+
+		items, err := cachedCompletionItems(key, ttl, func() ([]completionItem, error) {
+			list := new(briefList)
+			if err := completionRequest("GET", url, config.GetString("cmd.token_key"), list); err != nil {
+				return nil, err
+			}
+			var items []completionItem
+			for _, r := range list.Results {
+				value := fmt.Sprintf("%d", r.Id)
+				switch field {
+				case "name":
+					value = r.Name
+				case "slug":
+					value = r.Slug
+				case "serial":
+					value = r.Serial
+				}
+				if value != "" {
+					items = append(items, completionItem{Value: value, Description: r.Display})
+				}
+			}
+			return items, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return formatCompletionItems(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeChoices returns a completion function offering the enum values Netbox accepts for field.
+func completeChoices(endpointKey string, field string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		config, root, ttl, err := loadCompletionConfig(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		path := config.GetString(endpointKey)
+		if path == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		url := root + strings.SplitN(path, "?", 2)[0]
+
+		items, err := cachedCompletionItems("OPTIONS "+url+" "+field, ttl, func() ([]completionItem, error) {
+			choices := new(fieldChoices)
+			if err := completionRequest("OPTIONS", url, config.GetString("cmd.token_key"), choices); err != nil {
+				return nil, err
+			}
+			var items []completionItem
+			for _, c := range choices.Actions.POST[field].Choices {
+				items = append(items, completionItem{Value: fmt.Sprintf("%v", c.Value), Description: c.DisplayName})
+			}
+			return items, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return formatCompletionItems(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// formatCompletionItems filters items by prefix and renders them as "value\tdescription" candidates.
+func formatCompletionItems(items []completionItem, toComplete string) []string {
+	var out []string
+	for _, item := range items {
+		if !strings.HasPrefix(item.Value, toComplete) {
+			continue
+		}
+		if item.Description != "" {
+			out = append(out, item.Value+"\t"+item.Description)
+		} else {
+			out = append(out, item.Value)
+		}
+	}
+	return out
+}
+
+// loadCompletionConfig reads netbox_config.yaml and resolves the root URL for the --env value of cmd
+// and how long fetched candidates are reused. Unlike the loadConfig helpers of the API packages it never exits, since a failing completion must
+// stay silent in the user's shell.
+func loadCompletionConfig(cmd *cobra.Command) (*viper.Viper, string, time.Duration, error) {
+	vi := viper.New()
+	vi.SetConfigName("netbox_config")
+	vi.SetConfigType("yaml")
+	vi.AddConfigPath(".")
+	vi.AutomaticEnv()
+	if err := vi.ReadInConfig(); err != nil {
+		return nil, "", 0, err
+	}
+
+	ttl := defaultCompletionCacheTTL
+	if configured := vi.GetDuration("cmd.completion_cache_ttl"); configured > 0 {
+		ttl = configured
+	}
+
+	env := "development"
+	if f := cmd.Flags().Lookup("env"); f != nil && f.Value.String() != "" {
+		env = f.Value.String()
+	}
+	switch env {
+	case "development":
+		return vi, vi.GetString("cmd.netbox_dev_root_url"), ttl, nil
+	case "production":
+		return vi, vi.GetString("cmd.netbox_prod_root_url"), ttl, nil
+	default:
+		return nil, "", 0, fmt.Errorf("unrecognized environment: %s", env)
+	}
+}
+
+// completionRequest performs a single request against the Netbox API and decodes the JSON body into object.
+func completionRequest(method, url, token string, object interface{}) error {
+	client := resty.New().SetTimeout(completionTimeout)
+	resp, err := client.R().
+		SetHeaders(map[string]string{
+			"Authorization": token,
+			"Accept":        "application/json",
+		}).
+		Execute(method, url)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("bad status: %s", resp.Status())
+	}
+	return json.Unmarshal(resp.Body(), object)
+}
+
+// cachedCompletionItems returns the candidates stored for key if they are younger than ttl,
+// otherwise it calls fetch and stores the result.
+func cachedCompletionItems(key string, ttl time.Duration, fetch func() ([]completionItem, error)) ([]completionItem, error) {
+	path := completionCachePath(key)
+	if path != "" {
+		if raw, err := os.ReadFile(path); err == nil {
+			cache := new(completionCache)
+			if json.Unmarshal(raw, cache) == nil && time.Since(cache.Fetched) < ttl {
+				return cache.Items, nil
+			}
+		}
+	}
+
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		if raw, err := json.Marshal(completionCache{Fetched: time.Now(), Items: items}); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0o700) == nil {
+				_ = os.WriteFile(path, raw, 0o600)
+			}
+		}
+	}
+	return items, nil
+}
+
+// completionCachePath returns the cache file used for key, or an empty string when no cache directory exists.
+func completionCachePath(key string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(dir, "abc-netbox.cli", "completion", hex.EncodeToString(sum[:])+".json")
+}
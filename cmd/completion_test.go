@@ -0,0 +1,65 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+	"github.com/spf13/viper"
+)
+
+// TestSlugFlagEndpoints checks that every reference flag completes from an endpoint that
+// netbox_config.yaml defines.
+func TestSlugFlagEndpoints(t *testing.T) {
+	vi := viper.New()
+	vi.SetConfigFile("netbox_config.yaml")
+	if err := vi.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	for flag, key := range slugFlagEndpoints {
+		if vi.GetString(key) == "" {
+			t.Errorf("--%s completes from %s, which is not in netbox_config.yaml", flag, key)
+		}
+	}
+}
+
+// TestCompleteSite checks that --site is completed with the slugs of the sites on the server.
+func TestCompleteSite(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	result := run(t, srv, netboxtest.Options{}, "__complete", "rack", "elevation", "--site", "")
+	if result.ExitCode != 0 || !strings.Contains(result.Stdout, "nyc1\tNYC1") || !strings.Contains(result.Stdout, "lax1\tLAX1") {
+		t.Errorf("completing --site: %s", result.String())
+	}
+}
+
+// TestCompleteLaterCommands checks that the commands added by the init functions of files after
+// root.go, such as tag add, have their flags completed too.
+func TestCompleteLaterCommands(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	result := run(t, srv, netboxtest.Options{}, "__complete", "tag", "add", "dcim", "devices", "--env", "")
+	if result.ExitCode != 0 || !strings.Contains(result.Stdout, "development\tNetbox development server") {
+		t.Errorf("completing --env of tag add: %s", result.String())
+	}
+}
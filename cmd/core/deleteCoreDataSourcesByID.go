@@ -23,28 +23,38 @@ THE SOFTWARE.
 package core
 
 import (
-	"fmt"
+	"log"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteCoreDataSourcesByIDCmd represents the deleteCoreDataSourcesByID command
 var DeleteCoreDataSourcesByIDCmd = &cobra.Command{
 	Use:   "deleteCoreDataSourcesByID",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteCoreDataSourcesByID called")
+	Short: "DELETE a data source object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE a data source object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.core.core_api_url.data_sources_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteCoreDataSourcesByIDCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteCoreDataSourcesByIDCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteCoreDataSourcesByIDCmd", err)
+	}
+
+	DeleteCoreDataSourcesByIDCmd.Flags().IntP("id", "", 0, "ID of the data source object to be deleted")
+	api.AddLookupFlags(DeleteCoreDataSourcesByIDCmd, "cmd.core.core_api_url.data_sources_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
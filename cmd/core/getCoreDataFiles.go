@@ -50,9 +50,9 @@ var GetCoreDataFilesCmd = &cobra.Command{
 	Use:   "getCoreDataFiles",
 	Short: "Get ABC Netbox core data file objects.",
 	Long:  `Get ABC Netbox core data file objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(dataFiles)
-		dcim.ApiConnectionNonID(responseObject, "GET", "cmd.core.core_api_url.data_files")
+		return dcim.ApiConnectionNonID(cmd, responseObject, "cmd.core.core_api_url.data_files")
 	},
 }
 
@@ -23,28 +23,47 @@ THE SOFTWARE.
 package core
 
 import (
-	"fmt"
+	"log"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchCoreDataSourcesByIDCmd represents the patchCoreDataSourcesByID command
 var PatchCoreDataSourcesByIDCmd = &cobra.Command{
 	Use:   "patchCoreDataSourcesByID",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchCoreDataSourcesByID called")
+	Short: "PATCH a data source object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH a data source object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.core.core_api_url.data_sources_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchCoreDataSourcesByIDCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchCoreDataSourcesByIDCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchCoreDataSourcesByIDCmd", err)
+	}
+
+	PatchCoreDataSourcesByIDCmd.Flags().IntP("id", "", 0, "ID of the data source object to patch")
+	api.AddLookupFlags(PatchCoreDataSourcesByIDCmd, "cmd.core.core_api_url.data_sources_id")
+
+	PatchCoreDataSourcesByIDCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchCoreDataSourcesByIDCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchCoreDataSourcesByIDCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchCoreDataSourcesByIDCmd)
+	api.AddCustomFieldFlag(PatchCoreDataSourcesByIDCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
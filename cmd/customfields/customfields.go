@@ -0,0 +1,372 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package customfields reads and writes the custom fields of Netbox objects. Values given on the
+// command line as name=value are typed after the custom field definitions of the Netbox server, so
+//
+//	--cf warranty_end=2027-03-31 --cf rack_units=4 --cf monitored=true
+//
+// is sent as {"custom_fields": {"warranty_end": "2027-03-31", "rack_units": 4, "monitored": true}}.
+package customfields
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+)
+
+// definitionsKey is the config key of the endpoint holding the custom field definitions.
+const definitionsKey = "cmd.extras.extras_api_url.custom_fields"
+
+// Definition is a custom field as defined in extras.
+type Definition struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  struct {
+		Value string `json:"value"`
+	} `json:"type"`
+	// ObjectTypes lists the models the field applies to, e.g. dcim.device. Netbox before 4.0
+	// calls them content types.
+	ObjectTypes  []string `json:"object_types"`
+	ContentTypes []string `json:"content_types"`
+}
+
+// AppliesTo reports whether the field is defined for objectType.
+func (d Definition) AppliesTo(objectType string) bool {
+	for _, t := range append(d.ObjectTypes, d.ContentTypes...) {
+		if t == objectType {
+			return true
+		}
+	}
+	return false
+}
+
+// ObjectType returns the model of the objects an API path holds, e.g. dcim.device for
+// /api/dcim/devices/ and ipam.ipaddress for /api/ipam/ip-addresses/.
+func ObjectType(path string) string {
+	parts := strings.Split(strings.Trim(strings.SplitN(path, "?", 2)[0], "/"), "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	app, resource := parts[len(parts)-2], strings.ReplaceAll(parts[len(parts)-1], "-", "")
+	switch {
+	case strings.HasSuffix(resource, "ies"):
+		resource = strings.TrimSuffix(resource, "ies") + "y"
+	case strings.HasSuffix(resource, "sses"), strings.HasSuffix(resource, "xes"):
+		resource = strings.TrimSuffix(resource, "es")
+	case strings.HasSuffix(resource, "chassis"):
+	default:
+		resource = strings.TrimSuffix(resource, "s")
+	}
+	return app + "." + resource
+}
+
+// Definitions returns the custom fields the server at rootURL defines for objectType, by name.
+func Definitions(rootURL string, objectType string) (map[string]Definition, error) {
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	path := strings.SplitN(config.GetString(definitionsKey), "?", 2)[0]
+	if path == "" {
+		return nil, fmt.Errorf("no endpoint configured for %s", definitionsKey)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	definitions := map[string]Definition{}
+	next := rootURL + path + "?limit=1000"
+	for next != "" {
+		resp, err := session.Client().R().
+			SetHeaders(map[string]string{
+				"Authorization": config.GetString("cmd.token_key"),
+				"Accept":        "application/json",
+			}).
+			Get(next)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("reading custom field definitions from %s returned %s", next, resp.Status())
+		}
+		var page struct {
+			Next    *string      `json:"next"`
+			Results []Definition `json:"results"`
+		}
+		if err := json.Unmarshal(resp.Body(), &page); err != nil {
+			return nil, fmt.Errorf("error while parsing the response bytes: %s", err)
+		}
+		for _, d := range page.Results {
+			if d.AppliesTo(objectType) {
+				definitions[d.Name] = d
+			}
+		}
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return definitions, nil
+}
+
+// Parse turns name=value pairs into custom field values of the type their definition gives.
+// An empty value clears the field.
+func Parse(definitions map[string]Definition, objectType string, pairs []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, pair := range pairs {
+		name, raw, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid custom field %q: expected name=value", pair)
+		}
+		d, ok := definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("%s has no custom field %q%s", objectType, name, known(definitions))
+		}
+		value, err := typed(d, raw)
+		if err != nil {
+			return nil, fmt.Errorf("custom field %s: %s", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// typed converts raw to the JSON value Netbox expects for a field of d's type.
+func typed(d Definition, raw string) (interface{}, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	switch d.Type.Value {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return n, nil
+	case "decimal":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a decimal number", raw)
+		}
+		return json.Number(raw), nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", raw)
+		}
+		return b, nil
+	case "json":
+		decoder := json.NewDecoder(strings.NewReader(raw))
+		decoder.UseNumber()
+		var v interface{}
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("%q is not valid JSON", raw)
+		}
+		return v, nil
+	case "multiselect":
+		return splitList(raw), nil
+	case "object":
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an object ID", raw)
+		}
+		return id, nil
+	case "multiobject":
+		var ids []interface{}
+		for _, item := range splitList(raw) {
+			id, err := strconv.ParseInt(item.(string), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a list of object IDs", raw)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	default:
+		// text, longtext, url, date, datetime and select are sent as they are.
+		return raw, nil
+	}
+}
+
+func splitList(raw string) []interface{} {
+	var items []interface{}
+	for _, item := range strings.Split(raw, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items
+}
+
+func known(definitions map[string]Definition) string {
+	if len(definitions) == 0 {
+		return ", it has none"
+	}
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return " (known: " + strings.Join(names, ", ") + ")"
+}
+
+// Merge returns the --data payload data with values added to the custom_fields of the object,
+// or of every object of a bulk payload. An empty payload stands for an empty object.
+func Merge(data string, values map[string]interface{}) (string, error) {
+	if strings.TrimSpace(data) == "" {
+		data = "{}"
+	}
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return "", fmt.Errorf("--data is not valid JSON: %s", err)
+	}
+
+	merge := func(object map[string]interface{}) {
+		customFields, _ := object["custom_fields"].(map[string]interface{})
+		if customFields == nil {
+			customFields = map[string]interface{}{}
+		}
+		for name, value := range values {
+			customFields[name] = value
+		}
+		object["custom_fields"] = customFields
+	}
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		merge(p)
+	case []interface{}:
+		for i, item := range p {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("item %d of --data is not an object", i)
+			}
+			merge(object)
+		}
+	default:
+		return "", fmt.Errorf("--data is not an object or a list of objects")
+	}
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Apply adds the custom fields given as name=value pairs to data, a payload written to the
+// endpoint with config key endpointKey on the server at rootURL.
+func Apply(rootURL string, endpointKey string, data string, pairs []string) (string, error) {
+	config, err := session.Config()
+	if err != nil {
+		return "", err
+	}
+	objectType := ObjectType(config.GetString(endpointKey))
+	if objectType == "" {
+		return "", fmt.Errorf("no endpoint configured for %s", endpointKey)
+	}
+	definitions, err := Definitions(rootURL, objectType)
+	if err != nil {
+		return "", err
+	}
+	values, err := Parse(definitions, objectType, pairs)
+	if err != nil {
+		return "", err
+	}
+	return Merge(data, values)
+}
+
+// Filters turns name=value pairs into the cf_<name> query parameters Netbox filters custom fields by.
+func Filters(pairs []string) (url.Values, error) {
+	filters := url.Values{}
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid custom field filter %q: expected name=value", pair)
+		}
+		filters.Add("cf_"+name, value)
+	}
+	return filters, nil
+}
+
+// AddFilters returns rawURL with the filters of pairs added to its query.
+func AddFilters(rawURL string, pairs []string) (string, error) {
+	if len(pairs) == 0 {
+		return rawURL, nil
+	}
+	filters, err := Filters(pairs)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	for key, values := range filters {
+		query[key] = append(query[key], values...)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Names returns the names of customFields in alphabetical order.
+func Names(customFields map[string]interface{}) []string {
+	names := make([]string, 0, len(customFields))
+	for name := range customFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format renders a custom field value for text output: related objects by their display name,
+// lists joined with commas and unset fields as "-".
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case map[string]interface{}:
+		for _, key := range []string{"display", "name", "id"} {
+			if name, ok := v[key]; ok {
+				return Format(name)
+			}
+		}
+		out, _ := json.Marshal(v)
+		return string(out)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = Format(item)
+		}
+		return strings.Join(items, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package customfields
+
+import (
+	"testing"
+)
+
+func TestObjectType(t *testing.T) {
+	for path, want := range map[string]string{
+		"/api/dcim/devices/":              "dcim.device",
+		"/api/dcim/devices/?limit=2":      "dcim.device",
+		"/api/ipam/ip-addresses/":         "ipam.ipaddress",
+		"/api/ipam/prefixes/":             "ipam.prefix",
+		"/api/dcim/virtual-chassis/":      "dcim.virtualchassis",
+		"/api/vpn/ike-policies/":          "vpn.ikepolicy",
+		"/api/circuits/circuits/?page=":   "circuits.circuit",
+		"/api/dcim/inventory-item-roles/": "dcim.inventoryitemrole",
+		"/api/wireless/wireless-lans/":    "wireless.wirelesslan",
+		"/api/dcim/device-bays/":          "dcim.devicebay",
+		"/api/":                           "",
+	} {
+		if got := ObjectType(path); got != want {
+			t.Errorf("ObjectType(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	definitions := map[string]Definition{}
+	for name, kind := range map[string]string{
+		"count": "integer", "ratio": "decimal", "enabled": "boolean", "extra": "json",
+		"colors": "multiselect", "owner": "object", "links": "multiobject", "note": "text",
+	} {
+		d := Definition{Name: name}
+		d.Type.Value = kind
+		definitions[name] = d
+	}
+
+	values, err := Parse(definitions, "dcim.device", []string{
+		"count=4", "ratio=0.25", "enabled=false", `extra={"a": [1]}`, "colors=red, blue", "owner=7", "links=1,2", "note=a=b", "count=",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Merge("", values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"custom_fields":{"colors":["red","blue"],"count":null,"enabled":false,"extra":{"a":[1]},"links":[1,2],"note":"a=b","owner":7,"ratio":0.25}}`
+	if out != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+
+	for _, pair := range []string{"count=four", "ratio=x", "enabled=maybe", "extra={", "owner=core1", "links=1,b", "missing=1", "=1", "count"} {
+		if _, err := Parse(definitions, "dcim.device", []string{pair}); err == nil {
+			t.Errorf("Parse(%q) succeeded", pair)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	values := map[string]interface{}{"owner": "noc"}
+	tests := []struct {
+		data, want string
+	}{
+		{`{"name":"a","custom_fields":{"note":"x"}}`, `{"custom_fields":{"note":"x","owner":"noc"},"name":"a"}`},
+		{`[{"id":1},{"id":2,"custom_fields":{"owner":"ops"}}]`, `[{"custom_fields":{"owner":"noc"},"id":1},{"custom_fields":{"owner":"noc"},"id":2}]`},
+	}
+	for _, tt := range tests {
+		got, err := Merge(tt.data, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Merge(%s) = %s, want %s", tt.data, got, tt.want)
+		}
+	}
+	for _, data := range []string{`"a"`, `[1]`, `{`} {
+		if _, err := Merge(data, values); err == nil {
+			t.Errorf("Merge(%s) succeeded", data)
+		}
+	}
+}
+
+func TestAddFilters(t *testing.T) {
+	got, err := AddFilters("http://netbox.test/api/dcim/devices/?limit=2", []string{"owner=noc", "owner=ops", "warranty_end=2027-03-31"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "http://netbox.test/api/dcim/devices/?cf_owner=noc&cf_owner=ops&cf_warranty_end=2027-03-31&limit=2"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if _, err := AddFilters("http://netbox.test/api/dcim/devices/", []string{"owner"}); err == nil {
+		t.Error("a filter without value was accepted")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for _, tt := range []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "-"},
+		{"2027-03-31", "2027-03-31"},
+		{float64(4), "4"},
+		{true, "true"},
+		{[]interface{}{"red", "blue"}, "red, blue"},
+		{map[string]interface{}{"id": float64(7), "display": "core1"}, "core1"},
+		{[]interface{}{map[string]interface{}{"id": float64(7)}}, "7"},
+	} {
+		if got := Format(tt.value); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
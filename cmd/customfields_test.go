@@ -0,0 +1,107 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestCustomFields(t *testing.T) {
+	// sentBody checks the body of the request with method sent to the server.
+	sentBody := func(method string, want string) func(t *testing.T, srv *netboxtest.Server) {
+		return func(t *testing.T, srv *netboxtest.Server) {
+			for _, r := range srv.Requests() {
+				if r.Method == method {
+					if r.Body != want {
+						t.Errorf("%s %s sent %s, want %s", r.Method, r.Path, r.Body, want)
+					}
+					return
+				}
+			}
+			t.Errorf("no %s request sent", method)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name:  "update_typed",
+			args:  []string{"dcim", "devices", "update", "nyc1-leaf2", "--env", "development", "--cf", "monitored=true", "--cf", "uplink_count=2", "--cf", "warranty_end="},
+			check: sentBody("PATCH", `{"custom_fields":{"monitored":true,"uplink_count":2,"warranty_end":null}}`),
+		},
+		{
+			name: "create_dry_run",
+			args: []string{"dcim", "devices", "create", "--env", "development", "--dry-run", "--cf", "support_contract=SC-2001", "--cf", "uplink_count=4", "--data",
+				`{"name": "nyc1-leaf3", "site": 1, "role": 1, "device_type": 1, "custom_fields": {"rack_owner": "Network Engineering"}}`},
+		},
+		{
+			name:  "update_bulk",
+			args:  []string{"dcim", "devices", "update", "--env", "development", "--data", `[{"id": 1}]`, "--cf", "monitored=true", "--cf", "uplink_count=2"},
+			check: sentBody("PATCH", `[{"custom_fields":{"monitored":true,"uplink_count":2},"id":1}]`),
+		},
+		{
+			name:  "circuits_update_bulk",
+			args:  []string{"Circuits", "CircuitsPatch", "patchCircuitsCircuits", "--env", "development", "--data", `[{"id": 1}, {"id": 2}]`, "--cf", "commit_rate_mbps=500"},
+			check: sentBody("PATCH", `[{"custom_fields":{"commit_rate_mbps":500},"id":1},{"custom_fields":{"commit_rate_mbps":500},"id":2}]`),
+		},
+		{
+			name: "circuits_show",
+			args: []string{"Circuits", "CircuitsGet", "getCircuitsCircuitsById", "--env", "development", "--id", "1"},
+		},
+		{
+			name: "unknown_field",
+			args: []string{"dcim", "devices", "update", "nyc1-leaf1", "--env", "development", "--cf", "warranty=2027-01-01"},
+		},
+		{
+			name: "not_an_integer",
+			args: []string{"dcim", "devices", "update", "nyc1-leaf1", "--env", "development", "--cf", "uplink_count=four"},
+		},
+		{
+			name: "list_filter",
+			args: []string{"DCIM", "DcimGet", "getDcimDevices", "--env", "development", "--cf-filter", "warranty_end=2025-11-30"},
+		},
+		{
+			name: "fanout_filter",
+			args: []string{"dcim", "devices", "list", "--profiles", "production", "--cf-filter", "monitored=true"},
+		},
+	}
+
+	fixtures := netboxtest.DefaultFixtures()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, fixtures)
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "customfields/"+tt.name, result.String())
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
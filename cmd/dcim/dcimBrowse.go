@@ -0,0 +1,232 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package dcim
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
+	"github.com/decassidy/abc-netbox-cli/cmd/browse"
+)
+
+func init() {
+	browse.Register(browse.Resource{Domain: "dcim", Name: "devices", Fetch: browseDevices})
+	browse.Register(browse.Resource{Domain: "dcim", Name: "interfaces", Fetch: browseInterfaces})
+	browse.Register(browse.Resource{Domain: "dcim", Name: "cables", Fetch: browseCables})
+	browse.Register(browse.Resource{Domain: "dcim", Name: "sites", Fetch: browseSites})
+	browse.Register(browse.Resource{Domain: "dcim", Name: "racks", Fetch: browseRacks})
+}
+
+func browseDevices(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.dcim.dcim_api_url.devices", query, func() *devices { return new(devices) }, func(page *devices) string {
+		for _, device := range page.Results {
+			objects = append(objects, browse.Object{
+				Id:      device.Id,
+				Display: device.Display,
+				ApiUrl:  device.Url,
+				Fields: []browse.Field{
+					{Label: "Name", Value: device.Name},
+					{Label: "Device Type", Value: device.DeviceType.Display},
+					{Label: "Manufacturer", Value: device.DeviceType.Manufacturer.Name},
+					{Label: "Role", Value: device.Role.Name},
+					{Label: "Tenant", Value: device.Tenant.Name},
+					{Label: "Platform", Value: device.Platform.Name},
+					{Label: "Serial", Value: device.Serial},
+					{Label: "Asset Tag", Value: device.AssetTag},
+					{Label: "Site", Value: device.Site.Display},
+					{Label: "Location", Value: device.Location.Display},
+					{Label: "Rack", Value: device.Rack.Display},
+					{Label: "Position", Value: fmt.Sprintf("%v", device.Position)},
+					{Label: "Status", Value: device.Status.Label},
+					{Label: "Primary IP", Value: device.PrimaryIp.Address},
+					{Label: "Description", Value: device.Description},
+					{Label: "Interface Count", Value: fmt.Sprintf("%d", device.InterfaceCount)},
+					{Label: "Last Updated", Value: device.LastUpdated},
+				},
+				Related: []browse.Link{
+					{Label: "Interfaces", Domain: "dcim", Resource: "interfaces", Query: fmt.Sprintf("device_id=%d", device.Id)},
+					{Label: "Cables", Domain: "dcim", Resource: "cables", Query: fmt.Sprintf("device_id=%d", device.Id)},
+					{Label: "Site", Domain: "dcim", Resource: "sites", Query: fmt.Sprintf("id=%d", device.Site.Id)},
+					{Label: "Rack", Domain: "dcim", Resource: "racks", Query: fmt.Sprintf("id=%d", device.Rack.Id)},
+				},
+			})
+		}
+		return derefNext(page.Next)
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseInterfaces(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.dcim.dcim_api_url.interfaces", query, func() *interfaces { return new(interfaces) }, func(page *interfaces) string {
+		for _, iface := range page.Results {
+			object := browse.Object{
+				Id:      uint(iface.Id),
+				Display: iface.Device.Name + ":" + iface.Name,
+				ApiUrl:  iface.Url,
+				Fields: []browse.Field{
+					{Label: "Name", Value: iface.Name},
+					{Label: "Device", Value: iface.Device.Display},
+					{Label: "Label", Value: iface.Label},
+					{Label: "Type", Value: iface.Type.Label},
+					{Label: "Enabled", Value: strconv.FormatBool(iface.Enabled)},
+					{Label: "MTU", Value: fmt.Sprintf("%d", iface.Mtu)},
+					{Label: "MAC Address", Value: iface.MacAddress},
+					{Label: "Mode", Value: iface.Mode.Label},
+					{Label: "Untagged VLAN", Value: iface.UntaggedVlan.Display},
+					{Label: "Cable", Value: iface.Cable.Display},
+					{Label: "Description", Value: iface.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Device", Domain: "dcim", Resource: "devices", Query: fmt.Sprintf("id=%d", iface.Device.Id)},
+				},
+			}
+			if iface.Cable.Id != 0 {
+				object.Related = append(object.Related, browse.Link{Label: "Cable", Domain: "dcim", Resource: "cables", Query: fmt.Sprintf("id=%d", iface.Cable.Id)})
+			}
+			objects = append(objects, object)
+		}
+		return derefNext(page.Next)
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseCables(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.dcim.dcim_api_url.cables", query, func() *cables { return new(cables) }, func(page *cables) string {
+		for _, cable := range page.Results {
+			object := browse.Object{
+				Id:      cable.Id,
+				Display: cable.Display,
+				ApiUrl:  cable.Url,
+				Fields: []browse.Field{
+					{Label: "Type", Value: cable.Type},
+					{Label: "Status", Value: cable.Status.Label},
+					{Label: "Label", Value: cable.Label},
+					{Label: "Color", Value: cable.Color},
+					{Label: "Length", Value: fmt.Sprintf("%v %s", cable.Length, cable.LengthUnit.Label)},
+					{Label: "Tenant", Value: cable.Tenant.Name},
+					{Label: "Description", Value: cable.Description},
+				},
+			}
+			for _, term := range cable.ATerminations {
+				object.Fields = append(object.Fields, browse.Field{Label: "A Side", Value: term.Object.Device.Name + ":" + term.Object.Name})
+				object.Related = append(object.Related, cablePeerLink("A", term.ObjectType, term.ObjectId, term.Object.Display))
+			}
+			for _, term := range cable.BTerminations {
+				object.Fields = append(object.Fields, browse.Field{Label: "B Side", Value: term.Object.Device.Name + ":" + term.Object.Name})
+				object.Related = append(object.Related, cablePeerLink("B", term.ObjectType, term.ObjectId, term.Object.Display))
+			}
+			objects = append(objects, object)
+		}
+		return derefNext(page.Next)
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseSites(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.dcim.dcim_api_url.sites", query, func() *sites { return new(sites) }, func(page *sites) string {
+		for _, site := range page.Results {
+			objects = append(objects, browse.Object{
+				Id:      site.Id,
+				Display: site.Display,
+				ApiUrl:  site.Url,
+				Fields: []browse.Field{
+					{Label: "Name", Value: site.Name},
+					{Label: "Slug", Value: site.Slug},
+					{Label: "Status", Value: site.Status.Label},
+					{Label: "Region", Value: site.Region.Name},
+					{Label: "Group", Value: site.Group.Name},
+					{Label: "Tenant", Value: site.Tenant.Name},
+					{Label: "Facility", Value: site.Facility},
+					{Label: "Time Zone", Value: site.TimeZone},
+					{Label: "Physical Address", Value: site.PhysicalAddress},
+					{Label: "Device Count", Value: fmt.Sprintf("%d", site.DeviceCount)},
+					{Label: "Rack Count", Value: fmt.Sprintf("%d", site.RackCount)},
+					{Label: "Description", Value: site.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Devices", Domain: "dcim", Resource: "devices", Query: fmt.Sprintf("site_id=%d", site.Id)},
+					{Label: "Racks", Domain: "dcim", Resource: "racks", Query: fmt.Sprintf("site_id=%d", site.Id)},
+					{Label: "Circuits", Domain: "circuits", Resource: "circuits", Query: fmt.Sprintf("site_id=%d", site.Id)},
+				},
+			})
+		}
+		return page.Next
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+func browseRacks(ctx context.Context, env string, query string) (browse.List, error) {
+	var objects []browse.Object
+	count, err := api.BrowsePages(ctx, env, "cmd.dcim.dcim_api_url.racks", query, func() *racks { return new(racks) }, func(page *racks) string {
+		for _, rack := range page.Results {
+			objects = append(objects, browse.Object{
+				Id:      rack.Id,
+				Display: rack.Display,
+				ApiUrl:  rack.Url,
+				Fields: []browse.Field{
+					{Label: "Name", Value: rack.Name},
+					{Label: "Site", Value: rack.Site.Display},
+					{Label: "Location", Value: rack.Location.Display},
+					{Label: "Status", Value: rack.Status.Label},
+					{Label: "Role", Value: rack.Role.Name},
+					{Label: "Type", Value: rack.Type.Label},
+					{Label: "Height (U)", Value: fmt.Sprintf("%d", rack.UHeight)},
+					{Label: "Device Count", Value: fmt.Sprintf("%d", rack.DeviceCount)},
+					{Label: "Description", Value: rack.Description},
+				},
+				Related: []browse.Link{
+					{Label: "Devices", Domain: "dcim", Resource: "devices", Query: fmt.Sprintf("rack_id=%d", rack.Id)},
+					{Label: "Site", Domain: "dcim", Resource: "sites", Query: fmt.Sprintf("id=%d", rack.Site.Id)},
+				},
+			})
+		}
+		return page.Next
+	})
+	return browse.List{Objects: objects, Count: count}, err
+}
+
+// cablePeerLink links a cable to the object terminating one of its ends.
+func cablePeerLink(side string, objectType string, objectId uint, display string) browse.Link {
+	label := fmt.Sprintf("%s Side: %s", side, display)
+	switch objectType {
+	case "dcim.interface":
+		return browse.Link{Label: label, Domain: "dcim", Resource: "interfaces", Query: fmt.Sprintf("id=%d", objectId)}
+	case "circuits.circuittermination":
+		return browse.Link{Label: label, Domain: "circuits", Resource: "circuit-terminations", Query: fmt.Sprintf("id=%d", objectId)}
+	default:
+		return browse.Link{Label: label + " (" + objectType + ")"}
+	}
+}
+
+func derefNext(next *string) string {
+	if next == nil {
+		return ""
+	}
+	return *next
+}
@@ -34,8 +34,9 @@ var DeleteDcimCableTerminationCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of cable termination objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("deleteDcimCableTermination called")
+		return nil
 	},
 }
 
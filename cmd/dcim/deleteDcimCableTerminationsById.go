@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimCableTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an cable termination object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.cable_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.cable_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimCableTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimCableTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimCableTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
 	}
 
-	DeleteDcimCableTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the cable termination object to delete")
-	err = DeleteDcimCableTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %v", err)
-	}
+	DeleteDcimCableTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the cable termination object to delete")
+	api.AddLookupFlags(DeleteDcimCableTerminationsByIdCmd, "cmd.dcim.dcim_api_url.cable_terminations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
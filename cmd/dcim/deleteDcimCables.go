@@ -34,21 +34,24 @@ var DeleteDcimCablesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of cable objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.cables_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.cables_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimCablesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimCablesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimCablesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimCablesCmd", err)
 	}
 
-	DeleteDcimCablesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be deleted")
+	DeleteDcimCablesCmd.Flags().StringP("data", "", "", "JSON data to be deleted")
 	err = DeleteDcimCablesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimCablesCmd", err)
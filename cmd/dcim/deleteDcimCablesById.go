@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimCablesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a cable object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.cables_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.cables_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimCablesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimCablesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimCablesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
 	}
 
-	DeleteDcimCablesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Cable ID to delete")
-	err = DeleteDcimCablesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %v", err)
-	}
+	DeleteDcimCablesByIdCmd.Flags().IntP("id", "", 0, "Cable ID to delete")
+	api.AddLookupFlags(DeleteDcimCablesByIdCmd, "cmd.dcim.dcim_api_url.cables_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -34,21 +34,24 @@ var DeleteDcimConsolePortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of console port template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.console_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsolePortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsolePortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsolePortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimConsolePortTemplatesCmd", err)
 	}
 
-	DeleteDcimConsolePortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to sent in the delete request")
+	DeleteDcimConsolePortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to sent in the delete request")
 	err = DeleteDcimConsolePortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimConsolePortTemplatesCmd", err)
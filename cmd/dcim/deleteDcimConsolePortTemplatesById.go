@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimConsolePortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an console port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.console_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsolePortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsolePortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - DDeleteDcimConsolePortTemplatesByIdCmd", err)
 	}
 
-	DeleteDcimConsolePortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console port template object to be deleted")
-	err = DeleteDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - DeleteDcimConsolePortTemplatesByIdCmd", err)
-	}
+	DeleteDcimConsolePortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the console port template object to be deleted")
+	api.AddLookupFlags(DeleteDcimConsolePortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.console_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -34,21 +34,24 @@ var DeleteDcimConsolePortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of console port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.console_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.console_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsolePortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsolePortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsolePortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimConsolePortsCmd", err)
 	}
 
-	DeleteDcimConsolePortsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in the request body")
+	DeleteDcimConsolePortsCmd.Flags().StringP("data", "", "", "JSON data to be sent in the request body")
 	err = DeleteDcimConsolePortsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimConsolePortsCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -35,25 +36,25 @@ var DeleteDcimConsolePortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an console port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.console_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.console_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsolePortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsolePortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsolePortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - DeleteDcimConsolePortsByIdCmd", err)
 	}
 
-	DeleteDcimConsolePortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console port to be deleted")
-	err = DeleteDcimConsolePortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - DeleteDcimConsolePortsById", err)
-	}
+	DeleteDcimConsolePortsByIdCmd.Flags().IntP("id", "", 0, "ID of the console port to be deleted")
+	api.AddLookupFlags(DeleteDcimConsolePortsByIdCmd, "cmd.dcim.dcim_api_url.console_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
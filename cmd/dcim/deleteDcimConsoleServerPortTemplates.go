@@ -34,21 +34,24 @@ var DeleteDcimConsoleServerPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of console server port template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.console_server_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsoleServerPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimConsoleServerPortTemplatesCmd", err)
 	}
 
-	DeleteDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in the delete request")
+	DeleteDcimConsoleServerPortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be sent in the delete request")
 	err = DeleteDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimConsolePortTemplatesCmd", err)
@@ -34,21 +34,24 @@ var DeleteDcimConsoleServerPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of console server port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.console_server_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.console_server_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsoleServerPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "", "Environment ('development' or 'production')")
+	DeleteDcimConsoleServerPortsCmd.Flags().StringP("env", "", "", "Environment ('development' or 'production')")
 	err := DeleteDcimConsoleServerPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimConsoleServerPortsCmd", err)
 	}
 
-	DeleteDcimConsoleServerPortsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in the delete request")
+	DeleteDcimConsoleServerPortsCmd.Flags().StringP("data", "", "", "JSON data to be sent in the delete request")
 	err = DeleteDcimConsoleServerPortsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimConsoleServerPortsCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimConsoleServerPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an console server port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.console_server_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.console_server_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimConsoleServerPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimConsoleServerPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimConsoleServerPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimConsoleServerPortsByIdCmd", err)
 	}
 
-	DeleteDcimConsoleServerPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console server port object to deleted")
-	err = DeleteDcimConsoleServerPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteDcimConsoleServerPortsByIdCmd", err)
-	}
+	DeleteDcimConsoleServerPortsByIdCmd.Flags().IntP("id", "", 0, "ID of the console server port object to deleted")
+	api.AddLookupFlags(DeleteDcimConsoleServerPortsByIdCmd, "cmd.dcim.dcim_api_url.console_server_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
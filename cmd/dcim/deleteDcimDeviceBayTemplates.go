@@ -34,21 +34,24 @@ var DeleteDcimDeviceBayTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of device bay template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.device_bay_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.device_bay_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceBayTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceBayTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceBayTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimDeviceBayTemplatesCmd", err)
 	}
 
-	DeleteDcimDeviceBayTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in delete request")
+	DeleteDcimDeviceBayTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be sent in delete request")
 	err = DeleteDcimDeviceBayTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimDeviceBayTemplatesCmd", err)
@@ -34,21 +34,24 @@ var DeleteDcimDeviceBaysCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of device bay objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.device_bays_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.device_bays_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceBaysCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceBaysCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceBaysCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimDeviceBaysCmd", err)
 	}
 
-	DeleteDcimDeviceBaysCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in the request")
+	DeleteDcimDeviceBaysCmd.Flags().StringP("data", "", "", "JSON data to be sent in the request")
 	err = DeleteDcimDeviceBaysCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimDeviceBaysCmd", err)
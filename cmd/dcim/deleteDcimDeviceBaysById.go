@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimDeviceBaysByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an device bay object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.device_bays_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.device_bays_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceBaysByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceBaysByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceBaysByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDeviceBaysByIdCmd", err)
 	}
 
-	DeleteDcimDeviceBaysByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device bay object to be deleted")
-	err = DeleteDcimDeviceBaysByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteDcimDeviceBaysByIdCmd", err)
-	}
+	DeleteDcimDeviceBaysByIdCmd.Flags().IntP("id", "", 0, "ID of the device bay object to be deleted")
+	api.AddLookupFlags(DeleteDcimDeviceBaysByIdCmd, "cmd.dcim.dcim_api_url.device_bays_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
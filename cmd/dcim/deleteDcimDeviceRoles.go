@@ -34,21 +34,24 @@ var DeleteDcimDeviceRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of device role objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.device_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimDeviceRolesCmd", err)
 	}
 
-	DeleteDcimDeviceRolesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in delete request")
+	DeleteDcimDeviceRolesCmd.Flags().StringP("data", "", "", "JSON data to be sent in delete request")
 	err = DeleteDcimDeviceRolesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimDeviceRolesCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimDeviceRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an device role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.device_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDeviceRolesByIdCmd", err)
 	}
 
-	DeleteDcimDeviceRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device role object to deleted")
-	err = DeleteDcimDeviceRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteDcimDeviceRolesByIdCmd", err)
-	}
+	DeleteDcimDeviceRolesByIdCmd.Flags().IntP("id", "", 0, "ID of the device role object to deleted")
+	api.AddLookupFlags(DeleteDcimDeviceRolesByIdCmd, "cmd.dcim.dcim_api_url.device_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -35,25 +36,25 @@ var DeleteDcimDeviceTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an device type object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.device_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.device_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDeviceTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDeviceTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDeviceTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDeviceTypesByIdCmd", err)
 	}
 
-	DeleteDcimDeviceTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device type to be deleted")
-	err = DeleteDcimDeviceTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDeviceTypesByIdCmd", err)
-	}
+	DeleteDcimDeviceTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the device type to be deleted")
+	api.AddLookupFlags(DeleteDcimDeviceTypesByIdCmd, "cmd.dcim.dcim_api_url.device_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -35,25 +36,25 @@ var DeleteDcimDevicesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an device object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.devices_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.devices_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimDevicesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimDevicesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimDevicesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDevicesByIdCmd", err)
 	}
 
-	DeleteDcimDevicesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device to be deleted")
-	err = DeleteDcimDevicesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimDevicesByIdCmd", err)
-	}
+	DeleteDcimDevicesByIdCmd.Flags().IntP("id", "", 0, "ID of the device to be deleted")
+	api.AddLookupFlags(DeleteDcimDevicesByIdCmd, "cmd.dcim.dcim_api_url.devices_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -34,21 +34,24 @@ var DeleteDcimFrontPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of front port template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.front_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.front_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimFrontPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimFrontPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimFrontPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimFrontPortTemplatesByIdCmd", err)
 	}
 
-	DeleteDcimFrontPortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent with delete request")
+	DeleteDcimFrontPortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be sent with delete request")
 	err = DeleteDcimFrontPortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimFrontPortTemplatesByIdCmd", err)
@@ -34,21 +34,24 @@ var DeleteDcimFrontPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of front port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.front_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.front_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimFrontPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimFrontPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimFrontPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimFrontPortsCmd", err)
 	}
 
-	DeleteDcimFrontPortsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in delete request")
+	DeleteDcimFrontPortsCmd.Flags().StringP("data", "", "", "JSON data to be sent in delete request")
 	err = DeleteDcimFrontPortsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimFrontPortsCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimInterfaceTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an interface templates object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.interface_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.interface_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimInterfaceTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimInterfaceTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimInterfaceTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimInterfaceTemplatesByIdCmd", err)
 	}
 
-	DeleteDcimInterfaceTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the interface template to be deleted")
-	err = DeleteDcimInterfaceTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimInterfaceTemplatesByIdCmd", err)
-	}
+	DeleteDcimInterfaceTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the interface template to be deleted")
+	api.AddLookupFlags(DeleteDcimInterfaceTemplatesByIdCmd, "cmd.dcim.dcim_api_url.interface_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
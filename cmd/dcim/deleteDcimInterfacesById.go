@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimInterfacesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an interface object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.interfaces_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.interfaces_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimInterfacesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimInterfacesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimInterfacesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimInterfacesByIdCmd", err)
 	}
 
-	DeleteDcimInterfacesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the interface to be deleted")
-	err = DeleteDcimInterfacesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimInterfacesByIdCmd", err)
-	}
+	DeleteDcimInterfacesByIdCmd.Flags().IntP("id", "", 0, "ID of the interface to be deleted")
+	api.AddLookupFlags(DeleteDcimInterfacesByIdCmd, "cmd.dcim.dcim_api_url.interfaces_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimInventoryItemRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an inventory item role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.inventory_item_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.inventory_item_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimInventoryItemRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimInventoryItemRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimInventoryItemRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimInventoryItemRolesByIdCmd", err)
 	}
 
-	DeleteDcimInventoryItemRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the inventory item role object to be deleted")
-	err = DeleteDcimInventoryItemRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteDcimInventoryItemRolesByIdCmd", err)
-	}
+	DeleteDcimInventoryItemRolesByIdCmd.Flags().IntP("id", "", 0, "ID of the inventory item role object to be deleted")
+	api.AddLookupFlags(DeleteDcimInventoryItemRolesByIdCmd, "cmd.dcim.dcim_api_url.inventory_item_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
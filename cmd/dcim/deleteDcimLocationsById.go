@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimLocationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an location object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.locations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.locations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimLocationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimLocationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimLocationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimLocationsByIdCmd", err)
 	}
 
-	DeleteDcimLocationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the location object to be deleted")
-	err = DeleteDcimLocationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: id: %s - for DeleteDcimLocationsByIdCmd", err)
-	}
+	DeleteDcimLocationsByIdCmd.Flags().IntP("id", "", 0, "ID of the location object to be deleted")
+	api.AddLookupFlags(DeleteDcimLocationsByIdCmd, "cmd.dcim.dcim_api_url.locations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
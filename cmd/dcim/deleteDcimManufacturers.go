@@ -34,21 +34,24 @@ var DeleteDcimManufacturersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of manufacturer objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.manufacturers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.manufacturers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimManufacturersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimManufacturersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimManufacturersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimManufacturersCmd", err)
 	}
 
-	DeleteDcimManufacturersCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in delete request")
+	DeleteDcimManufacturersCmd.Flags().StringP("data", "", "", "JSON data to be sent in delete request")
 	err = DeleteDcimManufacturersCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimManufacturersCmd", err)
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimModuleTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an module type object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.module_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.module_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimModuleTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimModuleTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimModuleTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimModuleTypesByIdCmd", err)
 	}
 
-	DeleteDcimModuleTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the module type to be deleted")
-	err = DeleteDcimModuleTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimModuleTypesByIdCmd", err)
-	}
+	DeleteDcimModuleTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the module type to be deleted")
+	api.AddLookupFlags(DeleteDcimModuleTypesByIdCmd, "cmd.dcim.dcim_api_url.module_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimPlatformsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an platform object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.platforms_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.platforms_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimPlatformsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimPlatformsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimPlatformsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPlatformsByIdCmd", err)
 	}
 
-	DeleteDcimPlatformsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the platform object to be deleted")
-	err = DeleteDcimPlatformsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPlatformsByIdCmd", err)
-	}
+	DeleteDcimPlatformsByIdCmd.Flags().IntP("id", "", 0, "ID of the platform object to be deleted")
+	api.AddLookupFlags(DeleteDcimPlatformsByIdCmd, "cmd.dcim.dcim_api_url.platforms_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
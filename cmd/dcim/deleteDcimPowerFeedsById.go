@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimPowerFeedsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an powerfeed object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.power_feeds_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.power_feeds_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimPowerFeedsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimPowerFeedsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimPowerFeedsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerFeedsByIdCmd", err)
 	}
 
-	DeleteDcimPowerFeedsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the powerfeed object to be deleted")
-	err = DeleteDcimPowerFeedsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerFeedsByIdCmd", err)
-	}
+	DeleteDcimPowerFeedsByIdCmd.Flags().IntP("id", "", 0, "ID of the powerfeed object to be deleted")
+	api.AddLookupFlags(DeleteDcimPowerFeedsByIdCmd, "cmd.dcim.dcim_api_url.power_feeds_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
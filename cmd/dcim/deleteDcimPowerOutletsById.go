@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimPowerOutletsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an power outlet object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.power_outlets_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.power_outlets_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimPowerOutletsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimPowerOutletsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimPowerOutletsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerOutletsByIdCmd", err)
 	}
 
-	DeleteDcimPowerOutletsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power outlet object to be deleted")
-	err = DeleteDcimPowerOutletsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerOutletsByIdCmd", err)
-	}
+	DeleteDcimPowerOutletsByIdCmd.Flags().IntP("id", "", 0, "ID of the power outlet object to be deleted")
+	api.AddLookupFlags(DeleteDcimPowerOutletsByIdCmd, "cmd.dcim.dcim_api_url.power_outlets_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimPowerPanelsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an power panel object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.power_panels_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.power_panels_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimPowerPanelsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimPowerPanelsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimPowerPanelsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerPanelsByIdCmd", err)
 	}
 
-	DeleteDcimPowerPanelsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power panel object to be deleted")
-	err = DeleteDcimPowerPanelsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimPowerPanelsByIdCmd", err)
-	}
+	DeleteDcimPowerPanelsByIdCmd.Flags().IntP("id", "", 0, "ID of the power panel object to be deleted")
+	api.AddLookupFlags(DeleteDcimPowerPanelsByIdCmd, "cmd.dcim.dcim_api_url.power_panels_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimRackReservationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an rack reservation object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.rack_reservations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.rack_reservations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimRackReservationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimRackReservationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimRackReservationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRackReservationsByIdCmd", err)
 	}
 
-	DeleteDcimRackReservationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rack reservation object to be deleted")
-	err = DeleteDcimRackReservationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRackReservationsByIdCmd", err)
-	}
+	DeleteDcimRackReservationsByIdCmd.Flags().IntP("id", "", 0, "ID of the rack reservation object to be deleted")
+	api.AddLookupFlags(DeleteDcimRackReservationsByIdCmd, "cmd.dcim.dcim_api_url.rack_reservations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
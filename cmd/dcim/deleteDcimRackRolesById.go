@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimRackRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an rack role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.rack_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.rack_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimRackRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimRackRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimRackRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRackRolesByIdCmd", err)
 	}
 
-	DeleteDcimRackRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rack role object to be deleted")
-	err = DeleteDcimRackRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRackRolesByIdCmd", err)
-	}
+	DeleteDcimRackRolesByIdCmd.Flags().IntP("id", "", 0, "ID of the rack role object to be deleted")
+	api.AddLookupFlags(DeleteDcimRackRolesByIdCmd, "cmd.dcim.dcim_api_url.rack_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
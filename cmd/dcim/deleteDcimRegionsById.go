@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,25 +35,25 @@ var DeleteDcimRegionsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an region object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.regions_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.regions_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimRegionsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimRegionsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimRegionsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRegionsByIdCmd", err)
 	}
 
-	DeleteDcimRegionsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the region object to be deleted")
-	err = DeleteDcimRegionsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimRegionsByIdCmd", err)
-	}
+	DeleteDcimRegionsByIdCmd.Flags().IntP("id", "", 0, "ID of the region object to be deleted")
+	api.AddLookupFlags(DeleteDcimRegionsByIdCmd, "cmd.dcim.dcim_api_url.regions_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,25 +17,25 @@ var DeleteDcimSiteGroupsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an site group object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.site_groups_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.site_groups_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimSiteGroupsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimSiteGroupsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimSiteGroupsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimSiteGroupsByIdCmd", err)
 	}
 
-	DeleteDcimSiteGroupsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the site group object to be deleted")
-	err = DeleteDcimSiteGroupsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimSiteGroupsByIdCmd", err)
-	}
+	DeleteDcimSiteGroupsByIdCmd.Flags().IntP("id", "", 0, "ID of the site group object to be deleted")
+	api.AddLookupFlags(DeleteDcimSiteGroupsByIdCmd, "cmd.dcim.dcim_api_url.site_groups_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
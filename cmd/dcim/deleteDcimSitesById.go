@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,25 +17,25 @@ var DeleteDcimSitesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an site object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.sites_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.sites_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimSitesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimSitesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimSitesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimSitesByIdCmd", err)
 	}
 
-	DeleteDcimSitesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the site object to be deleted")
-	err = DeleteDcimSitesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimSitesByIdCmd", err)
-	}
+	DeleteDcimSitesByIdCmd.Flags().IntP("id", "", 0, "ID of the site object to be deleted")
+	api.AddLookupFlags(DeleteDcimSitesByIdCmd, "cmd.dcim.dcim_api_url.sites_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -16,21 +16,24 @@ var DeleteDcimVirtualChassisCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE a list of virtual chassis objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDelete("cmd.dcim.dcim_api_url.virtual_chassis_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDelete(cmd, "cmd.dcim.dcim_api_url.virtual_chassis_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimVirtualChassisCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimVirtualChassisCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimVirtualChassisCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for DeleteDcimVirtualChassisCmd", err)
 	}
 
-	DeleteDcimVirtualChassisCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be sent in delete request")
+	DeleteDcimVirtualChassisCmd.Flags().StringP("data", "", "", "JSON data to be sent in delete request")
 	err = DeleteDcimVirtualChassisCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for DeleteDcimVirtualChassisCmd", err)
@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,25 +17,25 @@ var DeleteDcimVirtualChassisByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an virtual chassis object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.virtual_chassis_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.virtual_chassis_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimVirtualChassisByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimVirtualChassisByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimVirtualChassisByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimVirtualChassisByIdCmd", err)
 	}
 
-	DeleteDcimVirtualChassisByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of virtual chassis object to be deleted")
-	err = DeleteDcimVirtualChassisByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimVirtualChassisByIdCmd", err)
-	}
+	DeleteDcimVirtualChassisByIdCmd.Flags().IntP("id", "", 0, "ID of virtual chassis object to be deleted")
+	api.AddLookupFlags(DeleteDcimVirtualChassisByIdCmd, "cmd.dcim.dcim_api_url.virtual_chassis_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,25 +17,25 @@ var DeleteDcimVirtualDeviceContextsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   DELETE an virtual device context object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionDeleteID("cmd.dcim.dcim_api_url.virtual_device_contexts_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.dcim.dcim_api_url.virtual_device_contexts_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	DeleteDcimVirtualDeviceContextsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	DeleteDcimVirtualDeviceContextsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := DeleteDcimVirtualDeviceContextsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for DeleteDcimVirtualDeviceContextsByIdCmd", err)
 	}
 
-	DeleteDcimVirtualDeviceContextsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the virtual device context object to be deleted")
-	err = DeleteDcimVirtualDeviceContextsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for DeleteDcimVirtualDeviceContextsByIdCmd", err)
-	}
+	DeleteDcimVirtualDeviceContextsByIdCmd.Flags().IntP("id", "", 0, "ID of the virtual device context object to be deleted")
+	api.AddLookupFlags(DeleteDcimVirtualDeviceContextsByIdCmd, "cmd.dcim.dcim_api_url.virtual_device_contexts_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
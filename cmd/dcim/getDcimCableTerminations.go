@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -76,8 +75,9 @@ type cableTerminations struct {
 			} `json:"device,omitempty"`
 			Name string `json:"name,omitempty"`
 		} `json:"termination"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -90,8 +90,10 @@ var GetDcimCableTerminationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools: 
   GET a list of cable termination objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.cable_terminations")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.cable_terminations"); err != nil {
+			return err
+		}
 		if responseObject.Count > 0 {
 			color.Cyan("\n  Total ABC Cable Terminations: "+color.YellowString("%v"), responseObject.Count)
 			for _, result := range responseObject.Results {
@@ -188,11 +190,18 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\t  Name: " + color.RedString("No name found for: "+color.YellowString("%s", result.Display)))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 			for responseObject.Next != nil {
-				nextPage()
+				more, err := nextPage(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObject.Next == nil {
 				display := color.HiGreenString("\tAll Netbox cable termination objects have been successfully displayed...")
@@ -204,6 +213,7 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Cable Terminations: " + color.RedString("No cable termination entries found on server.\n"))
 		}
+		return nil
 	},
 }
 
@@ -302,37 +312,41 @@ func displayCableTerminationsOutput() {
 		} else {
 			color.Cyan("\t  Name: " + color.RedString("No name found for: "+color.YellowString("%s", result.Display)))
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 	}
 }
 
-func nextPage() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPage(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of cable termination objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPage(responseObject, "GET", *responseObject.Next)
+		if err := apiConnectionNextPage(cmd, responseObject, *responseObject.Next); err != nil {
+			return false, err
+		}
 		displayCableTerminationsOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimCableTerminationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimCableTerminationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimCableTerminationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimCableTerminationsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimCableTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimAllCableTeminationsCmd.PersistentFlags().String("foo", "", "A help for foo")
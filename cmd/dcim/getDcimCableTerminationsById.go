@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -68,8 +69,9 @@ type cableTerminationsByID struct {
 		} `json:"device,omitempty"`
 		Name string `json:"name,omitempty"`
 	} `json:"termination"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimCableTerminationsByIdCmd represents the getDcimCableTerminationsById command
@@ -79,9 +81,11 @@ var GetDcimCableTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an cable termination object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(cableTerminationsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.cable_terminations_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.cable_terminations_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Cable Termination: %s\n", color.YellowString(responseObject.Display))
@@ -177,28 +181,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\t  Name: " + color.RedString("No name found for: "+color.YellowString("%s", responseObject.Display)))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Cyan("\tABC Cable Termination: " + color.RedString("No cable termination entries found for on server for ID: %d. Exiting...\n", id))
+			color.Cyan("\tABC Cable Termination: " + color.RedString("No cable termination entries found for on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimCableTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimCableTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimCableTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - GetDcimCableTerminationsByIdCmd", err)
 	}
 
-	GetDcimCableTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of Cable Termination")
-	err = GetDcimCableTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %v", err)
-	}
+	GetDcimCableTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of Cable Termination")
+	api.AddLookupFlags(GetDcimCableTerminationsByIdCmd, "cmd.dcim.dcim_api_url.cable_terminations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -56,17 +55,11 @@ type cables struct {
 					Cid         string `json:"cid"`
 					Description string `json:"description"`
 				} `json:"circuit,omitempty"`
-				TermSide    string `json:"term_side,omitempty"`
-				Description string `json:"description"`
-				Cable       struct {
-					Id          uint   `json:"id"`
-					Url         string `json:"url"`
-					Display     string `json:"display"`
-					Label       string `json:"label"`
-					Description string `json:"description"`
-				} `json:"cable"`
-				Occupied bool `json:"_occupied"`
-				Device   struct {
+				TermSide    string   `json:"term_side,omitempty"`
+				Description string   `json:"description"`
+				Cable       CableRef `json:"cable"`
+				Occupied    bool     `json:"_occupied"`
+				Device      struct {
 					Id          uint   `json:"id"`
 					Url         string `json:"url"`
 					Display     string `json:"display"`
@@ -90,16 +83,10 @@ type cables struct {
 					Name        string `json:"name"`
 					Description string `json:"description"`
 				} `json:"device"`
-				Name        string `json:"name"`
-				Description string `json:"description"`
-				Cable       struct {
-					Id          uint   `json:"id"`
-					Url         string `json:"url"`
-					Display     string `json:"display"`
-					Label       string `json:"label"`
-					Description string `json:"description"`
-				} `json:"cable"`
-				Occupied bool `json:"_occupied"`
+				Name        string   `json:"name"`
+				Description string   `json:"description"`
+				Cable       CableRef `json:"cable"`
+				Occupied    bool     `json:"_occupied"`
 			} `json:"object"`
 		} `json:"b_terminations"`
 		Status struct {
@@ -121,8 +108,9 @@ type cables struct {
 			CommonFieldsSlug
 			Color string `json:"color,omitempty"`
 		} `json:"tags,omitempty"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -135,8 +123,10 @@ var GetDcimCablesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   Get a list of cable objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectCables, "GET", "cmd.dcim.dcim_api_url.cables")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectCables, "cmd.dcim.dcim_api_url.cables"); err != nil {
+			return err
+		}
 
 		if responseObjectCables.Count > 0 {
 			color.Cyan("\n  Total ABC Cables: "+color.YellowString("%v"), responseObjectCables.Count)
@@ -301,11 +291,18 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No Tags entry found for device: ") + color.YellowString("%s", cable.Display))
 					}
 				}
+				api.DisplayCustomFields(cable.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%v", cable.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%v\n", cable.LastUpdated))
 			}
 			for responseObjectCables.Next != nil {
-				nextPageCables()
+				more, err := nextPageCables(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectCables.Next == nil {
 				display := color.HiGreenString("\tAll Netbox cable objects have been successfully displayed...")
@@ -317,45 +314,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Cables: " + color.RedString("No cables entries found for on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageCables[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectCables.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageCables() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageCables(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of cable objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageCables(responseObjectCables, "GET", *responseObjectCables.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectCables, *responseObjectCables.Next); err != nil {
+			return false, err
+		}
 		displayCablesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayCablesOutput() {
@@ -519,6 +497,7 @@ func displayCablesOutput() {
 				color.Cyan("\tTags: " + color.RedString("No Tags entry found for device: ") + color.YellowString("%s", cable.Display))
 			}
 		}
+		api.DisplayCustomFields(cable.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%v", cable.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%v", cable.LastUpdated))
 	}
@@ -527,12 +506,14 @@ func displayCablesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimCablesCmd.Flags().StringVarP(&serverEnv, "env", "", "", "Environment to get DcimCables ('development', 'production').")
+	GetDcimCablesCmd.Flags().StringP("env", "", "", "Environment to get DcimCables ('development', 'production').")
 	err := GetDcimCablesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimCablesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimCablesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimCablesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -53,17 +54,11 @@ type cablesByID struct {
 				Cid         string `json:"cid"`
 				Description string `json:"description"`
 			} `json:"circuit,omitempty"`
-			TermSide    string `json:"term_side,omitempty"`
-			Description string `json:"description"`
-			Cable       struct {
-				Id          uint   `json:"id"`
-				Url         string `json:"url"`
-				Display     string `json:"display"`
-				Label       string `json:"label"`
-				Description string `json:"description"`
-			} `json:"cable"`
-			Occupied bool `json:"_occupied"`
-			Device   struct {
+			TermSide    string   `json:"term_side,omitempty"`
+			Description string   `json:"description"`
+			Cable       CableRef `json:"cable"`
+			Occupied    bool     `json:"_occupied"`
+			Device      struct {
 				Id          uint   `json:"id"`
 				Url         string `json:"url"`
 				Display     string `json:"display"`
@@ -87,16 +82,10 @@ type cablesByID struct {
 				Name        string `json:"name"`
 				Description string `json:"description"`
 			} `json:"device"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			Cable       struct {
-				Id          uint   `json:"id"`
-				Url         string `json:"url"`
-				Display     string `json:"display"`
-				Label       string `json:"label"`
-				Description string `json:"description"`
-			} `json:"cable"`
-			Occupied bool `json:"_occupied"`
+			Name        string   `json:"name"`
+			Description string   `json:"description"`
+			Cable       CableRef `json:"cable"`
+			Occupied    bool     `json:"_occupied"`
 		} `json:"object"`
 	} `json:"b_terminations"`
 	Status struct {
@@ -118,8 +107,9 @@ type cablesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color,omitempty"`
 	} `json:"tags,omitempty"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimCablesByIdCmd represents the getDcimCablesById command.
@@ -129,9 +119,11 @@ var GetDcimCablesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an cable object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(cablesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.cables_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.cables_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Cable: %s\n", color.YellowString(responseObject.Display))
@@ -259,7 +251,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tColor: " + color.RedString("No Color entry found for device: ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Length != 0 {
-				color.Cyan("\tLength: " + color.YellowString("%s", responseObject.Length))
+				color.Cyan("\tLength: " + color.YellowString("%v", responseObject.Length))
 			} else {
 				color.Cyan("\tLength: " + color.RedString("No Length entry found for device: ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -293,11 +285,13 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No Tags entry found for device: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Cyan("\n  ABC Cables: " + color.RedString("No cables entries found for on server for ID: %d. Exiting...\n", id))
+			color.Cyan("\n  ABC Cables: " + color.RedString("No cables entries found for on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
@@ -305,17 +299,14 @@ ABC Netbox Automation Tools:
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimCablesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimCablesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimCablesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimCablesByIdCmd", err)
 	}
 
-	GetDcimCablesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the cable")
-	err = GetDcimCablesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for GetDcimCablesByIdCmd", err)
-	}
+	GetDcimCablesByIdCmd.Flags().IntP("id", "", 0, "ID of the cable")
+	api.AddLookupFlags(GetDcimCablesByIdCmd, "cmd.dcim.dcim_api_url.cables_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
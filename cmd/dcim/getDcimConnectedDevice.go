@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -136,18 +137,19 @@ type connectedDevice struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created                string `json:"created"`
-	LastUpdated            string `json:"last_updated"`
-	ConsolePortCount       uint   `json:"console_port_count"`
-	ConsoleServerPortCount uint   `json:"console_server_port_count"`
-	PowerPortCount         uint   `json:"power_port_count"`
-	PowerOutletCount       uint   `json:"power_outlet_count"`
-	InterfaceCount         uint   `json:"interface_count"`
-	FrontPortCount         uint   `json:"front_port_count"`
-	RearPortCount          uint   `json:"rear_port_count"`
-	DeviceBayCount         uint   `json:"device_bay_count"`
-	ModuleBayCount         uint   `json:"module_bay_count"`
-	InventoryItemCount     uint   `json:"inventory_item_count"`
+	CustomFields           map[string]interface{} `json:"custom_fields"`
+	Created                string                 `json:"created"`
+	LastUpdated            string                 `json:"last_updated"`
+	ConsolePortCount       uint                   `json:"console_port_count"`
+	ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+	PowerPortCount         uint                   `json:"power_port_count"`
+	PowerOutletCount       uint                   `json:"power_outlet_count"`
+	InterfaceCount         uint                   `json:"interface_count"`
+	FrontPortCount         uint                   `json:"front_port_count"`
+	RearPortCount          uint                   `json:"rear_port_count"`
+	DeviceBayCount         uint                   `json:"device_bay_count"`
+	ModuleBayCount         uint                   `json:"module_bay_count"`
+	InventoryItemCount     uint                   `json:"inventory_item_count"`
 }
 
 // GetDcimConnectedDeviceCmd represents the getDcimConnectedDevice command
@@ -157,9 +159,11 @@ var GetDcimConnectedDeviceCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of connected device objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(connectedDevice)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.connected_device")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.connected_device"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			color.Cyan("\n  ABC Connected Devices: "+color.YellowString("for %s"), responseObject.Display)
@@ -444,6 +448,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No Tags entry found for console port name: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.ConsolePortCount > 0 {
@@ -499,18 +504,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Connected Device: " + color.RedString("No connected device found on server. Exiting..."))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConnectedDeviceCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConnectedDeviceCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConnectedDeviceCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConnectedDeviceCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimConnectedDeviceCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimConnectedDeviceCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -73,8 +72,10 @@ var GetDcimConsolePortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of console port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectConsolePortTemplates, "GET", "cmd.dcim.dcim_api_url.console_port_templates")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectConsolePortTemplates, "cmd.dcim.dcim_api_url.console_port_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\nTotal ABC Console Port Templates: "+color.YellowString("%v"), responseObjectConsolePortTemplates.Count)
@@ -144,7 +145,13 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tCreated: %s\n", result.LastUpdated)
 			}
 			for responseObjectConsolePortTemplates.Next != nil {
-				nextPageConsolePortTemplates()
+				more, err := nextPageConsolePortTemplates(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectConsolePortTemplates.Next == nil {
 				display := color.HiGreenString("\tAll Netbox console port template objects have been successfully displayed...")
@@ -156,45 +163,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Console Port Templates: " + color.RedString("No console port templates entries found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageConsolePortTemplates[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectConsolePortTemplates.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageConsolePortTemplates() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageConsolePortTemplates(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of console port template objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageConsolePortTemplates(responseObjectConsolePortTemplates, "GET", *responseObjectConsolePortTemplates.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectConsolePortTemplates, *responseObjectConsolePortTemplates.Next); err != nil {
+			return false, err
+		}
 		displayConsolePortTemplatesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayConsolePortTemplatesOutput() {
@@ -268,12 +256,14 @@ func displayConsolePortTemplatesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsolePortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsolePortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsolePortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsolePortTemplatesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimConsolePortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimConsolePortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
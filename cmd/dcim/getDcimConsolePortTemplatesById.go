@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -64,9 +65,11 @@ var GetDcimConsolePortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an console port template object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(consolePortTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.console_port_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Console Port Template: %s\n", color.YellowString(responseObject.Display))
@@ -133,25 +136,23 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tCreated: %s", responseObject.Created)
 			color.Cyan("\tCreated: %s\n", responseObject.LastUpdated)
 		} else {
-			color.Cyan("  ABC Console Port Template: " + color.RedString("No console port template entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Console Port Template: " + color.RedString("No console port template entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsolePortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsolePortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsolePortTemplatesByIdCmd", err)
 	}
 
-	GetDcimConsolePortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console port template object")
-	err = GetDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimConsolePortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the console port template object")
+	api.AddLookupFlags(GetDcimConsolePortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.console_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
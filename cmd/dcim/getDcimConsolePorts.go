@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -78,9 +77,10 @@ type consolePorts struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -93,8 +93,10 @@ var GetDcimConsolePortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of console port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectConsolePorts, "GET", "cmd.dcim.dcim_api_url.console_ports")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectConsolePorts, "cmd.dcim.dcim_api_url.console_ports"); err != nil {
+			return err
+		}
 
 		if responseObjectConsolePorts.Count > 0 {
 			color.Cyan("\n  Total ABC Console Ports: "+color.YellowString("%v"), responseObjectConsolePorts.Count)
@@ -217,12 +219,19 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No Tags entry found for console port name: ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 			}
 			for responseObjectConsolePorts.Next != nil {
-				nextPageConsolePorts()
+				more, err := nextPageConsolePorts(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectConsolePorts.Next == nil {
 				display := color.HiGreenString("\tAll Netbox console port objects have been successfully displayed...")
@@ -234,45 +243,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Console Ports: " + color.RedString("No console ports found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageConsolePorts[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectConsolePorts.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageConsolePorts() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageConsolePorts(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of console poert objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageConsolePorts(responseObjectConsolePorts, "GET", *responseObjectConsolePorts.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectConsolePorts, *responseObjectConsolePorts.Next); err != nil {
+			return false, err
+		}
 		displayConsolePortsOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayConsolePortsOutput() {
@@ -395,6 +385,7 @@ func displayConsolePortsOutput() {
 				color.Cyan("\tTags: " + color.RedString("No Tags entry found for console port name: ") + color.YellowString("%s", result.Display))
 			}
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 		color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
@@ -404,12 +395,14 @@ func displayConsolePortsOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsolePortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsolePortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsolePortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsolePortsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimConsolePortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimConsolePortsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -72,9 +73,10 @@ type consolePortsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimConsolePortsByIdCmd represents the getDcimConsolePortsById command
@@ -84,9 +86,11 @@ var GetDcimConsolePortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an console port object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(consolePortsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.console_ports_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.console_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Console Port Name: %s\n", color.YellowString(responseObject.Display))
@@ -207,29 +211,28 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No Tags entry found for console port name: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Cyan("  ABC Console Port: " + color.RedString("No console port entries found for on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Console Port: " + color.RedString("No console port entries found for on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsolePortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsolePortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsolePortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsolePortsByIdCmd", err)
 	}
 
-	GetDcimConsolePortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Netbox ID of the console port")
-	err = GetDcimConsolePortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimConsolePortsByIdCmd.Flags().IntP("id", "", 0, "Netbox ID of the console port")
+	api.AddLookupFlags(GetDcimConsolePortsByIdCmd, "cmd.dcim.dcim_api_url.console_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
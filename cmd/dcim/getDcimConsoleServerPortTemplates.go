@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -55,12 +54,13 @@ type consoleServerPortTemplates struct {
 			Manufacturer CommonFieldsSlug `json:"manufacturer"`
 			Model        string           `json:"model"`
 		} `json:"module_type"`
-		Name        string     `json:"name"`
-		Label       string     `json:"label"`
-		Type        ValueLabel `json:"type"`
-		Description string     `json:"description"`
-		Created     string     `json:"created"`
-		LastUpdated string     `json:"last_updated"`
+		Name         string                 `json:"name"`
+		Label        string                 `json:"label"`
+		Type         ValueLabel             `json:"type"`
+		Description  string                 `json:"description"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -73,8 +73,10 @@ var GetDcimConsoleServerPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of console server port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectConsoleServerPortTemplates, "GET", "cmd.dcim.dcim_api_url.console_server_port_templates")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectConsoleServerPortTemplates, "cmd.dcim.dcim_api_url.console_server_port_templates"); err != nil {
+			return err
+		}
 
 		if responseObjectConsoleServerPortTemplates.Count > 0 {
 			color.Cyan("\nTotal ABC Console Server Port Templates: "+color.YellowString("%v"), responseObjectConsoleServerPortTemplates.Count)
@@ -140,11 +142,18 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tDescription: " + color.RedString("No description entry found for: ") + color.YellowString("%s", result.DeviceType.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: %s", result.Created)
 				color.Cyan("\tLast Updated: %s\n", result.LastUpdated)
 			}
 			for responseObjectConsoleServerPortTemplates.Next != nil {
-				nextPageConsoleServerPortTemplates()
+				more, err := nextPageConsoleServerPortTemplates(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectConsoleServerPortTemplates.Next == nil {
 				display := color.HiGreenString("\tAll Netbox console server port template objects have been successfully displayed...")
@@ -156,45 +165,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Console Server Port Templates: " + color.RedString("No console server port templates entries found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageConsoleServerPortTemplates[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectConsolePortTemplates.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageConsoleServerPortTemplates() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageConsoleServerPortTemplates(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of console server port template objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageConsoleServerPortTemplates(responseObjectConsoleServerPortTemplates, "GET", *responseObjectConsoleServerPortTemplates.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectConsoleServerPortTemplates, *responseObjectConsoleServerPortTemplates.Next); err != nil {
+			return false, err
+		}
 		displayConsoleServerPortTemplatesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayConsoleServerPortTemplatesOutput() {
@@ -260,6 +250,7 @@ func displayConsoleServerPortTemplatesOutput() {
 		} else {
 			color.Cyan("\tDescription: " + color.RedString("No description entry found for: ") + color.YellowString("%s", result.DeviceType.Display))
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: %s", result.Created)
 		color.Cyan("\tLast Updated: %s\n", result.LastUpdated)
 	}
@@ -268,12 +259,14 @@ func displayConsoleServerPortTemplatesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsoleServerPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsoleServerPortTemplatesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimConsoleServerPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimServerPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -49,12 +50,13 @@ type consoleServerPortTemplatesByID struct {
 		Manufacturer CommonFieldsSlug `json:"manufacturer"`
 		Model        string           `json:"model"`
 	} `json:"module_type"`
-	Name        string     `json:"name"`
-	Label       string     `json:"label"`
-	Type        ValueLabel `json:"type"`
-	Description string     `json:"description"`
-	Created     string     `json:"created"`
-	LastUpdated string     `json:"last_updated"`
+	Name         string                 `json:"name"`
+	Label        string                 `json:"label"`
+	Type         ValueLabel             `json:"type"`
+	Description  string                 `json:"description"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimConsoleServerPortTemplatesByIdCmd represents the getDcimConsoleServerPortTemplatesById command
@@ -64,9 +66,11 @@ var GetDcimConsoleServerPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an console server port template object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(consoleServerPortTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.console_server_port_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.console_server_port_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Console Server Port Template: %s\n", color.YellowString(responseObject.Display))
@@ -130,28 +134,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tDescription: " + color.RedString("No description entry found for: ") + color.YellowString("%s", responseObject.DeviceType.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: %s", responseObject.Created)
 			color.Cyan("\tLast Updated: %s\n", responseObject.LastUpdated)
 		} else {
-			color.Cyan("  ABC Console Server Port Template: " + color.RedString("No console server port template entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Console Server Port Template: " + color.RedString("No console server port template entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsoleServerPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsoleServerPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsoleServerPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsoleServerPortTemplatesByIdCmd", err)
 	}
 
-	GetDcimConsoleServerPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console server port template")
-	err = GetDcimConsoleServerPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimConsoleServerPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the console server port template")
+	api.AddLookupFlags(GetDcimConsoleServerPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
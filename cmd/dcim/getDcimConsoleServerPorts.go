@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strconv"
 	"strings"
 )
@@ -70,16 +69,17 @@ type consoleServerPorts struct {
 			Display string `json:"display"`
 			Label   string `json:"label"`
 		} `json:"cable"`
-		CableEnd                    string           `json:"cable_end"`
-		LinkPeers                   []string         `json:"link_peers"`
-		LinkPeersType               string           `json:"link_peers_type"`
-		ConnectedEndpoints          []string         `json:"connected_endpoints"`
-		ConnectedEndpointsType      string           `json:"connected_endpoints_type"`
-		ConnectedEndpointsReachable bool             `json:"connected_endpoints_reachable"`
-		Tags                        CommonFieldsSlug `json:"tags"`
-		Created                     string           `json:"created"`
-		LastUpdated                 string           `json:"last_updated"`
-		Occupied                    bool             `json:"_occupied"`
+		CableEnd                    string                 `json:"cable_end"`
+		LinkPeers                   []string               `json:"link_peers"`
+		LinkPeersType               string                 `json:"link_peers_type"`
+		ConnectedEndpoints          []string               `json:"connected_endpoints"`
+		ConnectedEndpointsType      string                 `json:"connected_endpoints_type"`
+		ConnectedEndpointsReachable bool                   `json:"connected_endpoints_reachable"`
+		Tags                        CommonFieldsSlug       `json:"tags"`
+		CustomFields                map[string]interface{} `json:"custom_fields"`
+		Created                     string                 `json:"created"`
+		LastUpdated                 string                 `json:"last_updated"`
+		Occupied                    bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -92,8 +92,10 @@ var GetDcimConsoleServerPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of console server port objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectConsoleServerPorts, "GET", "cmd.dcim.dcim_api_url.console_server_ports")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectConsoleServerPorts, "cmd.dcim.dcim_api_url.console_server_ports"); err != nil {
+			return err
+		}
 
 		if len(responseObjectConsoleServerPorts.Results) == 0 {
 			color.Cyan("  Total ABC Console Server Ports: " + color.RedString("No console server port entries found on server. Exiting...\n"))
@@ -210,12 +212,19 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tTags: " + color.RedString("No tags entry for console server port: ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%v", result.Occupied))
 			}
 			for responseObjectConsoleServerPorts.Next != nil {
-				nextPageConsoleServerPorts()
+				more, err := nextPageConsoleServerPorts(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectConsoleServerPorts.Next == nil {
 				display := color.HiGreenString("\tAll Netbox console server port objects have been successfully displayed...")
@@ -225,45 +234,26 @@ ABC Netbox Automation Tools:
 				color.HiGreen("  " + equals + "\n")
 			}
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageConsoleServerPorts[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectConsolePorts.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageConsoleServerPorts() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageConsoleServerPorts(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of console server port objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageConsoleServerPorts(responseObjectConsoleServerPorts, "GET", *responseObjectConsoleServerPorts.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectConsoleServerPorts, *responseObjectConsoleServerPorts.Next); err != nil {
+			return false, err
+		}
 		displayConsoleServerPortsOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayConsoleServerPortsOutput() {
@@ -377,6 +367,7 @@ func displayConsoleServerPortsOutput() {
 		} else {
 			color.Cyan("\tTags: " + color.RedString("No tags entry for console server port: ") + color.YellowString("%s", result.Display))
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 		color.Cyan("\tOccupied: " + color.YellowString("%v", result.Occupied))
@@ -386,12 +377,14 @@ func displayConsoleServerPortsOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsoleServerPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsoleServerPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsoleServerPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsoleServerPortsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimConsoleServerPortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getConsoleServerPortsCmd.PersistentFlags().String("foo", "", "A help for foo")
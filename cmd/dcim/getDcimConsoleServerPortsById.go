@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"log"
 	"strconv"
@@ -65,16 +66,17 @@ type consoleServerPortsByID struct {
 		Display string `json:"display"`
 		Label   string `json:"label"`
 	} `json:"cable"`
-	CableEnd                    string           `json:"cable_end"`
-	LinkPeers                   []string         `json:"link_peers"`
-	LinkPeersType               string           `json:"link_peers_type"`
-	ConnectedEndpoints          []string         `json:"connected_endpoints"`
-	ConnectedEndpointsType      string           `json:"connected_endpoints_type"`
-	ConnectedEndpointsReachable bool             `json:"connected_endpoints_reachable"`
-	Tags                        CommonFieldsSlug `json:"tags"`
-	Created                     string           `json:"created"`
-	LastUpdated                 string           `json:"last_updated"`
-	Occupied                    bool             `json:"_occupied"`
+	CableEnd                    string                 `json:"cable_end"`
+	LinkPeers                   []string               `json:"link_peers"`
+	LinkPeersType               string                 `json:"link_peers_type"`
+	ConnectedEndpoints          []string               `json:"connected_endpoints"`
+	ConnectedEndpointsType      string                 `json:"connected_endpoints_type"`
+	ConnectedEndpointsReachable bool                   `json:"connected_endpoints_reachable"`
+	Tags                        CommonFieldsSlug       `json:"tags"`
+	CustomFields                map[string]interface{} `json:"custom_fields"`
+	Created                     string                 `json:"created"`
+	LastUpdated                 string                 `json:"last_updated"`
+	Occupied                    bool                   `json:"_occupied"`
 }
 
 // GetDcimConsoleServerPortsByIdCmd represents the getConsoleServerPortsById command
@@ -84,9 +86,11 @@ var GetDcimConsoleServerPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an console server port object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(consoleServerPortsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.console_server_ports_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.console_server_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Console Server Port: %s\n", color.YellowString(responseObject.Display))
@@ -198,29 +202,28 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tTags: " + color.RedString("No tags entry for console server port: ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Cyan("  ABC Console Server Port: " + color.RedString("No console server port entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Console Server Port: " + color.RedString("No console server port entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimConsoleServerPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimConsoleServerPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimConsoleServerPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimConsoleServerPortsByIdCmd", err)
 	}
 
-	GetDcimConsoleServerPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console server port")
-	err = GetDcimConsoleServerPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimConsoleServerPortsByIdCmd.Flags().IntP("id", "", 0, "ID of the console server port")
+	api.AddLookupFlags(GetDcimConsoleServerPortsByIdCmd, "cmd.dcim.dcim_api_url.console_server_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
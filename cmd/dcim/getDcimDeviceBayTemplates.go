@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -48,11 +47,12 @@ type deviceBayTemplates struct {
 			Model        string           `json:"model"`
 			Slug         string           `json:"slug"`
 		} `json:"device_type"`
-		Name        string `json:"name"`
-		Label       string `json:"label"`
-		Description string `json:"description"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		Name         string                 `json:"name"`
+		Label        string                 `json:"label"`
+		Description  string                 `json:"description"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -65,8 +65,10 @@ var GetDcimDeviceBayTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device bay template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectDeviceBayTemplates, "GET", "cmd.dcim.dcim_api_url.device_bay_templates")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectDeviceBayTemplates, "cmd.dcim.dcim_api_url.device_bay_templates"); err != nil {
+			return err
+		}
 
 		if len(responseObjectDeviceBayTemplates.Results) == 0 {
 			color.Cyan("  Total ABC Device Bay Templates: " + color.RedString("No device bay templates entries found on server. Exiting...\n"))
@@ -111,11 +113,18 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tDescription: " + color.RedString("No description entry for device bay template: ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 			for responseObjectDeviceBayTemplates.Next != nil {
-				nextPageDeviceBayTemplates()
+				more, err := nextPageDeviceBayTemplates(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectDeviceBayTemplates.Next == nil {
 				display := color.HiGreenString("\tAll Netbox device bay template objects have been successfully displayed...")
@@ -125,45 +134,26 @@ ABC Netbox Automation Tools:
 				color.HiGreen("  " + equals + "\n\n")
 			}
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageDeviceBayTemplates[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectDeviceBayTemplates.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageDeviceBayTemplates() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageDeviceBayTemplates(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of device bay objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageDeviceBayTemplates(responseObjectDeviceBayTemplates, "GET", *responseObjectDeviceBayTemplates.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectDeviceBayTemplates, *responseObjectDeviceBayTemplates.Next); err != nil {
+			return false, err
+		}
 		displayDeviceBayTemplatesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayDeviceBayTemplatesOutput() {
@@ -205,6 +195,7 @@ func displayDeviceBayTemplatesOutput() {
 		} else {
 			color.Cyan("\tDescription: " + color.RedString("No description entry for device bay template: ") + color.YellowString("%s", result.Display))
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 	}
@@ -213,12 +204,14 @@ func displayDeviceBayTemplatesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceBayTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceBayTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceBayTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimDeviceBayTemplatesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimDeviceBayTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimDeviceBayTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
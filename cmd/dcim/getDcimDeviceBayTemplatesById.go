@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -42,11 +43,12 @@ type deviceBayTemplatesByID struct {
 		Model        string           `json:"model"`
 		Slug         string           `json:"slug"`
 	} `json:"device_type"`
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	Description string `json:"description"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	Name         string                 `json:"name"`
+	Label        string                 `json:"label"`
+	Description  string                 `json:"description"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimDeviceBayTemplatesByIdCmd represents the getDcimDeviceBayTemplatesById command
@@ -56,9 +58,11 @@ var GetDcimDeviceBayTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an device bay template object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(deviceBayTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.device_bay_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.device_bay_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Device Bay Template: %s\n", color.YellowString(responseObject.Display))
@@ -98,28 +102,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tDescription: " + color.RedString("No description entry for device bay template: ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Cyan("  ABC Device Bay Templates: " + color.RedString("No device bay template entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Device Bay Templates: " + color.RedString("No device bay template entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceBayTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceBayTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceBayTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimDeviceBayTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device bay template")
-	err = GetDcimDeviceBayTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimDeviceBayTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the device bay template")
+	api.AddLookupFlags(GetDcimDeviceBayTemplatesByIdCmd, "cmd.dcim.dcim_api_url.device_bay_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
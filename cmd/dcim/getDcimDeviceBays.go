@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -55,9 +54,10 @@ type deviceBays struct {
 			Display string `json:"display"`
 			Name    string `json:"name"`
 		} `json:"installed_device"`
-		Tags        []CommonFieldsSlug `json:"tags"`
-		Created     string             `json:"created"`
-		LastUpdated string             `json:"last_updated"`
+		Tags         []CommonFieldsSlug     `json:"tags"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -70,8 +70,10 @@ var GetDcimDeviceBaysCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device bay objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectDeviceBays, "GET", "cmd.dcim.dcim_api_url.device_bays")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectDeviceBays, "cmd.dcim.dcim_api_url.device_bays"); err != nil {
+			return err
+		}
 
 		if len(responseObjectDeviceBays.Results) == 0 {
 			color.Cyan("  Total ABC Device Bays: " + color.RedString("No device bays entries found on server. Exiting...\n"))
@@ -132,11 +134,18 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 			for responseObjectDeviceBays.Next != nil {
-				nextPageDeviceBays()
+				more, err := nextPageDeviceBays(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectDeviceBays.Next == nil {
 				display := color.HiGreenString("\tAll Netbox device bay objects have been successfully displayed...")
@@ -146,45 +155,26 @@ ABC Netbox Automation Tools:
 				color.HiGreen("  " + equals + "\n")
 			}
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageDeviceBays[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectDeviceBays.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageDeviceBays() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageDeviceBays(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of device bay objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageDeviceBays(responseObjectDeviceBays, "GET", *responseObjectDeviceBays.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectDeviceBays, *responseObjectDeviceBays.Next); err != nil {
+			return false, err
+		}
 		displayDeviceBaysOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayDeviceBaysOutput() {
@@ -242,6 +232,7 @@ func displayDeviceBaysOutput() {
 				color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", result.Display))
 			}
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 	}
@@ -250,12 +241,14 @@ func displayDeviceBaysOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceBaysCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceBaysCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceBaysCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimDeviceBaysCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimDeviceBaysCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimDeviceBaysCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -43,9 +44,10 @@ type deviceBaysByID struct {
 	InstalledDevice struct {
 		CommonFieldsNoSlug
 	} `json:"installed_device"`
-	Tags        []CommonFieldsSlug `json:"tags"`
-	Created     string             `json:"created"`
-	LastUpdated string             `json:"last_updated"`
+	Tags         []CommonFieldsSlug     `json:"tags"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimDeviceBaysByIdCmd represents the getDcimDeviceBaysById command
@@ -55,9 +57,11 @@ var GetDcimDeviceBaysByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an device bay object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(deviceBaysByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.device_bays_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.device_bays_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Device Bay: %s\n", color.YellowString(responseObject.Display))
@@ -113,28 +117,27 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("Doh! No Device Bay Object was found with ID: " + color.YellowString("%d", id))
+			color.Red("Doh! No Device Bay Object was found with ID: " + color.YellowString("%d", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceBaysByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceBaysByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceBaysByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimDeviceBaysByIdCmd", err)
 	}
 
-	GetDcimDeviceBaysByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Device Bay ID")
-	err = GetDcimDeviceBaysByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimDeviceBaysByIdCmd.Flags().IntP("id", "", 0, "Device Bay ID")
+	api.AddLookupFlags(GetDcimDeviceBaysByIdCmd, "cmd.dcim.dcim_api_url.device_bays_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
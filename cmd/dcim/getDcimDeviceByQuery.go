@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,9 +38,11 @@ var GetDcimDeviceByQueryCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device objects by string query`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(devices)
-		ApiConnectionQuery(responseObject, "GET", "cmd.dcim.dcim_api_url.devices_id")
+		if err := ApiConnectionQuery(cmd, responseObject, "cmd.dcim.dcim_api_url.devices_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\n  Total ABC Devices Return from Query: "+color.YellowString("%d"), responseObject.Count)
@@ -204,7 +207,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", device.PrimaryIp6.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", device.PrimaryIp6.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", device.PrimaryIp6.Display))
-					color.Cyan("\t  Family: " + color.YellowString("%d", device.PrimaryIp6.Family))
+					color.Cyan("\t  Family: " + color.YellowString("%d", device.PrimaryIp6.Family.Value))
 					color.Cyan("\t  Address: " + color.YellowString("%s", device.PrimaryIp6.Address))
 				} else {
 					color.Cyan("\tPrimary IPv6: " + color.RedString("No primary ipv6 entry found for device: ") + color.YellowString("%s", device.Name))
@@ -214,7 +217,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", device.OobIp.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", device.OobIp.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", device.OobIp.Display))
-					color.Cyan("\t  Family: " + color.YellowString("%d", device.OobIp.Family))
+					color.Cyan("\t  Family: " + color.YellowString("%d", device.OobIp.Family.Value))
 					color.Cyan("\t  Address: " + color.YellowString("%s", device.OobIp.Address))
 				} else {
 					color.Cyan("\tOOB IP: " + color.RedString("No oop ip entry found for device: ") + color.YellowString("%s", device.Name))
@@ -277,6 +280,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(device.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", device.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", device.LastUpdated))
 				if device.ConsolePortCount != 0 {
@@ -331,21 +335,22 @@ ABC Netbox Automation Tools:
 				}
 			}
 		} else {
-			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server with serial number: "+color.YellowString("%s", serial)+color.RedString(" Exiting...\n")))
+			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server with serial number: "+color.YellowString("%s", cmd.Flag("query").Value)+color.RedString(" Exiting...\n")))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceByQueryCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceByQueryCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceByQueryCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimDeviceByQueryCmd", err)
 	}
 
-	GetDcimDeviceByQueryCmd.Flags().StringVarP(&query, "query", "q", "", "string query of object you want to get")
+	GetDcimDeviceByQueryCmd.Flags().StringP("query", "q", "", "string query of object you want to get")
 	err = GetDcimDeviceByQueryCmd.MarkFlagRequired("query")
 	if err != nil {
 		log.Fatalf("Error marking query flag as required: %s - for GetDcimDeviceByQueryCmd", err)
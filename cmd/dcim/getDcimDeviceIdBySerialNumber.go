@@ -37,9 +37,11 @@ var GetDcimDeviceIdBySerialNumberCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a device's ID by serial number query`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(devices)
-		ApiConnectionSerialNumber(responseObject, "GET", "cmd.dcim.dcim_api_url.devices_serial_number")
+		if err := ApiConnectionSerialNumber(cmd, responseObject, "cmd.dcim.dcim_api_url.devices_serial_number"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			for _, device := range responseObject.Results {
@@ -54,21 +56,22 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tPrimary IP: " + color.YellowString("%s\n", device.PrimaryIp.Address))
 			}
 		} else {
-			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server with serial number: "+color.YellowString("%s", serial)+color.RedString(" Exiting...\n")))
+			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server with serial number: "+color.YellowString("%s", cmd.Flag("serial").Value)+color.RedString(" Exiting...\n")))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceIdBySerialNumberCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceIdBySerialNumberCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceIdBySerialNumberCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimDeviceIdBySerialNumberCmd", err)
 	}
 
-	GetDcimDeviceIdBySerialNumberCmd.Flags().StringVarP(&serial, "serial", "s", "", "serial number of object you want to get")
+	GetDcimDeviceIdBySerialNumberCmd.Flags().StringP("serial", "s", "", "serial number of object you want to get")
 	err = GetDcimDeviceIdBySerialNumberCmd.MarkFlagRequired("serial")
 	if err != nil {
 		log.Fatalf("Error marking serial flag as required: %s - for GetDcimDeviceIdBySerialNumberCmd", err)
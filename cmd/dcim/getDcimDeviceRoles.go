@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -43,12 +42,13 @@ type deviceRoles struct {
 		ConfigTemplate struct {
 			CommonFieldsNoSlug
 		} `json:"config_template"`
-		Description         string             `json:"description"`
-		Tags                []CommonFieldsSlug `json:"tags"`
-		Created             string             `json:"created"`
-		LastUpdated         string             `json:"last_updated"`
-		DeviceCount         int                `json:"device_count"`
-		VirtualmachineCount int                `json:"virtualmachine_count"`
+		Description         string                 `json:"description"`
+		Tags                []CommonFieldsSlug     `json:"tags"`
+		CustomFields        map[string]interface{} `json:"custom_fields"`
+		Created             string                 `json:"created"`
+		LastUpdated         string                 `json:"last_updated"`
+		DeviceCount         int                    `json:"device_count"`
+		VirtualmachineCount int                    `json:"virtualmachine_count"`
 	} `json:"results"`
 }
 
@@ -61,8 +61,10 @@ var GetDcimDeviceRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device role objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectDeviceRoles, "GET", "cmd.dcim.dcim_api_url.device_roles")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectDeviceRoles, "cmd.dcim.dcim_api_url.device_roles"); err != nil {
+			return err
+		}
 
 		if len(responseObjectDeviceRoles.Results) == 0 {
 			color.Cyan("\n  Total ABC Device Roles: " + color.RedString("No device roles entries found on server. Exiting...\n"))
@@ -112,6 +114,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.DeviceCount != 0 {
@@ -126,7 +129,13 @@ ABC Netbox Automation Tools:
 				}
 			}
 			for responseObjectDeviceRoles.Next != nil {
-				nextPageDeviceRoles()
+				more, err := nextPageDeviceRoles(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectDeviceRoles.Next == nil {
 				display := color.HiGreenString("\tAll Netbox device role objects have been successfully displayed...")
@@ -136,45 +145,26 @@ ABC Netbox Automation Tools:
 				color.HiGreen("  " + equals + "\n\n")
 			}
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageDeviceRoles[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectDeviceRoles.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageDeviceRoles() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageDeviceRoles(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of device roles objects? ['Y' or 'yes'] or ['n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageDeviceRoles(responseObjectDeviceRoles, "GET", *responseObjectDeviceRoles.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectDeviceRoles, *responseObjectDeviceRoles.Next); err != nil {
+			return false, err
+		}
 		displayDeviceRolesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("Invalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayDeviceRolesOutput() {
@@ -221,6 +211,7 @@ func displayDeviceRolesOutput() {
 				color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", result.Display))
 			}
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 		if result.DeviceCount != 0 {
@@ -239,12 +230,14 @@ func displayDeviceRolesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimDeviceRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimDeviceRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,12 +38,13 @@ type deviceRolesByID struct {
 	ConfigTemplate struct {
 		CommonFieldsNoSlug
 	} `json:"config_template"`
-	Description         string             `json:"description"`
-	Tags                []CommonFieldsSlug `json:"tags"`
-	Created             string             `json:"created"`
-	LastUpdated         string             `json:"last_updated"`
-	DeviceCount         int                `json:"device_count"`
-	VirtualmachineCount int                `json:"virtualmachine_count"`
+	Description         string                 `json:"description"`
+	Tags                []CommonFieldsSlug     `json:"tags"`
+	CustomFields        map[string]interface{} `json:"custom_fields"`
+	Created             string                 `json:"created"`
+	LastUpdated         string                 `json:"last_updated"`
+	DeviceCount         int                    `json:"device_count"`
+	VirtualmachineCount int                    `json:"virtualmachine_count"`
 }
 
 // GetDcimDeviceRolesByIdCmd represents the getDcimDeviceRolesById command
@@ -52,9 +54,11 @@ var GetDcimDeviceRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an device role object by ID.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(deviceRolesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.device_roles_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Device Role: %s\n", color.YellowString(responseObject.Display))
@@ -99,6 +103,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry for device bay: ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.DeviceCount != 0 {
@@ -112,25 +117,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tVirtual Machine Count: " + color.RedString("No virtual machine count entry for device bay: ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Cyan("  ABC Device Role: " + color.RedString("No device role entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Device Role: " + color.RedString("No device role entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimDeviceRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Device Role ID")
-	err = GetDcimDeviceRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimDeviceRolesByIdCmd.Flags().IntP("id", "", 0, "Device Role ID")
+	api.AddLookupFlags(GetDcimDeviceRolesByIdCmd, "cmd.dcim.dcim_api_url.device_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -70,19 +69,20 @@ type deviceTypes struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created                        string `json:"created"`
-		LastUpdated                    string `json:"last_updated"`
-		DeviceCount                    int    `json:"device_count"`
-		ConsolePortTemplateCount       int    `json:"console_port_template_count"`
-		ConsoleServerPortTemplateCount int    `json:"console_server_port_template_count"`
-		PowerPortTemplateCount         int    `json:"power_port_template_count"`
-		PowerOutletTemplateCount       int    `json:"power_outlet_template_count"`
-		InterfaceTemplateCount         int    `json:"interface_template_count"`
-		FrontPortTemplateCount         int    `json:"front_port_template_count"`
-		RearPortTemplateCount          int    `json:"rear_port_template_count"`
-		DeviceBayTemplateCount         int    `json:"device_bay_template_count"`
-		ModuleBayTemplateCount         int    `json:"module_bay_template_count"`
-		InventoryItemTemplateCount     int    `json:"inventory_item_template_count"`
+		CustomFields                   map[string]interface{} `json:"custom_fields"`
+		Created                        string                 `json:"created"`
+		LastUpdated                    string                 `json:"last_updated"`
+		DeviceCount                    int                    `json:"device_count"`
+		ConsolePortTemplateCount       int                    `json:"console_port_template_count"`
+		ConsoleServerPortTemplateCount int                    `json:"console_server_port_template_count"`
+		PowerPortTemplateCount         int                    `json:"power_port_template_count"`
+		PowerOutletTemplateCount       int                    `json:"power_outlet_template_count"`
+		InterfaceTemplateCount         int                    `json:"interface_template_count"`
+		FrontPortTemplateCount         int                    `json:"front_port_template_count"`
+		RearPortTemplateCount          int                    `json:"rear_port_template_count"`
+		DeviceBayTemplateCount         int                    `json:"device_bay_template_count"`
+		ModuleBayTemplateCount         int                    `json:"module_bay_template_count"`
+		InventoryItemTemplateCount     int                    `json:"inventory_item_template_count"`
 	} `json:"results"`
 }
 
@@ -95,8 +95,10 @@ var GetDcimDeviceTypesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device type objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectDeviceTypes, "GET", "cmd.dcim.dcim_api_url.device_types")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectDeviceTypes, "cmd.dcim.dcim_api_url.device_types"); err != nil {
+			return err
+		}
 
 		if responseObjectDeviceTypes.Count != 0 {
 			color.Cyan("\n  Total ABC Device Types: "+color.YellowString("%v"), responseObjectDeviceTypes.Count)
@@ -167,7 +169,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tAirflow: " + color.RedString("No airflow found for device: ") + color.YellowString(deviceType.Display))
 				}
 				if deviceType.Weight != 0.0 {
-					color.Cyan("\tWeight: " + color.YellowString("%d", deviceType.Weight))
+					color.Cyan("\tWeight: " + color.YellowString("%v", deviceType.Weight))
 				} else {
 					color.Cyan("\tWeight: " + color.RedString("No weight found for device: ") + color.YellowString(deviceType.Display))
 				}
@@ -212,6 +214,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(deviceType.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", deviceType.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", deviceType.LastUpdated))
 				if deviceType.DeviceCount != 0 {
@@ -271,7 +274,13 @@ ABC Netbox Automation Tools:
 				}
 			}
 			for responseObjectDeviceTypes.Next != nil {
-				nextPageDeviceTypes()
+				more, err := nextPageDeviceTypes(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectDeviceTypes.Next == nil {
 				display := color.HiGreenString("\tAll Netbox device type objects have been successfully displayed...")
@@ -283,45 +292,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Device Types: " + color.RedString("No device types found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func nextPageDeviceTypes() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageDeviceTypes(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\n\tDo you want to continue to the next page of device objects? [yes/no]: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageDeviceTypes(responseObjectDeviceTypes, "GET", *responseObjectDeviceTypes.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectDeviceTypes, *responseObjectDeviceTypes.Next); err != nil {
+			return false, err
+		}
 		displayDeviceTypesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\nInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
-}
-
-func ApiConnectionNextPageDeviceTypes[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectDeviceTypes.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error patching Netbox API objects: %s\n", err)
-	}
+	return true, nil
 }
 
 func displayDeviceTypesOutput() {
@@ -413,7 +403,7 @@ func displayDeviceTypesOutput() {
 			color.Cyan("\tRack: " + color.RedString("No rack entry found for device: ") + color.YellowString("%s", device.Name))
 		}
 		if device.Position != 0 {
-			color.Cyan("\tPosition: " + color.YellowString("%d", device.Position))
+			color.Cyan("\tPosition: " + color.YellowString("%v", device.Position))
 		} else {
 			color.Cyan("\tPosition: " + color.RedString("No position entry found for device: ") + color.YellowString("%s", device.Name))
 		}
@@ -556,6 +546,7 @@ func displayDeviceTypesOutput() {
 			}
 		}
 
+		api.DisplayCustomFields(device.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", device.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", device.LastUpdated))
 		if device.ConsolePortCount != 0 {
@@ -614,12 +605,14 @@ func displayDeviceTypesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceTypesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceTypesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceTypesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag required flag: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimDeviceTypesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimDeviceTypesCmd.PersistentFlags().String("foo", "", "A help for foo")
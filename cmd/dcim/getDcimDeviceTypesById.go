@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -79,19 +80,20 @@ type deviceTypesByID struct {
 		Slug    string `json:"slug"`
 		Color   string `json:"color"`
 	} `json:"tags"`
-	Created                        string `json:"created"`
-	LastUpdated                    string `json:"last_updated"`
-	DeviceCount                    int    `json:"device_count"`
-	ConsolePortTemplateCount       int    `json:"console_port_template_count"`
-	ConsoleServerPortTemplateCount int    `json:"console_server_port_template_count"`
-	PowerPortTemplateCount         int    `json:"power_port_template_count"`
-	PowerOutletTemplateCount       int    `json:"power_outlet_template_count"`
-	InterfaceTemplateCount         int    `json:"interface_template_count"`
-	FrontPortTemplateCount         int    `json:"front_port_template_count"`
-	RearPortTemplateCount          int    `json:"rear_port_template_count"`
-	DeviceBayTemplateCount         int    `json:"device_bay_template_count"`
-	ModuleBayTemplateCount         int    `json:"module_bay_template_count"`
-	InventoryItemTemplateCount     int    `json:"inventory_item_template_count"`
+	CustomFields                   map[string]interface{} `json:"custom_fields"`
+	Created                        string                 `json:"created"`
+	LastUpdated                    string                 `json:"last_updated"`
+	DeviceCount                    int                    `json:"device_count"`
+	ConsolePortTemplateCount       int                    `json:"console_port_template_count"`
+	ConsoleServerPortTemplateCount int                    `json:"console_server_port_template_count"`
+	PowerPortTemplateCount         int                    `json:"power_port_template_count"`
+	PowerOutletTemplateCount       int                    `json:"power_outlet_template_count"`
+	InterfaceTemplateCount         int                    `json:"interface_template_count"`
+	FrontPortTemplateCount         int                    `json:"front_port_template_count"`
+	RearPortTemplateCount          int                    `json:"rear_port_template_count"`
+	DeviceBayTemplateCount         int                    `json:"device_bay_template_count"`
+	ModuleBayTemplateCount         int                    `json:"module_bay_template_count"`
+	InventoryItemTemplateCount     int                    `json:"inventory_item_template_count"`
 }
 
 // GetDcimDeviceTypesByIdCmd represents the getDcimDeviceTypesById command
@@ -101,9 +103,11 @@ var GetDcimDeviceTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an device type object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(deviceTypesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.device_types_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.device_types_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Device Type: %s\n", color.YellowString(responseObject.Display))
@@ -171,7 +175,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tAirflow: " + color.RedString("No airflow found for device: ") + color.YellowString(responseObject.Display))
 			}
 			if responseObject.Weight != 0.0 {
-				color.Cyan("\tWeight: " + color.YellowString("%d", responseObject.Weight))
+				color.Cyan("\tWeight: " + color.YellowString("%v", responseObject.Weight))
 			} else {
 				color.Cyan("\tWeight: " + color.RedString("No weight found for device: ") + color.YellowString(responseObject.Display))
 			}
@@ -216,6 +220,7 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.DeviceCount != 0 {
@@ -274,25 +279,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tInventory Item Template Count: " + color.RedString("No inventory template count found for device: ") + color.YellowString(responseObject.Display+"\n"))
 			}
 		} else {
-			color.Red("Doh! No device type object was found with ID: " + color.YellowString("%s\n", id))
+			color.Red("Doh! No device type object was found with ID: " + color.YellowString("%d\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDeviceTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDeviceTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDeviceTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimDeviceTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device type")
-	err = GetDcimDeviceTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimDeviceTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the device type")
+	api.AddLookupFlags(GetDcimDeviceTypesByIdCmd, "cmd.dcim.dcim_api_url.device_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
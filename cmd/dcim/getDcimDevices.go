@@ -23,13 +23,12 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	_ "github.com/spf13/viper"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -224,18 +223,19 @@ type devices struct {
 			Slug    string `json:"slug"`
 			Color   string `json:"color"`
 		} `json:"tags"`
-		Created                string `json:"created"`
-		LastUpdated            string `json:"last_updated"`
-		ConsolePortCount       uint   `json:"console_port_count"`
-		ConsoleServerPortCount uint   `json:"console_server_port_count"`
-		PowerPortCount         uint   `json:"power_port_count"`
-		PowerOutletCount       uint   `json:"power_outlet_count"`
-		InterfaceCount         uint   `json:"interface_count"`
-		FrontPortCount         uint   `json:"front_port_count"`
-		RearPortCount          uint   `json:"rear_port_count"`
-		DeviceBayCount         uint   `json:"device_bay_count"`
-		ModuleBayCount         uint   `json:"module_bay_count"`
-		InventoryItemCount     uint   `json:"inventory_item_count"`
+		CustomFields           map[string]interface{} `json:"custom_fields"`
+		Created                string                 `json:"created"`
+		LastUpdated            string                 `json:"last_updated"`
+		ConsolePortCount       uint                   `json:"console_port_count"`
+		ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+		PowerPortCount         uint                   `json:"power_port_count"`
+		PowerOutletCount       uint                   `json:"power_outlet_count"`
+		InterfaceCount         uint                   `json:"interface_count"`
+		FrontPortCount         uint                   `json:"front_port_count"`
+		RearPortCount          uint                   `json:"rear_port_count"`
+		DeviceBayCount         uint                   `json:"device_bay_count"`
+		ModuleBayCount         uint                   `json:"module_bay_count"`
+		InventoryItemCount     uint                   `json:"inventory_item_count"`
 	} `json:"results"`
 }
 
@@ -248,9 +248,11 @@ var GetDcimDevicesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of device objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 
-		ApiConnectionNonID(responseObjectDevices, "GET", "cmd.dcim.dcim_api_url.devices")
+		if err := ApiConnectionNonID(cmd, responseObjectDevices, "cmd.dcim.dcim_api_url.devices"); err != nil {
+			return err
+		}
 
 		if responseObjectDevices.Count > 0 {
 			color.Cyan("\n  Total ABC Devices: "+color.YellowString("%d"), responseObjectDevices.Count)
@@ -486,6 +488,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(device.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", device.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", device.LastUpdated))
 				if device.ConsolePortCount != 0 {
@@ -540,7 +543,13 @@ ABC Netbox Automation Tools:
 				}
 			}
 			for responseObjectDevices.Next != nil {
-				nextPageDevices()
+				more, err := nextPageDevices(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectDevices.Next == nil {
 				display := color.HiGreenString("\tAll Netbox device objects have been successfully displayed...")
@@ -552,45 +561,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Devices: " + color.RedString("No devices entries found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func nextPageDevices() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageDevices(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of device objects? ['Y' or 'yes'/'n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageDevices(responseObjectDevices, "GET", *responseObjectDevices.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectDevices, *responseObjectDevices.Next); err != nil {
+			return false, err
+		}
 		displayDevicesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\nInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
-}
-
-func ApiConnectionNextPageDevices[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectDevices.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error patching Netbox API objects: %s\n", err)
-	}
+	return true, nil
 }
 
 func displayDevicesOutput() {
@@ -825,6 +815,7 @@ func displayDevicesOutput() {
 			}
 		}
 
+		api.DisplayCustomFields(device.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", device.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", device.LastUpdated))
 		if device.ConsolePortCount != 0 {
@@ -883,12 +874,14 @@ func displayDevicesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDevicesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDevicesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDevicesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimDevicesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimDevicesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimDevicesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -129,29 +130,19 @@ type devicesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	CustomFields struct {
-		IpAddr          interface{} `json:"ip_addr"`
-		CellCarrier     interface{} `json:"Cell Carrier"`
-		ChipSerialNo    interface{} `json:"Chip Serial No"`
-		MerakiIP        interface{} `json:"Meraki IP"`
-		UUID            interface{} `json:"UUID"`
-		BleId           interface{} `json:"ble_id"`
-		LaneId          interface{} `json:"lane_id"`
-		OrionPartNumber interface{} `json:"orion_part_number"`
-		IMEI            interface{} `json:"IMEI"`
-	} `json:"custom_fields"`
-	Created                string `json:"created"`
-	LastUpdated            string `json:"last_updated"`
-	ConsolePortCount       uint   `json:"console_port_count"`
-	ConsoleServerPortCount uint   `json:"console_server_port_count"`
-	PowerPortCount         uint   `json:"power_port_count"`
-	PowerOutletCount       uint   `json:"power_outlet_count"`
-	InterfaceCount         uint   `json:"interface_count"`
-	FrontPortCount         uint   `json:"front_port_count"`
-	RearPortCount          uint   `json:"rear_port_count"`
-	DeviceBayCount         uint   `json:"device_bay_count"`
-	ModuleBayCount         uint   `json:"module_bay_count"`
-	InventoryItemCount     uint   `json:"inventory_item_count"`
+	CustomFields           map[string]interface{} `json:"custom_fields"`
+	Created                string                 `json:"created"`
+	LastUpdated            string                 `json:"last_updated"`
+	ConsolePortCount       uint                   `json:"console_port_count"`
+	ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+	PowerPortCount         uint                   `json:"power_port_count"`
+	PowerOutletCount       uint                   `json:"power_outlet_count"`
+	InterfaceCount         uint                   `json:"interface_count"`
+	FrontPortCount         uint                   `json:"front_port_count"`
+	RearPortCount          uint                   `json:"rear_port_count"`
+	DeviceBayCount         uint                   `json:"device_bay_count"`
+	ModuleBayCount         uint                   `json:"module_bay_count"`
+	InventoryItemCount     uint                   `json:"inventory_item_count"`
 }
 
 // GetDcimDevicesByIdCmd represents the getDcimDevicesById command
@@ -161,9 +152,11 @@ var GetDcimDevicesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an device object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(devicesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.devices_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.devices_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Device Name: %s\n", color.YellowString(responseObject.Display))
@@ -393,6 +386,7 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.ConsolePortCount != 0 {
@@ -446,25 +440,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tInventory Item Count: " + color.RedString("No inventory item count found for device: ") + color.YellowString("%s\n", responseObject.Name))
 			}
 		} else {
-			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Device: " + color.RedString("No device entries found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimDevicesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimDevicesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimDevicesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimDevicesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Device ID to retrieve")
-	err = GetDcimDevicesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimDevicesByIdCmd.Flags().IntP("id", "", 0, "Device ID to retrieve")
+	api.AddLookupFlags(GetDcimDevicesByIdCmd, "cmd.dcim.dcim_api_url.devices_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -68,10 +67,11 @@ type frontPortTemplates struct {
 		RearPort struct {
 			CommonFieldsNoSlug
 		} `json:"rear_port"`
-		RearPortPosition int    `json:"rear_port_position"`
-		Description      string `json:"description"`
-		Created          string `json:"created"`
-		LastUpdated      string `json:"last_updated"`
+		RearPortPosition int                    `json:"rear_port_position"`
+		Description      string                 `json:"description"`
+		CustomFields     map[string]interface{} `json:"custom_fields"`
+		Created          string                 `json:"created"`
+		LastUpdated      string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -84,8 +84,10 @@ var GetDcimFrontPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of front port template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectFrontPortTemplates, "GET", "cmd.dcim.dcim_api_url.front_port_templates")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectFrontPortTemplates, "cmd.dcim.dcim_api_url.front_port_templates"); err != nil {
+			return err
+		}
 
 		if responseObjectFrontPortTemplates.Count > 0 {
 			color.Cyan("Total ABC Front Port Templates: "+color.YellowString("%d"), responseObjectFrontPortTemplates.Count)
@@ -99,11 +101,11 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 				color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
 				color.Cyan("\tDevice Type: ")
-				color.Cyan("\t  ID: " + color.YellowString("%s", result.DeviceType.Id))
+				color.Cyan("\t  ID: " + color.YellowString("%d", result.DeviceType.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.DeviceType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.DeviceType.Display))
 				color.Cyan("\t  Manufacturer: ")
-				color.Cyan("\t    ID: " + color.YellowString("%s", result.DeviceType.Manufacturer.Id))
+				color.Cyan("\t    ID: " + color.YellowString("%d", result.DeviceType.Manufacturer.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", result.DeviceType.Manufacturer.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", result.DeviceType.Manufacturer.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", result.DeviceType.Manufacturer.Name))
@@ -111,11 +113,11 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  Model: " + color.YellowString("%s", result.DeviceType.Model))
 				color.Cyan("\t  Slug: " + color.YellowString("%s", result.DeviceType.Slug))
 				color.Cyan("\tModule Type: ")
-				color.Cyan("\t  ID: " + color.YellowString("%s", result.ModuleType.Id))
+				color.Cyan("\t  ID: " + color.YellowString("%d", result.ModuleType.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.ModuleType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.ModuleType.Display))
 				color.Cyan("\t  Manufacturer: ")
-				color.Cyan("\t    ID: " + color.YellowString("%s", result.ModuleType.Manufacturer.Id))
+				color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleType.Manufacturer.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleType.Manufacturer.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleType.Manufacturer.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
@@ -127,18 +129,25 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  Value: " + color.YellowString("%s", result.Type.Value))
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.Type.Label))
 				color.Cyan("\tColor: " + color.YellowString("%s", result.Color))
-				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort))
-				color.Cyan("\t  ID: " + color.YellowString("%s", result.RearPort.Id))
+				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort.Name))
+				color.Cyan("\t  ID: " + color.YellowString("%d", result.RearPort.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.RearPort.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.RearPort.Display))
 				color.Cyan("\t  Name: " + color.YellowString("%s", result.RearPort.Name))
 				color.Cyan("\tRear Port Position: " + color.YellowString("%d", result.RearPortPosition))
 				color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 			for responseObjectFrontPortTemplates.Next != nil {
-				nextPageFrontPortTemplates()
+				more, err := nextPageFrontPortTemplates(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectFrontPorts.Next == nil {
 				display := color.HiGreenString("\tAll Netbox front port template objects have been successfully displayed...")
@@ -150,45 +159,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Front Port Templates: " + color.RedString("No front port templates found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func nextPageFrontPortTemplates() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageFrontPortTemplates(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\n\tDo you want to continue to the next page of front port template objects? [yes/no]: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageFrontPortTemplates(responseObjectFrontPortTemplates, "GET", *responseObjectFrontPortTemplates.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectFrontPortTemplates, *responseObjectFrontPortTemplates.Next); err != nil {
+			return false, err
+		}
 		displayFrontPortTemplatesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\nInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
-}
-
-func ApiConnectionNextPageFrontPortTemplates[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectFrontPortTemplates.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
+	return true, nil
 }
 
 func displayFrontPortTemplatesOutput() {
@@ -202,11 +192,11 @@ func displayFrontPortTemplatesOutput() {
 		color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 		color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
 		color.Cyan("\tDevice Type: ")
-		color.Cyan("\t  ID: " + color.YellowString("%s", result.DeviceType.Id))
+		color.Cyan("\t  ID: " + color.YellowString("%d", result.DeviceType.Id))
 		color.Cyan("\t  URL: " + color.YellowString("%s", result.DeviceType.Url))
 		color.Cyan("\t  Display: " + color.YellowString("%s", result.DeviceType.Display))
 		color.Cyan("\t  Manufacturer: ")
-		color.Cyan("\t    ID: " + color.YellowString("%s", result.DeviceType.Manufacturer.Id))
+		color.Cyan("\t    ID: " + color.YellowString("%d", result.DeviceType.Manufacturer.Id))
 		color.Cyan("\t    URL: " + color.YellowString("%s", result.DeviceType.Manufacturer.Url))
 		color.Cyan("\t    Display: " + color.YellowString("%s", result.DeviceType.Manufacturer.Display))
 		color.Cyan("\t    Name: " + color.YellowString("%s", result.DeviceType.Manufacturer.Name))
@@ -214,11 +204,11 @@ func displayFrontPortTemplatesOutput() {
 		color.Cyan("\t  Model: " + color.YellowString("%s", result.DeviceType.Model))
 		color.Cyan("\t  Slug: " + color.YellowString("%s", result.DeviceType.Slug))
 		color.Cyan("\tModule Type: ")
-		color.Cyan("\t  ID: " + color.YellowString("%s", result.ModuleType.Id))
+		color.Cyan("\t  ID: " + color.YellowString("%d", result.ModuleType.Id))
 		color.Cyan("\t  URL: " + color.YellowString("%s", result.ModuleType.Url))
 		color.Cyan("\t  Display: " + color.YellowString("%s", result.ModuleType.Display))
 		color.Cyan("\t  Manufacturer: ")
-		color.Cyan("\t    ID: " + color.YellowString("%s", result.ModuleType.Manufacturer.Id))
+		color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleType.Manufacturer.Id))
 		color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleType.Manufacturer.Url))
 		color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleType.Manufacturer.Display))
 		color.Cyan("\t    Name: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
@@ -230,13 +220,14 @@ func displayFrontPortTemplatesOutput() {
 		color.Cyan("\t  Value: " + color.YellowString("%s", result.Type.Value))
 		color.Cyan("\t  Label: " + color.YellowString("%s", result.Type.Label))
 		color.Cyan("\tColor: " + color.YellowString("%s", result.Color))
-		color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort))
-		color.Cyan("\t  ID: " + color.YellowString("%s", result.RearPort.Id))
+		color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort.Name))
+		color.Cyan("\t  ID: " + color.YellowString("%d", result.RearPort.Id))
 		color.Cyan("\t  URL: " + color.YellowString("%s", result.RearPort.Url))
 		color.Cyan("\t  Display: " + color.YellowString("%s", result.RearPort.Display))
 		color.Cyan("\t  Name: " + color.YellowString("%s", result.RearPort.Name))
 		color.Cyan("\tRear Port Position: " + color.YellowString("%d", result.RearPortPosition))
 		color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 	}
@@ -245,12 +236,14 @@ func displayFrontPortTemplatesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimFrontPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimFrontPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimFrontPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimFrontPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimFrontPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -62,10 +63,11 @@ type frontPortTemplatesByID struct {
 	RearPort struct {
 		CommonFieldsNoSlug
 	} `json:"rear_port"`
-	RearPortPosition int    `json:"rear_port_position"`
-	Description      string `json:"description"`
-	Created          string `json:"created"`
-	LastUpdated      string `json:"last_updated"`
+	RearPortPosition int                    `json:"rear_port_position"`
+	Description      string                 `json:"description"`
+	CustomFields     map[string]interface{} `json:"custom_fields"`
+	Created          string                 `json:"created"`
+	LastUpdated      string                 `json:"last_updated"`
 }
 
 // GetDcimFrontPortTemplatesByIdCmd represents the getDcimFrontPortTemplatesById command
@@ -75,9 +77,11 @@ var GetDcimFrontPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an front port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(frontPortTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.front_port_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.front_port_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Front Port Template Name: %s\n", color.YellowString(responseObject.Display))
@@ -89,11 +93,11 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tURL: " + color.YellowString("%s", responseObject.Url))
 			color.Cyan("\tDisplay: " + color.YellowString("%s", responseObject.Display))
 			color.Cyan("\tDevice Type: ")
-			color.Cyan("\t  ID: " + color.YellowString("%s", responseObject.DeviceType.Id))
+			color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.DeviceType.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.DeviceType.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.DeviceType.Display))
 			color.Cyan("\t  Manufacturer: ")
-			color.Cyan("\t    ID: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Id))
+			color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Display))
 			color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
@@ -101,11 +105,11 @@ ABC Netbox Automation Tools:
 			color.Cyan("\t  Model: " + color.YellowString("%s", responseObject.DeviceType.Model))
 			color.Cyan("\t  Slug: " + color.YellowString("%s", responseObject.DeviceType.Slug))
 			color.Cyan("\tModule Type: ")
-			color.Cyan("\t  ID: " + color.YellowString("%s", responseObject.ModuleType.Id))
+			color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.ModuleType.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ModuleType.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ModuleType.Display))
 			color.Cyan("\t  Manufacturer: ")
-			color.Cyan("\t    ID: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Id))
+			color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Display))
 			color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
@@ -117,35 +121,34 @@ ABC Netbox Automation Tools:
 			color.Cyan("\t  Value: " + color.YellowString("%s", responseObject.Type.Value))
 			color.Cyan("\t  Label: " + color.YellowString("%s", responseObject.Type.Label))
 			color.Cyan("\tColor: " + color.YellowString("%s", responseObject.Color))
-			color.Cyan("\tRear Port: " + color.YellowString("%s", responseObject.RearPort))
-			color.Cyan("\t  ID: " + color.YellowString("%s", responseObject.RearPort.Id))
+			color.Cyan("\tRear Port: " + color.YellowString("%s", responseObject.RearPort.Name))
+			color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.RearPort.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.RearPort.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.RearPort.Display))
 			color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.RearPort.Name))
 			color.Cyan("\tRear Port Position: " + color.YellowString("%d", responseObject.RearPortPosition))
 			color.Cyan("\tDescription: " + color.YellowString("%s", responseObject.Description))
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! Front Port Template object not found with ID: " + color.YellowString("%s\n", id))
+			color.Red("  Doh! Front Port Template object not found with ID: " + color.YellowString("%d\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimFrontPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimFrontPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimFrontPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimFrontPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of front port template object")
-	err = GetDcimFrontPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimFrontPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of front port template object")
+	api.AddLookupFlags(GetDcimFrontPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.front_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
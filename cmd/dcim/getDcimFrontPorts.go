@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -79,9 +78,10 @@ type frontPorts struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -94,8 +94,10 @@ var GetDcimFrontPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of front port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectFrontPorts, "GET", "cmd.dcim.dcim_api_url.front_ports")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectFrontPorts, "cmd.dcim.dcim_api_url.front_ports"); err != nil {
+			return err
+		}
 
 		if responseObjectFrontPorts.Count != 0 {
 			color.Cyan("Total ABC Front Ports: "+color.YellowString("%v"), responseObjectFrontPorts.Count)
@@ -129,7 +131,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  Value: " + color.YellowString("%s", result.Type.Value))
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.Type.Label))
 				color.Cyan("\tColor: " + color.YellowString("%s", result.Color))
-				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort))
+				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort.Name))
 				color.Cyan("\t  ID: " + color.YellowString("%d", result.RearPort.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.RearPort.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.RearPort.Display))
@@ -137,7 +139,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.RearPort.Label))
 				color.Cyan("\t  Description: " + color.YellowString("%s", result.RearPort.Description))
 				color.Cyan("\tRear Port Position: " + color.YellowString("%d", result.RearPortPosition))
-				color.Cyan("\tDescription: " + color.YellowString("%d", result.Description))
+				color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 				color.Cyan("\tMarked Connected: " + color.YellowString("%v", result.MarkConnected))
 				color.Cyan("\tCable: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", result.Cable.Id))
@@ -146,24 +148,31 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.Cable.Label))
 				color.Cyan("\tCable End: " + color.YellowString("%s", result.CableEnd))
 				for _, link := range result.LinkPeers {
-					color.Cyan("\tLink Peer: " + color.YellowString("%d", link))
+					color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
 				}
 				color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 				for _, tag := range result.Tags {
 					color.Cyan("\tTags: ")
 					color.Cyan("\t  ID: " + color.YellowString("%d", tag.Id))
-					color.Cyan("\t  URL: " + color.YellowString("%d", tag.Url))
-					color.Cyan("\t  Display: " + color.YellowString("%d", tag.Display))
-					color.Cyan("\t  Name: " + color.YellowString("%d", tag.Name))
-					color.Cyan("\t  Slug: " + color.YellowString("%d", tag.Slug))
-					color.Cyan("\t  Color: " + color.YellowString("%d", tag.Color))
+					color.Cyan("\t  URL: " + color.YellowString("%s", tag.Url))
+					color.Cyan("\t  Display: " + color.YellowString("%s", tag.Display))
+					color.Cyan("\t  Name: " + color.YellowString("%s", tag.Name))
+					color.Cyan("\t  Slug: " + color.YellowString("%s", tag.Slug))
+					color.Cyan("\t  Color: " + color.YellowString("%s", tag.Color))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
-				color.Cyan("\tOccupied: " + color.YellowString("%s\n", result.Occupied))
+				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 			}
 			for responseObjectFrontPorts.Next != nil {
-				nextPageFrontPorts()
+				more, err := nextPageFrontPorts(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectFrontPorts.Next == nil {
 				display := color.HiGreenString("\tAll Netbox front port objects have been successfully displayed...")
@@ -175,45 +184,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("Total ABC Front Ports: " + color.RedString("No front ports found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func nextPageFrontPorts() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageFrontPorts(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\n\tDo you want to continue to the next page of device objects? [yes/no]: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageFrontPorts(responseObjectFrontPorts, "GET", *responseObjectFrontPorts.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectFrontPorts, *responseObjectFrontPorts.Next); err != nil {
+			return false, err
+		}
 		displayFrontPortsOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\nInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
-}
-
-func ApiConnectionNextPageFrontPorts[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectFrontPorts.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
+	return true, nil
 }
 
 func displayFrontPortsOutput() {
@@ -247,7 +237,7 @@ func displayFrontPortsOutput() {
 		color.Cyan("\t  Value: " + color.YellowString("%s", result.Type.Value))
 		color.Cyan("\t  Label: " + color.YellowString("%s", result.Type.Label))
 		color.Cyan("\tColor: " + color.YellowString("%s", result.Color))
-		color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort))
+		color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort.Name))
 		color.Cyan("\t  ID: " + color.YellowString("%d", result.RearPort.Id))
 		color.Cyan("\t  URL: " + color.YellowString("%s", result.RearPort.Url))
 		color.Cyan("\t  Display: " + color.YellowString("%s", result.RearPort.Display))
@@ -255,7 +245,7 @@ func displayFrontPortsOutput() {
 		color.Cyan("\t  Label: " + color.YellowString("%s", result.RearPort.Label))
 		color.Cyan("\t  Description: " + color.YellowString("%s", result.RearPort.Description))
 		color.Cyan("\tRear Port Position: " + color.YellowString("%d", result.RearPortPosition))
-		color.Cyan("\tDescription: " + color.YellowString("%d", result.Description))
+		color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 		color.Cyan("\tMarked Connected: " + color.YellowString("%v", result.MarkConnected))
 		color.Cyan("\tCable: ")
 		color.Cyan("\t  ID: " + color.YellowString("%d", result.Cable.Id))
@@ -264,33 +254,36 @@ func displayFrontPortsOutput() {
 		color.Cyan("\t  Label: " + color.YellowString("%s", result.Cable.Label))
 		color.Cyan("\tCable End: " + color.YellowString("%s", result.CableEnd))
 		for _, link := range result.LinkPeers {
-			color.Cyan("\tLink Peer: " + color.YellowString("%d", link))
+			color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
 		}
 		color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 		for _, tag := range result.Tags {
 			color.Cyan("\tTags: ")
 			color.Cyan("\t  ID: " + color.YellowString("%d", tag.Id))
-			color.Cyan("\t  URL: " + color.YellowString("%d", tag.Url))
-			color.Cyan("\t  Display: " + color.YellowString("%d", tag.Display))
-			color.Cyan("\t  Name: " + color.YellowString("%d", tag.Name))
-			color.Cyan("\t  Slug: " + color.YellowString("%d", tag.Slug))
-			color.Cyan("\t  Color: " + color.YellowString("%d", tag.Color))
+			color.Cyan("\t  URL: " + color.YellowString("%s", tag.Url))
+			color.Cyan("\t  Display: " + color.YellowString("%s", tag.Display))
+			color.Cyan("\t  Name: " + color.YellowString("%s", tag.Name))
+			color.Cyan("\t  Slug: " + color.YellowString("%s", tag.Slug))
+			color.Cyan("\t  Color: " + color.YellowString("%s", tag.Color))
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
-		color.Cyan("\tOccupied: " + color.YellowString("%s\n", result.Occupied))
+		color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 	}
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimFrontPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimFrontPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimFrontPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimFrontPortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimFrontPortsCmd.PersistentFlags().String("foo", "", "A help for foo")
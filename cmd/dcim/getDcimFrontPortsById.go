@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -73,9 +74,10 @@ type frontPortsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimFrontPortsByIdCmd represents the getDcimFrontPortsById command
@@ -85,9 +87,11 @@ var GetDcimFrontPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an front port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(frontPortsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.front_ports_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.front_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Front Port Name: %s\n", color.YellowString(responseObject.Display))
@@ -119,7 +123,7 @@ ABC Netbox Automation Tools:
 			color.Cyan("\t  Value: " + color.YellowString("%s", responseObject.Type.Value))
 			color.Cyan("\t  Label: " + color.YellowString("%s", responseObject.Type.Label))
 			color.Cyan("\tColor: " + color.YellowString("%s", responseObject.Color))
-			color.Cyan("\tRear Port: " + color.YellowString("%s", responseObject.RearPort))
+			color.Cyan("\tRear Port: " + color.YellowString("%s", responseObject.RearPort.Name))
 			color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.RearPort.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.RearPort.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.RearPort.Display))
@@ -127,7 +131,7 @@ ABC Netbox Automation Tools:
 			color.Cyan("\t  Label: " + color.YellowString("%s", responseObject.RearPort.Label))
 			color.Cyan("\t  Description: " + color.YellowString("%s", responseObject.RearPort.Description))
 			color.Cyan("\tRear Port Position: " + color.YellowString("%d", responseObject.RearPortPosition))
-			color.Cyan("\tDescription: " + color.YellowString("%d", responseObject.Description))
+			color.Cyan("\tDescription: " + color.YellowString("%s", responseObject.Description))
 			color.Cyan("\tMarked Connected: " + color.YellowString("%v", responseObject.MarkConnected))
 			color.Cyan("\tCable: ")
 			color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Cable.Id))
@@ -136,41 +140,40 @@ ABC Netbox Automation Tools:
 			color.Cyan("\t  Label: " + color.YellowString("%s", responseObject.Cable.Label))
 			color.Cyan("\tCable End: " + color.YellowString("%s", responseObject.CableEnd))
 			for _, link := range responseObject.LinkPeers {
-				color.Cyan("\tLink Peer: " + color.YellowString("%d", link))
+				color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
 			}
 			color.Cyan("\tLink Peers Type: " + color.YellowString("%s", responseObject.LinkPeersType))
 			for _, tag := range responseObject.Tags {
 				color.Cyan("\tTags: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", tag.Id))
-				color.Cyan("\t  URL: " + color.YellowString("%d", tag.Url))
-				color.Cyan("\t  Display: " + color.YellowString("%d", tag.Display))
-				color.Cyan("\t  Name: " + color.YellowString("%d", tag.Name))
-				color.Cyan("\t  Slug: " + color.YellowString("%d", tag.Slug))
-				color.Cyan("\t  Color: " + color.YellowString("%d", tag.Color))
+				color.Cyan("\t  URL: " + color.YellowString("%s", tag.Url))
+				color.Cyan("\t  Display: " + color.YellowString("%s", tag.Display))
+				color.Cyan("\t  Name: " + color.YellowString("%s", tag.Name))
+				color.Cyan("\t  Slug: " + color.YellowString("%s", tag.Slug))
+				color.Cyan("\t  Color: " + color.YellowString("%s", tag.Color))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
-			color.Cyan("\tOccupied: " + color.YellowString("%s\n", responseObject.Occupied))
+			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! Front Port object not found with ID: " + color.YellowString("%s\n", id))
+			color.Red("  Doh! Front Port object not found with ID: " + color.YellowString("%d\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimFrontPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimFrontPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimFrontPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimFrontPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of front port object")
-	err = GetDcimFrontPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimFrontPortsByIdCmd.Flags().IntP("id", "", 0, "ID of front port object")
+	api.AddLookupFlags(GetDcimFrontPortsByIdCmd, "cmd.dcim.dcim_api_url.front_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
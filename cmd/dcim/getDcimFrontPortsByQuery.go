@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,9 +38,11 @@ var GetDcimFrontPortsByQueryCmd = &cobra.Command{
 	Long: `
 Netbox Automation Tools:
   GET a front port object(s) by string query`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(frontPorts)
-		ApiConnectionQuery(responseObject, "GET", "cmd.dcim.dcim_api_url.front_ports_id")
+		if err := ApiConnectionQuery(cmd, responseObject, "cmd.dcim.dcim_api_url.front_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("Total ABC Front Ports: "+color.YellowString("%v"), responseObject.Count)
@@ -73,7 +76,7 @@ Netbox Automation Tools:
 				color.Cyan("\t  Value: " + color.YellowString("%s", result.Type.Value))
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.Type.Label))
 				color.Cyan("\tColor: " + color.YellowString("%s", result.Color))
-				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort))
+				color.Cyan("\tRear Port: " + color.YellowString("%s", result.RearPort.Name))
 				color.Cyan("\t  ID: " + color.YellowString("%d", result.RearPort.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.RearPort.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.RearPort.Display))
@@ -81,7 +84,7 @@ Netbox Automation Tools:
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.RearPort.Label))
 				color.Cyan("\t  Description: " + color.YellowString("%s", result.RearPort.Description))
 				color.Cyan("\tRear Port Position: " + color.YellowString("%d", result.RearPortPosition))
-				color.Cyan("\tDescription: " + color.YellowString("%d", result.Description))
+				color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 				color.Cyan("\tMarked Connected: " + color.YellowString("%v", result.MarkConnected))
 				color.Cyan("\tCable: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", result.Cable.Id))
@@ -90,39 +93,41 @@ Netbox Automation Tools:
 				color.Cyan("\t  Label: " + color.YellowString("%s", result.Cable.Label))
 				color.Cyan("\tCable End: " + color.YellowString("%s", result.CableEnd))
 				for _, link := range result.LinkPeers {
-					color.Cyan("\tLink Peer: " + color.YellowString("%d", link))
+					color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
 				}
 				color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 				for _, tag := range result.Tags {
 					color.Cyan("\tTags: ")
 					color.Cyan("\t  ID: " + color.YellowString("%d", tag.Id))
-					color.Cyan("\t  URL: " + color.YellowString("%d", tag.Url))
-					color.Cyan("\t  Display: " + color.YellowString("%d", tag.Display))
-					color.Cyan("\t  Name: " + color.YellowString("%d", tag.Name))
-					color.Cyan("\t  Slug: " + color.YellowString("%d", tag.Slug))
-					color.Cyan("\t  Color: " + color.YellowString("%d", tag.Color))
+					color.Cyan("\t  URL: " + color.YellowString("%s", tag.Url))
+					color.Cyan("\t  Display: " + color.YellowString("%s", tag.Display))
+					color.Cyan("\t  Name: " + color.YellowString("%s", tag.Name))
+					color.Cyan("\t  Slug: " + color.YellowString("%s", tag.Slug))
+					color.Cyan("\t  Color: " + color.YellowString("%s", tag.Color))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
-				color.Cyan("\tOccupied: " + color.YellowString("%s\n", result.Occupied))
+				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 			}
 
 		} else {
 			color.Cyan("  Total ABC Front Ports: " + color.RedString("No front ports found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimFrontPortsByQueryCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimFrontPortsByQueryCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimFrontPortsByQueryCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimFrontPortsByQueryCmd", err)
 	}
 
-	GetDcimFrontPortsByQueryCmd.Flags().StringVarP(&query, "query", "q", "", "string query of object you want to get")
+	GetDcimFrontPortsByQueryCmd.Flags().StringP("query", "q", "", "string query of object you want to get")
 	err = GetDcimFrontPortsByQueryCmd.MarkFlagRequired("query")
 	if err != nil {
 		log.Fatalf("Error marking query flag as required: %s - for GetDcimFrontPortsByQueryCmd", err)
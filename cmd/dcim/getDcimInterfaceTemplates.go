@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -77,8 +78,9 @@ type interfaceTemplates struct {
 		RfRole struct {
 			ValueLabel
 		} `json:"rf_role"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -89,9 +91,11 @@ var GetDcimInterfaceTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of interface template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(interfaceTemplates)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.interface_templates")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.interface_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  Total ABC Interface Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -202,24 +206,28 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tRF Role: " + color.RedString("No RF role entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Interface Template Name: " + color.RedString("No interface template found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInterfaceTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInterfaceTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInterfaceTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimInterfaceTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimInterfaceTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -73,8 +74,9 @@ type interfaceTemplatesByID struct {
 	RfRole struct {
 		ValueLabel
 	} `json:"rf_role"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimInterfaceTemplatesByIdCmd represents the getDcimInterfaceTemplatesById command
@@ -84,9 +86,11 @@ var GetDcimInterfaceTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an interface template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(interfaceTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.interface_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.interface_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Interface Template Name: %s\n", color.YellowString(responseObject.Display))
@@ -195,28 +199,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tRF Role: " + color.RedString("No RF role entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No Interface template object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No Interface template object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInterfaceTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInterfaceTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInterfaceTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimInterfaceTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the interface template")
-	err = GetDcimInterfaceTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimInterfaceTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the interface template")
+	api.AddLookupFlags(GetDcimInterfaceTemplatesByIdCmd, "cmd.dcim.dcim_api_url.interface_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,12 +23,11 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
-	"os"
 	"strings"
 )
 
@@ -154,18 +153,19 @@ type interfaces struct {
 				CommonFieldsSlug
 				Color string `json:"color"`
 			} `json:"tags"`
-			Created                string `json:"created"`
-			LastUpdated            string `json:"last_updated"`
-			ConsolePortCount       uint   `json:"console_port_count"`
-			ConsoleServerPortCount uint   `json:"console_server_port_count"`
-			PowerPortCount         uint   `json:"power_port_count"`
-			PowerOutletCount       uint   `json:"power_outlet_count"`
-			InterfaceCount         uint   `json:"interface_count"`
-			FrontPortCount         uint   `json:"front_port_count"`
-			RearPortCount          uint   `json:"rear_port_count"`
-			DeviceBayCount         uint   `json:"device_bay_count"`
-			ModuleBayCount         uint   `json:"module_bay_count"`
-			InventoryItemCount     uint   `json:"inventory_item_count"`
+			CustomFields           map[string]interface{} `json:"custom_fields"`
+			Created                string                 `json:"created"`
+			LastUpdated            string                 `json:"last_updated"`
+			ConsolePortCount       uint                   `json:"console_port_count"`
+			ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+			PowerPortCount         uint                   `json:"power_port_count"`
+			PowerOutletCount       uint                   `json:"power_outlet_count"`
+			InterfaceCount         uint                   `json:"interface_count"`
+			FrontPortCount         uint                   `json:"front_port_count"`
+			RearPortCount          uint                   `json:"rear_port_count"`
+			DeviceBayCount         uint                   `json:"device_bay_count"`
+			ModuleBayCount         uint                   `json:"module_bay_count"`
+			InventoryItemCount     uint                   `json:"inventory_item_count"`
 		} `json:"device"`
 		Vdcs []struct {
 			CommonFieldsNoSlug
@@ -286,18 +286,19 @@ type interfaces struct {
 					CommonFieldsSlug
 					Color string `json:"color"`
 				} `json:"tags"`
-				Created                string `json:"created"`
-				LastUpdated            string `json:"last_updated"`
-				ConsolePortCount       uint   `json:"console_port_count"`
-				ConsoleServerPortCount uint   `json:"console_server_port_count"`
-				PowerPortCount         uint   `json:"power_port_count"`
-				PowerOutletCount       uint   `json:"power_outlet_count"`
-				InterfaceCount         uint   `json:"interface_count"`
-				FrontPortCount         uint   `json:"front_port_count"`
-				RearPortCount          uint   `json:"rear_port_count"`
-				DeviceBayCount         uint   `json:"device_bay_count"`
-				ModuleBayCount         uint   `json:"module_bay_count"`
-				InventoryItemCount     uint   `json:"inventory_item_count"`
+				CustomFields           map[string]interface{} `json:"custom_fields"`
+				Created                string                 `json:"created"`
+				LastUpdated            string                 `json:"last_updated"`
+				ConsolePortCount       uint                   `json:"console_port_count"`
+				ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+				PowerPortCount         uint                   `json:"power_port_count"`
+				PowerOutletCount       uint                   `json:"power_outlet_count"`
+				InterfaceCount         uint                   `json:"interface_count"`
+				FrontPortCount         uint                   `json:"front_port_count"`
+				RearPortCount          uint                   `json:"rear_port_count"`
+				DeviceBayCount         uint                   `json:"device_bay_count"`
+				ModuleBayCount         uint                   `json:"module_bay_count"`
+				InventoryItemCount     uint                   `json:"inventory_item_count"`
 			} `json:"device"`
 			Identifier int `json:"identifier"`
 			Tenant     struct {
@@ -334,9 +335,10 @@ type interfaces struct {
 				CommonFieldsSlug
 				Color string `json:"color"`
 			} `json:"tags"`
-			Created        string `json:"created"`
-			LastUpdated    string `json:"last_updated"`
-			InterfaceCount int    `json:"interface_count"`
+			CustomFields   map[string]interface{} `json:"custom_fields"`
+			Created        string                 `json:"created"`
+			LastUpdated    string                 `json:"last_updated"`
+			InterfaceCount int                    `json:"interface_count"`
 		} `json:"vdcs"`
 		Module struct {
 			idUrlDisplay
@@ -457,18 +459,19 @@ type interfaces struct {
 					CommonFieldsSlug
 					Color string `json:"color"`
 				} `json:"tags"`
-				Created                string `json:"created"`
-				LastUpdated            string `json:"last_updated"`
-				ConsolePortCount       uint   `json:"console_port_count"`
-				ConsoleServerPortCount uint   `json:"console_server_port_count"`
-				PowerPortCount         uint   `json:"power_port_count"`
-				PowerOutletCount       uint   `json:"power_outlet_count"`
-				InterfaceCount         uint   `json:"interface_count"`
-				FrontPortCount         uint   `json:"front_port_count"`
-				RearPortCount          uint   `json:"rear_port_count"`
-				DeviceBayCount         uint   `json:"device_bay_count"`
-				ModuleBayCount         uint   `json:"module_bay_count"`
-				InventoryItemCount     uint   `json:"inventory_item_count"`
+				CustomFields           map[string]interface{} `json:"custom_fields"`
+				Created                string                 `json:"created"`
+				LastUpdated            string                 `json:"last_updated"`
+				ConsolePortCount       uint                   `json:"console_port_count"`
+				ConsoleServerPortCount uint                   `json:"console_server_port_count"`
+				PowerPortCount         uint                   `json:"power_port_count"`
+				PowerOutletCount       uint                   `json:"power_outlet_count"`
+				InterfaceCount         uint                   `json:"interface_count"`
+				FrontPortCount         uint                   `json:"front_port_count"`
+				RearPortCount          uint                   `json:"rear_port_count"`
+				DeviceBayCount         uint                   `json:"device_bay_count"`
+				ModuleBayCount         uint                   `json:"module_bay_count"`
+				InventoryItemCount     uint                   `json:"inventory_item_count"`
 			} `json:"device"`
 			ModuleBay struct {
 				idUrlDisplay
@@ -605,8 +608,9 @@ type interfaces struct {
 				CommonFieldsSlug
 				Color string `json:"color"`
 			} `json:"tags"`
-			Created     string `json:"created"`
-			LastUpdated string `json:"last_updated,omitempty"`
+			CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+			Created      string                 `json:"created"`
+			LastUpdated  string                 `json:"last_updated,omitempty"`
 		} `json:"wireless_lans,omitempty"`
 		Vrf struct {
 			Id          uint   `json:"id,omitempty"`
@@ -637,11 +641,12 @@ type interfaces struct {
 			CommonFieldsSlug
 			Color string `json:"color,omitempty"`
 		} `json:"tags"`
-		Created          string `json:"created"`
-		LastUpdated      string `json:"last_updated"`
-		CountIpaddresses int    `json:"count_ipaddresses,omitempty"`
-		CountFhrpGroups  int    `json:"count_fhrp_groups,omitempty"`
-		Occupied         bool   `json:"_occupied,omitempty"`
+		CustomFields     map[string]interface{} `json:"custom_fields"`
+		Created          string                 `json:"created"`
+		LastUpdated      string                 `json:"last_updated"`
+		CountIpaddresses int                    `json:"count_ipaddresses,omitempty"`
+		CountFhrpGroups  int                    `json:"count_fhrp_groups,omitempty"`
+		Occupied         bool                   `json:"_occupied,omitempty"`
 	} `json:"results"`
 }
 
@@ -670,9 +675,11 @@ var GetDcimInterfacesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of interface objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 
-		ApiConnectionNonID(responseObjectInterfaces, "GET", "cmd.dcim.dcim_api_url.interfaces")
+		if err := ApiConnectionNonID(cmd, responseObjectInterfaces, "cmd.dcim.dcim_api_url.interfaces"); err != nil {
+			return err
+		}
 
 		if responseObjectInterfaces.Count > 0 {
 			color.Cyan("\n  Total ABC Interfaces: "+color.YellowString("%d"), responseObjectInterfaces.Count)
@@ -822,7 +829,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  Latitude: " + color.RedString("No latitude found for interface: %s", color.YellowString("%s", result.Display)))
 				}
 				if result.Device.Longitude > 0 {
-					color.Cyan("\t  Longitude: " + color.YellowString("%d", result.Device.Longitude))
+					color.Cyan("\t  Longitude: " + color.YellowString("%v", result.Device.Longitude))
 				} else {
 					color.Cyan("\t  Longitude: " + color.RedString("No longitude found for interface: %s", color.YellowString("%s", result.Display)))
 				}
@@ -1088,17 +1095,17 @@ ABC Netbox Automation Tools:
 						color.Cyan("\t    Device Count: " + color.YellowString("%d", vdc.Device.Rack.DeviceCount))
 						color.Cyan("\t  Position: " + color.YellowString("%d", vdc.Device.Position))
 						color.Cyan("\t  Face: ")
-						color.Cyan("\t    Value: " + color.YellowString("%d", vdc.Device.Face.Value))
+						color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Face.Value))
 						color.Cyan("\t    Label: " + color.YellowString("%s", vdc.Device.Face.Label))
-						color.Cyan("\t  Latitude: " + color.YellowString("%d", vdc.Device.Latitude))
-						color.Cyan("\t  Longitude: " + color.YellowString("%d", vdc.Device.Longitude))
+						color.Cyan("\t  Latitude: " + color.YellowString("%v", vdc.Device.Latitude))
+						color.Cyan("\t  Longitude: " + color.YellowString("%v", vdc.Device.Longitude))
 						color.Cyan("\t  Parent Device: ")
 						color.Cyan("\t    ID: " + color.YellowString("%d", vdc.Device.ParentDevice.Id))
 						color.Cyan("\t    URL: " + color.YellowString("%s", vdc.Device.ParentDevice.Url))
 						color.Cyan("\t    Display: " + color.YellowString("%s", vdc.Device.ParentDevice.Display))
 						color.Cyan("\t    Name: " + color.YellowString("%s", vdc.Device.ParentDevice.Name))
 						color.Cyan("\t  Status: ")
-						color.Cyan("\t    Value: " + color.YellowString("%d", vdc.Device.Status.Value))
+						color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Status.Value))
 						color.Cyan("\t    Label: " + color.YellowString("%s", vdc.Device.Status.Label))
 						color.Cyan("\t  AirFlow: ")
 						color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Airflow.Value))
@@ -1198,7 +1205,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Module.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Module.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Module.Device.Display))
-					color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType))
+					color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
 					color.Cyan("\t      ID: " + color.YellowString("%d", result.Module.Device.DeviceType.Id))
 					color.Cyan("\t      URL: " + color.YellowString("%s", result.Module.Device.DeviceType.Url))
 					color.Cyan("\t      Display: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
@@ -1468,13 +1475,13 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Parent.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Parent.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Parent.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Parent.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Parent.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Parent.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Parent.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Parent.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Parent.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Parent.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Parent.Occupied))
 				} else {
 					color.Cyan("\tParent: " + color.RedString("No parent found for interface: %s", color.YellowString("%s", result.Display)))
@@ -1484,13 +1491,13 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Bridge.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Bridge.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Bridge.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Bridge.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Bridge.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Bridge.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Bridge.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Bridge.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Bridge.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Bridge.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Bridge.Occupied))
 				} else {
 					color.Cyan("\tBridge: " + color.RedString("No bridge found for interface: %s", color.YellowString("%s", result.Display)))
@@ -1500,13 +1507,13 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Lag.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Lag.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Lag.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Lag.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Lag.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Lag.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Lag.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Lag.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Lag.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Lag.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Lag.Occupied))
 				} else {
 					color.Cyan("\tLink Aggregation Groups: " + color.RedString("No LAGs found for interface: %s", color.YellowString("%s", result.Display)))
@@ -1631,17 +1638,20 @@ ABC Netbox Automation Tools:
 				if result.LinkPeers == nil || len(result.LinkPeers) == 0 {
 					color.Cyan("\tLink Peers: " + color.RedString("No link peers found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tLink Peers: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tLink Peers: " + color.YellowString("%v", result.LinkPeers))
 				}
 				if result.LinkPeersType != "" {
-					color.Cyan("\tLink Peers Type: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 				} else {
 					color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type found for interface: %s", color.YellowString("%s", result.Display)))
 				}
 				if result.WirelessLans == nil || len(result.WirelessLans) == 0 {
 					color.Cyan("\tWireless LANs: " + color.RedString("No wireless lans found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tWireless LANs: " + color.YellowString("%d", result.WirelessLans))
+					color.Cyan("\tWireless LANs: ")
+					for _, wirelessLan := range result.WirelessLans {
+						color.Cyan("\t  SSID: " + color.YellowString("%s", wirelessLan.Ssid))
+					}
 				}
 				if result.Vrf.Id != 0 {
 					color.Cyan("\tVirtual Router Forwarding (vrf): ")
@@ -1672,10 +1682,10 @@ ABC Netbox Automation Tools:
 				if result.ConnectedEndpoints == nil || len(result.ConnectedEndpoints) == 0 {
 					color.Cyan("\tConnected Enpoints: " + color.RedString("No connected endpoints found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tConnected Enpoints: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tConnected Enpoints: " + color.YellowString("%v", result.LinkPeers))
 				}
 				if result.ConnectedEndpointsType != "" {
-					color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%d", result.ConnectedEndpointsType))
+					color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%s", result.ConnectedEndpointsType))
 				} else {
 					color.Cyan("\tConnected Endpoints Type: " + color.RedString("No connected endpoints type found for interface: %s", color.YellowString("%s", result.Display)))
 				}
@@ -1693,6 +1703,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for interface: %s", color.YellowString("%s", result.Display)))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.CountIpaddresses != 0 {
@@ -1708,7 +1719,13 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 			}
 			for responseObjectInterfaces.Next != nil {
-				nextPageInterfaces()
+				more, err := nextPageInterfaces(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectInterfaces.Next == nil {
 				display := color.HiGreenString("\tAll Netbox interface objects have been successfully displayed...")
@@ -1720,45 +1737,26 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Interfaces: " + color.RedString("No interfaces found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
-func ApiConnectionNextPageInterfaces[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := *responseObjectInterfaces.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
-}
-
-func nextPageInterfaces() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageInterfaces(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\n\tDo you want to continue to the next page of interface objects? ['Y' or 'yes'/'n' or 'no']: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageInterfaces(responseObjectInterfaces, "GET", *responseObjectInterfaces.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectInterfaces, *responseObjectInterfaces.Next); err != nil {
+			return false, err
+		}
 		displayInterfacesOutput()
 	case "n", "no":
 		color.HiMagenta("\n\tExiting the ABC-netbox-cli application...\n\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\n\tInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] ")
 	}
+	return true, nil
 }
 
 func displayInterfacesOutput() {
@@ -1908,7 +1906,7 @@ func displayInterfacesOutput() {
 			color.Cyan("\t  Latitude: " + color.RedString("No latitude found for interface: %s", color.YellowString("%s", result.Display)))
 		}
 		if result.Device.Longitude > 0 {
-			color.Cyan("\t  Longitude: " + color.YellowString("%d", result.Device.Longitude))
+			color.Cyan("\t  Longitude: " + color.YellowString("%v", result.Device.Longitude))
 		} else {
 			color.Cyan("\t  Longitude: " + color.RedString("No longitude found for interface: %s", color.YellowString("%s", result.Display)))
 		}
@@ -2174,17 +2172,17 @@ func displayInterfacesOutput() {
 				color.Cyan("\t    Device Count: " + color.YellowString("%d", vdc.Device.Rack.DeviceCount))
 				color.Cyan("\t  Position: " + color.YellowString("%d", vdc.Device.Position))
 				color.Cyan("\t  Face: ")
-				color.Cyan("\t    Value: " + color.YellowString("%d", vdc.Device.Face.Value))
+				color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Face.Value))
 				color.Cyan("\t    Label: " + color.YellowString("%s", vdc.Device.Face.Label))
-				color.Cyan("\t  Latitude: " + color.YellowString("%d", vdc.Device.Latitude))
-				color.Cyan("\t  Longitude: " + color.YellowString("%d", vdc.Device.Longitude))
+				color.Cyan("\t  Latitude: " + color.YellowString("%v", vdc.Device.Latitude))
+				color.Cyan("\t  Longitude: " + color.YellowString("%v", vdc.Device.Longitude))
 				color.Cyan("\t  Parent Device: ")
 				color.Cyan("\t    ID: " + color.YellowString("%d", vdc.Device.ParentDevice.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", vdc.Device.ParentDevice.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", vdc.Device.ParentDevice.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", vdc.Device.ParentDevice.Name))
 				color.Cyan("\t  Status: ")
-				color.Cyan("\t    Value: " + color.YellowString("%d", vdc.Device.Status.Value))
+				color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Status.Value))
 				color.Cyan("\t    Label: " + color.YellowString("%s", vdc.Device.Status.Label))
 				color.Cyan("\t  AirFlow: ")
 				color.Cyan("\t    Value: " + color.YellowString("%s", vdc.Device.Airflow.Value))
@@ -2284,7 +2282,7 @@ func displayInterfacesOutput() {
 			color.Cyan("\t    ID: " + color.YellowString("%d", result.Module.Device.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", result.Module.Device.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", result.Module.Device.Display))
-			color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType))
+			color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
 			color.Cyan("\t      ID: " + color.YellowString("%d", result.Module.Device.DeviceType.Id))
 			color.Cyan("\t      URL: " + color.YellowString("%s", result.Module.Device.DeviceType.Url))
 			color.Cyan("\t      Display: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
@@ -2554,13 +2552,13 @@ func displayInterfacesOutput() {
 			color.Cyan("\t  ID: " + color.YellowString("%d", result.Parent.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", result.Parent.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", result.Parent.Display))
-			color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device))
+			color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device.Name))
 			color.Cyan("\t    ID: " + color.YellowString("%d", result.Parent.Device.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", result.Parent.Device.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", result.Parent.Device.Display))
 			color.Cyan("\t    Name: " + color.YellowString("%s", result.Parent.Device.Name))
 			color.Cyan("\t  Name: " + color.YellowString("%s", result.Parent.Name))
-			color.Cyan("\t  Cable: " + color.YellowString("%s", result.Parent.Cable))
+			color.Cyan("\t  Cable: " + color.YellowString("%d", result.Parent.Cable))
 			color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Parent.Occupied))
 		} else {
 			color.Cyan("\tParent: " + color.RedString("No parent found for interface: %s", color.YellowString("%s", result.Display)))
@@ -2570,13 +2568,13 @@ func displayInterfacesOutput() {
 			color.Cyan("\t  ID: " + color.YellowString("%d", result.Bridge.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", result.Bridge.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", result.Bridge.Display))
-			color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device))
+			color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device.Name))
 			color.Cyan("\t    ID: " + color.YellowString("%d", result.Bridge.Device.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", result.Bridge.Device.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", result.Bridge.Device.Display))
 			color.Cyan("\t    Name: " + color.YellowString("%s", result.Bridge.Device.Name))
 			color.Cyan("\t  Name: " + color.YellowString("%s", result.Bridge.Name))
-			color.Cyan("\t  Cable: " + color.YellowString("%s", result.Bridge.Cable))
+			color.Cyan("\t  Cable: " + color.YellowString("%d", result.Bridge.Cable))
 			color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Bridge.Occupied))
 		} else {
 			color.Cyan("\tBridge: " + color.RedString("No bridge found for interface: %s", color.YellowString("%s", result.Display)))
@@ -2586,13 +2584,13 @@ func displayInterfacesOutput() {
 			color.Cyan("\t  ID: " + color.YellowString("%d", result.Lag.Id))
 			color.Cyan("\t  URL: " + color.YellowString("%s", result.Lag.Url))
 			color.Cyan("\t  Display: " + color.YellowString("%s", result.Lag.Display))
-			color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device))
+			color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device.Name))
 			color.Cyan("\t    ID: " + color.YellowString("%d", result.Lag.Device.Id))
 			color.Cyan("\t    URL: " + color.YellowString("%s", result.Lag.Device.Url))
 			color.Cyan("\t    Display: " + color.YellowString("%s", result.Lag.Device.Display))
 			color.Cyan("\t    Name: " + color.YellowString("%s", result.Lag.Device.Name))
 			color.Cyan("\t  Name: " + color.YellowString("%s", result.Lag.Name))
-			color.Cyan("\t  Cable: " + color.YellowString("%s", result.Lag.Cable))
+			color.Cyan("\t  Cable: " + color.YellowString("%d", result.Lag.Cable))
 			color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Lag.Occupied))
 		} else {
 			color.Cyan("\tLink Aggregation Groups: " + color.RedString("No LAGs found for interface: %s", color.YellowString("%s", result.Display)))
@@ -2717,17 +2715,20 @@ func displayInterfacesOutput() {
 		if result.LinkPeers == nil || len(result.LinkPeers) == 0 {
 			color.Cyan("\tLink Peers: " + color.RedString("No link peers found for interface: %s", color.YellowString("%s", result.Display)))
 		} else {
-			color.Cyan("\tLink Peers: " + color.YellowString("%d", result.LinkPeers))
+			color.Cyan("\tLink Peers: " + color.YellowString("%v", result.LinkPeers))
 		}
 		if result.LinkPeersType != "" {
-			color.Cyan("\tLink Peers Type: " + color.YellowString("%d", result.LinkPeers))
+			color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 		} else {
 			color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type found for interface: %s", color.YellowString("%s", result.Display)))
 		}
 		if result.WirelessLans == nil || len(result.WirelessLans) == 0 {
 			color.Cyan("\tWireless LANs: " + color.RedString("No wireless lans found for interface: %s", color.YellowString("%s", result.Display)))
 		} else {
-			color.Cyan("\tWireless LANs: " + color.YellowString("%d", result.WirelessLans))
+			color.Cyan("\tWireless LANs: ")
+			for _, wirelessLan := range result.WirelessLans {
+				color.Cyan("\t  SSID: " + color.YellowString("%s", wirelessLan.Ssid))
+			}
 		}
 		if result.Vrf.Id != 0 {
 			color.Cyan("\tVirtual Router Forwarding (vrf): ")
@@ -2758,10 +2759,10 @@ func displayInterfacesOutput() {
 		if result.ConnectedEndpoints == nil || len(result.ConnectedEndpoints) == 0 {
 			color.Cyan("\tConnected Enpoints: " + color.RedString("No connected endpoints found for interface: %s", color.YellowString("%s", result.Display)))
 		} else {
-			color.Cyan("\tConnected Enpoints: " + color.YellowString("%d", result.LinkPeers))
+			color.Cyan("\tConnected Enpoints: " + color.YellowString("%v", result.LinkPeers))
 		}
 		if result.ConnectedEndpointsType != "" {
-			color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%d", result.ConnectedEndpointsType))
+			color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%s", result.ConnectedEndpointsType))
 		} else {
 			color.Cyan("\tConnected Endpoints Type: " + color.RedString("No connected endpoints type found for interface: %s", color.YellowString("%s", result.Display)))
 		}
@@ -2779,6 +2780,7 @@ func displayInterfacesOutput() {
 				color.Cyan("\tTags: " + color.RedString("No tags found for interface: %s", color.YellowString("%s", result.Display)))
 			}
 		}
+		api.DisplayCustomFields(result.CustomFields)
 		color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 		color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 		if result.CountIpaddresses != 0 {
@@ -2798,12 +2800,14 @@ func displayInterfacesOutput() {
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInterfacesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInterfacesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInterfacesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimInterfacesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimInterfacesCmd.PersistentFlags().String("foo", "", "A help for foo")
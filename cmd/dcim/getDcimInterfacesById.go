@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -156,11 +157,12 @@ type interfacesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color,omitempty"`
 	} `json:"tags,omitempty"`
-	Created          string `json:"created,omitempty"`
-	LastUpdated      string `json:"last_updated,omitempty"`
-	CountIpaddresses int    `json:"count_ipaddresses,omitempty"`
-	CountFhrpGroups  int    `json:"count_fhrp_groups,omitempty"`
-	Occupied         bool   `json:"_occupied,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+	Created          string                 `json:"created,omitempty"`
+	LastUpdated      string                 `json:"last_updated,omitempty"`
+	CountIpaddresses int                    `json:"count_ipaddresses,omitempty"`
+	CountFhrpGroups  int                    `json:"count_fhrp_groups,omitempty"`
+	Occupied         bool                   `json:"_occupied,omitempty"`
 }
 
 // GetDcimInterfacesByIdCmd represents the getDcimInterfacesById command
@@ -170,9 +172,11 @@ var GetDcimInterfacesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an interface object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(interfacesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.interfaces_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.interfaces_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Interface Name: %s\n", color.YellowString(responseObject.Display)+color.CyanString(" Device: ")+color.YellowString(responseObject.Device.Name))
@@ -207,9 +211,9 @@ ABC Netbox Automation Tools:
 			if responseObject.Module.ModuleBay.Id != 0 {
 				color.Cyan("\t  Module Bay: ")
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Module.ModuleBay.Id))
-				color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.Module.ModuleBay.Url))
-				color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.Module.ModuleBay.Display))
-				color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.Module.ModuleBay.Name))
+				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Module.ModuleBay.Url))
+				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Module.ModuleBay.Display))
+				color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.Module.ModuleBay.Name))
 			} else {
 				color.Cyan("\t  Module Bay: " + color.RedString("No module bay found for interface: %s", color.YellowString("%s", responseObject.Display)))
 			}
@@ -236,13 +240,13 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Parent.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.Parent.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.Parent.Display))
-				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Parent.Device))
+				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Parent.Device.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Parent.Device.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Parent.Device.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Parent.Device.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.Parent.Device.Name))
 				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.Parent.Name))
-				color.Cyan("\t  Cable: " + color.YellowString("%s", responseObject.Parent.Cable))
+				color.Cyan("\t  Cable: " + color.YellowString("%d", responseObject.Parent.Cable))
 				color.Cyan("\t  Occupied: " + color.YellowString("%t", responseObject.Parent.Occupied))
 			} else {
 				color.Cyan("\tParent: " + color.RedString("No parent found for interface: %s", color.YellowString("%s", responseObject.Display)))
@@ -252,13 +256,13 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Bridge.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.Bridge.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.Bridge.Display))
-				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Bridge.Device))
+				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Bridge.Device.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Bridge.Device.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Bridge.Device.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Bridge.Device.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.Bridge.Device.Name))
 				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.Bridge.Name))
-				color.Cyan("\t  Cable: " + color.YellowString("%s", responseObject.Bridge.Cable))
+				color.Cyan("\t  Cable: " + color.YellowString("%d", responseObject.Bridge.Cable))
 				color.Cyan("\t  Occupied: " + color.YellowString("%t", responseObject.Bridge.Occupied))
 			} else {
 				color.Cyan("\tBridge: " + color.RedString("No bridge found for interface: %s", color.YellowString("%s", responseObject.Display)))
@@ -268,13 +272,13 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Lag.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.Lag.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.Lag.Display))
-				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Lag.Device))
+				color.Cyan("\t  Device: " + color.YellowString("%s", responseObject.Lag.Device.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Lag.Device.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Lag.Device.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Lag.Device.Display))
 				color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.Lag.Device.Name))
 				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.Lag.Name))
-				color.Cyan("\t  Cable: " + color.YellowString("%s", responseObject.Lag.Cable))
+				color.Cyan("\t  Cable: " + color.YellowString("%d", responseObject.Lag.Cable))
 				color.Cyan("\t  Occupied: " + color.YellowString("%t", responseObject.Lag.Occupied))
 			} else {
 				color.Cyan("\tLink Aggregation Groups: " + color.RedString("No LAGs found for interface: %s", color.YellowString("%s", responseObject.Display)))
@@ -399,10 +403,10 @@ ABC Netbox Automation Tools:
 			if responseObject.LinkPeers == nil || len(responseObject.LinkPeers) == 0 {
 				color.Cyan("\tLink Peers: " + color.RedString("No link peers found for interface: %s", color.YellowString("%s", responseObject.Display)))
 			} else {
-				color.Cyan("\tLink Peers: " + color.YellowString("%d", responseObject.LinkPeers))
+				color.Cyan("\tLink Peers: " + color.YellowString("%v", responseObject.LinkPeers))
 			}
 			if responseObject.LinkPeersType != "" {
-				color.Cyan("\tLink Peers Type: " + color.YellowString("%d", responseObject.LinkPeers))
+				color.Cyan("\tLink Peers Type: " + color.YellowString("%s", responseObject.LinkPeersType))
 			} else {
 				color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type found for interface: %s", color.YellowString("%s", responseObject.Display)))
 			}
@@ -440,10 +444,10 @@ ABC Netbox Automation Tools:
 			if responseObject.ConnectedEndpoints == nil || len(responseObject.ConnectedEndpoints) == 0 {
 				color.Cyan("\tConnected Enpoints: " + color.RedString("No connected endpoints found for interface: %s", color.YellowString("%s", responseObject.Display)))
 			} else {
-				color.Cyan("\tConnected Enpoints: " + color.YellowString("%d", responseObject.LinkPeers))
+				color.Cyan("\tConnected Enpoints: " + color.YellowString("%v", responseObject.LinkPeers))
 			}
 			if responseObject.ConnectedEndpointsType != "" {
-				color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%d", responseObject.ConnectedEndpointsType))
+				color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%s", responseObject.ConnectedEndpointsType))
 			} else {
 				color.Cyan("\tConnected Endpoints Type: " + color.RedString("No connected endpoints type found for interface: %s", color.YellowString("%s", responseObject.Display)))
 			}
@@ -461,6 +465,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags found for interface: %s", color.YellowString("%s", responseObject.Display)))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.CountIpaddresses != 0 {
@@ -475,25 +480,23 @@ ABC Netbox Automation Tools:
 			}
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! No Interface object found on server for ID: "+color.YellowString("%d/n"), id)
+			color.Red("  Doh! No Interface object found on server for ID: "+color.YellowString("%d/n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInterfacesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInterfacesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInterfacesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimInterfacesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the interface")
-	err = GetDcimInterfacesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err.Error())
-	}
+	GetDcimInterfacesByIdCmd.Flags().IntP("id", "", 0, "ID of the interface")
+	api.AddLookupFlags(GetDcimInterfacesByIdCmd, "cmd.dcim.dcim_api_url.interfaces_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,11 +38,13 @@ var GetDcimInterfacesByQueryCmd = &cobra.Command{
 	Long: `
 Netbox Automation Tools:
   GET a interface object(s) by string query`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionQuery(responseObjectInterfaces, "GET", "cmd.dcim.dcim_api_url.interfaces_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionQuery(cmd, responseObjectInterfaces, "cmd.dcim.dcim_api_url.interfaces_id"); err != nil {
+			return err
+		}
 
 		if responseObjectInterfaces.Count != 0 {
-			color.Cyan("\n  Total ABC Interfaces: "+color.YellowString("%d, for Query: %s"), responseObjectInterfaces.Count, query)
+			color.Cyan("\n  Total ABC Interfaces: "+color.YellowString("%d, for Query: %s"), responseObjectInterfaces.Count, cmd.Flag("query").Value)
 			for _, result := range responseObjectInterfaces.Results {
 				display := fmt.Sprintf("    ABC Interface Name: %s\n", color.YellowString(result.Display)+color.CyanString(" Device: ")+color.YellowString(result.Device.Name))
 				equals := strings.Repeat("=", len(display))
@@ -69,7 +72,7 @@ Netbox Automation Tools:
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Module.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Module.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Module.Device.Display))
-					color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType))
+					color.Cyan("\t    Device Type: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
 					color.Cyan("\t      ID: " + color.YellowString("%d", result.Module.Device.DeviceType.Id))
 					color.Cyan("\t      URL: " + color.YellowString("%s", result.Module.Device.DeviceType.Url))
 					color.Cyan("\t      Display: " + color.YellowString("%s", result.Module.Device.DeviceType.Display))
@@ -343,13 +346,13 @@ Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Parent.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Parent.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Parent.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Parent.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Parent.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Parent.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Parent.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Parent.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Parent.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Parent.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Parent.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Parent.Occupied))
 				} else {
 					color.Cyan("\tParent: " + color.RedString("No parent found for interface: %s", color.YellowString("%s", result.Display)))
@@ -359,13 +362,13 @@ Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Bridge.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Bridge.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Bridge.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Bridge.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Bridge.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Bridge.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Bridge.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Bridge.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Bridge.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Bridge.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Bridge.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Bridge.Occupied))
 				} else {
 					color.Cyan("\tBridge: " + color.RedString("No bridge found for interface: %s", color.YellowString("%s", result.Display)))
@@ -375,13 +378,13 @@ Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Lag.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.Lag.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.Lag.Display))
-					color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device))
+					color.Cyan("\t  Device: " + color.YellowString("%s", result.Lag.Device.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Lag.Device.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Lag.Device.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Lag.Device.Display))
 					color.Cyan("\t    Name: " + color.YellowString("%s", result.Lag.Device.Name))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.Lag.Name))
-					color.Cyan("\t  Cable: " + color.YellowString("%s", result.Lag.Cable))
+					color.Cyan("\t  Cable: " + color.YellowString("%d", result.Lag.Cable))
 					color.Cyan("\t  Occupied: " + color.YellowString("%t", result.Lag.Occupied))
 				} else {
 					color.Cyan("\tLink Aggregation Groups: " + color.RedString("No LAGs found for interface: %s", color.YellowString("%s", result.Display)))
@@ -506,17 +509,20 @@ Netbox Automation Tools:
 				if result.LinkPeers == nil || len(result.LinkPeers) == 0 {
 					color.Cyan("\tLink Peers: " + color.RedString("No link peers found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tLink Peers: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tLink Peers: " + color.YellowString("%v", result.LinkPeers))
 				}
 				if result.LinkPeersType != "" {
-					color.Cyan("\tLink Peers Type: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tLink Peers Type: " + color.YellowString("%s", result.LinkPeersType))
 				} else {
 					color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type found for interface: %s", color.YellowString("%s", result.Display)))
 				}
 				if result.WirelessLans == nil || len(result.WirelessLans) == 0 {
 					color.Cyan("\tWireless LANs: " + color.RedString("No wireless lans found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tWireless LANs: " + color.YellowString("%d", result.WirelessLans))
+					color.Cyan("\tWireless LANs: ")
+					for _, wirelessLan := range result.WirelessLans {
+						color.Cyan("\t  SSID: " + color.YellowString("%s", wirelessLan.Ssid))
+					}
 				}
 				if result.Vrf.Id != 0 {
 					color.Cyan("\tVirtual Router Forwarding (vrf): ")
@@ -547,10 +553,10 @@ Netbox Automation Tools:
 				if result.ConnectedEndpoints == nil || len(result.ConnectedEndpoints) == 0 {
 					color.Cyan("\tConnected Enpoints: " + color.RedString("No connected endpoints found for interface: %s", color.YellowString("%s", result.Display)))
 				} else {
-					color.Cyan("\tConnected Enpoints: " + color.YellowString("%d", result.LinkPeers))
+					color.Cyan("\tConnected Enpoints: " + color.YellowString("%v", result.LinkPeers))
 				}
 				if result.ConnectedEndpointsType != "" {
-					color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%d", result.ConnectedEndpointsType))
+					color.Cyan("\tConnected Endpoints Type: " + color.YellowString("%s", result.ConnectedEndpointsType))
 				} else {
 					color.Cyan("\tConnected Endpoints Type: " + color.RedString("No connected endpoints type found for interface: %s", color.YellowString("%s", result.Display)))
 				}
@@ -568,6 +574,7 @@ Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags found for interface: %s", color.YellowString("%s", result.Display)))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.CountIpaddresses != 0 {
@@ -583,10 +590,16 @@ Netbox Automation Tools:
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
 			}
 			for responseObjectInterfaces.Next != nil {
-				nextPageInterfaces()
+				more, err := nextPageInterfaces(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectInterfaces.Next == nil {
-				display := color.HiGreenString("\tAll Netbox interface objects have been successfully displayed for Query: " + color.YellowString("%s", query))
+				display := color.HiGreenString("\tAll Netbox interface objects have been successfully displayed for Query: " + color.YellowString("%s", cmd.Flag("query").Value))
 				equals := strings.Repeat("*", len(display))
 				color.HiGreen("\n  " + equals)
 				color.Cyan(display)
@@ -595,19 +608,20 @@ Netbox Automation Tools:
 		} else {
 			color.Cyan("  Total ABC Interfaces: " + color.RedString("No interfaces found on the server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInterfacesByQueryCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInterfacesByQueryCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInterfacesByQueryCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimInterfacesByQueryCmd", err)
 	}
 
-	GetDcimInterfacesByQueryCmd.Flags().StringVarP(&query, "query", "q", "", "string query of object you want to get")
+	GetDcimInterfacesByQueryCmd.Flags().StringP("query", "q", "", "string query of object you want to get")
 	err = GetDcimInterfacesByQueryCmd.MarkFlagRequired("query")
 	if err != nil {
 		log.Fatalf("Error marking query flag as required: %s - for GetDcimInterfacesByQueryCmd", err)
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -42,9 +43,10 @@ type inventoryItemRoles struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created            string `json:"created"`
-		LastUpdated        string `json:"last_updated"`
-		InventoryitemCount int    `json:"inventoryitem_count"`
+		CustomFields       map[string]interface{} `json:"custom_fields"`
+		Created            string                 `json:"created"`
+		LastUpdated        string                 `json:"last_updated"`
+		InventoryitemCount int                    `json:"inventoryitem_count"`
 	} `json:"results"`
 }
 
@@ -55,10 +57,12 @@ var GetDcimInventoryItemRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of inventory item role objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 
 		responseObject := new(inventoryItemRoles)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_item_roles")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_item_roles"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Inventory Item Roles: "+color.YellowString("%d"), responseObject.Count)
@@ -91,8 +95,10 @@ ABC Netbox Automation Tools:
 					} else {
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
+					api.DisplayCustomFields(result.CustomFields)
 					color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 					color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
+					api.DisplayCustomFields(result.CustomFields)
 					color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 					color.Cyan("\tInventory Item Count: " + color.YellowString("%d\n", result.InventoryitemCount))
 				}
@@ -100,18 +106,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Inventory Item Roles: " + color.RedString("No interface template found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking dcim inventory item roles flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimInventoryItemRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimInventoryItemRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -38,9 +39,10 @@ type inventoryItemRolesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created            string `json:"created"`
-	LastUpdated        string `json:"last_updated"`
-	InventoryitemCount int    `json:"inventoryitem_count"`
+	CustomFields       map[string]interface{} `json:"custom_fields"`
+	Created            string                 `json:"created"`
+	LastUpdated        string                 `json:"last_updated"`
+	InventoryitemCount int                    `json:"inventoryitem_count"`
 }
 
 // GetDcimInventoryItemRolesByIdCmd represents the getDcimInventoryItemRolesById command
@@ -50,9 +52,11 @@ var GetDcimInventoryItemRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an inventory item role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(inventoryItemRolesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_item_roles_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_item_roles_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Inventory Item Role Name: %s\n", color.YellowString(responseObject.Display))
@@ -83,31 +87,31 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
+				api.DisplayCustomFields(responseObject.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
+				api.DisplayCustomFields(responseObject.CustomFields)
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 				color.Cyan("\tInventory Item Count: " + color.YellowString("%d\n", responseObject.InventoryitemCount))
 			}
 		} else {
-			color.Red("  Doh! No Inventory Item Role object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No Inventory Item Role object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimInventoryItemRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of inventory item role object")
-	err = GetDcimInventoryItemRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimInventoryItemRolesByIdCmd.Flags().IntP("id", "", 0, "ID of inventory item role object")
+	api.AddLookupFlags(GetDcimInventoryItemRolesByIdCmd, "cmd.dcim.dcim_api_url.inventory_item_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -57,14 +58,15 @@ type inventoryItemTemplates struct {
 		Manufacturer struct {
 			CommonFieldsSlug
 		} `json:"manufacturer"`
-		PartId        string  `json:"part_id"`
-		Description   string  `json:"description"`
-		ComponentType string  `json:"component_type"`
-		ComponentId   float32 `json:"component_id"`
-		Component     string  `json:"component"`
-		Created       string  `json:"created"`
-		LastUpdated   string  `json:"last_updated"`
-		Depth         int     `json:"_depth"`
+		PartId        string                 `json:"part_id"`
+		Description   string                 `json:"description"`
+		ComponentType string                 `json:"component_type"`
+		ComponentId   float32                `json:"component_id"`
+		Component     string                 `json:"component"`
+		CustomFields  map[string]interface{} `json:"custom_fields"`
+		Created       string                 `json:"created"`
+		LastUpdated   string                 `json:"last_updated"`
+		Depth         int                    `json:"_depth"`
 	} `json:"results"`
 }
 
@@ -75,9 +77,11 @@ var GetDcimInventoryItemTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of inventory item template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(inventoryItemTemplates)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_item_templates")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_item_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Inventory Item Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -91,11 +95,11 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 				color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
 				if result.DeviceType.Id != 0 {
-					color.Cyan("\tDevice Type: " + color.YellowString("%s", result.DeviceType))
+					color.Cyan("\tDevice Type: " + color.YellowString("%s", result.DeviceType.Display))
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.DeviceType.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.DeviceType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.DeviceType.Display))
-					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.DeviceType.Manufacturer))
+					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.DeviceType.Manufacturer.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Manufacturer.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.Manufacturer.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.Manufacturer.Display))
@@ -117,7 +121,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tName: " + color.RedString("No name entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Label != "" {
-					color.Cyan("\tLabel: " + color.YellowString("%d", result.Label))
+					color.Cyan("\tLabel: " + color.YellowString("%s", result.Label))
 				} else {
 					color.Cyan("\tLabel: " + color.RedString("No label entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -166,6 +170,7 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tComponent: " + color.RedString("No component entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.Depth != 0 {
@@ -177,18 +182,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Inventory Item Templates: " + color.RedString("No inventory item templates found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimInventoryItemTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimInventoryItemTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
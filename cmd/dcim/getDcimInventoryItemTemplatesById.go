@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -53,14 +54,15 @@ type inventoryItemTemplateByID struct {
 	Manufacturer struct {
 		CommonFieldsSlug
 	} `json:"manufacturer"`
-	PartId        string  `json:"part_id"`
-	Description   string  `json:"description"`
-	ComponentType string  `json:"component_type"`
-	ComponentId   float32 `json:"component_id"`
-	Component     string  `json:"component"`
-	Created       string  `json:"created"`
-	LastUpdated   string  `json:"last_updated"`
-	Depth         int     `json:"_depth"`
+	PartId        string                 `json:"part_id"`
+	Description   string                 `json:"description"`
+	ComponentType string                 `json:"component_type"`
+	ComponentId   float32                `json:"component_id"`
+	Component     string                 `json:"component"`
+	CustomFields  map[string]interface{} `json:"custom_fields"`
+	Created       string                 `json:"created"`
+	LastUpdated   string                 `json:"last_updated"`
+	Depth         int                    `json:"_depth"`
 }
 
 // GetDcimInventoryItemTemplatesByIdCmd represents the getDcimInventoryItemTemplatesById command
@@ -70,9 +72,11 @@ var GetDcimInventoryItemTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an inventory item template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(inventoryItemTemplateByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_item_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_item_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Inventory Item Templates: %s\n", color.YellowString(responseObject.Display))
@@ -84,11 +88,11 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tURL: " + color.YellowString("%s", responseObject.Url))
 			color.Cyan("\tDisplay: " + color.YellowString("%s", responseObject.Display))
 			if responseObject.DeviceType.Id != 0 {
-				color.Cyan("\tDevice Type: " + color.YellowString("%s", responseObject.DeviceType))
+				color.Cyan("\tDevice Type: " + color.YellowString("%s", responseObject.DeviceType.Display))
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.DeviceType.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.DeviceType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.DeviceType.Display))
-				color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer))
+				color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Manufacturer.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Manufacturer.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Manufacturer.Display))
@@ -110,7 +114,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tName: " + color.RedString("No name entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Label != "" {
-				color.Cyan("\tLabel: " + color.YellowString("%d", responseObject.Label))
+				color.Cyan("\tLabel: " + color.YellowString("%s", responseObject.Label))
 			} else {
 				color.Cyan("\tLabel: " + color.RedString("No label entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -159,6 +163,7 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tComponent: " + color.RedString("No component entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.Depth != 0 {
@@ -168,25 +173,23 @@ ABC Netbox Automation Tools:
 			}
 
 		} else {
-			color.Red("  Doh! No Inventory Item template object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No Inventory Item template object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimInventoryItemTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the inventory item template object")
-	err = GetDcimInventoryItemTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimInventoryItemTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the inventory item template object")
+	api.AddLookupFlags(GetDcimInventoryItemTemplatesByIdCmd, "cmd.dcim.dcim_api_url.inventory_item_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
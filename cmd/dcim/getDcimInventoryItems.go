@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -62,9 +63,10 @@ type inventoryItems struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Depth       int    `json:"_depth"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Depth        int                    `json:"_depth"`
 	} `json:"results"`
 }
 
@@ -75,9 +77,11 @@ var GetDcimInventoryItemsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of inventory item objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(inventoryItems)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_items")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_items"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Inventory Items: "+color.YellowString("%d"), responseObject.Count)
@@ -174,7 +178,7 @@ ABC Netbox Automation Tools:
 				}
 
 				if result.ComponentId != 0 {
-					color.Cyan("\tComponent ID: " + color.YellowString("%d", result.ComponentId))
+					color.Cyan("\tComponent ID: " + color.YellowString("%v", result.ComponentId))
 				} else {
 					color.Cyan("\tComponent ID: " + color.RedString("No component type entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -199,6 +203,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tDepth: " + color.YellowString("%d\n", result.Depth))
@@ -206,18 +211,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Inventory Items: " + color.RedString("No inventory items found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimInventoryItemsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimInventoryItemsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -58,9 +59,10 @@ type inventoryItemsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Depth       int    `json:"_depth"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Depth        int                    `json:"_depth"`
 }
 
 // GetDcimInventoryItemsByIdCmd represents the getDcimInventoryItemsById command
@@ -70,9 +72,11 @@ var GetDcimInventoryItemsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an inventory item object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(inventoryItemsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.inventory_items_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.inventory_items_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Inventory Items: %s\n", color.YellowString(responseObject.Display))
@@ -167,7 +171,7 @@ ABC Netbox Automation Tools:
 			}
 
 			if responseObject.ComponentId != 0 {
-				color.Cyan("\tComponent ID: " + color.YellowString("%d", responseObject.ComponentId))
+				color.Cyan("\tComponent ID: " + color.YellowString("%v", responseObject.ComponentId))
 			} else {
 				color.Cyan("\tComponent ID: " + color.RedString("No component type entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -192,29 +196,28 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tDepth: " + color.YellowString("%d\n", responseObject.Depth))
 		} else {
-			color.Red("  Doh! No Inventory Item object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No Inventory Item object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimInventoryItemsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimInventoryItemsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimInventoryItemsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimInventoryItemsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Inventory Item object by ID")
-	err = GetDcimInventoryItemsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimInventoryItemsByIdCmd.Flags().IntP("id", "", 0, "Inventory Item object by ID")
+	api.AddLookupFlags(GetDcimInventoryItemsByIdCmd, "cmd.dcim.dcim_api_url.inventory_items_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
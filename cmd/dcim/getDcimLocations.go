@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -54,11 +55,12 @@ type locations struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		RackCount   int    `json:"rack_count"`
-		DeviceCount int    `json:"device_count"`
-		Depth       int    `json:"_depth"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		RackCount    int                    `json:"rack_count"`
+		DeviceCount  int                    `json:"device_count"`
+		Depth        int                    `json:"_depth"`
 	} `json:"results"`
 }
 
@@ -69,9 +71,11 @@ var GetDcimLocationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of location objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(locations)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.locations")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.locations"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Locations: "+color.YellowString("%d"), responseObject.Count)
@@ -131,6 +135,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.RackCount != 0 {
@@ -152,18 +157,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Locations: " + color.RedString("No locations found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimLocationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimLocationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimLocationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimLocationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimLocationsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -50,11 +51,12 @@ type locationsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	RackCount   int    `json:"rack_count"`
-	DeviceCount int    `json:"device_count"`
-	Depth       int    `json:"_depth"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	RackCount    int                    `json:"rack_count"`
+	DeviceCount  int                    `json:"device_count"`
+	Depth        int                    `json:"_depth"`
 }
 
 // GetDcimLocationsByIdCmd represents the getDcimLocationsById command
@@ -64,9 +66,11 @@ var GetDcimLocationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an location object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(locationsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.locations_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.locations_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Location: %s\n", color.YellowString(responseObject.Display))
@@ -124,6 +128,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.RackCount != 0 {
@@ -142,25 +147,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tDepth: " + color.RedString("No depth entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No location object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No location object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimLocationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimLocationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimLocationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s", err)
 	}
 
-	GetDcimLocationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of location object")
-	err = GetDcimLocationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s", err)
-	}
+	GetDcimLocationsByIdCmd.Flags().IntP("id", "", 0, "ID of location object")
+	api.AddLookupFlags(GetDcimLocationsByIdCmd, "cmd.dcim.dcim_api_url.locations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -41,11 +42,12 @@ type manufacturers struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created            string `json:"created"`
-		LastUpdated        string `json:"last_updated"`
-		DevicetypeCount    int    `json:"devicetype_count"`
-		InventoryitemCount int    `json:"inventoryitem_count"`
-		PlatformCount      int    `json:"platform_count"`
+		CustomFields       map[string]interface{} `json:"custom_fields"`
+		Created            string                 `json:"created"`
+		LastUpdated        string                 `json:"last_updated"`
+		DevicetypeCount    int                    `json:"devicetype_count"`
+		InventoryitemCount int                    `json:"inventoryitem_count"`
+		PlatformCount      int                    `json:"platform_count"`
 	} `json:"results"`
 }
 
@@ -56,9 +58,11 @@ var GetDcimManufacturersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of manufacturer objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(manufacturers)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.manufacturers")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.manufacturers"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Manufacturers: "+color.YellowString("%d"), responseObject.Count)
@@ -91,6 +95,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.DevicetypeCount != 0 {
@@ -112,18 +117,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Manufacturers: " + color.RedString("No manufacturers found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimManufacturersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimManufacturersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimManufacturersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimManufacturersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimManufacturersCmd.PersistentFlags().String("foo", "", "A help for foo")
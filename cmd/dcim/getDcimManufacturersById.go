@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,11 +38,12 @@ type manufacturersByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created            string `json:"created"`
-	LastUpdated        string `json:"last_updated"`
-	DevicetypeCount    int    `json:"devicetype_count"`
-	InventoryitemCount int    `json:"inventoryitem_count"`
-	PlatformCount      int    `json:"platform_count"`
+	CustomFields       map[string]interface{} `json:"custom_fields"`
+	Created            string                 `json:"created"`
+	LastUpdated        string                 `json:"last_updated"`
+	DevicetypeCount    int                    `json:"devicetype_count"`
+	InventoryitemCount int                    `json:"inventoryitem_count"`
+	PlatformCount      int                    `json:"platform_count"`
 }
 
 // GetDcimManufacturersByIdCmd represents the getDcimManufacturersById command
@@ -51,9 +53,11 @@ var GetDcimManufacturersByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an manufacturer object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(manufacturersByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.manufacturers_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.manufacturers_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Manufacturers: %s\n", color.YellowString(responseObject.Display))
@@ -84,6 +88,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.DevicetypeCount != 0 {
@@ -102,25 +107,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tPlatform Count: " + color.RedString("No platform count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No manufacturers object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No manufacturers object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimManufacturersByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimManufacturersByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimManufacturersByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	GetDcimManufacturersByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of manufacturers object")
-	err = GetDcimManufacturersByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimManufacturersByIdCmd.Flags().IntP("id", "", 0, "ID of manufacturers object")
+	api.AddLookupFlags(GetDcimManufacturersByIdCmd, "cmd.dcim.dcim_api_url.manufacturers_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -48,12 +49,13 @@ type moduleBayTemplates struct {
 			Model string `json:"model"`
 			Slug  string `json:"slug"`
 		} `json:"device_type"`
-		Name        string `json:"name"`
-		Label       string `json:"label"`
-		Position    string `json:"position"`
-		Description string `json:"description"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		Name         string                 `json:"name"`
+		Label        string                 `json:"label"`
+		Position     string                 `json:"position"`
+		Description  string                 `json:"description"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -64,9 +66,11 @@ var GetDcimModuleBayTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of module bay template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(moduleBayTemplates)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.module_bay_templates")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.module_bay_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Module Bay Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -80,7 +84,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 				color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
 				if result.DeviceType.Id != 0 {
-					color.Cyan("\tDevice Type: " + color.YellowString("%s", result.DeviceType))
+					color.Cyan("\tDevice Type: " + color.YellowString("%s", result.DeviceType.Display))
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.DeviceType.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.DeviceType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.DeviceType.Display))
@@ -101,38 +105,42 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tName: " + color.RedString("No name entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Label != "" {
-					color.Cyan("\tLabel: " + color.YellowString("%d", result.Label))
+					color.Cyan("\tLabel: " + color.YellowString("%s", result.Label))
 				} else {
 					color.Cyan("\tLabel: " + color.RedString("No label entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Position != "" {
-					color.Cyan("\tPosition: " + color.YellowString("%d", result.Position))
+					color.Cyan("\tPosition: " + color.YellowString("%s", result.Position))
 				} else {
 					color.Cyan("\tPosition: " + color.RedString("No position entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Description != "" {
-					color.Cyan("\tDescription: " + color.YellowString("%d", result.Description))
+					color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 				} else {
 					color.Cyan("\tDescription: " + color.RedString("No position entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Module Bay Templates: " + color.RedString("No module bay templates found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModuleBayTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModuleBayTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModuleBayTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimModuleBayTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimModuleBayTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
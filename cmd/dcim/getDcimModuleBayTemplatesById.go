@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -44,12 +45,13 @@ type moduleBayTemplatesByID struct {
 		Model string `json:"model"`
 		Slug  string `json:"slug"`
 	} `json:"device_type"`
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	Position    string `json:"position"`
-	Description string `json:"description"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	Name         string                 `json:"name"`
+	Label        string                 `json:"label"`
+	Position     string                 `json:"position"`
+	Description  string                 `json:"description"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimModuleBayTemplatesByIdCmd represents the getDcimModuleBayTemplatesById command
@@ -59,9 +61,11 @@ var GetDcimModuleBayTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an module bay template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(moduleBayTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.module_bay_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.module_bay_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Module Bay Templates: %s\n", color.YellowString(responseObject.Display))
@@ -73,7 +77,7 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tURL: " + color.YellowString("%s", responseObject.Url))
 			color.Cyan("\tDisplay: " + color.YellowString("%s", responseObject.Display))
 			if responseObject.DeviceType.Id != 0 {
-				color.Cyan("\tDevice Type: " + color.YellowString("%s", responseObject.DeviceType))
+				color.Cyan("\tDevice Type: " + color.YellowString("%s", responseObject.DeviceType.Display))
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.DeviceType.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.DeviceType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.DeviceType.Display))
@@ -94,43 +98,42 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tName: " + color.RedString("No name entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Label != "" {
-				color.Cyan("\tLabel: " + color.YellowString("%d", responseObject.Label))
+				color.Cyan("\tLabel: " + color.YellowString("%s", responseObject.Label))
 			} else {
 				color.Cyan("\tLabel: " + color.RedString("No label entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Position != "" {
-				color.Cyan("\tPosition: " + color.YellowString("%d", responseObject.Position))
+				color.Cyan("\tPosition: " + color.YellowString("%s", responseObject.Position))
 			} else {
 				color.Cyan("\tPosition: " + color.RedString("No position entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Description != "" {
-				color.Cyan("\tDescription: " + color.YellowString("%d", responseObject.Description))
+				color.Cyan("\tDescription: " + color.YellowString("%s", responseObject.Description))
 			} else {
 				color.Cyan("\tDescription: " + color.RedString("No position entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 
 		} else {
-			color.Red("  Doh! No module bay template object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No module bay template object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModuleBayTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModuleBayTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModuleBayTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required. %s - for GetDcimModuleBayTemplatesByIdCmd", err)
 	}
 
-	GetDcimModuleBayTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the module bay template object")
-	err = GetDcimModuleBayTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required. %s - for GetDcimModuleBayTemplatesByIdCmd", err)
-	}
+	GetDcimModuleBayTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the module bay template object")
+	api.AddLookupFlags(GetDcimModuleBayTemplatesByIdCmd, "cmd.dcim.dcim_api_url.module_bay_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
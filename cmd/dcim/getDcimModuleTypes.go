@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -53,8 +54,9 @@ type moduleTypes struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -65,9 +67,11 @@ var GetDcimModuleTypesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of module type objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(moduleTypes)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.module_types")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.module_types"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Module Types: "+color.YellowString("%d"), responseObject.Count)
@@ -80,7 +84,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tID: " + color.YellowString("%d", result.Id))
 				color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 				color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
-				color.Cyan("\tManufacturer: " + color.YellowString("%s", result.Manufacturer))
+				color.Cyan("\tManufacturer: " + color.YellowString("%s", result.Manufacturer.Name))
 				color.Cyan("\t  ID: " + color.YellowString("%d", result.Manufacturer.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", result.Manufacturer.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", result.Manufacturer.Display))
@@ -90,8 +94,8 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tPart Number: " + color.YellowString("%s", result.PartNumber))
 				color.Cyan("\tWeight: " + color.YellowString("%d", result.Weight))
 				color.Cyan("\tWeight Unit: ")
-				color.Cyan("\t  Value: " + color.YellowString("%d", result.WeightUnit.Value))
-				color.Cyan("\t  Label: " + color.YellowString("%d", result.WeightUnit.Label))
+				color.Cyan("\t  Value: " + color.YellowString("%s", result.WeightUnit.Value))
+				color.Cyan("\t  Label: " + color.YellowString("%s", result.WeightUnit.Label))
 				color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 				color.Cyan("\tComments: " + color.YellowString("%s", result.Comments))
 				for _, tag := range result.Tags {
@@ -107,24 +111,28 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Module Types: " + color.RedString("No modules found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModuleTypesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModuleTypesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModuleTypesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimModuleTypesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimModuleTypesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimModuleTypesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -50,8 +51,9 @@ type moduleTypesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimModuleTypesByIdCmd represents the getDcimModuleTypesById command
@@ -61,9 +63,11 @@ var GetDcimModuleTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an module type object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(moduleTypesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.module_types_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.module_types_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Module Type: %s\n", color.YellowString(responseObject.Display))
@@ -129,28 +133,27 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No module type object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No module type object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModuleTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModuleTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModuleTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking id flag as required flag: %s - for GetDcimModuleTypesByIdCmd", err)
 	}
 
-	GetDcimModuleTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the module type object")
-	err = GetDcimModuleTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required flag: %s - for GetDcimModuleTypesByIdCmd", err)
-	}
+	GetDcimModuleTypesByIdCmd.Flags().IntP("id", "", 0, "ID of the module type object")
+	api.AddLookupFlags(GetDcimModuleTypesByIdCmd, "cmd.dcim.dcim_api_url.module_types_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -87,8 +88,9 @@ type modules struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -99,9 +101,11 @@ var GetDcimModulesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of module objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(modules)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.modules")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.modules"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Modules: "+color.YellowString("%d"), responseObject.Count)
@@ -131,8 +135,8 @@ ABC Netbox Automation Tools:
 					if result.ModuleBay.Module.Id != 0 {
 						color.Cyan("\t  Module: ")
 						color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleBay.Module.Id))
-						color.Cyan("\t    URL: " + color.YellowString("%d", result.ModuleBay.Module.Url))
-						color.Cyan("\t    Display: " + color.YellowString("%d", result.ModuleBay.Module.Display))
+						color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleBay.Module.Url))
+						color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleBay.Module.Display))
 					} else {
 						color.Cyan("\t  Module: " + color.RedString("No module entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -166,7 +170,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.ModuleType.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.ModuleType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.ModuleType.Display))
-					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer))
+					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleType.Manufacturer.Id))
 					color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleType.Manufacturer.Url))
 					color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleType.Manufacturer.Display))
@@ -196,24 +200,28 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Modules: " + color.RedString("No modules found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModulesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModulesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModulesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimModulesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimModulesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimModulesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -83,8 +84,9 @@ type modulesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimModulesByIdCmd represents the getDcimModulesById command
@@ -94,9 +96,11 @@ var GetDcimModulesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an module object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(modulesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.modules_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.modules_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Module: %s\n", color.YellowString(responseObject.Display))
@@ -124,8 +128,8 @@ ABC Netbox Automation Tools:
 				if responseObject.ModuleBay.Module.Id != 0 {
 					color.Cyan("\t  Module: ")
 					color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleBay.Module.Id))
-					color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.ModuleBay.Module.Url))
-					color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.ModuleBay.Module.Display))
+					color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleBay.Module.Url))
+					color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleBay.Module.Display))
 				} else {
 					color.Cyan("\t  Module: " + color.RedString("No module entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -159,7 +163,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.ModuleType.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ModuleType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ModuleType.Display))
-				color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer))
+				color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Id))
 				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Url))
 				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Display))
@@ -189,28 +193,27 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No module object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No module object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimModulesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimModulesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimModulesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking id flag as required flag: %s - for GetDcimModulesByIdCmd", err)
 	}
 
-	GetDcimModulesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the module object")
-	err = GetDcimModulesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required flag: %s - for GetDcimModulesByIdCmd", err)
-	}
+	GetDcimModulesByIdCmd.Flags().IntP("id", "", 0, "ID of the module object")
+	api.AddLookupFlags(GetDcimModulesByIdCmd, "cmd.dcim.dcim_api_url.modules_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -47,10 +48,11 @@ type platforms struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created             string `json:"created"`
-		LastUpdated         string `json:"last_updated"`
-		DeviceCount         int    `json:"device_count"`
-		VirtualmachineCount int    `json:"virtualmachine_count"`
+		CustomFields        map[string]interface{} `json:"custom_fields"`
+		Created             string                 `json:"created"`
+		LastUpdated         string                 `json:"last_updated"`
+		DeviceCount         int                    `json:"device_count"`
+		VirtualmachineCount int                    `json:"virtualmachine_count"`
 	} `json:"results"`
 }
 
@@ -61,9 +63,11 @@ var GetDcimPlatformsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of platform objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(platforms)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.platforms")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.platforms"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Platforms: "+color.YellowString("%d"), responseObject.Count)
@@ -90,7 +94,7 @@ ABC Netbox Automation Tools:
 				}
 				if result.ConfigTemplate.Id != 0 {
 					color.Cyan("\tConfig Template: ")
-					color.Cyan("\t  ID: " + color.YellowString("%s", result.ConfigTemplate.Id))
+					color.Cyan("\t  ID: " + color.YellowString("%d", result.ConfigTemplate.Id))
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.ConfigTemplate.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.ConfigTemplate.Display))
 					color.Cyan("\t  Name: " + color.YellowString("%s", result.ConfigTemplate.Name))
@@ -115,6 +119,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.DeviceCount != 0 {
@@ -123,7 +128,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tDevice Count: " + color.RedString("No device count entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.VirtualmachineCount != 0 {
-					color.Cyan("\tVirtualmachine Count: " + color.YellowString("%s\n", result.VirtualmachineCount))
+					color.Cyan("\tVirtualmachine Count: " + color.YellowString("%d\n", result.VirtualmachineCount))
 				} else {
 					color.Cyan("\tVirtualmachine Count: " + color.RedString("No virtualmachine count entry found for ") + color.YellowString("%s\n", result.Display))
 				}
@@ -131,18 +136,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Platforms: " + color.RedString("No platforms found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPlatformsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPlatformsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPlatformsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPlatformsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPlatformsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPlatformsCmd.PersistentFlags().String("foo", "", "A help for foo")
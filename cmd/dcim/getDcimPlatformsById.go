@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -43,10 +44,11 @@ type platformsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created             string `json:"created"`
-	LastUpdated         string `json:"last_updated"`
-	DeviceCount         int    `json:"device_count"`
-	VirtualmachineCount int    `json:"virtualmachine_count"`
+	CustomFields        map[string]interface{} `json:"custom_fields"`
+	Created             string                 `json:"created"`
+	LastUpdated         string                 `json:"last_updated"`
+	DeviceCount         int                    `json:"device_count"`
+	VirtualmachineCount int                    `json:"virtualmachine_count"`
 }
 
 // GetDcimPlatformsByIdCmd represents the getDcimPlatformsById command
@@ -56,9 +58,11 @@ var GetDcimPlatformsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an platform object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(platformsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.platforms_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.platforms_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Platform: %s\n", color.YellowString(responseObject.Display))
@@ -83,7 +87,7 @@ ABC Netbox Automation Tools:
 			}
 			if responseObject.ConfigTemplate.Id != 0 {
 				color.Cyan("\tConfig Template: ")
-				color.Cyan("\t  ID: " + color.YellowString("%s", responseObject.ConfigTemplate.Id))
+				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.ConfigTemplate.Id))
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ConfigTemplate.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ConfigTemplate.Display))
 				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.ConfigTemplate.Name))
@@ -108,6 +112,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.DeviceCount != 0 {
@@ -116,30 +121,28 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tDevice Count: " + color.RedString("No device count entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.VirtualmachineCount != 0 {
-				color.Cyan("\tVirtualmachine Count: " + color.YellowString("%s\n", responseObject.VirtualmachineCount))
+				color.Cyan("\tVirtualmachine Count: " + color.YellowString("%d\n", responseObject.VirtualmachineCount))
 			} else {
 				color.Cyan("\tVirtualmachine Count: " + color.RedString("No virtualmachine count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No platform object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No platform object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPlatformsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPlatformsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPlatformsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPlatformsByIdCmd", err)
 	}
 
-	GetDcimPlatformsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the platform object")
-	err = GetDcimPlatformsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetDcimPlatformsByIdCmd", err)
-	}
+	GetDcimPlatformsByIdCmd.Flags().IntP("id", "", 0, "ID of the platform object")
+	api.AddLookupFlags(GetDcimPlatformsByIdCmd, "cmd.dcim.dcim_api_url.platforms_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
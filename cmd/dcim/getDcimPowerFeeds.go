@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -67,13 +68,13 @@ type powerFeeds struct {
 			Display string `json:"display"`
 			Label   string `json:"label"`
 		} `json:"cable"`
-		CableEnd                    string   `json:"cable_end"`
-		LinkPeers                   []string `json:"link_peers"`
-		LinkPeersType               string   `json:"link_peers_type"`
-		ConnectedEndpoints          []string `json:"connected_endpoints"`
-		ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-		ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
-		Description                 string   `json:"description"`
+		CableEnd                    string               `json:"cable_end"`
+		LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+		LinkPeersType               string               `json:"link_peers_type"`
+		ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+		ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+		ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
+		Description                 string               `json:"description"`
 		Tenant                      struct {
 			CommonFieldsSlug
 		} `json:"tenant"`
@@ -82,9 +83,10 @@ type powerFeeds struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -95,9 +97,11 @@ var GetDcimPowerFeedsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of powerfeed objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerFeeds)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_feeds")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_feeds"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Power Feeds: "+color.YellowString("%d"), responseObject.Count)
@@ -192,8 +196,8 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tCable End: " + color.RedString("No cable end entry found for ") + color.YellowString("%s", result.Display))
 				}
 				for _, link := range result.LinkPeers {
-					if link != "" {
-						color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+					if link.Display != "" {
+						color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 					} else {
 						color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -204,8 +208,8 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type entry found for ") + color.YellowString("%s", result.Display))
 				}
 				for _, endpoint := range result.ConnectedEndpoints {
-					if endpoint != "" {
-						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+					if endpoint.Display != "" {
+						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 					} else {
 						color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -219,7 +223,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tConnected Endpoint Type: " + color.YellowString("%t", result.ConnectedEndpointsReachable))
 
 				if result.Description != "" {
-					color.Cyan("\tDescription: " + color.YellowString("%t", result.Description))
+					color.Cyan("\tDescription: " + color.YellowString("%s", result.Description))
 				} else {
 					color.Cyan("\tDescription: " + color.RedString("No description entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -234,7 +238,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTenant: " + color.RedString("No tenant entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Comments != "" {
-					color.Cyan("\tComments: " + color.YellowString("%t", result.Comments))
+					color.Cyan("\tComments: " + color.YellowString("%s", result.Comments))
 				} else {
 					color.Cyan("\tComments: " + color.RedString("No comments entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -251,6 +255,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
@@ -258,18 +263,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Power Feeds: " + color.RedString("No power feeds found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerFeedsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerFeedsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerFeedsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerFeedsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPowerFeedsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerFeedsCmd.PersistentFlags().String("foo", "", "A help for foo")
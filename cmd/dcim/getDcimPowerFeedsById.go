@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -63,13 +64,13 @@ type powerFeedsByID struct {
 		Display string `json:"display"`
 		Label   string `json:"label"`
 	} `json:"cable"`
-	CableEnd                    string   `json:"cable_end"`
-	LinkPeers                   []string `json:"link_peers"`
-	LinkPeersType               string   `json:"link_peers_type"`
-	ConnectedEndpoints          []string `json:"connected_endpoints"`
-	ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-	ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
-	Description                 string   `json:"description"`
+	CableEnd                    string               `json:"cable_end"`
+	LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+	LinkPeersType               string               `json:"link_peers_type"`
+	ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+	ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+	ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
+	Description                 string               `json:"description"`
 	Tenant                      struct {
 		CommonFieldsSlug
 	} `json:"tenant"`
@@ -78,9 +79,10 @@ type powerFeedsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimPowerFeedsByIdCmd represents the getDcimPowerFeedsById command
@@ -90,9 +92,11 @@ var GetDcimPowerFeedsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power feed object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerFeedsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_feeds_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_feeds_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Power Feed: %s\n", color.YellowString(responseObject.Display))
@@ -185,8 +189,8 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tCable End: " + color.RedString("No cable end entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			for _, link := range responseObject.LinkPeers {
-				if link != "" {
-					color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+				if link.Display != "" {
+					color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 				} else {
 					color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -197,8 +201,8 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			for _, endpoint := range responseObject.ConnectedEndpoints {
-				if endpoint != "" {
-					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+				if endpoint.Display != "" {
+					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 				} else {
 					color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -212,7 +216,7 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tConnected Endpoint Type: " + color.YellowString("%t", responseObject.ConnectedEndpointsReachable))
 
 			if responseObject.Description != "" {
-				color.Cyan("\tDescription: " + color.YellowString("%t", responseObject.Description))
+				color.Cyan("\tDescription: " + color.YellowString("%s", responseObject.Description))
 			} else {
 				color.Cyan("\tDescription: " + color.RedString("No description entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -227,7 +231,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tTenant: " + color.RedString("No tenant entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Comments != "" {
-				color.Cyan("\tComments: " + color.YellowString("%t", responseObject.Comments))
+				color.Cyan("\tComments: " + color.YellowString("%s", responseObject.Comments))
 			} else {
 				color.Cyan("\tComments: " + color.RedString("No comments entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -244,29 +248,28 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! No powerfeed object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No powerfeed object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerFeedsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerFeedsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerFeedsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerFeedsByIdCmd", err)
 	}
 
-	GetDcimPowerFeedsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the powerfeed object")
-	err = GetDcimPowerFeedsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerFeedsByIdCmd", err)
-	}
+	GetDcimPowerFeedsByIdCmd.Flags().IntP("id", "", 0, "ID of the powerfeed object")
+	api.AddLookupFlags(GetDcimPowerFeedsByIdCmd, "cmd.dcim.dcim_api_url.power_feeds_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -68,9 +69,10 @@ type powerOutletTemplates struct {
 		FeedLeg struct {
 			ValueLabel
 		} `json:"feed_leg"`
-		Description string `json:"description"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		Description  string                 `json:"description"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -81,9 +83,11 @@ var GetDcimPowerOutletTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of power outlet template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerOutletTemplates)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_outlet_templates")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_outlet_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Power Outlet Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -102,12 +106,12 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.DeviceType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.DeviceType.Display))
 					if result.DeviceType.Manufacturer.Id != 0 {
-						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.DeviceType.Manufacturer))
+						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.DeviceType.Manufacturer.Name))
 						color.Cyan("\t    ID: " + color.YellowString("%d", result.DeviceType.Manufacturer.Id))
-						color.Cyan("\t    URL: " + color.YellowString("%d", result.DeviceType.Manufacturer.Url))
-						color.Cyan("\t    Display: " + color.YellowString("%d", result.DeviceType.Manufacturer.Display))
-						color.Cyan("\t    Name: " + color.YellowString("%d", result.DeviceType.Manufacturer.Name))
-						color.Cyan("\t    Slug: " + color.YellowString("%d", result.DeviceType.Manufacturer.Slug))
+						color.Cyan("\t    URL: " + color.YellowString("%s", result.DeviceType.Manufacturer.Url))
+						color.Cyan("\t    Display: " + color.YellowString("%s", result.DeviceType.Manufacturer.Display))
+						color.Cyan("\t    Name: " + color.YellowString("%s", result.DeviceType.Manufacturer.Name))
+						color.Cyan("\t    Slug: " + color.YellowString("%s", result.DeviceType.Manufacturer.Slug))
 					} else {
 						color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -127,12 +131,12 @@ ABC Netbox Automation Tools:
 						color.Cyan("\t  URL: " + color.YellowString("%s", result.ModuleType.Url))
 						color.Cyan("\t  Display: " + color.YellowString("%s", result.ModuleType.Display))
 						if result.ModuleType.Manufacturer.Id > 0 {
-							color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer))
+							color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
 							color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleType.Manufacturer.Id))
-							color.Cyan("\t    URL: " + color.YellowString("%d", result.ModuleType.Manufacturer.Url))
-							color.Cyan("\t    Display: " + color.YellowString("%d", result.ModuleType.Manufacturer.Display))
-							color.Cyan("\t    Name: " + color.YellowString("%d", result.ModuleType.Manufacturer.Name))
-							color.Cyan("\t    Slug: " + color.YellowString("%d", result.ModuleType.Manufacturer.Slug))
+							color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleType.Manufacturer.Url))
+							color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleType.Manufacturer.Display))
+							color.Cyan("\t    Name: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
+							color.Cyan("\t    Slug: " + color.YellowString("%s", result.ModuleType.Manufacturer.Slug))
 						} else {
 							color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", result.Display))
 						}
@@ -163,9 +167,9 @@ ABC Netbox Automation Tools:
 				if result.PowerPort.Id > 0 {
 					color.Cyan("\tPower Port: ")
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.PowerPort.Id))
-					color.Cyan("\t  URL: " + color.YellowString("%d", result.PowerPort.Url))
-					color.Cyan("\t  Display: " + color.YellowString("%d", result.PowerPort.Display))
-					color.Cyan("\t  Name: " + color.YellowString("%d", result.PowerPort.Name))
+					color.Cyan("\t  URL: " + color.YellowString("%s", result.PowerPort.Url))
+					color.Cyan("\t  Display: " + color.YellowString("%s", result.PowerPort.Display))
+					color.Cyan("\t  Name: " + color.YellowString("%s", result.PowerPort.Name))
 				} else {
 					color.Cyan("\tPower Port" + color.RedString("No power port entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -181,24 +185,28 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tDescription" + color.RedString("No description entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Power Outlet Templates: " + color.RedString("No power outlet templates found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerOutletTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerOutletTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerOutletTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerOutletTemplatesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPowerOutletTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerOutletTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -64,9 +65,10 @@ type powerOutletTemplatesById struct {
 	FeedLeg struct {
 		ValueLabel
 	} `json:"feed_leg"`
-	Description string `json:"description"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	Description  string                 `json:"description"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimPowerOutletTemplatesByIdCmd represents the getDcimPowerOutletTemplatesById command
@@ -76,9 +78,11 @@ var GetDcimPowerOutletTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power outlet object template by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerOutletTemplatesById)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_outlet_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_outlet_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id != 0 {
 			display := fmt.Sprintf("    ABC Power Outlet Template: %s\n", color.YellowString(responseObject.Display))
@@ -95,12 +99,12 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.DeviceType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.DeviceType.Display))
 				if responseObject.DeviceType.Manufacturer.Id != 0 {
-					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer))
+					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Id))
-					color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Url))
-					color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Display))
-					color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Name))
-					color.Cyan("\t    Slug: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Slug))
+					color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Url))
+					color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Display))
+					color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
+					color.Cyan("\t    Slug: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Slug))
 				} else {
 					color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -120,12 +124,12 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ModuleType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ModuleType.Display))
 					if responseObject.ModuleType.Manufacturer.Id > 0 {
-						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer))
+						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
 						color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Id))
-						color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Url))
-						color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Display))
-						color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Name))
-						color.Cyan("\t    Slug: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Slug))
+						color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Url))
+						color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Display))
+						color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
+						color.Cyan("\t    Slug: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Slug))
 					} else {
 						color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", responseObject.Display))
 					}
@@ -156,9 +160,9 @@ ABC Netbox Automation Tools:
 			if responseObject.PowerPort.Id != 0 {
 				color.Cyan("\tPower Port: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.PowerPort.Id))
-				color.Cyan("\t  URL: " + color.YellowString("%d", responseObject.PowerPort.Url))
-				color.Cyan("\t  Display: " + color.YellowString("%d", responseObject.PowerPort.Display))
-				color.Cyan("\t  Name: " + color.YellowString("%d", responseObject.PowerPort.Name))
+				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.PowerPort.Url))
+				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.PowerPort.Display))
+				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.PowerPort.Name))
 			} else {
 				color.Cyan("\tPower Port" + color.RedString("No power port entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -174,28 +178,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tDescription" + color.RedString("No description entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No power outlet template object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No power outlet template object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerOutletTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerOutletTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerOutletTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerOutletTemplatesByIdCmd", err)
 	}
 
-	GetDcimPowerOutletTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power outlet template")
-	err = GetDcimPowerOutletTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimPowerOutletTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the power outlet template")
+	api.AddLookupFlags(GetDcimPowerOutletTemplatesByIdCmd, "cmd.dcim.dcim_api_url.power_outlet_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -77,19 +78,20 @@ type powerOutlets struct {
 			Display string `json:"display"`
 			Label   string `json:"label"`
 		} `json:"cable"`
-		CableEnd                    string   `json:"cable_end"`
-		LinkPeers                   []string `json:"link_peers"`
-		LinkPeersType               string   `json:"link_peers_type"`
-		ConnectedEndpoints          []string `json:"connected_endpoints"`
-		ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-		ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
+		CableEnd                    string               `json:"cable_end"`
+		LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+		LinkPeersType               string               `json:"link_peers_type"`
+		ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+		ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+		ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
 		Tags                        []struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -100,9 +102,11 @@ var GetDcimPowerOutletsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of power outlet objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerOutlets)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_outlets")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_outlets"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Power Outlets: "+color.YellowString("%d"), responseObject.Count)
@@ -134,11 +138,11 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tModule: " + color.RedString("No module entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Module.ModuleBay.Id != 0 {
-					color.Cyan("\t  Module Bay: " + color.YellowString("%d", result.Module.ModuleBay))
+					color.Cyan("\t  Module Bay: " + color.YellowString("%s", result.Module.ModuleBay.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", result.Module.ModuleBay.Id))
-					color.Cyan("\t    URL: " + color.YellowString("%d", result.Module.ModuleBay.Url))
-					color.Cyan("\t    Display: " + color.YellowString("%d", result.Module.ModuleBay.Display))
-					color.Cyan("\t    Name: " + color.YellowString("%d", result.Module.ModuleBay.Name))
+					color.Cyan("\t    URL: " + color.YellowString("%s", result.Module.ModuleBay.Url))
+					color.Cyan("\t    Display: " + color.YellowString("%s", result.Module.ModuleBay.Display))
+					color.Cyan("\t    Name: " + color.YellowString("%s", result.Module.ModuleBay.Name))
 				} else {
 					color.Cyan("\t  Module Bay" + color.RedString("No module bay entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -202,8 +206,8 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tCable End: " + color.RedString("No cable end entry found for ") + color.YellowString("%s", result.Display))
 				}
 				for _, link := range result.LinkPeers {
-					if link != "" {
-						color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+					if link.Display != "" {
+						color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 					} else {
 						color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -214,8 +218,8 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type entry found for ") + color.YellowString("%s", result.Display))
 				}
 				for _, endpoint := range result.ConnectedEndpoints {
-					if endpoint != "" {
-						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+					if endpoint.Display != "" {
+						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 					} else {
 						color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -240,6 +244,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
@@ -247,18 +252,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Power Outlets: " + color.RedString("No power outlets found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerOutletsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerOutletsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerOutletsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerOutletsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPowerOutletsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerOutletsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -73,19 +74,20 @@ type powerOutletsByID struct {
 		Display string `json:"display"`
 		Label   string `json:"label"`
 	} `json:"cable"`
-	CableEnd                    string   `json:"cable_end"`
-	LinkPeers                   []string `json:"link_peers"`
-	LinkPeersType               string   `json:"link_peers_type"`
-	ConnectedEndpoints          []string `json:"connected_endpoints"`
-	ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-	ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
+	CableEnd                    string               `json:"cable_end"`
+	LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+	LinkPeersType               string               `json:"link_peers_type"`
+	ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+	ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+	ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
 	Tags                        []struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimPowerOutletsByIdCmd represents the getDcimPowerOutletsById command
@@ -95,9 +97,11 @@ var GetDcimPowerOutletsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power outlet object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerOutletsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_outlets_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_outlets_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Power Outlet: %s\n", color.YellowString(responseObject.Display))
@@ -127,11 +131,11 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tModule: " + color.RedString("No module entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Module.ModuleBay.Id != 0 {
-				color.Cyan("\t  Module Bay: " + color.YellowString("%d", responseObject.Module.ModuleBay))
+				color.Cyan("\t  Module Bay: " + color.YellowString("%s", responseObject.Module.ModuleBay.Name))
 				color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.Module.ModuleBay.Id))
-				color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.Module.ModuleBay.Url))
-				color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.Module.ModuleBay.Display))
-				color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.Module.ModuleBay.Name))
+				color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.Module.ModuleBay.Url))
+				color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.Module.ModuleBay.Display))
+				color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.Module.ModuleBay.Name))
 			} else {
 				color.Cyan("\t  Module Bay" + color.RedString("No module bay entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -195,8 +199,8 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tCable End: " + color.RedString("No cable end entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			for _, link := range responseObject.LinkPeers {
-				if link != "" {
-					color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+				if link.Display != "" {
+					color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 				} else {
 					color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -207,8 +211,8 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tLink Peers Type: " + color.RedString("No link peers type entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			for _, endpoint := range responseObject.ConnectedEndpoints {
-				if endpoint != "" {
-					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+				if endpoint.Display != "" {
+					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 				} else {
 					color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -233,29 +237,28 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! No power outlet object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No power outlet object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerOutletsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerOutletsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerOutletsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimPowerOutletsByIdCmd", err)
 	}
 
-	GetDcimPowerOutletsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power outlet object")
-	err = GetDcimPowerOutletsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for GetDcimPowerOutletsByIdCmd", err)
-	}
+	GetDcimPowerOutletsByIdCmd.Flags().IntP("id", "", 0, "ID of the power outlet object")
+	api.AddLookupFlags(GetDcimPowerOutletsByIdCmd, "cmd.dcim.dcim_api_url.power_outlets_id")
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerOutletsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
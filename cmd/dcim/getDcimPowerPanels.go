@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -52,9 +53,10 @@ type powerPanels struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		PowerfeedCount uint   `json:"powerfeed_count"`
-		Created        string `json:"created"`
-		LastUpdated    string `json:"last_updated"`
+		PowerfeedCount uint                   `json:"powerfeed_count"`
+		CustomFields   map[string]interface{} `json:"custom_fields"`
+		Created        string                 `json:"created"`
+		LastUpdated    string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -65,9 +67,11 @@ var GetDcimPowerPanelsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of power panel objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerPanels)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_panels")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_panels"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Power Panels: "+color.YellowString("%d"), responseObject.Count)
@@ -83,20 +87,20 @@ ABC Netbox Automation Tools:
 				if result.Site.Id != 0 {
 					color.Cyan("\tSite: ")
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Site.Id))
-					color.Cyan("\t  URL: " + color.YellowString("%d", result.Site.Url))
-					color.Cyan("\t  Display: " + color.YellowString("%d", result.Site.Display))
-					color.Cyan("\t  Name: " + color.YellowString("%d", result.Site.Name))
-					color.Cyan("\t  Slug: " + color.YellowString("%d", result.Site.Slug))
+					color.Cyan("\t  URL: " + color.YellowString("%s", result.Site.Url))
+					color.Cyan("\t  Display: " + color.YellowString("%s", result.Site.Display))
+					color.Cyan("\t  Name: " + color.YellowString("%s", result.Site.Name))
+					color.Cyan("\t  Slug: " + color.YellowString("%s", result.Site.Slug))
 				} else {
 					color.Cyan("\tSite" + color.RedString("No site entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Location.Id != 0 {
 					color.Cyan("\tLocation: ")
 					color.Cyan("\t  ID: " + color.YellowString("%d", result.Location.Id))
-					color.Cyan("\t  URL: " + color.YellowString("%d", result.Location.Url))
-					color.Cyan("\t  Display: " + color.YellowString("%d", result.Location.Display))
-					color.Cyan("\t  Name: " + color.YellowString("%d", result.Location.Name))
-					color.Cyan("\t  Slug: " + color.YellowString("%d", result.Location.Slug))
+					color.Cyan("\t  URL: " + color.YellowString("%s", result.Location.Url))
+					color.Cyan("\t  Display: " + color.YellowString("%s", result.Location.Display))
+					color.Cyan("\t  Name: " + color.YellowString("%s", result.Location.Name))
+					color.Cyan("\t  Slug: " + color.YellowString("%s", result.Location.Slug))
 					color.Cyan("\t  Depth: " + color.YellowString("%d", result.Location.Depth))
 				} else {
 					color.Cyan("\tLocation" + color.RedString("No location entry found for ") + color.YellowString("%s", result.Display))
@@ -121,24 +125,28 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tPowerfeed Count" + color.RedString("No powerfeed count entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Power Panels: " + color.RedString("No power panels found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerPanelsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerPanelsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerPanelsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerPanelsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPowerPanelsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerPanelsCmd.PersistentFlags().String("foo", "", "A help for foo")
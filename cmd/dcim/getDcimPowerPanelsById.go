@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -48,9 +49,10 @@ type powerPanelsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	PowerfeedCount uint   `json:"powerfeed_count"`
-	Created        string `json:"created"`
-	LastUpdated    string `json:"last_updated"`
+	PowerfeedCount uint                   `json:"powerfeed_count"`
+	CustomFields   map[string]interface{} `json:"custom_fields"`
+	Created        string                 `json:"created"`
+	LastUpdated    string                 `json:"last_updated"`
 }
 
 // GetDcimPowerPanelsByIdCmd represents the getDcimPowerPanelsById command
@@ -60,9 +62,11 @@ var GetDcimPowerPanelsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power outlet panel by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerPanelsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_panels_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_panels_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Power Panel: %s\n", color.YellowString(responseObject.Display))
@@ -76,20 +80,20 @@ ABC Netbox Automation Tools:
 			if responseObject.Site.Id != 0 {
 				color.Cyan("\tSite: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Site.Id))
-				color.Cyan("\t  URL: " + color.YellowString("%d", responseObject.Site.Url))
-				color.Cyan("\t  Display: " + color.YellowString("%d", responseObject.Site.Display))
-				color.Cyan("\t  Name: " + color.YellowString("%d", responseObject.Site.Name))
-				color.Cyan("\t  Slug: " + color.YellowString("%d", responseObject.Site.Slug))
+				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.Site.Url))
+				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.Site.Display))
+				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.Site.Name))
+				color.Cyan("\t  Slug: " + color.YellowString("%s", responseObject.Site.Slug))
 			} else {
 				color.Cyan("\tSite" + color.RedString("No site entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Location.Id != 0 {
 				color.Cyan("\tLocation: ")
 				color.Cyan("\t  ID: " + color.YellowString("%d", responseObject.Location.Id))
-				color.Cyan("\t  URL: " + color.YellowString("%d", responseObject.Location.Url))
-				color.Cyan("\t  Display: " + color.YellowString("%d", responseObject.Location.Display))
-				color.Cyan("\t  Name: " + color.YellowString("%d", responseObject.Location.Name))
-				color.Cyan("\t  Slug: " + color.YellowString("%d", responseObject.Location.Slug))
+				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.Location.Url))
+				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.Location.Display))
+				color.Cyan("\t  Name: " + color.YellowString("%s", responseObject.Location.Name))
+				color.Cyan("\t  Slug: " + color.YellowString("%s", responseObject.Location.Slug))
 				color.Cyan("\t  Depth: " + color.YellowString("%d", responseObject.Location.Depth))
 			} else {
 				color.Cyan("\tLocation" + color.RedString("No location entry found for ") + color.YellowString("%s", responseObject.Display))
@@ -114,28 +118,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tPowerfeed Count" + color.RedString("No powerfeed count entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No power panel object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No power panel object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerPanelsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerPanelsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerPanelsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking dcim power panel flag as required, %s - for GetDcimPowerPanelsByIdCmd", err)
 	}
 
-	GetDcimPowerPanelsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power panel object")
-	err = GetDcimPowerPanelsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required, %s - for GetDcimPowerPanelsByIdCmd", err)
-	}
+	GetDcimPowerPanelsByIdCmd.Flags().IntP("id", "", 0, "ID of the power panel object")
+	api.AddLookupFlags(GetDcimPowerPanelsByIdCmd, "cmd.dcim.dcim_api_url.power_panels_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -58,11 +59,12 @@ type powerPortTemplatesByID struct {
 	Type  struct {
 		ValueLabel
 	} `json:"type"`
-	MaximumDraw   uint   `json:"maximum_draw"`
-	AllocatedDraw uint   `json:"allocated_draw"`
-	Description   string `json:"description"`
-	Created       string `json:"created"`
-	LastUpdated   string `json:"last_updated"`
+	MaximumDraw   uint                   `json:"maximum_draw"`
+	AllocatedDraw uint                   `json:"allocated_draw"`
+	Description   string                 `json:"description"`
+	CustomFields  map[string]interface{} `json:"custom_fields"`
+	Created       string                 `json:"created"`
+	LastUpdated   string                 `json:"last_updated"`
 }
 
 // GetDcimPowerPortTemplatesByIdCmd represents the getDcimPowerPortTemplatesById command
@@ -72,9 +74,11 @@ var GetDcimPowerPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerPortTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_port_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_port_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Power Port Template: %s\n", color.YellowString(responseObject.Display))
@@ -91,12 +95,12 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.DeviceType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.DeviceType.Display))
 				if responseObject.DeviceType.Manufacturer.Id != 0 {
-					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer))
+					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Id))
-					color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Url))
-					color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Display))
-					color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Name))
-					color.Cyan("\t    Slug: " + color.YellowString("%d", responseObject.DeviceType.Manufacturer.Slug))
+					color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Url))
+					color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Display))
+					color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Name))
+					color.Cyan("\t    Slug: " + color.YellowString("%s", responseObject.DeviceType.Manufacturer.Slug))
 				} else {
 					color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -116,12 +120,12 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ModuleType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ModuleType.Display))
 					if responseObject.ModuleType.Manufacturer.Id > 0 {
-						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer))
+						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
 						color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Id))
-						color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Url))
-						color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Display))
-						color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Name))
-						color.Cyan("\t    Slug: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Slug))
+						color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Url))
+						color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Display))
+						color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
+						color.Cyan("\t    Slug: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Slug))
 					} else {
 						color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", responseObject.Display))
 					}
@@ -166,28 +170,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tDescription" + color.RedString("No description entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Red("  Doh! No power port template object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No power port template object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerPortTemplatesByIdCmd", err)
 	}
 
-	GetDcimPowerPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the power port template object")
-	err = GetDcimPowerPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimPowerPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the power port template object")
+	api.AddLookupFlags(GetDcimPowerPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.power_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -65,19 +66,20 @@ type powerPorts struct {
 			Display string `json:"display"`
 			Label   string `json:"label"`
 		} `json:"cable"`
-		CableEnd                    string   `json:"cable_end"`
-		LinkPeers                   []string `json:"link_peers"`
-		LinkPeersType               string   `json:"link_peers_type"`
-		ConnectedEndpoints          []string `json:"connected_endpoints"`
-		ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-		ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
+		CableEnd                    string               `json:"cable_end"`
+		LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+		LinkPeersType               string               `json:"link_peers_type"`
+		ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+		ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+		ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
 		Tags                        []struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -88,9 +90,11 @@ var GetDcimPowerPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of power port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerPorts)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_ports")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_ports"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Power Ports: "+color.YellowString("%d"), responseObject.Count)
@@ -181,8 +185,8 @@ ABC Netbox Automation Tools:
 				}
 
 				for _, link := range result.LinkPeers {
-					if link != "" {
-						color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+					if link.Display != "" {
+						color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 					} else {
 						color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -195,8 +199,8 @@ ABC Netbox Automation Tools:
 				}
 
 				for _, endpoint := range result.ConnectedEndpoints {
-					if endpoint != "" {
-						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+					if endpoint.Display != "" {
+						color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 					} else {
 						color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -224,6 +228,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
@@ -231,18 +236,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Power Ports: " + color.RedString("No power ports found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerPortsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimPowerPortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimPowerPortsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -61,19 +62,20 @@ type powerPortsByID struct {
 		Display string `json:"display"`
 		Label   string `json:"label"`
 	} `json:"cable"`
-	CableEnd                    string   `json:"cable_end"`
-	LinkPeers                   []string `json:"link_peers"`
-	LinkPeersType               string   `json:"link_peers_type"`
-	ConnectedEndpoints          []string `json:"connected_endpoints"`
-	ConnectedEndpointsType      string   `json:"connected_endpoints_type"`
-	ConnectedEndpointsReachable bool     `json:"connected_endpoints_reachable"`
+	CableEnd                    string               `json:"cable_end"`
+	LinkPeers                   []CommonFieldsNoSlug `json:"link_peers"`
+	LinkPeersType               string               `json:"link_peers_type"`
+	ConnectedEndpoints          []CommonFieldsNoSlug `json:"connected_endpoints"`
+	ConnectedEndpointsType      string               `json:"connected_endpoints_type"`
+	ConnectedEndpointsReachable bool                 `json:"connected_endpoints_reachable"`
 	Tags                        []struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimPowerPortsByIdCmd represents the getDcimPowerPortsById command
@@ -83,9 +85,11 @@ var GetDcimPowerPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an power outlet port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(powerPortsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.power_ports_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.power_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Power Port: %s\n", color.YellowString(responseObject.Display))
@@ -174,8 +178,8 @@ ABC Netbox Automation Tools:
 			}
 
 			for _, link := range responseObject.LinkPeers {
-				if link != "" {
-					color.Cyan("\tLink Peer: " + color.YellowString("%s", link))
+				if link.Display != "" {
+					color.Cyan("\tLink Peer: " + color.YellowString("%s", link.Display))
 				} else {
 					color.Cyan("\tLink Peer: " + color.RedString("No link peer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -188,8 +192,8 @@ ABC Netbox Automation Tools:
 			}
 
 			for _, endpoint := range responseObject.ConnectedEndpoints {
-				if endpoint != "" {
-					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint))
+				if endpoint.Display != "" {
+					color.Cyan("\tConnected Endpoint: " + color.YellowString("%s", endpoint.Display))
 				} else {
 					color.Cyan("\tConnected Endpoint: " + color.RedString("No connected endpoint entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -217,29 +221,28 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! No power port object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No power port object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimPowerPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimPowerPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimPowerPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimPowerPortsByIdCmd", err)
 	}
 
-	GetDcimPowerPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "Id of the power port object")
-	err = GetDcimPowerPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimPowerPortsByIdCmd.Flags().IntP("id", "", 0, "Id of the power port object")
+	api.AddLookupFlags(GetDcimPowerPortsByIdCmd, "cmd.dcim.dcim_api_url.power_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -42,10 +43,11 @@ type rackReservations struct {
 		Rack    struct {
 			CommonFieldsNoSlug
 		} `json:"rack"`
-		Units       []int     `json:"units"`
-		Created     time.Time `json:"created"`
-		LastUpdated time.Time `json:"last_updated"`
-		User        struct {
+		Units        []int                  `json:"units"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      time.Time              `json:"created"`
+		LastUpdated  time.Time              `json:"last_updated"`
+		User         struct {
 			Id       uint   `json:"id"`
 			Url      string `json:"url"`
 			Display  string `json:"display"`
@@ -70,9 +72,11 @@ var GetDcimRackReservationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of rack reservation objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rackReservations)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.rack_reservations")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.rack_reservations"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Rack Reservations: "+color.YellowString("%d"), responseObject.Count)
@@ -93,6 +97,7 @@ ABC Netbox Automation Tools:
 				for _, unit := range result.Units {
 					color.Cyan("\tUnits: " + color.YellowString("%d", unit))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 
@@ -138,18 +143,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Rack Reservations: " + color.RedString("No rack reservations found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRackReservationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRackReservationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRackReservationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRackReservationsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRackReservationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRackReservationsCmd.PersistentFlags().String("foo", "", "A help for foo")
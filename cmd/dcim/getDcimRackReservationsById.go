@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -38,10 +39,11 @@ type rackReservationsByID struct {
 	Rack    struct {
 		CommonFieldsNoSlug
 	} `json:"rack"`
-	Units       []int     `json:"units"`
-	Created     time.Time `json:"created"`
-	LastUpdated time.Time `json:"last_updated"`
-	User        struct {
+	Units        []int                  `json:"units"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      time.Time              `json:"created"`
+	LastUpdated  time.Time              `json:"last_updated"`
+	User         struct {
 		Id       uint   `json:"id"`
 		Url      string `json:"url"`
 		Display  string `json:"display"`
@@ -65,9 +67,11 @@ var GetDcimRackReservationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an rack reservation object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rackReservationsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.rack_reservations_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.rack_reservations_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Rack Reservation: %s\n", color.YellowString(responseObject.Display))
@@ -86,6 +90,7 @@ ABC Netbox Automation Tools:
 			for _, unit := range responseObject.Units {
 				color.Cyan("\tUnits: " + color.YellowString("%d", unit))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 
@@ -128,25 +133,23 @@ ABC Netbox Automation Tools:
 				}
 			}
 		} else {
-			color.Red("  Doh! No rack reservation object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No rack reservation object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRackReservationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRackReservationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRackReservationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRackReservationsByIdCmd", err)
 	}
 
-	GetDcimRackReservationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rack reservation object")
-	err = GetDcimRackReservationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRackReservationsByIdCmd.Flags().IntP("id", "", 0, "ID of the rack reservation object")
+	api.AddLookupFlags(GetDcimRackReservationsByIdCmd, "cmd.dcim.dcim_api_url.rack_reservations_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
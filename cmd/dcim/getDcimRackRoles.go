@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -41,9 +42,10 @@ type rackRoles struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		RackCount   uint   `json:"rack_count"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		RackCount    uint                   `json:"rack_count"`
 	} `json:"results"`
 }
 
@@ -54,9 +56,11 @@ var GetDcimRackRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of rack role objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rackRoles)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.rack_roles")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.rack_roles"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Rack Reservations: "+color.YellowString("%d"), responseObject.Count)
@@ -89,6 +93,7 @@ ABC Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				if result.RackCount != 0 {
@@ -100,18 +105,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Rack Roles: " + color.RedString("No rack roles found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRackRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRackRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRackRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRackRolesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRackRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRackRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,9 +38,10 @@ type rackRolesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	RackCount   uint   `json:"rack_count"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	RackCount    uint                   `json:"rack_count"`
 }
 
 // GetDcimRackRolesByIdCmd represents the getDcimRackRolesById command
@@ -49,12 +51,14 @@ var GetDcimRackRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an rack role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rackRolesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.rack_roles_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.rack_roles_id"); err != nil {
+			return err
+		}
 
-		if responseObject.RackCount > 0 {
-			display := fmt.Sprintf("    ABC Rack Reservation: %s\n", color.YellowString(responseObject.Display))
+		if responseObject.Id != 0 {
+			display := fmt.Sprintf("    ABC Rack Role: %s\n", color.YellowString(responseObject.Display))
 			equals := strings.Repeat("=", len(display))
 			color.Cyan("\n  " + equals + "\n")
 			color.Cyan(display)
@@ -82,6 +86,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			if responseObject.RackCount != 0 {
@@ -90,25 +95,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tRack Count: " + color.RedString("No rack count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No rack role object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No rack role object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRackRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRackRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRackRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRackRolesByIdCmd", err)
 	}
 
-	GetDcimRackRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rack role object")
-	err = GetDcimRackRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRackRolesByIdCmd.Flags().IntP("id", "", 0, "ID of the rack role object")
+	api.AddLookupFlags(GetDcimRackRolesByIdCmd, "cmd.dcim.dcim_api_url.rack_roles_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
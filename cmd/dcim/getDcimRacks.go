@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -82,10 +83,11 @@ type racks struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created        string `json:"created"`
-		LastUpdated    string `json:"last_updated"`
-		DeviceCount    uint   `json:"device_count"`
-		PowerfeedCount uint   `json:"powerfeed_count"`
+		CustomFields   map[string]interface{} `json:"custom_fields"`
+		Created        string                 `json:"created"`
+		LastUpdated    string                 `json:"last_updated"`
+		DeviceCount    uint                   `json:"device_count"`
+		PowerfeedCount uint                   `json:"powerfeed_count"`
 	} `json:"results"`
 }
 
@@ -96,9 +98,11 @@ var GetDcimRacksCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of rack objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(racks)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.racks")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.racks"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Rack Count: "+color.YellowString("%d"), responseObject.Count)
@@ -112,7 +116,7 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tURL: " + color.YellowString("%s", result.Url))
 				color.Cyan("\tDisplay: " + color.YellowString("%s", result.Display))
 				if result.FacilityId != "" {
-					color.Cyan("\tFacility ID: " + color.YellowString("%d", result.FacilityId))
+					color.Cyan("\tFacility ID: " + color.YellowString("%s", result.FacilityId))
 				} else {
 					color.Cyan("\tFacility ID: " + color.RedString("No facility ID entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -165,12 +169,12 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tRole: " + color.RedString("No role entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.Serial != "" {
-					color.Cyan("\tSerial: " + color.YellowString("%d", result.Serial))
+					color.Cyan("\tSerial: " + color.YellowString("%s", result.Serial))
 				} else {
 					color.Cyan("\tSerial: " + color.RedString("No serial entry found for ") + color.YellowString("%s", result.Display))
 				}
 				if result.AssetTag != "" {
-					color.Cyan("\tAsset Tag: " + color.YellowString("%d", result.AssetTag))
+					color.Cyan("\tAsset Tag: " + color.YellowString("%s", result.AssetTag))
 				} else {
 					color.Cyan("\tAsset Tag: " + color.RedString("No asset tag entry found for ") + color.YellowString("%s", result.Display))
 				}
@@ -270,6 +274,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 
@@ -288,18 +293,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Racks: " + color.RedString("No racks found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRacksCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRacksCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRacksCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRacksCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRacksCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRacksCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -78,10 +79,11 @@ type racksByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created        string `json:"created"`
-	LastUpdated    string `json:"last_updated"`
-	DeviceCount    uint   `json:"device_count"`
-	PowerfeedCount uint   `json:"powerfeed_count"`
+	CustomFields   map[string]interface{} `json:"custom_fields"`
+	Created        string                 `json:"created"`
+	LastUpdated    string                 `json:"last_updated"`
+	DeviceCount    uint                   `json:"device_count"`
+	PowerfeedCount uint                   `json:"powerfeed_count"`
 }
 
 // GetDcimRacksByIdCmd represents the getDcimRacksById command
@@ -91,9 +93,11 @@ var GetDcimRacksByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an rack object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(racksByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.racks_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.racks_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Rack: %s\n", color.YellowString(responseObject.Display))
@@ -105,7 +109,7 @@ ABC Netbox Automation Tools:
 			color.Cyan("\tURL: " + color.YellowString("%s", responseObject.Url))
 			color.Cyan("\tDisplay: " + color.YellowString("%s", responseObject.Display))
 			if responseObject.FacilityId != "" {
-				color.Cyan("\tFacility ID: " + color.YellowString("%d", responseObject.FacilityId))
+				color.Cyan("\tFacility ID: " + color.YellowString("%s", responseObject.FacilityId))
 			} else {
 				color.Cyan("\tFacility ID: " + color.RedString("No facility ID entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -158,12 +162,12 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tRole: " + color.RedString("No role entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.Serial != "" {
-				color.Cyan("\tSerial: " + color.YellowString("%d", responseObject.Serial))
+				color.Cyan("\tSerial: " + color.YellowString("%s", responseObject.Serial))
 			} else {
 				color.Cyan("\tSerial: " + color.RedString("No serial entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 			if responseObject.AssetTag != "" {
-				color.Cyan("\tAsset Tag: " + color.YellowString("%d", responseObject.AssetTag))
+				color.Cyan("\tAsset Tag: " + color.YellowString("%s", responseObject.AssetTag))
 			} else {
 				color.Cyan("\tAsset Tag: " + color.RedString("No asset tag entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
@@ -263,6 +267,7 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 
@@ -278,25 +283,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tPowerfeed Count: " + color.RedString("No powerfeed count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No rack object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No rack object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRacksByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRacksByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRacksByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRacksByIdCmd", err)
 	}
 
-	GetDcimRacksByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rack object")
-	err = GetDcimRacksByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRacksByIdCmd.Flags().IntP("id", "", 0, "ID of the rack object")
+	api.AddLookupFlags(GetDcimRacksByIdCmd, "cmd.dcim.dcim_api_url.racks_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -62,11 +63,12 @@ type rearPortTemplates struct {
 		Type  struct {
 			ValueLabel
 		} `json:"type"`
-		Color       string `json:"color"`
-		Positions   uint   `json:"positions"`
-		Description string `json:"description"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
+		Color        string                 `json:"color"`
+		Positions    uint                   `json:"positions"`
+		Description  string                 `json:"description"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
 	} `json:"results"`
 }
 
@@ -77,9 +79,11 @@ var GetDcimRearPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of rear port template objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rearPortTemplates)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.rear_port_templates")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.rear_port_templates"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Rear Port Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -124,12 +128,12 @@ ABC Netbox Automation Tools:
 					color.Cyan("\t  URL: " + color.YellowString("%s", result.ModuleType.Url))
 					color.Cyan("\t  Display: " + color.YellowString("%s", result.ModuleType.Display))
 					if result.ModuleType.Manufacturer.Id > 0 {
-						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer))
+						color.Cyan("\t  Manufacturer: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
 						color.Cyan("\t    ID: " + color.YellowString("%d", result.ModuleType.Manufacturer.Id))
-						color.Cyan("\t    URL: " + color.YellowString("%d", result.ModuleType.Manufacturer.Url))
-						color.Cyan("\t    Display: " + color.YellowString("%d", result.ModuleType.Manufacturer.Display))
-						color.Cyan("\t    Name: " + color.YellowString("%d", result.ModuleType.Manufacturer.Name))
-						color.Cyan("\t    Slug: " + color.YellowString("%d", result.ModuleType.Manufacturer.Slug))
+						color.Cyan("\t    URL: " + color.YellowString("%s", result.ModuleType.Manufacturer.Url))
+						color.Cyan("\t    Display: " + color.YellowString("%s", result.ModuleType.Manufacturer.Display))
+						color.Cyan("\t    Name: " + color.YellowString("%s", result.ModuleType.Manufacturer.Name))
+						color.Cyan("\t    Slug: " + color.YellowString("%s", result.ModuleType.Manufacturer.Slug))
 					} else {
 						color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", result.Display))
 					}
@@ -172,24 +176,28 @@ ABC Netbox Automation Tools:
 				} else {
 					color.Cyan("\tDescription" + color.RedString("No description entry found for ") + color.YellowString("%s", result.Display))
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s\n", result.LastUpdated))
 			}
 		} else {
 			color.Cyan("  ABC Rear Port Templates: " + color.RedString("No rear port templates found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRearPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRearPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRearPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRearPortTemplatesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRearPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRearPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
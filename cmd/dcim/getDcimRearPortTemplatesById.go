@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -58,11 +59,12 @@ type rearPortTemplatesByID struct {
 	Type  struct {
 		ValueLabel
 	} `json:"type"`
-	Color       string `json:"color"`
-	Positions   uint   `json:"positions"`
-	Description string `json:"description"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
+	Color        string                 `json:"color"`
+	Positions    uint                   `json:"positions"`
+	Description  string                 `json:"description"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
 }
 
 // GetDcimRearPortTemplatesByIdCmd represents the getDcimRearPortTemplatesById command
@@ -72,9 +74,11 @@ var GetDcimRearPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an rear port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rearPortTemplatesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.rear_port_templates_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.rear_port_templates_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Rear Port Template: %s\n", color.YellowString(responseObject.Display))
@@ -117,12 +121,12 @@ ABC Netbox Automation Tools:
 				color.Cyan("\t  URL: " + color.YellowString("%s", responseObject.ModuleType.Url))
 				color.Cyan("\t  Display: " + color.YellowString("%s", responseObject.ModuleType.Display))
 				if responseObject.ModuleType.Manufacturer.Id > 0 {
-					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer))
+					color.Cyan("\t  Manufacturer: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
 					color.Cyan("\t    ID: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Id))
-					color.Cyan("\t    URL: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Url))
-					color.Cyan("\t    Display: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Display))
-					color.Cyan("\t    Name: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Name))
-					color.Cyan("\t    Slug: " + color.YellowString("%d", responseObject.ModuleType.Manufacturer.Slug))
+					color.Cyan("\t    URL: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Url))
+					color.Cyan("\t    Display: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Display))
+					color.Cyan("\t    Name: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Name))
+					color.Cyan("\t    Slug: " + color.YellowString("%s", responseObject.ModuleType.Manufacturer.Slug))
 				} else {
 					color.Cyan("\t  Manufacturer" + color.RedString("No manufacturer entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
@@ -165,28 +169,27 @@ ABC Netbox Automation Tools:
 			} else {
 				color.Cyan("\tDescription: " + color.RedString("No description entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s\n", responseObject.LastUpdated))
 		} else {
-			color.Cyan("  ABC Rear Port Template: " + color.RedString("No rear port template found on server for ID: %d. Exiting...\n", id))
+			color.Cyan("  ABC Rear Port Template: " + color.RedString("No rear port template found on server for ID: %d. Exiting...\n", objectID(cmd)))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRearPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRearPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRearPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRearPortTemplatesByIdCmd", err)
 	}
 
-	GetDcimRearPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of rear port template object")
-	err = GetDcimRearPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRearPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of rear port template object")
+	api.AddLookupFlags(GetDcimRearPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.rear_port_templates_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -85,9 +86,10 @@ type rearPorts struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		Occupied    bool   `json:"_occupied"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		Occupied     bool                   `json:"_occupied"`
 	} `json:"results"`
 }
 
@@ -98,9 +100,11 @@ var GetDcimRearPortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of rear port objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rearPorts)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.rear_ports")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.rear_ports"); err != nil {
+			return err
+		}
 
 		if responseObject.Count != 0 {
 			color.Cyan("\n  ABC Rear Ports Count: "+color.YellowString("%d"), responseObject.Count)
@@ -236,6 +240,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tOccupied: " + color.YellowString("%t\n", result.Occupied))
@@ -243,18 +248,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Rear Ports: " + color.RedString("No rear ports found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRearPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRearPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRearPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRearPortsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRearPortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRearPortsCmd.PersistentFlags().String("foo", "", "A help for foo")
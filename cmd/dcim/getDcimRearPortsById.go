@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -81,9 +82,10 @@ type rearPortsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	Occupied    bool   `json:"_occupied"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	Occupied     bool                   `json:"_occupied"`
 }
 
 // GetDcimRearPortsByIdCmd represents the getDcimRearPortsById command
@@ -93,9 +95,11 @@ var GetDcimRearPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an rear port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(rearPortsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.rear_ports_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.rear_ports_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Rear Port: %s\n", color.YellowString(responseObject.Display))
@@ -229,29 +233,28 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tOccupied: " + color.YellowString("%t\n", responseObject.Occupied))
 		} else {
-			color.Red("  Doh! No rear port object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No rear port object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRearPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRearPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRearPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRearPortsByIdCmd", err)
 	}
 
-	GetDcimRearPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the rear port object")
-	err = GetDcimRearPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRearPortsByIdCmd.Flags().IntP("id", "", 0, "ID of the rear port object")
+	api.AddLookupFlags(GetDcimRearPortsByIdCmd, "cmd.dcim.dcim_api_url.rear_ports_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -45,10 +46,11 @@ type regions struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		SiteCount   uint   `json:"site_count"`
-		Depth       uint   `json:"_depth"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		SiteCount    uint                   `json:"site_count"`
+		Depth        uint                   `json:"_depth"`
 	} `json:"results"`
 }
 
@@ -59,9 +61,11 @@ var GetDcimRegionsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of region objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(regions)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.regions")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.regions"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\n  ABC Rear Port Templates: "+color.YellowString("%d"), responseObject.Count)
@@ -96,6 +100,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 
@@ -114,18 +119,21 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Regions: " + color.RedString("No regions found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRegionsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRegionsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRegionsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRegionsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimRegionsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimRegionsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -41,10 +42,11 @@ type regionsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	SiteCount   int    `json:"site_count"`
-	Depth       int    `json:"_depth"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	SiteCount    int                    `json:"site_count"`
+	Depth        int                    `json:"_depth"`
 }
 
 // GetDcimRegionsByIdCmd represents the getDcimRegionsById command
@@ -54,9 +56,11 @@ var GetDcimRegionsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an region object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(regionsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.regions_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.regions_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Rear Port Template: %s\n", color.YellowString(responseObject.Display))
@@ -89,6 +93,7 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 
@@ -104,25 +109,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tDepth: " + color.RedString("No depth entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No region object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No region object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimRegionsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimRegionsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimRegionsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimRegionsByIdCmd", err)
 	}
 
-	GetDcimRegionsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the region object")
-	err = GetDcimRegionsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimRegionsByIdCmd.Flags().IntP("id", "", 0, "ID of the region object")
+	api.AddLookupFlags(GetDcimRegionsByIdCmd, "cmd.dcim.dcim_api_url.regions_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
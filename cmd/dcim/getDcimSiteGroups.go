@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -27,10 +28,11 @@ type siteGroups struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		SiteCount   uint   `json:"site_count"`
-		Depth       uint   `json:"_depth"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		SiteCount    uint                   `json:"site_count"`
+		Depth        uint                   `json:"_depth"`
 	} `json:"results"`
 }
 
@@ -41,9 +43,11 @@ var GetDcimSiteGroupsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a list of site group objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(siteGroups)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.site_groups")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.site_groups"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\n  ABC Site Groups: "+color.YellowString("%d"), responseObject.Count)
@@ -89,6 +93,7 @@ ABC Netbox Automation Tools:
 					}
 				}
 
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: " + color.YellowString("%s", result.Created))
 				color.Cyan("\tLast Updated: " + color.YellowString("%s", result.LastUpdated))
 				color.Cyan("\tSite Count: " + color.YellowString("%d", result.SiteCount))
@@ -97,17 +102,20 @@ ABC Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Site Groups: " + color.RedString("No site groups found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimSiteGroupsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimSiteGroupsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimSiteGroupsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimSiteGroupsCmd", err)
 	}
+
+	api.AddCustomFieldFilterFlag(GetDcimSiteGroupsCmd)
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimSiteGroupsCmd.PersistentFlags().String("foo", "", "A help for foo")
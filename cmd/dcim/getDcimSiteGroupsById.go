@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -23,10 +24,11 @@ type siteGroupsByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	SiteCount   uint   `json:"site_count"`
-	Depth       uint   `json:"_depth"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	SiteCount    uint                   `json:"site_count"`
+	Depth        uint                   `json:"_depth"`
 }
 
 // GetDcimSiteGroupsByIdCmd represents the getDcimSiteGroupsById command
@@ -36,9 +38,11 @@ var GetDcimSiteGroupsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an site group object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(siteGroupsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.site_groups_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.site_groups_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Site Group: %s\n", color.YellowString(responseObject.Display))
@@ -82,30 +86,29 @@ ABC Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: " + color.YellowString("%s", responseObject.Created))
 			color.Cyan("\tLast Updated: " + color.YellowString("%s", responseObject.LastUpdated))
 			color.Cyan("\tSite Count: " + color.YellowString("%d", responseObject.SiteCount))
 			color.Cyan("\tDepth: " + color.YellowString("%d\n", responseObject.Depth))
 		} else {
-			color.Red("  Doh! No site groups object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No site groups object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimSiteGroupsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimSiteGroupsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimSiteGroupsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimSiteGroupsByIdCmd", err)
 	}
 
-	GetDcimSiteGroupsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the site group object")
-	err = GetDcimSiteGroupsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimSiteGroupsByIdCmd.Flags().IntP("id", "", 0, "ID of the site group object")
+	api.AddLookupFlags(GetDcimSiteGroupsByIdCmd, "cmd.dcim.dcim_api_url.site_groups_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,16 +23,15 @@ THE SOFTWARE.
 package dcim
 
 import (
-	"bufio"
 	"fmt"
 	_ "fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	_ "github.com/spf13/viper"
 	_ "io"
 	"log"
 	_ "net/http"
-	"os"
 	_ "strconv"
 	"strings"
 )
@@ -72,14 +71,15 @@ type sites struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created             string `json:"created"`
-		LastUpdated         string `json:"last_updated"`
-		CircuitCount        uint   `json:"circuit_count"`
-		DeviceCount         uint   `json:"device_count"`
-		PrefixCount         uint   `json:"prefix_count"`
-		RackCount           uint   `json:"rack_count"`
-		VirtualmachineCount uint   `json:"virtualmachine_count"`
-		VlanCount           uint   `json:"vlan_count"`
+		CustomFields        map[string]interface{} `json:"custom_fields"`
+		Created             string                 `json:"created"`
+		LastUpdated         string                 `json:"last_updated"`
+		CircuitCount        uint                   `json:"circuit_count"`
+		DeviceCount         uint                   `json:"device_count"`
+		PrefixCount         uint                   `json:"prefix_count"`
+		RackCount           uint                   `json:"rack_count"`
+		VirtualmachineCount uint                   `json:"virtualmachine_count"`
+		VlanCount           uint                   `json:"vlan_count"`
 	} `json:"results"`
 }
 
@@ -92,8 +92,10 @@ var GetDcimSitesCmd = &cobra.Command{
 	Long: `
 .Netbox Automation Tools:
   GET a list of site objects`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ApiConnectionNonID(responseObjectSites, "GET", "cmd.dcim.dcim_api_url.sites")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ApiConnectionNonID(cmd, responseObjectSites, "cmd.dcim.dcim_api_url.sites"); err != nil {
+			return err
+		}
 
 		if responseObjectSites.Count > 0 {
 			color.Cyan("\n  Total ABC Sites: "+color.YellowString("%d"), responseObjectSites.Count)
@@ -214,6 +216,7 @@ var GetDcimSitesCmd = &cobra.Command{
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", site.Display))
 					}
 				}
+				api.DisplayCustomFields(site.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), site.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), site.LastUpdated)
 				if site.CircuitCount != 0 {
@@ -248,7 +251,13 @@ var GetDcimSitesCmd = &cobra.Command{
 				}
 			}
 			for responseObjectSites.Next != "" {
-				nextPageSites()
+				more, err := nextPageSites(cmd)
+				if err != nil {
+					return err
+				}
+				if !more {
+					return nil
+				}
 			}
 			if responseObjectSites.Next == "" {
 				display := color.HiGreenString("\tAll Netbox site objects have been successfully displayed...")
@@ -261,6 +270,7 @@ var GetDcimSitesCmd = &cobra.Command{
 		} else {
 			color.Cyan("  ABC Sites: " + color.RedString("No sites found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
@@ -382,6 +392,7 @@ func displaySitesOutput() {
 				color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", site.Display))
 			}
 		}
+		api.DisplayCustomFields(site.CustomFields)
 		color.Cyan("\tCreated: "+color.YellowString("%s"), site.Created)
 		color.Cyan("\tLast Updated: "+color.YellowString("%s"), site.LastUpdated)
 		if site.CircuitCount != 0 {
@@ -417,53 +428,35 @@ func displaySitesOutput() {
 	}
 }
 
-func nextPageSites() {
-	reader := bufio.NewReader(os.Stdin)
+func nextPageSites(cmd *cobra.Command) (bool, error) {
 	fmt.Printf("\tDo you want to continue to the next page of site objects? [yes/no]: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	input := api.ReadAnswer(cmd.Context())
 	switch input {
 	case "Y", "yes":
-		ApiConnectionNextPageSites(responseObjectSites, "GET", responseObjectSites.Next)
+		if err := apiConnectionNextPage(cmd, responseObjectSites, responseObjectSites.Next); err != nil {
+			return false, err
+		}
 		displaySitesOutput()
 	case "n", "no":
 		color.HiMagenta("\tExiting the ABC-netbox-cli application...\n")
-		os.Exit(0)
+		return false, nil
 	default:
 		color.Cyan("\tInvalid input, Please type ['Y' or 'yes'] or ['n' or 'no'] \n")
 	}
-}
-
-func ApiConnectionNextPageSites[T anyStruct](r T, httpMethod string, suffix string) {
-	config := loadConfig()
-
-	fullAPIPath := responseObjectSites.Next
-
-	color.Yellow("\n  Getting Netbox API objects from %s\n", suffix)
-	token := config.GetString("cmd.token_key")
-
-	err := CheckSSL(rootURL)
-	if err != nil {
-		fmt.Println("  SSL certificate is not valid: ", err)
-	} else {
-		color.Cyan("  SSL certificate is valid for: " + color.YellowString("%s", rootURL))
-	}
-
-	executeAPIRequest(httpMethod, fullAPIPath, token, r)
-	if err != nil {
-		log.Fatalf("Error getting Netbox API objects: %s\n", err)
-	}
+	return true, nil
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimSitesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimSitesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimSitesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimSitesCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimSitesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// allNetboxSitesCmd.PersistentFlags().String("foo", "", "A help for foo")
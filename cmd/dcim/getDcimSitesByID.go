@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"log"
 	"strings"
@@ -61,14 +62,15 @@ type sitesByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created             string `json:"created"`
-	LastUpdated         string `json:"last_updated"`
-	CircuitCount        uint   `json:"circuit_count"`
-	DeviceCount         uint   `json:"device_count"`
-	PrefixCount         uint   `json:"prefix_count"`
-	RackCount           uint   `json:"rack_count"`
-	VirtualmachineCount uint   `json:"virtualmachine_count"`
-	VlanCount           uint   `json:"vlan_count"`
+	CustomFields        map[string]interface{} `json:"custom_fields"`
+	Created             string                 `json:"created"`
+	LastUpdated         string                 `json:"last_updated"`
+	CircuitCount        uint                   `json:"circuit_count"`
+	DeviceCount         uint                   `json:"device_count"`
+	PrefixCount         uint                   `json:"prefix_count"`
+	RackCount           uint                   `json:"rack_count"`
+	VirtualmachineCount uint                   `json:"virtualmachine_count"`
+	VlanCount           uint                   `json:"vlan_count"`
 }
 
 // GetDcimSitesByIDCmd represents the responseObjectByID command
@@ -78,9 +80,11 @@ var GetDcimSitesByIDCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an site object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(sitesByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.sites_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.sites_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Site: %s", color.YellowString(responseObject.Display))
@@ -199,6 +203,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 			if responseObject.CircuitCount != 0 {
@@ -232,8 +237,9 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tVLAN Count: " + color.RedString("No vlan count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No site object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No site object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
@@ -243,17 +249,14 @@ ABC Netbox Automation Tools:
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimSitesByIDCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimSitesByIDCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimSitesByIDCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimSitesByIDCmd", err)
 	}
 
-	GetDcimSitesByIDCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the site object")
-	err = GetDcimSitesByIDCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s", err)
-	}
+	GetDcimSitesByIDCmd.Flags().IntP("id", "", 0, "ID of the site object")
+	api.AddLookupFlags(GetDcimSitesByIDCmd, "cmd.dcim.dcim_api_url.sites_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
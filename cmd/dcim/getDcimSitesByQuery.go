@@ -24,6 +24,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -37,9 +38,11 @@ var GetDcimSitesByQueryCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET a site object by string query`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(sites)
-		ApiConnectionQuery(responseObject, "GET", "cmd.dcim.dcim_api_url.sites_id")
+		if err := ApiConnectionQuery(cmd, responseObject, "cmd.dcim.dcim_api_url.sites_id"); err != nil {
+			return err
+		}
 
 		for _, site := range responseObject.Results {
 			display := fmt.Sprintf("    ABC Site: %s", color.YellowString(site.Display))
@@ -158,6 +161,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", site.Display))
 				}
 			}
+			api.DisplayCustomFields(site.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), site.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), site.LastUpdated)
 			if site.CircuitCount != 0 {
@@ -191,19 +195,20 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tVLAN Count: " + color.RedString("No vlan count entry found for ") + color.YellowString("%s\n", site.Display))
 			}
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimSitesByQueryCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimSitesByQueryCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimSitesByQueryCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for GetDcimSitesByQueryCmd", err)
 	}
 
-	GetDcimSitesByQueryCmd.Flags().StringVarP(&query, "query", "q", "", "string of object search you want to get")
+	GetDcimSitesByQueryCmd.Flags().StringP("query", "q", "", "string of object search you want to get")
 	err = GetDcimSitesByQueryCmd.MarkFlagRequired("query")
 	if err != nil {
 		log.Fatalf("Error marking query flag as required: %s - for GetDcimSitesByQueryCmd", err)
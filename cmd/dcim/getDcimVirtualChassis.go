@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -28,9 +29,10 @@ type virtualChassis struct {
 			CommonFieldsSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created     string `json:"created"`
-		LastUpdated string `json:"last_updated"`
-		MemberCount int    `json:"member_count"`
+		CustomFields map[string]interface{} `json:"custom_fields"`
+		Created      string                 `json:"created"`
+		LastUpdated  string                 `json:"last_updated"`
+		MemberCount  int                    `json:"member_count"`
 	} `json:"results"`
 }
 
@@ -41,9 +43,11 @@ var GetDcimVirtualChassisCmd = &cobra.Command{
 	Long: `
 Netbox Automation Tools:
   GET a list of virtual chassis objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(virtualChassis)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.virtual_chassis")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.virtual_chassis"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\n  Total ABC Virtual Chassis: "+color.YellowString("%d"), responseObject.Count)
@@ -95,6 +99,7 @@ Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), result.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), result.LastUpdated)
 
@@ -107,18 +112,21 @@ Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Virtual Chassis: " + color.RedString("No virtual chassis found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimVirtualChassisCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimVirtualChassisCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimVirtualChassisCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking dcim virtual chassis flag as required: %s - for GetDcimVirtualChassisCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimVirtualChassisCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimVirtualChassisCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -24,9 +25,10 @@ type virtualChassisByID struct {
 		CommonFieldsSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created     string `json:"created"`
-	LastUpdated string `json:"last_updated"`
-	MemberCount int    `json:"member_count"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Created      string                 `json:"created"`
+	LastUpdated  string                 `json:"last_updated"`
+	MemberCount  int                    `json:"member_count"`
 }
 
 // GetDcimVirtualChassisByIdCmd represents the getDcimVirtualChassisById command
@@ -36,9 +38,11 @@ var GetDcimVirtualChassisByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   GET an virtual chassis object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(virtualChassisByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.virtual_chassis_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.virtual_chassis_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Virtual Chassis: %s", color.YellowString(responseObject.Display))
@@ -87,6 +91,7 @@ ABC Netbox Automation Tools:
 					color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", responseObject.Display))
 				}
 			}
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 
@@ -96,25 +101,23 @@ ABC Netbox Automation Tools:
 				color.Cyan("\tDevice Count: " + color.RedString("No device count entry found for ") + color.YellowString("%s\n", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No virtual chassis object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No virtual chassis object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimVirtualChassisByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimVirtualChassisByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimVirtualChassisByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimVirtualChassisByIdCmd", err)
 	}
 
-	GetDcimVirtualChassisByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of virtual chassis to retrieve")
-	err = GetDcimVirtualChassisByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetDcimVirtualChassByIdCmd", err)
-	}
+	GetDcimVirtualChassisByIdCmd.Flags().IntP("id", "", 0, "ID of virtual chassis to retrieve")
+	api.AddLookupFlags(GetDcimVirtualChassisByIdCmd, "cmd.dcim.dcim_api_url.virtual_chassis_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -55,9 +56,10 @@ type virtualDeviceContexts struct {
 			CommonFieldsNoSlug
 			Color string `json:"color"`
 		} `json:"tags"`
-		Created        string `json:"created"`
-		LastUpdated    string `json:"last_updated"`
-		InterfaceCount uint32 `json:"interface_count"`
+		CustomFields   map[string]interface{} `json:"custom_fields"`
+		Created        string                 `json:"created"`
+		LastUpdated    string                 `json:"last_updated"`
+		InterfaceCount uint32                 `json:"interface_count"`
 	} `json:"results"`
 }
 
@@ -68,9 +70,11 @@ var GetDcimVirtualDeviceContextsCmd = &cobra.Command{
 	Long: `
 Netbox Automation Tools:
   GET a list of device context objects`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(virtualDeviceContexts)
-		ApiConnectionNonID(responseObject, "GET", "cmd.dcim.dcim_api_url.virtual_device_contexts")
+		if err := ApiConnectionNonID(cmd, responseObject, "cmd.dcim.dcim_api_url.virtual_device_contexts"); err != nil {
+			return err
+		}
 
 		if responseObject.Count > 0 {
 			color.Cyan("\n  Total ABC Virtual Device Contexts: "+color.YellowString("%d"), responseObject.Count)
@@ -154,6 +158,7 @@ Netbox Automation Tools:
 						color.Cyan("\tTags: " + color.RedString("No tags entry found for ") + color.YellowString("%s", result.Display))
 					}
 				}
+				api.DisplayCustomFields(result.CustomFields)
 				color.Cyan("\tCreated: "+color.YellowString("%s"), result.Created)
 				color.Cyan("\tLast Updated: "+color.YellowString("%s"), result.LastUpdated)
 				if result.InterfaceCount > 0 {
@@ -165,18 +170,21 @@ Netbox Automation Tools:
 		} else {
 			color.Cyan("  ABC Virtual Device Contexts: " + color.RedString("No virtual device context found on server. Exiting...\n"))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimVirtualDeviceContextsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimVirtualDeviceContextsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimVirtualDeviceContextsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimVirtualDeviceContextsCmd", err)
 	}
 
+	api.AddCustomFieldFilterFlag(GetDcimVirtualDeviceContextsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// getDcimVirtualDeviceContextsCmd.PersistentFlags().String("foo", "", "A help for foo")
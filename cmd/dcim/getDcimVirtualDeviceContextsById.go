@@ -6,6 +6,7 @@ package dcim
 
 import (
 	"fmt"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"log"
@@ -51,9 +52,10 @@ type virtualDeviceContextsByID struct {
 		CommonFieldsNoSlug
 		Color string `json:"color"`
 	} `json:"tags"`
-	Created        string `json:"created"`
-	LastUpdated    string `json:"last_updated"`
-	InterfaceCount uint32 `json:"interface_count"`
+	CustomFields   map[string]interface{} `json:"custom_fields"`
+	Created        string                 `json:"created"`
+	LastUpdated    string                 `json:"last_updated"`
+	InterfaceCount uint32                 `json:"interface_count"`
 }
 
 // GetDcimVirtualDeviceContextsByIdCmd represents the getDcimVirtualDeviceContextsById command
@@ -63,9 +65,11 @@ var GetDcimVirtualDeviceContextsByIdCmd = &cobra.Command{
 	Long: `
 Netbox Automation Tools:
   GET an device context object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		responseObject := new(virtualDeviceContextsByID)
-		apiConnectionID(responseObject, "GET", "cmd.dcim.dcim_api_url.virtual_device_contexts_id")
+		if err := apiConnectionID(cmd, responseObject, "cmd.dcim.dcim_api_url.virtual_device_contexts_id"); err != nil {
+			return err
+		}
 
 		if responseObject.Id > 0 {
 			display := fmt.Sprintf("    ABC Virtual Device Context: %s", color.YellowString(responseObject.Display))
@@ -152,6 +156,7 @@ Netbox Automation Tools:
 				}
 			}
 
+			api.DisplayCustomFields(responseObject.CustomFields)
 			color.Cyan("\tCreated: "+color.YellowString("%s"), responseObject.Created)
 			color.Cyan("\tLast Updated: "+color.YellowString("%s"), responseObject.LastUpdated)
 
@@ -161,25 +166,23 @@ Netbox Automation Tools:
 				color.Cyan("\tInterface Count: " + color.RedString("No interface count entry found for ") + color.YellowString("%s", responseObject.Display))
 			}
 		} else {
-			color.Red("  Doh! No virtual device context object found on server for ID: "+color.YellowString("%d\n"), id)
+			color.Red("  Doh! No virtual device context object found on server for ID: "+color.YellowString("%d\n"), objectID(cmd))
 		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	GetDcimVirtualDeviceContextsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	GetDcimVirtualDeviceContextsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := GetDcimVirtualDeviceContextsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for GetDcimVirtualDeviceContextsByIdCmd", err)
 	}
 
-	GetDcimVirtualDeviceContextsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of virtual device context object")
-	err = GetDcimVirtualDeviceContextsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for GetDcimVirtualDeviceContextsByIdCmd", err)
-	}
+	GetDcimVirtualDeviceContextsByIdCmd.Flags().IntP("id", "", 0, "ID of virtual device context object")
+	api.AddLookupFlags(GetDcimVirtualDeviceContextsByIdCmd, "cmd.dcim.dcim_api_url.virtual_device_contexts_id")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
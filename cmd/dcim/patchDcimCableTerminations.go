@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimCableTerminationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of cable termination objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.cable_termination_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.cable_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimCableTerminationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimCableTerminationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimCableTerminationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimCableTerminationsCmd", err)
 	}
 
-	PatchDcimCableTerminationsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (changed)")
+	PatchDcimCableTerminationsCmd.Flags().StringP("data", "", "", "JSON data to be patched (changed)")
 	err = PatchDcimCableTerminationsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimCableTerminationsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimCableTerminationsCmd)
+	api.AddCustomFieldFlag(PatchDcimCableTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimCableTerminationCmd.PersistentFlags().String("foo", "", "A help for foo")
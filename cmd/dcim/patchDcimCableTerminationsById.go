@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimCableTerminationsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an cable termination object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.cable_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.cable_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimCableTerminationsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'development')")
+	PatchDcimCableTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'development')")
 	err := PatchDcimCableTerminationsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimCableTerminationsByIdCmd", err)
 	}
 
-	PatchDcimCableTerminationsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the cable termination object to be patched (changed)")
-	err = PatchDcimCableTerminationsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimCableTerminationsByIdCmd", err)
-	}
+	PatchDcimCableTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the cable termination object to be patched (changed)")
+	api.AddLookupFlags(PatchDcimCableTerminationsByIdCmd, "cmd.dcim.dcim_api_url.cable_terminations_id")
 
-	PatchDcimCableTerminationsByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimCableTerminationsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimCableTerminationsByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimCableTerminationsByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimCableTerminationsByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimCableTerminationsByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimCableTerminationsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimCablesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of cable objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.cables_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.cables_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimCablesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimCablesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimCablesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s", err)
 	}
 
-	PatchDcimCablesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to patch")
+	PatchDcimCablesCmd.Flags().StringP("data", "", "", "JSON data to patch")
 	err = PatchDcimCablesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for PatchDcimCablesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimCablesCmd)
+	api.AddCustomFieldFlag(PatchDcimCablesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimCablesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -36,27 +37,25 @@ var PatchDcimCablesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an cable object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		//apiConnectionPatchID[p T]("cmd.dcim.dcim_api_url.cables_id", p)
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimCablesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimCablesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimCablesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for PatchDcimCablesByIdCmd", err)
 	}
 
-	PatchDcimCablesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the cable to patch")
-	err = PatchDcimCablesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %s - for PatchDcimCablesByIdCmd", err)
-	}
+	PatchDcimCablesByIdCmd.Flags().IntP("id", "", 0, "ID of the cable to patch")
+	api.AddLookupFlags(PatchDcimCablesByIdCmd, "cmd.dcim.dcim_api_url.cables_id")
 
-	PatchDcimCablesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to patch")
+	PatchDcimCablesByIdCmd.Flags().StringP("data", "", "", "JSON data to patch")
 	err = PatchDcimCablesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %s - for PatchDcimCablesByIdCmd", err)
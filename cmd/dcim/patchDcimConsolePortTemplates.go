@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimConsolePortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of console port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.console_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsolePortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimConsolePortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimConsolePortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsolePortTemplatesCmd", err)
 	}
 
-	PatchDcimConsolePortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required")
+	PatchDcimConsolePortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required")
 	err = PatchDcimConsolePortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsolePortTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsolePortTemplatesCmd)
+	api.AddCustomFieldFlag(PatchDcimConsolePortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimConsolePortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimConsolePortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an console port templates object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.console_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsolePortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "", "Environment ('development' or 'production')")
+	PatchDcimConsolePortTemplatesByIdCmd.Flags().StringP("env", "", "", "Environment ('development' or 'production')")
 	err := PatchDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsolePortTemplatesByIdCmd", err)
 	}
 
-	PatchDcimConsolePortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console port template object")
-	err = PatchDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimConsolePortTemplatesByIdCmd", err)
-	}
+	PatchDcimConsolePortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the console port template object")
+	api.AddLookupFlags(PatchDcimConsolePortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.console_port_templates_id")
 
-	PatchDcimConsolePortTemplatesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimConsolePortTemplatesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimConsolePortTemplatesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsolePortTemplatesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsolePortTemplatesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimConsolePortTemplatesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimConsolePortTemplatesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimConsolePortsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of console port objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.console_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.console_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsolePortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimConsolePortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimConsolePortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsolePortsCmd", err)
 	}
 
-	PatchDcimConsolePortsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimConsolePortsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimConsolePortsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsolePortsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsolePortsCmd)
+	api.AddCustomFieldFlag(PatchDcimConsolePortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimConsolePortsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -35,8 +35,9 @@ var PatchDcimConsolePortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an console port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("patchDcimConsolePortsById called")
+		return nil
 	},
 }
 
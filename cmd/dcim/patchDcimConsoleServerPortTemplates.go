@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimConsoleServerPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of console server port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.console_server_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimConsoleServerPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsoleServerPortTemplatesCmd", err)
 	}
 
-	PatchDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimConsoleServerPortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsolePortTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsoleServerPortTemplatesCmd)
+	api.AddCustomFieldFlag(PatchDcimConsoleServerPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimServerPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
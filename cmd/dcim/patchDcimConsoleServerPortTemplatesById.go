@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimConsoleServerPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an console server port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.console_server_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimConsoleServerPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsoleServerPortTemplatesByIdCmd", err)
 	}
 
-	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console server port template object")
-	err = PatchDcimConsoleServerPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimConsoleServerPortTemplatesByIdCmd", err)
-	}
+	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of the console server port template object")
+	api.AddLookupFlags(PatchDcimConsoleServerPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id")
 
-	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (changed)")
+	PatchDcimConsoleServerPortTemplatesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (changed)")
 	err = PatchDcimConsoleServerPortTemplatesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsoleServerPortTemplatesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsoleServerPortTemplatesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimConsoleServerPortTemplatesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimConsoleServerPortTemplatesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimConsoleServerPortsByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an console server port object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.console_server_ports_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.console_server_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimConsoleServerPortsByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimConsoleServerPortsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimConsoleServerPortsByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimConsoleServerPortsByIdCmd", err)
 	}
 
-	PatchDcimConsoleServerPortsByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the console server port object to be patched")
-	err = PatchDcimConsoleServerPortsByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimConsoleServerPortsByIdCmd", err)
-	}
+	PatchDcimConsoleServerPortsByIdCmd.Flags().IntP("id", "", 0, "ID of the console server port object to be patched")
+	api.AddLookupFlags(PatchDcimConsoleServerPortsByIdCmd, "cmd.dcim.dcim_api_url.console_server_ports_id")
 
-	PatchDcimConsoleServerPortsByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (changed)")
+	PatchDcimConsoleServerPortsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (changed)")
 	err = PatchDcimConsoleServerPortsByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimConsoleServerPortsByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimConsoleServerPortsByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimConsoleServerPortsByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimConsoleServerPortsByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
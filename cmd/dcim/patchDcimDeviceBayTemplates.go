@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimDeviceBayTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of device bay template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.device_bay_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.device_bay_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceBayTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceBayTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceBayTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceBayTemplatesCmd", err)
 	}
 
-	PatchDcimDeviceBayTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDeviceBayTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDeviceBayTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceBayTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceBayTemplatesCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceBayTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceBayTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
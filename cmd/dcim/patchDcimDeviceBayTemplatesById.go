@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimDeviceBayTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an device bay template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.device_bay_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.device_bay_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceBayTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceBayTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceBayTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceBayTemplatesByIdCmd", err)
 	}
 
-	PatchDcimDeviceBayTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of device bay template to patch")
-	err = PatchDcimDeviceBayTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimDeviceBayTemplatesByIdCmd", err)
-	}
+	PatchDcimDeviceBayTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of device bay template to patch")
+	api.AddLookupFlags(PatchDcimDeviceBayTemplatesByIdCmd, "cmd.dcim.dcim_api_url.device_bay_templates_id")
 
-	PatchDcimDeviceBayTemplatesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDeviceBayTemplatesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDeviceBayTemplatesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceBayTemplatesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceBayTemplatesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceBayTemplatesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceBayTemplatesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimDeviceBaysCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of device bay objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.device_bays_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.device_bays_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceBaysCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceBaysCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceBaysCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceBaysCmd", err)
 	}
 
-	PatchDcimDeviceBaysCmd.Flags().StringVarP(&data, "data", "", "", "ID of the device bay object to patch")
+	PatchDcimDeviceBaysCmd.Flags().StringP("data", "", "", "ID of the device bay object to patch")
 	err = PatchDcimDeviceBaysCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceBaysCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceBaysCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceBaysCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceBaysCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimDeviceBaysByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an device bay object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.device_bays_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.device_bays_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceBaysByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceBaysByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceBaysByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceBaysByIdCmd", err)
 	}
 
-	PatchDcimDeviceBaysByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of the device bay object to patch")
-	err = PatchDcimDeviceBaysByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimDeviceBaysByIdCmd", err)
-	}
+	PatchDcimDeviceBaysByIdCmd.Flags().IntP("id", "", 0, "ID of the device bay object to patch")
+	api.AddLookupFlags(PatchDcimDeviceBaysByIdCmd, "cmd.dcim.dcim_api_url.device_bays_id")
 
-	PatchDcimDeviceBaysByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (changed)")
+	PatchDcimDeviceBaysByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (changed)")
 	err = PatchDcimDeviceBaysByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceBaysByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceBaysByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceBaysByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceBaysByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimDeviceRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of device role objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.device_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceRolesCmd", err)
 	}
 
-	PatchDcimDeviceRolesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDeviceRolesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDeviceRolesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceRolesCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceRolesCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
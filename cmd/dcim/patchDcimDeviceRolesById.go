@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimDeviceRolesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an device role object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.device_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceRolesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceRolesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceRolesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceRolesByIdCmd", err)
 	}
 
-	PatchDcimDeviceRolesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of device bay template to patch")
-	err = PatchDcimDeviceRolesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimDeviceRolesByIdCmd", err)
-	}
+	PatchDcimDeviceRolesByIdCmd.Flags().IntP("id", "", 0, "ID of device bay template to patch")
+	api.AddLookupFlags(PatchDcimDeviceRolesByIdCmd, "cmd.dcim.dcim_api_url.device_roles_id")
 
-	PatchDcimDeviceRolesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDeviceRolesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDeviceRolesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceRolesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceRolesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceRolesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceRolesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
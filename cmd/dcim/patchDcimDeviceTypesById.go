@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -35,32 +36,35 @@ var PatchDcimDeviceTypesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an device type object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.device_types_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.device_types_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDeviceTypesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDeviceTypesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDeviceTypesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDeviceTypesByIdCmd", err)
 	}
 
-	PatchDcimDeviceTypesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of device bay template to patch")
-	err = PatchDcimDeviceTypesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimDeviceTypesByIdCmd", err)
-	}
+	PatchDcimDeviceTypesByIdCmd.Flags().IntP("id", "", 0, "ID of device bay template to patch")
+	api.AddLookupFlags(PatchDcimDeviceTypesByIdCmd, "cmd.dcim.dcim_api_url.device_types_id")
 
-	PatchDcimDeviceTypesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDeviceTypesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDeviceTypesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDeviceTypesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDeviceTypesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimDeviceTypesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDeviceTypesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -35,32 +36,35 @@ var PatchDcimDevicesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an device object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.devices_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.devices_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimDevicesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimDevicesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimDevicesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimDevicesByIdCmd", err)
 	}
 
-	PatchDcimDevicesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of device bay template to patch")
-	err = PatchDcimDevicesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimDevicesByIdCmd", err)
-	}
+	PatchDcimDevicesByIdCmd.Flags().IntP("id", "", 0, "ID of device bay template to patch")
+	api.AddLookupFlags(PatchDcimDevicesByIdCmd, "cmd.dcim.dcim_api_url.devices_id")
 
-	PatchDcimDevicesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimDevicesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimDevicesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimDevicesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimDevicesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimDevicesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimDevicesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,32 +35,35 @@ var PatchDcimFrontPortTemplatesByIdCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH an front port template object by ID`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatchID("cmd.dcim.dcim_api_url.front_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.dcim.dcim_api_url.front_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimFrontPortTemplatesByIdCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimFrontPortTemplatesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimFrontPortTemplatesByIdCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimFrontPortTemplatesByIdCmd", err)
 	}
 
-	PatchDcimFrontPortTemplatesByIdCmd.Flags().IntVarP(&id, "id", "", 0, "ID of device bay template to patch")
-	err = PatchDcimFrontPortTemplatesByIdCmd.MarkFlagRequired("id")
-	if err != nil {
-		log.Fatalf("Error marking id flag as required: %s - for PatchDcimFrontPortTemplatesByIdCmd", err)
-	}
+	PatchDcimFrontPortTemplatesByIdCmd.Flags().IntP("id", "", 0, "ID of device bay template to patch")
+	api.AddLookupFlags(PatchDcimFrontPortTemplatesByIdCmd, "cmd.dcim.dcim_api_url.front_port_templates_id")
 
-	PatchDcimFrontPortTemplatesByIdCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimFrontPortTemplatesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimFrontPortTemplatesByIdCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimFrontPortTemplatesByIdCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimFrontPortTemplatesByIdCmd)
+	api.AddCustomFieldFlag(PatchDcimFrontPortTemplatesByIdCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimFrontPortTemplatesByIdCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimManufacturersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of manufacturer objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.manufacturers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.manufacturers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimManufacturersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimManufacturersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimManufacturersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimManufacturersCmd", err)
 	}
 
-	PatchDcimManufacturersCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimManufacturersCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimManufacturersCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimManufacturersCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimManufacturersCmd)
+	api.AddCustomFieldFlag(PatchDcimManufacturersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimManufacturersCmd.PersistentFlags().String("foo", "", "A help for foo")
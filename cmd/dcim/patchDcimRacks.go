@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PatchDcimRacksCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of rack objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.racks_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.racks_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimRacksCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimRacksCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimRacksCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimRacksCmd", err)
 	}
 
-	PatchDcimRacksCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimRacksCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimRacksCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimRacksCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimRacksCmd)
+	api.AddCustomFieldFlag(PatchDcimRacksCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimRacksCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,26 +17,32 @@ var PatchDcimSiteGroupsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of site group objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.site_groups_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.site_groups_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimSiteGroupsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimSiteGroupsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimSiteGroupsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimSiteGroupsCmd", err)
 	}
 
-	PatchDcimSiteGroupsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimSiteGroupsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimSiteGroupsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimSiteGroupsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimSiteGroupsCmd)
+	api.AddCustomFieldFlag(PatchDcimSiteGroupsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimSiteGroupsCmd.PersistentFlags().String("foo", "", "A help for foo")
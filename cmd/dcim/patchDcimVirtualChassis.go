@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,26 +17,32 @@ var PatchDcimVirtualChassisCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of virtual chassis objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.virtual_chassis_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.virtual_chassis_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimVirtualChassisCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimVirtualChassisCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimVirtualChassisCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimVirtualChassisCmd", err)
 	}
 
-	PatchDcimVirtualChassisCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimVirtualChassisCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimVirtualChassisCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimVirtualChassisCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimVirtualChassisCmd)
+	api.AddCustomFieldFlag(PatchDcimVirtualChassisCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimVirtualChassisCmd.PersistentFlags().String("foo", "", "A help for foo")
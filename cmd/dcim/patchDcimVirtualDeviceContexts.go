@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,26 +17,32 @@ var PatchDcimVirtualDeviceContextsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   PATCH a list of virtual device context objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPatch("cmd.dcim.dcim_api_url.virtual_device_contexts_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatch(cmd, "cmd.dcim.dcim_api_url.virtual_device_contexts_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PatchDcimVirtualDeviceContextsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PatchDcimVirtualDeviceContextsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PatchDcimVirtualDeviceContextsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PatchDcimVirtualDeviceContextsCmd", err)
 	}
 
-	PatchDcimVirtualDeviceContextsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PatchDcimVirtualDeviceContextsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PatchDcimVirtualDeviceContextsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PatchDcimVirtualDeviceContextsCmd", err)
 	}
 
+	api.AddDryRunFlag(PatchDcimVirtualDeviceContextsCmd)
+	api.AddCustomFieldFlag(PatchDcimVirtualDeviceContextsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// patchDcimVirtualDeviceContextsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimCableTerminationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of cable termination objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.cable_terminations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.cable_terminations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimCableTerminationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimCableTerminationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimCableTerminationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimCableTerminationsCmd", err)
 	}
 
-	PostDcimCableTerminationsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimCableTerminationsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimCableTerminationsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimCableTerminationsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimCableTerminationsCmd)
+	api.AddCustomFieldFlag(PostDcimCableTerminationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimCableTerminationCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimCablesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of cable objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.cables_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.cables_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimCablesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimCablesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimCablesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimCablesCmd", err)
 	}
 
-	PostDcimCablesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimCablesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimCablesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimCablesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimCablesCmd)
+	api.AddCustomFieldFlag(PostDcimCablesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimCablesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimConsolePortTemplatesCmd = &cobra.Command{
 	Short: "POST a list of console port templates objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of console port templates objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.console_port_templates_id")
+  POST a list of console port templates objects.
+  The name may be a range pattern such as console[1-2], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.console_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimConsolePortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimConsolePortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimConsolePortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimConsolePortTemplatesCmd", err)
 	}
 
-	PostDcimConsolePortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimConsolePortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimConsolePortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimConsolePortTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimConsolePortTemplatesCmd)
+	api.AddCustomFieldFlag(PostDcimConsolePortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimConsolePortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
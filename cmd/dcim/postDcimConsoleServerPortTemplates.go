@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimConsoleServerPortTemplatesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of server port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.server_port_templates_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.console_server_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimConsoleServerPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimConsoleServerPortTemplatesCmd", err)
 	}
 
-	PostDcimConsoleServerPortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimConsoleServerPortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimConsoleServerPortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimConsoleServerPortTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimConsoleServerPortTemplatesCmd)
+	api.AddCustomFieldFlag(PostDcimConsoleServerPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimServerPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
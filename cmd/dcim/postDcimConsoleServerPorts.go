@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimConsoleServerPortsCmd = &cobra.Command{
 	Short: "POST a list of console server port objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of console server port objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.console_server_ports_id")
+  POST a list of console server port objects.
+  The name may be a range pattern such as port[1-48], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.console_server_ports_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimConsoleServerPortsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimConsoleServerPortsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimConsoleServerPortsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimConsoleServerPortsCmd", err)
 	}
 
-	PostDcimConsoleServerPortsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimConsoleServerPortsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimConsoleServerPortsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimConsoleServerPortsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimConsoleServerPortsCmd)
+	api.AddCustomFieldFlag(PostDcimConsoleServerPortsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimConsoleServerPortsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimDeviceRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of device role objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.device_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.device_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimDeviceRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimDeviceRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimDeviceRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimDeviceRolesCmd", err)
 	}
 
-	PostDcimDeviceRolesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimDeviceRolesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimDeviceRolesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimDeviceRolesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimDeviceRolesCmd)
+	api.AddCustomFieldFlag(PostDcimDeviceRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimDeviceRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
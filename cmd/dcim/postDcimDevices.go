@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimDevicesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of device objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.devices_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.devices_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimDevicesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimDevicesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimDevicesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimDevicesCmd", err)
 	}
 
-	PostDcimDevicesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimDevicesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimDevicesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimDevicesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimDevicesCmd)
+	api.AddCustomFieldFlag(PostDcimDevicesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimDevicesCmd.PersistentFlags().String("foo", "", "A help for foo")
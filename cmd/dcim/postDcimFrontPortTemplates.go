@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,36 @@ var PostDcimFrontPortTemplatesCmd = &cobra.Command{
 	Short: "POST a list of front port template objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of front port template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.front_port_templates_id")
+  POST a list of front port template objects.
+  The name may be a range pattern such as front[1-24], which creates one object per name, each
+  with the other attributes of --data. A rear_port_position pattern such as [1-24] gives each
+  front port its own position on the rear port.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.front_port_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimFrontPortTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimFrontPortTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimFrontPortTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimFrontPortTemplatesCmd", err)
 	}
 
-	PostDcimFrontPortTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimFrontPortTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimFrontPortTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimFrontPortTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimFrontPortTemplatesCmd)
+	api.AddCustomFieldFlag(PostDcimFrontPortTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimFrontPortTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimInterfaceTemplatesCmd = &cobra.Command{
 	Short: "POST a list of interface template objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of interface template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.interface_templates_id")
+  POST a list of interface template objects.
+  The name may be a range pattern such as Ethernet1/[1-48], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.interface_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimInterfaceTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimInterfaceTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimInterfaceTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimInterfaceTemplatesCmd", err)
 	}
 
-	PostDcimInterfaceTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimInterfaceTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimInterfaceTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimInterfaceTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimInterfaceTemplatesCmd)
+	api.AddCustomFieldFlag(PostDcimInterfaceTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimInterfaceTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimInterfacesCmd = &cobra.Command{
 	Short: "POST a list of interface objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of interface objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.interfaces_id")
+  POST a list of interface objects.
+  The name may be a range pattern such as Ethernet1/[1-48], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.interfaces_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimInterfacesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimInterfacesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimInterfacesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimInterfacesCmd", err)
 	}
 
-	PostDcimInterfacesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimInterfacesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimInterfacesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimInterfacesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimInterfacesCmd)
+	api.AddCustomFieldFlag(PostDcimInterfacesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimInterfacesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimInventoryItemRolesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of inventory item role objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.inventory_item_roles_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.inventory_item_roles_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimInventoryItemRolesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimInventoryItemRolesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimInventoryItemRolesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimInventoryItemRolesCmd", err)
 	}
 
-	PostDcimInventoryItemRolesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimInventoryItemRolesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimInventoryItemRolesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimInventoryItemRolesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimInventoryItemRolesCmd)
+	api.AddCustomFieldFlag(PostDcimInventoryItemRolesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimInventoryItemRolesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimInventoryItemsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of inventory item objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.inventory_items_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.inventory_items_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimInventoryItemsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimInventoryItemsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimInventoryItemsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimInventoryItemsCmd", err)
 	}
 
-	PostDcimInventoryItemsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimInventoryItemsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimInventoryItemsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimInventoryItemsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimInventoryItemsCmd)
+	api.AddCustomFieldFlag(PostDcimInventoryItemsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimInventoryItemsCmd.PersistentFlags().String("foo", "", "A help for foo")
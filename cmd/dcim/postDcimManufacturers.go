@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimManufacturersCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of manufacturer objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.manufacturers_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.manufacturers_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimManufacturersCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimManufacturersCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimManufacturersCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimManufacturersCmd", err)
 	}
 
-	PostDcimManufacturersCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimManufacturersCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimManufacturersCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimManufacturersCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimManufacturersCmd)
+	api.AddCustomFieldFlag(PostDcimManufacturersCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimManufacturersCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimModulesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of module objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.modules_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.modules_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimModulesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimModulesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimModulesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimModulesCmd", err)
 	}
 
-	PostDcimModulesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimModulesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimModulesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimModulesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimModulesCmd)
+	api.AddCustomFieldFlag(PostDcimModulesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimModulesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimPowerFeedsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of power feed objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.power_feeds_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.power_feeds_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimPowerFeedsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimPowerFeedsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimPowerFeedsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimPowerFeedsCmd", err)
 	}
 
-	PostDcimPowerFeedsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimPowerFeedsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimPowerFeedsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimPowerFeedsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimPowerFeedsCmd)
+	api.AddCustomFieldFlag(PostDcimPowerFeedsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimPowerFeedsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimPowerOutletTemplatesCmd = &cobra.Command{
 	Short: "POST a list of power outlet template objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of power outlet template objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.power_outlet_templates_id")
+  POST a list of power outlet template objects.
+  The name may be a range pattern such as outlet[1-24], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.power_outlet_templates_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimPowerOutletTemplatesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimPowerOutletTemplatesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimPowerOutletTemplatesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimPowerOutletTemplatesCmd", err)
 	}
 
-	PostDcimPowerOutletTemplatesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimPowerOutletTemplatesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimPowerOutletTemplatesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimPowerOutletTemplatesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimPowerOutletTemplatesCmd)
+	api.AddCustomFieldFlag(PostDcimPowerOutletTemplatesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimPowerOutletTemplatesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -33,27 +34,35 @@ var PostDcimPowerOutletsCmd = &cobra.Command{
 	Short: "POST a list of power outlet objects.",
 	Long: `
 ABC Netbox Automation Tools:
-  POST a list of power outlet objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.power_outlets_id")
+  POST a list of power outlet objects.
+  The name may be a range pattern such as outlet[1-24], which creates one object per name, each
+  with the other attributes of --data.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.power_outlets_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimPowerOutletsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimPowerOutletsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimPowerOutletsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimPowerOutletsCmd", err)
 	}
 
-	PostDcimPowerOutletsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimPowerOutletsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimPowerOutletsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimPowerOutletsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimPowerOutletsCmd)
+	api.AddCustomFieldFlag(PostDcimPowerOutletsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimPowerOutletsCmd.PersistentFlags().String("foo", "", "A help for foo")
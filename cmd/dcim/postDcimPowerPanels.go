@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimPowerPanelsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of power panel objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.power_panels_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.power_panels_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimPowerPanelsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimPowerPanelsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimPowerPanelsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimPowerPanelsCmd", err)
 	}
 
-	PostDcimPowerPanelsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimPowerPanelsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimPowerPanelsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimPowerPanelsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimPowerPanelsCmd)
+	api.AddCustomFieldFlag(PostDcimPowerPanelsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimPowerPanelsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimRackReservationsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of rack reservation objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.rack_reservations_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.rack_reservations_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimRackReservationsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimRackReservationsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimRackReservationsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimRackReservationsCmd", err)
 	}
 
-	PostDcimRackReservationsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimRackReservationsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimRackReservationsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimRackReservationsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimRackReservationsCmd)
+	api.AddCustomFieldFlag(PostDcimRackReservationsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimRackReservationsCmd.PersistentFlags().String("foo", "", "A help for foo")
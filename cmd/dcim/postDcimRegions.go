@@ -23,6 +23,7 @@ THE SOFTWARE.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -34,26 +35,32 @@ var PostDcimRegionsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of region objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.regions_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.regions_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimRegionsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimRegionsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimRegionsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimRegionsCmd", err)
 	}
 
-	PostDcimRegionsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimRegionsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimRegionsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimRegionsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimRegionsCmd)
+	api.AddCustomFieldFlag(PostDcimRegionsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimRegionsCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -5,6 +5,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -16,26 +17,32 @@ var PostDcimSiteGroupsCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of site group objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.site_groups_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.site_groups_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimSiteGroupsCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimSiteGroupsCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimSiteGroupsCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimSiteGroupsCmd", err)
 	}
 
-	PostDcimSiteGroupsCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimSiteGroupsCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimSiteGroupsCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimSiteGroupsCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimSiteGroupsCmd)
+	api.AddCustomFieldFlag(PostDcimSiteGroupsCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimSiteGroupsCmd.PersistentFlags().String("foo", "", "A help for foo")
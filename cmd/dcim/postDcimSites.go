@@ -6,6 +6,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -17,26 +18,32 @@ var PostDcimSitesCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of site objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.sites_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.sites_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimSitesCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimSitesCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimSitesCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimSitesCmd", err)
 	}
 
-	PostDcimSitesCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimSitesCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimSitesCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimSitesCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimSitesCmd)
+	api.AddCustomFieldFlag(PostDcimSitesCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimSitesCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -6,6 +6,7 @@ Copyright © 2024 Derrick Cassidy.
 package dcim
 
 import (
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -17,26 +18,32 @@ var PostDcimVirtualChassisCmd = &cobra.Command{
 	Long: `
 ABC Netbox Automation Tools:
   POST a list of virtual chassis objects.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		apiConnectionPost("cmd.dcim.dcim_api_url.virtual_chassis_id")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPost(cmd, "cmd.dcim.dcim_api_url.virtual_chassis_id"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
-	PostDcimVirtualChassisCmd.Flags().StringVarP(&serverEnv, "env", "", "development", "Environment ('development' or 'production')")
+	PostDcimVirtualChassisCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
 	err := PostDcimVirtualChassisCmd.MarkFlagRequired("env")
 	if err != nil {
 		log.Fatalf("Error marking env flag as required: %s - for PostDcimVirtualChassisCmd", err)
 	}
 
-	PostDcimVirtualChassisCmd.Flags().StringVarP(&data, "data", "", "", "JSON data to be patched (required)")
+	PostDcimVirtualChassisCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
 	err = PostDcimVirtualChassisCmd.MarkFlagRequired("data")
 	if err != nil {
 		log.Fatalf("Error marking data flag as required: %s - for PostDcimVirtualChassisCmd", err)
 	}
 
+	api.AddDryRunFlag(PostDcimVirtualChassisCmd)
+	api.AddCustomFieldFlag(PostDcimVirtualChassisCmd)
+
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// postDcimVirtualChassisCmd.PersistentFlags().String("foo", "", "A help for foo")
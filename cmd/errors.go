@@ -0,0 +1,133 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// runError is an error returned by the RunE of a command, as opposed to an error in its flags or
+// arguments, which cobra reports before the command runs.
+type runError struct {
+	err error
+}
+
+func (e *runError) Error() string {
+	return e.err.Error()
+}
+
+func (e *runError) Unwrap() error {
+	return e.err
+}
+
+// exitError is an error of a command that ends the process with an exit status other than 1.
+// Without err the command has reported the error itself.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// commandTreeOnce guards the walks executeRoot makes over the finished command tree: markRunErrors
+// must wrap each RunE only once.
+var commandTreeOnce sync.Once
+
+// markRunErrors wraps the RunE of c and every command below it, so that reportError can tell the
+// errors of a command that ran from the errors in its command line.
+func markRunErrors(c *cobra.Command) {
+	if run := c.RunE; run != nil {
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			err := run(cmd, args)
+			var marked *runError
+			if err == nil || errors.As(err, &marked) {
+				return err
+			}
+			return &runError{err: err}
+		}
+	}
+	for _, child := range c.Commands() {
+		markRunErrors(child)
+	}
+}
+
+// runRoot runs the command line set on rootCmd. A command stopped by --timeout or a signal ends
+// with a *session.Stopped, whatever its RunE returned.
+func runRoot() (*cobra.Command, error) {
+	cmd, err := rootCmd.ExecuteC()
+	if stopped := session.EndCommand(); stopped != nil {
+		return cmd, &runError{err: stopped}
+	}
+	return cmd, err
+}
+
+// exitCode returns the exit status of a process whose command returned err.
+func exitCode(err error) int {
+	var stopped *session.Stopped
+	if errors.As(err, &stopped) {
+		return stoppedExitCode(stopped)
+	}
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return 1
+}
+
+// reportError prints the error cmd returned. Errors in the command line are printed like cobra
+// prints them, followed by the usage of cmd; the errors of a command that ran are printed in red.
+// A stopped command is reported with the changes it made. Commands run by the shell report their
+// errors the same way and the shell carries on.
+func reportError(cmd *cobra.Command, err error) {
+	var stopped *session.Stopped
+	if errors.As(err, &stopped) {
+		reportStopped(stopped)
+		return
+	}
+	var exit *exitError
+	if errors.As(err, &exit) && exit.err == nil {
+		return
+	}
+	var failed *runError
+	if !errors.As(err, &failed) {
+		cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+		cmd.PrintErrln(cmd.UsageString())
+		return
+	}
+	_, _ = fmt.Fprintln(color.Error, color.RedString("  Error: %s", err))
+}
@@ -0,0 +1,307 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var fanOutProfiles []string
+
+var fanOutAll bool
+
+var fanOutOutput string
+
+// fanOutFlags are the flags of a read command that --profiles turns into Netbox filters.
+var fanOutFlags = map[string]string{"name": "name", "slug": "slug", "serial": "serial", "query": "q"}
+
+// fanOutSummary are the fields shown, when present, for each object in the table of --profiles.
+var fanOutSummary = []string{"id", "display", "serial", "status", "site", "role", "device_type", "tenant"}
+
+// addFanOutFlags adds --profiles and --all-profiles to a read command of the noun-verb grammar.
+func addFanOutFlags(c *cobra.Command) {
+	c.Flags().StringSliceVar(&fanOutProfiles, "profiles", nil, "Run against several Netbox instances at once, e.g. production,emea (see cmd.profiles in netbox_config.yaml)")
+	c.Flags().BoolVar(&fanOutAll, "all-profiles", false, "Run against every Netbox instance in netbox_config.yaml")
+	c.Flags().StringVarP(&fanOutOutput, "output", "o", "", "Output format of --profiles ("+strings.Join(output.Formats, ", ")+"), default table")
+	c.MarkFlagsMutuallyExclusive("profiles", "all-profiles")
+	if c.Flags().Lookup("env") != nil {
+		c.MarkFlagsMutuallyExclusive("env", "profiles", "all-profiles")
+	}
+	_ = c.RegisterFlagCompletionFunc("profiles", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		profiles, _ := session.Profiles()
+		return profiles, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = c.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// fanOutRequested reports whether --profiles or --all-profiles was given to c.
+func fanOutRequested(c *cobra.Command) bool {
+	return c.Flags().Changed("profiles") || c.Flags().Changed("all-profiles")
+}
+
+// profileResult is what one Netbox instance returned for a fan-out query.
+type profileResult struct {
+	profile string
+	objects []interface{}
+	err     error
+}
+
+// fanOut runs a read command against several Netbox instances concurrently and prints the
+// objects they return together, each with the profile it came from. The instances that failed
+// are listed on stderr; the command exits with 3 when some failed and 1 when all did.
+func fanOut(c *cobra.Command, endpointKey string, args []string) error {
+	profiles := fanOutProfiles
+	if fanOutAll {
+		var err error
+		if profiles, err = session.Profiles(); err != nil {
+			return err
+		}
+	}
+	if len(profiles) == 0 {
+		return fmt.Errorf("no profiles given")
+	}
+	config, err := session.Config()
+	if err != nil {
+		return err
+	}
+	path := strings.SplitN(config.GetString(endpointKey), "?", 2)[0]
+	if path == "" {
+		return fmt.Errorf("%s has no API endpoint in netbox_config.yaml", c.CommandPath())
+	}
+	objectID, filters, err := fanOutQuery(c, endpointKey, args)
+	if err != nil {
+		return err
+	}
+	if c.Name() == "get" && objectID == "" && len(filters) == 0 {
+		return fmt.Errorf("%s needs an object: an ID, a name or one of --id, --name, --slug, --serial, --lookup", c.CommandPath())
+	}
+	format := fanOutOutput
+	if format == "" {
+		format = output.Table
+	}
+
+	results := make([]profileResult, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func(i int, profile string) {
+			defer wg.Done()
+			objects, err := queryProfile(c.Context(), profile, path, objectID, filters)
+			results[i] = profileResult{profile: profile, objects: objects, err: err}
+		}(i, profile)
+	}
+	wg.Wait()
+
+	rows := []interface{}{}
+	var failed []profileResult
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, result)
+			continue
+		}
+		for _, object := range result.objects {
+			rows = append(rows, withProfile(result.profile, object, format == output.Table))
+		}
+	}
+	if len(rows) > 0 || len(failed) < len(results) {
+		if err := output.Render(os.Stdout, rows, format); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(color.Error, color.RedString("\n  Failed profiles:"))
+	for _, result := range failed {
+		_, _ = fmt.Fprintln(color.Error, color.CyanString("    %s: ", result.profile)+color.YellowString("%s", result.err))
+	}
+	// Exit status 3 tells scripts that the output is incomplete rather than missing.
+	code := 3
+	if len(failed) == len(results) {
+		code = 1
+	}
+	return &exitError{code: code, err: fmt.Errorf("%d of %d profiles failed", len(failed), len(results))}
+}
+
+// fanOutQuery returns the object ID or the filters selected by the flags set on c and the
+// object given as argument, which is matched against the name, slug or serial of the objects
+// of the endpoint with config key endpointKey.
+func fanOutQuery(c *cobra.Command, endpointKey string, args []string) (string, url.Values, error) {
+	objectID := ""
+	filters := url.Values{}
+	if len(args) == 1 {
+		if _, err := strconv.Atoi(args[0]); err == nil {
+			objectID = args[0]
+		} else if field, ok := api.NameField(endpointKey); ok {
+			filters.Set(field, args[0])
+		} else {
+			return "", nil, fmt.Errorf("%s cannot look up objects by name, use --id or --lookup", c.CommandPath())
+		}
+	}
+
+	var err error
+	c.Flags().Visit(func(f *pflag.Flag) {
+		switch {
+		case c.InheritedFlags().Lookup(f.Name) != nil:
+		case f.Name == "profiles" || f.Name == "all-profiles" || f.Name == "output":
+		case f.Name == "id":
+			objectID = f.Value.String()
+		case f.Name == "lookup":
+			for _, pair := range strings.Split(f.Value.String(), ",") {
+				key, value, ok := strings.Cut(pair, "=")
+				if !ok {
+					err = fmt.Errorf("invalid lookup expression %q: expected key=value pairs", pair)
+					return
+				}
+				filters.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+			}
+		case fanOutFlags[f.Name] != "":
+			filters.Set(fanOutFlags[f.Name], f.Value.String())
+		case f.Name == "cf-filter":
+			pairs, _ := c.Flags().GetStringArray(f.Name)
+			cf, cfErr := customfields.Filters(pairs)
+			if cfErr != nil {
+				err = cfErr
+				return
+			}
+			for key, values := range cf {
+				filters[key] = append(filters[key], values...)
+			}
+		default:
+			err = fmt.Errorf("--%s cannot be used with --profiles", f.Name)
+		}
+	})
+	return objectID, filters, err
+}
+
+// queryProfile returns the objects the instance of a profile holds at path: the object with ID
+// objectID, if given, or every page of the objects matching filters.
+func queryProfile(ctx context.Context, profile string, path string, objectID string, filters url.Values) ([]interface{}, error) {
+	rootURL, err := session.RootURL(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return nil, fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+
+	if objectID != "" {
+		object, status, err := getJSON(ctx, profile, rootURL+path+objectID+"/")
+		if status == 404 {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{object}, nil
+	}
+	return listObjects(ctx, profile, rootURL+path, filters)
+}
+
+// getJSON requests url from the instance of a profile and decodes the response.
+func getJSON(ctx context.Context, profile string, url string) (interface{}, int, error) {
+	resp, err := session.Client().R().
+		SetContext(ctx).
+		SetHeaders(map[string]string{
+			"Authorization": session.ProfileToken(profile),
+			"Accept":        "application/json",
+		}).
+		Get(url)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, resp.StatusCode(), fmt.Errorf("%s returned %s", url, resp.Status())
+	}
+	v, err := output.Decode(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, resp.StatusCode(), fmt.Errorf("error while parsing the response bytes: %s", err)
+	}
+	return v, resp.StatusCode(), nil
+}
+
+// withProfile returns object with the profile it came from as first field. For a table, the
+// object is cut down to the fanOutSummary fields and its custom fields, shown as cf.<name>
+// columns, with related objects shown by name.
+func withProfile(profile string, object interface{}, summary bool) *output.Object {
+	row := &output.Object{Keys: []string{"profile"}, Values: map[string]interface{}{"profile": profile}}
+	o, ok := object.(*output.Object)
+	if !ok {
+		return row
+	}
+	keys := o.Keys
+	if summary {
+		keys = fanOutSummary
+	}
+	for _, key := range keys {
+		value, ok := o.Values[key]
+		if !ok || key == "profile" {
+			continue
+		}
+		if summary {
+			value = relatedName(value)
+		}
+		row.Keys = append(row.Keys, key)
+		row.Values[key] = value
+	}
+	if cf, ok := o.Values["custom_fields"].(*output.Object); ok && summary && len(cf.Keys) > 0 {
+		fields := &output.Object{Values: map[string]interface{}{}}
+		for _, name := range customfields.Names(cf.Values) {
+			fields.Keys = append(fields.Keys, name)
+			fields.Values[name] = relatedName(cf.Values[name])
+		}
+		row.Keys = append(row.Keys, "cf")
+		row.Values["cf"] = fields
+	}
+	return row
+}
+
+// relatedName returns the name of value if it is a related object, and value otherwise.
+func relatedName(value interface{}) interface{} {
+	related, ok := value.(*output.Object)
+	if !ok {
+		return value
+	}
+	for _, name := range []string{"name", "display", "label", "model"} {
+		if v, ok := related.Values[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestFanOut(t *testing.T) {
+	const emeaToken = "Token e3ea0000000000000000000000000000000000e3"
+	fixtures := netboxtest.DefaultFixtures()
+	emeaFixtures := netboxtest.Fixtures{
+		"/api/dcim/devices/": {Results: []map[string]interface{}{
+			{"id": 7, "url": netboxtest.BaseURL + "/api/dcim/devices/7/", "display": "lon1-leaf1", "name": "lon1-leaf1", "serial": "JPE0002",
+				"status": map[string]interface{}{"value": "active", "label": "Active"}, "site": map[string]interface{}{"id": 9, "name": "LON1", "slug": "lon1"}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		profiles map[string]string
+		args     []string
+	}{
+		{
+			name: "serial_table",
+			args: []string{"dcim", "devices", "get", "--serial", "JPE0002", "--all-profiles"},
+		},
+		{
+			name: "list_json",
+			args: []string{"dcim", "sites", "list", "--profiles", "production,emea", "-o", "json"},
+		},
+		{
+			name: "get_by_id",
+			args: []string{"dcim", "devices", "get", "7", "--profiles", "production,emea"},
+		},
+		{
+			name:     "partial_failure",
+			profiles: map[string]string{"cmd.profiles.apac.root_url": "http://127.0.0.1:1"},
+			args:     []string{"dcim", "devices", "find", "--query", "leaf", "--all-profiles"},
+		},
+		{
+			// Cables have no name, so a name would select every cable of every profile.
+			name: "cable_by_name",
+			args: []string{"dcim", "cables", "get", "nyc1-leaf1", "--profiles", "production,emea"},
+		},
+		{
+			name: "unknown_profile",
+			args: []string{"dcim", "devices", "list", "--profiles", "nowhere"},
+		},
+		{
+			name: "env_and_profiles",
+			args: []string{"dcim", "devices", "list", "--profiles", "emea", "--env", "production"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, fixtures)
+			emea := netboxtest.NewServer(t, emeaFixtures)
+			config := map[string]string{
+				"cmd.profiles.emea.root_url":  emea.URL,
+				"cmd.profiles.emea.token_key": emeaToken,
+			}
+			for key, value := range tt.profiles {
+				config[key] = value
+			}
+			result := run(t, srv, netboxtest.Options{Config: config}, tt.args...)
+			netboxtest.Golden(t, "fanout/"+tt.name, emea.Normalize(result.String()))
+
+			for _, r := range emea.Requests() {
+				if r.Path != "/" && r.Token != emeaToken {
+					t.Errorf("%s %s sent to emea with %q", r.Method, r.Path, r.Token)
+				}
+			}
+		})
+	}
+}
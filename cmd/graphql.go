@@ -0,0 +1,309 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var graphqlEnv string
+
+var graphqlFile string
+
+var graphqlVars []string
+
+var graphqlVariables string
+
+var graphqlOutput string
+
+var graphqlList bool
+
+// GraphqlCmd represents the graphql command
+var GraphqlCmd = &cobra.Command{
+	Use:   "graphql [query | name]",
+	Short: "Run a GraphQL query against Netbox",
+	Long: `
+ABC Netbox Automation Tools:
+  Run a GraphQL query against the Netbox /graphql/ endpoint and print the data it returns.
+
+  The query is given as the argument, read from --file, or read from standard input when the
+  argument is "-". An argument that is not a query names a saved query: the file <name>.graphql
+  in ~/.abc-netbox.cli/graphql/, or in the directory set with cmd.graphql_query_dir in
+  netbox_config.yaml. --list shows the saved queries.
+
+  Variables are set with --var name=value, where the value is read as JSON when it parses as
+  JSON and as a string otherwise, or all at once with --variables '{"name": "value"}'.
+
+  Examples:
+    abc-netbox.cli graphql '{ site_list { name status } }' --env production -o table
+    abc-netbox.cli graphql device-by-name --var name=core1 --env production`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names, _ := namedQueries()
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if graphqlList {
+			return listNamedQueries()
+		}
+
+		query, err := graphqlQuery(args)
+		if err != nil {
+			return fmt.Errorf("reading query: %s", err)
+		}
+		variables, err := graphqlVariablesFromFlags(graphqlVariables, graphqlVars)
+		if err != nil {
+			return fmt.Errorf("reading variables: %s", err)
+		}
+		if graphqlOutput == "" {
+			graphqlOutput = output.Default()
+		}
+
+		rootURL, err := session.RootURL(graphqlEnv)
+		if err != nil {
+			return fmt.Errorf("unrecognized environment: %s", graphqlEnv)
+		}
+		if err := session.CheckSSL(rootURL); err != nil {
+			return fmt.Errorf("checking SSL certificate: %s", err)
+		}
+
+		data, errs, err := runGraphqlQuery(cmd.Context(), rootURL+graphqlPath(), query, variables)
+		if err != nil {
+			return fmt.Errorf("running query: %s", err)
+		}
+		if data != nil {
+			if err := output.Render(os.Stdout, data, graphqlOutput); err != nil {
+				return fmt.Errorf("printing result: %s", err)
+			}
+		}
+		if len(errs) > 0 {
+			for _, e := range errs {
+				_, _ = fmt.Fprintln(os.Stderr, color.RedString("GraphQL error: %s", e))
+			}
+			return errors.New("the query returned GraphQL errors")
+		}
+		return nil
+	},
+}
+
+// graphqlError is an entry of the errors list of a GraphQL response.
+type graphqlError struct {
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations"`
+}
+
+func (e graphqlError) String() string {
+	s := e.Message
+	if len(e.Path) > 0 {
+		parts := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			parts[i] = fmt.Sprint(p)
+		}
+		s += " (at " + strings.Join(parts, ".") + ")"
+	} else if len(e.Locations) > 0 {
+		s += fmt.Sprintf(" (line %d, column %d)", e.Locations[0].Line, e.Locations[0].Column)
+	}
+	return s
+}
+
+// runGraphqlQuery posts query to url and returns the data and errors of the response.
+func runGraphqlQuery(ctx context.Context, url string, query string, variables map[string]interface{}) (interface{}, []graphqlError, error) {
+	body := map[string]interface{}{"query": query}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+	resp, err := session.Client().R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Accept", "application/json").
+		SetHeader("Authorization", session.Token()).
+		SetBody(body).
+		Post(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode() != 200 && resp.StatusCode() != 400 {
+		return nil, nil, fmt.Errorf("%s: %s", resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+
+	decoded, err := output.Decode(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+	result, ok := decoded.(*output.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected response: %s", strings.TrimSpace(string(resp.Body())))
+	}
+
+	var errs []graphqlError
+	if raw := result.Get("errors"); raw != nil {
+		b, _ := json.Marshal(raw)
+		if err := json.Unmarshal(b, &errs); err != nil {
+			return nil, nil, fmt.Errorf("unexpected errors in response: %s", b)
+		}
+	}
+	if len(errs) == 0 && resp.StatusCode() != 200 {
+		return nil, nil, fmt.Errorf("%s: %s", resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+	return result.Get("data"), errs, nil
+}
+
+// graphqlPath returns the path of the GraphQL endpoint, cmd.graphql_url or /graphql/.
+func graphqlPath() string {
+	if config, err := session.Config(); err == nil && config.GetString("cmd.graphql_url") != "" {
+		return config.GetString("cmd.graphql_url")
+	}
+	return "/graphql/"
+}
+
+// graphqlQuery returns the query given by --file or the argument.
+func graphqlQuery(args []string) (string, error) {
+	if graphqlFile != "" {
+		if len(args) > 0 {
+			return "", fmt.Errorf("give the query as an argument or with --file, not both")
+		}
+		b, err := os.ReadFile(graphqlFile)
+		return string(b), err
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("no query given")
+	}
+
+	arg := strings.TrimSpace(args[0])
+	switch {
+	case arg == "-":
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	case strings.ContainsAny(arg, "{ \n"):
+		return arg, nil
+	}
+	path := filepath.Join(graphqlQueryDir(), arg+".graphql")
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("no saved query named %q in %s", arg, graphqlQueryDir())
+	}
+	return string(b), err
+}
+
+// graphqlVariablesFromFlags merges the --variables object with the --var assignments.
+func graphqlVariablesFromFlags(object string, assignments []string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	if object != "" {
+		if err := json.Unmarshal([]byte(object), &variables); err != nil {
+			return nil, fmt.Errorf("--variables is not a JSON object: %s", err)
+		}
+	}
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("--var %q is not name=value", assignment)
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			v = value
+		}
+		variables[name] = v
+	}
+	return variables, nil
+}
+
+// graphqlQueryDir returns the directory of the saved queries.
+func graphqlQueryDir() string {
+	if config, err := session.Config(); err == nil && config.GetString("cmd.graphql_query_dir") != "" {
+		return config.GetString("cmd.graphql_query_dir")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".abc-netbox.cli", "graphql")
+	}
+	return filepath.Join(home, ".abc-netbox.cli", "graphql")
+}
+
+// namedQueries returns the names of the saved queries.
+func namedQueries() ([]string, error) {
+	entries, err := os.ReadDir(graphqlQueryDir())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".graphql") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".graphql"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func listNamedQueries() error {
+	names, err := namedQueries()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading saved queries: %s", err)
+	}
+	if len(names) == 0 {
+		color.Cyan("  No saved queries in %s", graphqlQueryDir())
+		return nil
+	}
+	color.Cyan("  Saved queries in %s:", graphqlQueryDir())
+	for _, name := range names {
+		color.Yellow("    %s", name)
+	}
+	return nil
+}
+
+func init() {
+	GraphqlCmd.Flags().StringVarP(&graphqlEnv, "env", "", "development", "Environment ('development' or 'production')")
+	err := GraphqlCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for GraphqlCmd", err)
+	}
+	GraphqlCmd.Flags().StringVarP(&graphqlFile, "file", "f", "", "Read the query from a file")
+	GraphqlCmd.Flags().StringArrayVarP(&graphqlVars, "var", "", nil, "Set a query variable (name=value, the value is read as JSON when it parses)")
+	GraphqlCmd.Flags().StringVarP(&graphqlVariables, "variables", "", "", "Set the query variables from a JSON object")
+	GraphqlCmd.Flags().StringVarP(&graphqlOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	GraphqlCmd.Flags().BoolVarP(&graphqlList, "list", "", false, "List the saved queries")
+	GraphqlCmd.MarkFlagsMutuallyExclusive("list", "file")
+	_ = GraphqlCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
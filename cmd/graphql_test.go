@@ -0,0 +1,125 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestGraphql(t *testing.T) {
+	queries, err := filepath.Abs(filepath.Join("testdata", "graphql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryDir := map[string]string{"cmd.graphql_query_dir": queries}
+
+	tests := []struct {
+		name string
+		opts netboxtest.Options
+		args []string
+	}{
+		{
+			name: "inline_text",
+			args: []string{"graphql", "{ site_list { name slug status } }", "--env", "development"},
+		},
+		{
+			name: "inline_table",
+			args: []string{"graphql", "{ device_list { id name site { name } status } }", "--env", "development", "-o", "table"},
+		},
+		{
+			name: "inline_json",
+			args: []string{"graphql", "query($id: ID!) { device(id: $id) { id name serial } }", "--var", "id=2", "--env", "development", "-o", "json"},
+		},
+		{
+			name: "named_query",
+			opts: netboxtest.Options{Config: queryDir},
+			args: []string{"graphql", "devices-by-site", "--var", "site=nyc1", "--env", "development", "-o", "yaml"},
+		},
+		{
+			name: "named_query_missing",
+			opts: netboxtest.Options{Config: queryDir},
+			args: []string{"graphql", "nope", "--env", "development"},
+		},
+		{
+			name: "list",
+			opts: netboxtest.Options{Config: queryDir},
+			args: []string{"graphql", "--list", "--env", "development"},
+		},
+		{
+			name: "stdin",
+			opts: netboxtest.Options{Stdin: "{ provider_list { name } }"},
+			args: []string{"graphql", "-", "--env", "development"},
+		},
+		{
+			name: "query_error",
+			args: []string{"graphql", "{ gadget_list { name } }", "--env", "development"},
+		},
+		{
+			name: "object_not_found",
+			args: []string{"graphql", "{ device(id: 99) { name } }", "--env", "development"},
+		},
+		{
+			name: "syntax_error",
+			args: []string{"graphql", "{ device_list { name }", "--env", "development"},
+		},
+		{
+			name: "bad_variable",
+			args: []string{"graphql", "{ site_list { name } }", "--var", "site", "--env", "development"},
+		},
+	}
+
+	fixtures := netboxtest.DefaultFixtures()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, fixtures)
+			result := run(t, srv, tt.opts, tt.args...)
+			netboxtest.Golden(t, "graphql/"+tt.name, strings.ReplaceAll(result.String(), queries, "testdata/graphql"))
+		})
+	}
+}
+
+func TestGraphqlVariablesFromFlags(t *testing.T) {
+	variables, err := graphqlVariablesFromFlags(`{"site": "nyc1", "limit": 5}`, []string{"limit=10", "name=core1", "tags=[\"a\",\"b\"]", "empty="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"site": "nyc1", "limit": "10", "name": "core1", "tags": "[a b]", "empty": ""}
+	for name, value := range want {
+		if got := fmt.Sprint(variables[name]); got != value {
+			t.Errorf("%s = %s, want %s", name, got, value)
+		}
+	}
+	if _, err := graphqlVariablesFromFlags("[1]", nil); err == nil {
+		t.Errorf("--variables accepted a list")
+	}
+	if _, err := graphqlVariablesFromFlags("", []string{"=1"}); err == nil {
+		t.Errorf("--var accepted an empty name")
+	}
+}
@@ -0,0 +1,534 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/refs"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// templateKinds are the component lists of a devicetype-library file and the template
+// resources they are created in, in the order they are created: power outlets refer to power
+// ports and front ports to rear ports by name.
+var templateKinds = []struct{ key, resource string }{
+	{"console-ports", "console-port-templates"},
+	{"console-server-ports", "console-server-port-templates"},
+	{"power-ports", "power-port-templates"},
+	{"power-outlets", "power-outlet-templates"},
+	{"interfaces", "interface-templates"},
+	{"rear-ports", "rear-port-templates"},
+	{"front-ports", "front-port-templates"},
+	{"module-bays", "module-bay-templates"},
+	{"device-bays", "device-bay-templates"},
+	{"inventory-items", "inventory-item-templates"},
+}
+
+// templateReferences are the template fields naming another template of the same type, by the
+// resource of the templates they name.
+var templateReferences = map[string]string{
+	"power_port": "power-port-templates",
+	"rear_port":  "rear-port-templates",
+}
+
+// elevationImageExtensions are the extensions elevation images are looked up with, in order.
+var elevationImageExtensions = []string{".png", ".jpg", ".jpeg", ".svg", ".gif", ".webp"}
+
+var importEnv string
+
+var importImages string
+
+var importDryRun bool
+
+// ImportCmd represents the import command
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import objects from community libraries",
+	Long: `
+ABC Netbox Automation Tools:
+  Import objects into Netbox from the files of community libraries.`,
+}
+
+// ImportDeviceTypesCmd represents the import device-types command
+var ImportDeviceTypesCmd = &cobra.Command{
+	Use:   "device-types <file|dir>...",
+	Short: "Import device and module types from devicetype-library YAML files",
+	Long: `
+ABC Netbox Automation Tools:
+  Import device types and module types in the YAML format of the netbox-community
+  devicetype-library, with their manufacturer, component templates and elevation images:
+
+    abc-netbox.cli import device-types devicetype-library/device-types/Arista --env production
+    abc-netbox.cli import device-types QFX5120-48Y.yaml QFX-EM-4Q.yaml --env production --dry-run
+
+  Directories are searched for .yaml and .yml files. A file with a slug is a device type, one
+  without a module type. Manufacturers, types and templates that already exist, by name, model
+  and template name, are left as they are and reported, so an import may be run again after
+  the library changed. Front and rear images are uploaded for device types that set front_image
+  or rear_image and have none yet; they are looked up as <manufacturer>/<slug>.front.png (or
+  .rear.png, .jpg, .svg ...) in --images, by default the elevation-images directory next to
+  the device-types directory of the file.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runImportDeviceTypes(cmd.Context(), args); err != nil {
+			return fmt.Errorf("importing device types: %s", err)
+		}
+		return nil
+	},
+}
+
+// importCounts counts the objects an import created and found existing.
+type importCounts struct {
+	created int
+	existed int
+}
+
+func (c *importCounts) add(other importCounts) {
+	c.created += other.created
+	c.existed += other.existed
+}
+
+// typeImporter imports devicetype-library files into the Netbox server at rootURL.
+type typeImporter struct {
+	ctx       context.Context
+	rootURL   string
+	endpoints map[string]*objectEndpoint
+	// manufacturers caches the IDs of manufacturers by name, 0 for one a dry run would create.
+	manufacturers map[string]int64
+	counts        importCounts
+}
+
+func runImportDeviceTypes(ctx context.Context, paths []string) error {
+	files, err := libraryFiles(paths)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no .yaml files in %s", strings.Join(paths, ", "))
+	}
+	rootURL, err := session.RootURL(importEnv)
+	if err != nil {
+		return fmt.Errorf("unrecognized environment: %s", importEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+
+	im := &typeImporter{ctx: ctx, rootURL: rootURL, endpoints: map[string]*objectEndpoint{}, manufacturers: map[string]int64{}}
+	for _, resource := range []string{"manufacturers", "device-types", "module-types"} {
+		if im.endpoints[resource], err = lookupEndpoint("dcim", resource); err != nil {
+			return err
+		}
+	}
+	for _, kind := range templateKinds {
+		if im.endpoints[kind.resource], err = lookupEndpoint("dcim", kind.resource); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, file := range files {
+		if err := im.importFile(file); err != nil {
+			color.Red("    Error importing %s: %s", file, err)
+			failed++
+		}
+	}
+
+	verb, verbExisted := "created", "already existed"
+	if importDryRun {
+		verb, verbExisted = "would be created", "exist"
+	}
+	fmt.Println()
+	color.Green("  Imported %d of %d files: %d objects %s, %d %s.", len(files)-failed, len(files), im.counts.created, verb, im.counts.existed, verbExisted)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files not imported", failed, len(files))
+	}
+	return nil
+}
+
+// libraryFiles returns the YAML files given, and those found in the directories given, sorted.
+func libraryFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := filepath.Ext(p); !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				found = append(found, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// importFile imports the device type or module type of one library file.
+func (im *typeImporter) importFile(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("not a devicetype-library file: %s", err)
+	}
+	manufacturer, _ := doc["manufacturer"].(string)
+	model, _ := doc["model"].(string)
+	if manufacturer == "" || model == "" {
+		return fmt.Errorf("manufacturer or model missing")
+	}
+	resource, typeField := "device-types", "device_type"
+	if _, ok := doc["slug"]; !ok {
+		resource, typeField = "module-types", "module_type"
+	}
+	color.Cyan("\n  %s %s "+color.YellowString("(%s)", file), manufacturer, model)
+
+	manufacturerID, err := im.manufacturer(manufacturer)
+	if err != nil {
+		return err
+	}
+	typeID, typeObject, err := im.importType(resource, doc, manufacturerID)
+	if err != nil {
+		return err
+	}
+	for _, kind := range templateKinds {
+		components, ok := doc[kind.key].([]interface{})
+		if !ok || len(components) == 0 {
+			continue
+		}
+		if err := im.importTemplates(kind.resource, typeField, typeID, components); err != nil {
+			return fmt.Errorf("%s: %s", kind.resource, err)
+		}
+	}
+	if resource == "device-types" {
+		for _, face := range []string{"front", "rear"} {
+			if doc[face+"_image"] != true {
+				continue
+			}
+			if err := im.importImage(file, manufacturer, doc["slug"], face, typeID, typeObject); err != nil {
+				return fmt.Errorf("%s image: %s", face, err)
+			}
+		}
+	}
+	return nil
+}
+
+// manufacturer returns the ID of the manufacturer named name, creating it when there is none
+// by that name or slug.
+func (im *typeImporter) manufacturer(name string) (int64, error) {
+	if id, ok := im.manufacturers[name]; ok {
+		return id, nil
+	}
+	endpoint := im.endpoints["manufacturers"]
+	existing, err := im.find(endpoint, url.Values{"name": {name}})
+	if err == nil && existing == nil {
+		existing, err = im.find(endpoint, url.Values{"slug": {refs.Slugify(name)}})
+	}
+	if err != nil {
+		return 0, err
+	}
+	var id int64
+	if existing != nil {
+		id, _ = toInt64(existing.Get("id"))
+		im.report(false, "manufacturer %s", name)
+	} else {
+		if id, _, err = im.create(endpoint, map[string]interface{}{"name": name, "slug": refs.Slugify(name)}); err != nil {
+			return 0, err
+		}
+		im.report(true, "manufacturer %s", name)
+	}
+	im.manufacturers[name] = id
+	return id, nil
+}
+
+// importType returns the ID and object of the device type or module type of doc, creating it
+// when the manufacturer has no type of that model. The ID is 0 for a type a dry run would create.
+func (im *typeImporter) importType(resource string, doc map[string]interface{}, manufacturerID int64) (int64, *output.Object, error) {
+	endpoint := im.endpoints[resource]
+	noun := strings.TrimSuffix(strings.ReplaceAll(resource, "-", " "), "s")
+	model := doc["model"].(string)
+	if manufacturerID != 0 {
+		existing, err := im.find(endpoint, url.Values{"manufacturer_id": {fmt.Sprint(manufacturerID)}, "model": {model}})
+		if err != nil {
+			return 0, nil, err
+		}
+		if existing != nil {
+			id, _ := toInt64(existing.Get("id"))
+			im.report(false, "%s %s", noun, model)
+			return id, existing, nil
+		}
+	}
+
+	body := map[string]interface{}{"manufacturer": manufacturerID}
+	for key, value := range doc {
+		switch {
+		case key == "manufacturer", key == "front_image", key == "rear_image", isTemplateKind(key):
+		case key == "default_platform":
+			body[key] = map[string]interface{}{"slug": value}
+		default:
+			body[key] = value
+		}
+	}
+	id, created, err := im.create(endpoint, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	im.report(true, "%s %s", noun, model)
+	return id, created, nil
+}
+
+func isTemplateKind(key string) bool {
+	for _, kind := range templateKinds {
+		if kind.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// importTemplates creates the component templates of a type that it has none of by name.
+func (im *typeImporter) importTemplates(resource string, typeField string, typeID int64, components []interface{}) error {
+	endpoint := im.endpoints[resource]
+	existing := map[string]bool{}
+	if typeID != 0 {
+		names, err := im.templateIDs(resource, typeField, typeID)
+		if err != nil {
+			return err
+		}
+		for name := range names {
+			existing[name] = true
+		}
+	}
+
+	// The IDs of the templates the components refer to, looked up when first needed.
+	references := map[string]map[string]int64{}
+	var missing []interface{}
+	existed := 0
+	for i, item := range components {
+		component, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("item %d is not a mapping", i)
+		}
+		name, _ := component["name"].(string)
+		if existing[name] {
+			existed++
+			continue
+		}
+		body := map[string]interface{}{typeField: typeID}
+		for key, value := range component {
+			body[key] = value
+		}
+		for field, referenced := range templateReferences {
+			ref, ok := component[field].(string)
+			if !ok || typeID == 0 {
+				continue
+			}
+			if references[referenced] == nil {
+				ids, err := im.templateIDs(referenced, typeField, typeID)
+				if err != nil {
+					return err
+				}
+				references[referenced] = ids
+			}
+			id, ok := references[referenced][ref]
+			if !ok && !importDryRun {
+				return fmt.Errorf("%s %q of %s refers to no %s", field, ref, name, strings.TrimSuffix(referenced, "s"))
+			}
+			body[field] = id
+		}
+		missing = append(missing, body)
+	}
+
+	im.counts.existed += existed
+	im.counts.created += len(missing)
+	verb := "created"
+	if importDryRun {
+		verb = "to create"
+	}
+	fmt.Printf("    %s %d %s, %d existed\n", color.YellowString("%s:", strings.ReplaceAll(resource, "-", " ")), len(missing), verb, existed)
+	if len(missing) == 0 || importDryRun {
+		return nil
+	}
+	_, err := sendJSON(im.ctx, importEnv, "POST", im.rootURL+endpoint.path, missing)
+	return err
+}
+
+// templateIDs returns the IDs of the templates of resource that belong to a type, by name.
+func (im *typeImporter) templateIDs(resource string, typeField string, typeID int64) (map[string]int64, error) {
+	found, err := listObjects(im.ctx, importEnv, im.rootURL+im.endpoints[resource].path, url.Values{typeField + "_id": {fmt.Sprint(typeID)}})
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]int64{}
+	for _, item := range found {
+		if o, ok := item.(*output.Object); ok {
+			name, _ := o.Get("name").(string)
+			ids[name], _ = toInt64(o.Get("id"))
+		}
+	}
+	return ids, nil
+}
+
+// importImage uploads the front or rear image of a device type that has none.
+func (im *typeImporter) importImage(file string, manufacturer string, slug interface{}, face string, typeID int64, typeObject *output.Object) error {
+	if typeObject != nil {
+		if current, _ := typeObject.Get(face + "_image").(string); current != "" {
+			im.report(false, "%s image", face)
+			return nil
+		}
+	}
+	dir := importImages
+	if dir == "" {
+		dir = elevationImagesDir(file)
+	}
+	image := ""
+	for _, ext := range elevationImageExtensions {
+		candidate := filepath.Join(dir, manufacturer, fmt.Sprintf("%v.%s%s", slug, face, ext))
+		if _, err := os.Stat(candidate); err == nil {
+			image = candidate
+			break
+		}
+	}
+	if image == "" {
+		color.Yellow("    %s image: no %v.%s image in %s", face, slug, face, filepath.Join(dir, manufacturer))
+		return nil
+	}
+	if importDryRun || typeID == 0 {
+		im.report(true, "%s image %s", face, filepath.Base(image))
+		return nil
+	}
+
+	path := fmt.Sprintf("%s%s%d/", im.rootURL, im.endpoints["device-types"].path, typeID)
+	resp, err := session.Client().R().
+		SetContext(im.ctx).
+		SetHeaders(map[string]string{
+			"Authorization": session.ProfileToken(importEnv),
+			"Accept":        "application/json",
+		}).
+		SetFile(face+"_image", image).
+		Patch(path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("PATCH %s returned %s: %s", path, resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+	im.report(true, "%s image %s", face, filepath.Base(image))
+	return nil
+}
+
+// elevationImagesDir returns the elevation-images directory of the library a file is in: the
+// sibling of the device-types directory above it.
+func elevationImagesDir(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "elevation-images"
+	}
+	for dir := filepath.Dir(abs); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == "device-types" {
+			return filepath.Join(filepath.Dir(dir), "elevation-images")
+		}
+	}
+	return filepath.Join(filepath.Dir(abs), "elevation-images")
+}
+
+// find returns the only object of endpoint matching filters, nil if there is none.
+func (im *typeImporter) find(endpoint *objectEndpoint, filters url.Values) (*output.Object, error) {
+	found, err := listObjects(im.ctx, importEnv, im.rootURL+endpoint.path, filters)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("%d %s match %s", len(found), endpoint.resource, filters.Encode())
+	}
+	o, _ := found[0].(*output.Object)
+	return o, nil
+}
+
+// create creates an object at endpoint and returns its ID and the object, or 0 and nil on a
+// dry run.
+func (im *typeImporter) create(endpoint *objectEndpoint, body map[string]interface{}) (int64, *output.Object, error) {
+	if importDryRun {
+		return 0, nil, nil
+	}
+	created, err := sendJSON(im.ctx, importEnv, "POST", im.rootURL+endpoint.path, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	o, ok := created.(*output.Object)
+	if !ok {
+		return 0, nil, fmt.Errorf("unexpected response creating %s", endpoint.resource)
+	}
+	id, _ := toInt64(o.Get("id"))
+	return id, o, nil
+}
+
+// report prints that an object was created, or existed, and counts it.
+func (im *typeImporter) report(created bool, format string, args ...interface{}) {
+	what := fmt.Sprintf(format, args...)
+	switch {
+	case created && importDryRun:
+		im.counts.created++
+		fmt.Println("    " + color.YellowString("%s", what) + ": to create")
+	case created:
+		im.counts.created++
+		fmt.Println("    " + color.YellowString("%s", what) + ": " + color.GreenString("created"))
+	default:
+		im.counts.existed++
+		fmt.Println("    " + color.YellowString("%s", what) + ": existed")
+	}
+}
+
+func init() {
+	ImportDeviceTypesCmd.Flags().StringVarP(&importEnv, "env", "", "development", "Environment ('development' or 'production')")
+	_ = ImportDeviceTypesCmd.MarkFlagRequired("env")
+	ImportDeviceTypesCmd.Flags().StringVar(&importImages, "images", "", "Directory of the elevation images, by default elevation-images next to device-types")
+	ImportDeviceTypesCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Print what would be created instead of creating it")
+	ImportCmd.AddCommand(ImportDeviceTypesCmd)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestImportDeviceTypes(t *testing.T) {
+	// Commands run in a directory of their own, so files are given by absolute path and shown
+	// relative in the golden files.
+	library, err := filepath.Abs(filepath.Join("testdata", "devicetype-library"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := t.TempDir()
+	if err := os.WriteFile(filepath.Join(broken, "no-model.yaml"), []byte("manufacturer: Arista\nslug: arista-x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "not-yaml.yaml"), []byte("model: [unclosed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// names returns "parent name" for the objects of a collection created by the import.
+	names := func(srv *netboxtest.Server, path string, parent string) []string {
+		var got []string
+		for _, o := range srv.Objects(path) {
+			if id, ok := o[parent].(json.Number); ok {
+				got = append(got, id.String()+" "+o["name"].(string))
+			}
+		}
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		again bool
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name: "library",
+			args: []string{"import", "device-types", library, "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				if n := len(srv.Objects("/api/dcim/manufacturers/")); n != 4 {
+					t.Errorf("%d manufacturers, want 4", n)
+				}
+				types := srv.Objects("/api/dcim/device-types/")
+				if len(types) != 5 {
+					t.Fatalf("%d device types, want 5", len(types))
+				}
+				juniper := types[4]
+				if juniper["model"] != "QFX5120-48Y" || !strings.HasSuffix(fmt.Sprint(juniper["front_image"]), "/juniper-qfx5120-48y.front.png") || juniper["rear_image"] != nil {
+					t.Errorf("QFX5120-48Y: %v", juniper)
+				}
+				front, _ := json.Marshal(srv.Objects("/api/dcim/front-port-templates/")[1:])
+				if !strings.Contains(string(front), `"name":"F1","rear_port":2,"rear_port_position":1`) || !strings.Contains(string(front), `"name":"F2","rear_port":2,"rear_port_position":2`) {
+					t.Errorf("front port templates: %s", front)
+				}
+				outlets, _ := json.Marshal(srv.Objects("/api/dcim/power-outlet-templates/")[1:])
+				if !strings.Contains(string(outlets), `"power_port":2`) {
+					t.Errorf("power outlet templates: %s", outlets)
+				}
+				if got := strings.Join(names(srv, "/api/dcim/interface-templates/", "module_type"), ","); got != "2 et-0/1/0,2 et-0/1/1" {
+					t.Errorf("module interface templates: %s", got)
+				}
+			},
+		},
+		{
+			name:  "again",
+			args:  []string{"import", "device-types", library, "--env", "development"},
+			again: true,
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				if n := len(srv.Objects("/api/dcim/interface-templates/")); n != 9 {
+					t.Errorf("%d interface templates, want 9", n)
+				}
+			},
+		},
+		{
+			name: "dry_run",
+			args: []string{"import", "device-types", filepath.Join(library, "device-types", "Juniper", "QFX5120-48Y.yaml"), filepath.Join(library, "device-types", "Arista"), "--env", "development", "--dry-run"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				for _, r := range srv.Requests() {
+					if r.Method != "GET" {
+						t.Errorf("dry run sent %s %s", r.Method, r.Path)
+					}
+				}
+			},
+		},
+		{name: "broken", args: []string{"import", "device-types", broken, "--env", "development"}},
+		{name: "no_files", args: []string{"import", "device-types", filepath.Join(library, "elevation-images"), "--env", "development"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			if tt.again {
+				run(t, srv, netboxtest.Options{}, tt.args...)
+			}
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			out := strings.NewReplacer(library, "testdata/devicetype-library", broken, "<broken>").Replace(result.String())
+			netboxtest.Golden(t, "import/"+tt.name, out)
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
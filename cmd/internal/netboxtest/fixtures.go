@@ -0,0 +1,131 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package netboxtest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed fixtures
+var fixtureFiles embed.FS
+
+// Collection is the content of an API endpoint, loaded from fixtures/<domain>/<resource>.json.
+type Collection struct {
+	// Required lists the fields a POST must contain; objects missing one are rejected with 400.
+	Required []string `json:"required"`
+	// Protected lists the IDs of objects with dependents; deleting one is rejected with 409.
+	Protected []int                    `json:"protected"`
+	Results   []map[string]interface{} `json:"results"`
+	// Actions holds the responses of object actions such as /api/dcim/interfaces/5/trace/, by
+	// action and object ID.
+	Actions map[string]map[string]interface{} `json:"actions,omitempty"`
+	// generated marks a collection the server made up for an endpoint without a fixture. Its
+	// placeholder object tells nothing about the fields of the endpoint, so every filter applies.
+	generated bool
+}
+
+// Fixtures are the collections of a Server by API path, e.g. /api/dcim/devices/.
+type Fixtures map[string]*Collection
+
+// DefaultFixtures returns the fixtures shipped with this package.
+func DefaultFixtures() Fixtures {
+	sub, err := fs.Sub(fixtureFiles, "fixtures")
+	if err != nil {
+		panic(err)
+	}
+	fixtures, err := LoadFixtures(sub)
+	if err != nil {
+		panic(err)
+	}
+	return fixtures
+}
+
+// LoadFixtures reads every <domain>/<resource>.json file of fsys.
+func LoadFixtures(fsys fs.FS) (Fixtures, error) {
+	fixtures := Fixtures{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != ".json" {
+			return err
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		c := new(Collection)
+		decoder := json.NewDecoder(strings.NewReader(string(b)))
+		decoder.UseNumber()
+		if err := decoder.Decode(c); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		fixtures["/api/"+strings.TrimSuffix(name, ".json")+"/"] = c
+		return nil
+	})
+	return fixtures, err
+}
+
+// clone returns a deep copy, so that servers do not share changes.
+func (f Fixtures) clone() Fixtures {
+	out := Fixtures{}
+	for p, c := range f {
+		b, _ := json.Marshal(c)
+		copied := new(Collection)
+		decoder := json.NewDecoder(strings.NewReader(string(b)))
+		decoder.UseNumber()
+		_ = decoder.Decode(copied)
+		out[p] = copied
+	}
+	return out
+}
+
+func (c *Collection) index(id int) int {
+	for i, object := range c.Results {
+		if objectIDOf(object) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Collection) nextID() int {
+	next := 1
+	for _, object := range c.Results {
+		if id := objectIDOf(object); id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
+
+func (c *Collection) protected(id int) bool {
+	for _, p := range c.Protected {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
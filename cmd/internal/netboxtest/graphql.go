@@ -0,0 +1,462 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package netboxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// graphqlField is a field of a GraphQL selection set.
+type graphqlField struct {
+	Alias     string
+	Name      string
+	Arguments map[string]interface{}
+	Selection []graphqlField
+	Line      int
+	Column    int
+}
+
+// graphqlVariable is a reference to a query variable, resolved when arguments are evaluated.
+type graphqlVariable string
+
+// graphql answers POST /graphql/ with a subset of the Netbox GraphQL API: <type>_list fields with
+// optional filters, and <type> fields looked up by id, over the collections of the server.
+func (s *Server) graphql(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, detail(fmt.Sprintf("Method \"%s\" not allowed.", r.Method)))
+		return
+	}
+	var request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, detail("JSON parse error - "+err.Error()))
+		return
+	}
+
+	fields, err := parseGraphql(request.Query)
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": nil, "errors": []interface{}{err}})
+		return
+	}
+
+	// Unknown fields fail validation, and nothing is executed.
+	for _, field := range fields {
+		name := strings.TrimSuffix(field.Name, "_list")
+		if s.graphqlPath(name) == "" {
+			message := fmt.Sprintf("Cannot query field '%s' on type 'Query'.", field.Name)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": nil, "errors": []interface{}{graphqlError(message, field.Line, field.Column)}})
+			return
+		}
+	}
+
+	data := map[string]interface{}{}
+	var errs []interface{}
+	for _, field := range fields {
+		key := field.Alias
+		if key == "" {
+			key = field.Name
+		}
+		value, err := s.graphqlQueryField(field, request.Variables)
+		if err != nil {
+			errs = append(errs, map[string]interface{}{"message": err.Error(), "path": []string{key}})
+		}
+		data[key] = value
+	}
+	response := map[string]interface{}{"data": data}
+	if len(errs) > 0 {
+		response["errors"] = errs
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// graphqlQueryField resolves a top-level field.
+func (s *Server) graphqlQueryField(field graphqlField, variables map[string]interface{}) (interface{}, error) {
+	args := map[string]interface{}{}
+	for name, value := range field.Arguments {
+		args[name] = resolveGraphqlValue(value, variables)
+	}
+
+	name, list := strings.CutSuffix(field.Name, "_list")
+	c := s.collection(s.graphqlPath(name))
+
+	if !list {
+		id, ok := args["id"]
+		if !ok {
+			return nil, fmt.Errorf("Field '%s' argument 'id' of type 'ID!' is required, but it was not provided.", field.Name)
+		}
+		i := c.index(objectIDOf(map[string]interface{}{"id": id}))
+		if i < 0 {
+			return nil, fmt.Errorf("%s matching query does not exist.", graphqlTypeName(name))
+		}
+		return selectGraphql(c.Results[i], field.Selection), nil
+	}
+
+	query := map[string][]string{}
+	if filters, ok := args["filters"].(map[string]interface{}); ok {
+		for key, value := range filters {
+			if lookups, ok := value.(map[string]interface{}); ok {
+				value = lookups["exact"]
+			}
+			query[key] = []string{fmt.Sprint(value)}
+		}
+	}
+	results := []interface{}{}
+	for _, object := range c.Results {
+		if matchesFilters(object, query) {
+			results = append(results, selectGraphql(object, field.Selection))
+		}
+	}
+	return results, nil
+}
+
+// graphqlPath returns the collection path of a GraphQL type name such as device_type, or an
+// empty string when the server has no such collection.
+func (s *Server) graphqlPath(name string) string {
+	for path := range s.collections {
+		if strings.ReplaceAll(resourceName(path), "-", "_") == name {
+			return path
+		}
+	}
+	return ""
+}
+
+func graphqlTypeName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// selectGraphql returns the fields of value in selection.
+func selectGraphql(value interface{}, selection []graphqlField) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(selection) == 0 {
+			return v
+		}
+		out := map[string]interface{}{}
+		for _, field := range selection {
+			key := field.Alias
+			if key == "" {
+				key = field.Name
+			}
+			out[key] = selectGraphql(v[field.Name], field.Selection)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = selectGraphql(item, selection)
+		}
+		return out
+	}
+	return value
+}
+
+func resolveGraphqlValue(value interface{}, variables map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case graphqlVariable:
+		return variables[string(v)]
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for key, item := range v {
+			out[key] = resolveGraphqlValue(item, variables)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = resolveGraphqlValue(item, variables)
+		}
+		return out
+	}
+	return value
+}
+
+func graphqlError(message string, line, column int) map[string]interface{} {
+	return map[string]interface{}{"message": message, "locations": []map[string]int{{"line": line, "column": column}}}
+}
+
+// graphqlParser parses the selection set of a query document; fragments and directives are not
+// supported.
+type graphqlParser struct {
+	src  []rune
+	pos  int
+	line int
+	col  int
+}
+
+func parseGraphql(query string) ([]graphqlField, error) {
+	p := &graphqlParser{src: []rune(query), line: 1, col: 1}
+	p.skip()
+	if word := p.peekName(); word == "query" {
+		p.name()
+		p.skip()
+		if p.peekName() != "" {
+			p.name()
+			p.skip()
+		}
+		if p.peek() == '(' {
+			for p.pos < len(p.src) && p.peek() != ')' {
+				p.next()
+			}
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	fields, err := p.selection()
+	if err != nil {
+		return nil, err
+	}
+	if p.skip(); p.pos < len(p.src) {
+		return nil, p.errorf("Syntax Error: Unexpected '%c'.", p.peek())
+	}
+	return fields, nil
+}
+
+func (p *graphqlParser) selection() ([]graphqlField, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	var fields []graphqlField
+	for {
+		p.skip()
+		if p.peek() == '}' {
+			p.next()
+			if len(fields) == 0 {
+				return nil, p.errorf("Syntax Error: Expected Name, found '}'.")
+			}
+			return fields, nil
+		}
+		field := graphqlField{Line: p.line, Column: p.col}
+		field.Name = p.name()
+		if field.Name == "" {
+			return nil, p.unexpected()
+		}
+		if p.skip(); p.peek() == ':' {
+			p.next()
+			p.skip()
+			field.Alias, field.Name = field.Name, p.name()
+			if field.Name == "" {
+				return nil, p.unexpected()
+			}
+		}
+		if p.skip(); p.peek() == '(' {
+			p.next()
+			field.Arguments = map[string]interface{}{}
+			for {
+				p.skip()
+				if p.peek() == ')' {
+					p.next()
+					break
+				}
+				name := p.name()
+				if name == "" {
+					return nil, p.unexpected()
+				}
+				if err := p.expect(':'); err != nil {
+					return nil, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				field.Arguments[name] = value
+			}
+		}
+		if p.skip(); p.peek() == '{' {
+			selection, err := p.selection()
+			if err != nil {
+				return nil, err
+			}
+			field.Selection = selection
+		}
+		fields = append(fields, field)
+	}
+}
+
+func (p *graphqlParser) value() (interface{}, error) {
+	p.skip()
+	switch r := p.peek(); {
+	case r == '$':
+		p.next()
+		return graphqlVariable(p.name()), nil
+	case r == '"':
+		p.next()
+		var b strings.Builder
+		for p.pos < len(p.src) && p.peek() != '"' {
+			if p.peek() == '\\' {
+				p.next()
+			}
+			b.WriteRune(p.next())
+		}
+		if err := p.expect('"'); err != nil {
+			return nil, err
+		}
+		return b.String(), nil
+	case r == '-' || unicode.IsDigit(r):
+		start := p.pos
+		for p.pos < len(p.src) && strings.ContainsRune("-+.eE0123456789", p.peek()) {
+			p.next()
+		}
+		return json.Number(string(p.src[start:p.pos])), nil
+	case r == '{':
+		p.next()
+		object := map[string]interface{}{}
+		for {
+			p.skip()
+			if p.peek() == '}' {
+				p.next()
+				return object, nil
+			}
+			name := p.name()
+			if name == "" {
+				return nil, p.unexpected()
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			object[name] = value
+		}
+	case r == '[':
+		p.next()
+		list := []interface{}{}
+		for {
+			p.skip()
+			if p.peek() == ']' {
+				p.next()
+				return list, nil
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+	}
+	switch name := p.name(); name {
+	case "":
+		return nil, p.unexpected()
+	case "true", "false":
+		return name == "true", nil
+	case "null":
+		return nil, nil
+	default:
+		return name, nil
+	}
+}
+
+// skip skips white space, commas and comments.
+func (p *graphqlParser) skip() {
+	for p.pos < len(p.src) {
+		switch r := p.peek(); {
+		case r == '#':
+			for p.pos < len(p.src) && p.peek() != '\n' {
+				p.next()
+			}
+		case r == ',' || unicode.IsSpace(r):
+			p.next()
+		default:
+			return
+		}
+	}
+}
+
+func (p *graphqlParser) peek() rune {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *graphqlParser) peekName() string {
+	saved := *p
+	name := p.name()
+	*p = saved
+	return name
+}
+
+func (p *graphqlParser) next() rune {
+	r := p.peek()
+	p.pos++
+	if r == '\n' {
+		p.line, p.col = p.line+1, 1
+	} else {
+		p.col++
+	}
+	return r
+}
+
+func (p *graphqlParser) name() string {
+	start := p.pos
+	for p.pos < len(p.src) && (p.peek() == '_' || unicode.IsLetter(p.peek()) || (p.pos > start && unicode.IsDigit(p.peek()))) {
+		p.next()
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *graphqlParser) expect(r rune) error {
+	p.skip()
+	if p.peek() != r {
+		return p.unexpected()
+	}
+	p.next()
+	return nil
+}
+
+func (p *graphqlParser) unexpected() error {
+	if p.pos >= len(p.src) {
+		return p.errorf("Syntax Error: Unexpected <EOF>.")
+	}
+	return p.errorf("Syntax Error: Unexpected '%c'.", p.peek())
+}
+
+func (p *graphqlParser) errorf(format string, args ...interface{}) *graphqlSyntaxError {
+	return &graphqlSyntaxError{Message: fmt.Sprintf(format, args...), Locations: []map[string]int{{"line": p.line, "column": p.col}}}
+}
+
+// graphqlSyntaxError is a parse error, encoded as an entry of the errors list of the response.
+type graphqlSyntaxError struct {
+	Message   string           `json:"message"`
+	Locations []map[string]int `json:"locations"`
+}
+
+func (e *graphqlSyntaxError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package netboxtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Update rewrites golden files instead of comparing against them: go test ./... -update
+var Update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// argsEnv carries the command line of a command run by Run to the child process.
+const argsEnv = "NETBOXTEST_COMMAND_ARGS"
+
+// Token is the API token written to the configuration of commands run by Run.
+const Token = "Token 0123456789abcdef0123456789abcdef01234567"
+
+// Options configure a command run by Run.
+type Options struct {
+	// ConfigFile is the netbox_config.yaml the run's configuration is based on.
+	ConfigFile string
+	// Config overrides configuration keys, e.g. to lower the page size of an endpoint.
+	Config map[string]string
+	// Stdin is fed to the command, e.g. the answers to "continue to the next page?".
+	Stdin string
+}
+
+// Result is the outcome of a command run by Run.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// String renders a result for a golden file.
+func (r Result) String() string {
+	return fmt.Sprintf("exit status: %d\n--- stdout\n%s\n--- stderr\n%s\n", r.ExitCode, r.Stdout, r.Stderr)
+}
+
+// Main is called from TestMain. Commands share the state of their process, such as the session,
+// its configuration and stdin, so Run executes each of them in a child process of the test binary;
+// there Main runs the command with run instead of the tests and exits with the code it returns.
+func Main(m *testing.M, run func(args []string) int) {
+	encoded := os.Getenv(argsEnv)
+	if encoded == "" {
+		os.Exit(m.Run())
+	}
+	var args []string
+	if err := json.Unmarshal([]byte(encoded), &args); err != nil {
+		log.Fatalf("Invalid %s: %s\n", argsEnv, err)
+	}
+	log.SetFlags(0)
+	os.Exit(run(args))
+}
+
+// Run runs a command against srv in a child process of the test binary, from a directory holding
+// a netbox_config.yaml whose development and production servers both point to srv.
+func Run(t testing.TB, srv *Server, opts Options, args ...string) Result {
+	t.Helper()
+	dir := t.TempDir()
+	writeConfig(t, srv, opts, filepath.Join(dir, "netbox_config.yaml"))
+
+	encoded, _ := json.Marshal(args)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	c := exec.CommandContext(ctx, os.Args[0], "-test.run=^$")
+	c.Dir = dir
+	c.Env = append(os.Environ(), argsEnv+"="+string(encoded), "HOME="+dir, "XDG_CACHE_HOME="+filepath.Join(dir, ".cache"), "NO_COLOR=1", "TERM=dumb")
+	c.Stdin = strings.NewReader(opts.Stdin)
+	var stdout, stderr cappedBuffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("running %v: %s", args, err)
+	}
+	if stdout.full || stderr.full {
+		t.Fatalf("running %v: more than %d bytes of output, is it looping?", args, outputLimit)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("running %v: timed out, is it waiting for input?\n%s", args, srv.Normalize(stdout.String()))
+	}
+	return Result{
+		Stdout:   srv.Normalize(stdout.String()),
+		Stderr:   srv.Normalize(stderr.String()),
+		ExitCode: c.ProcessState.ExitCode(),
+	}
+}
+
+// outputLimit caps the output kept from a command, so that a command stuck in a loop fails the
+// test instead of exhausting memory.
+const outputLimit = 4 << 20
+
+// cappedBuffer is a bytes.Buffer refusing writes past outputLimit, which ends the command with a
+// broken pipe.
+type cappedBuffer struct {
+	bytes.Buffer
+	full bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > outputLimit {
+		b.full = true
+		return 0, errors.New("output limit exceeded")
+	}
+	return b.Buffer.Write(p)
+}
+
+func writeConfig(t testing.TB, srv *Server, opts Options, target string) {
+	t.Helper()
+	vi := viper.New()
+	vi.SetConfigFile(opts.ConfigFile)
+	if err := vi.ReadInConfig(); err != nil {
+		t.Fatalf("reading %s: %s", opts.ConfigFile, err)
+	}
+	vi.Set("cmd.netbox_dev_root_url", srv.URL)
+	vi.Set("cmd.netbox_prod_root_url", srv.URL)
+	vi.Set("cmd.token_key", Token)
+	for key, value := range opts.Config {
+		vi.Set(key, value)
+	}
+	if err := vi.WriteConfigAs(target); err != nil {
+		t.Fatalf("writing %s: %s", target, err)
+	}
+}
+
+// Golden compares got with testdata/golden/<name>.golden, or rewrites the file with -update.
+func Golden(t testing.TB, name string, got string) {
+	t.Helper()
+	file := filepath.Join("testdata", "golden", name+".golden")
+	if *Update {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("%s (run go test with -update to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("output differs from %s (run go test with -update to accept it)\n--- want\n%s\n--- got\n%s", file, want, got)
+	}
+}
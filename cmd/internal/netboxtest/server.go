@@ -0,0 +1,808 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package netboxtest provides a fake Netbox server for tests. It serves the REST API from JSON
+// fixtures, keeps the changes made through it, and answers like Netbox does: paginated lists,
+// filters, brief mode, bulk create, update and delete, 400 on invalid payloads, 404 on unknown
+// objects and 409 when deleting a protected object. A subset of the GraphQL API is served on
+// /graphql/ from the same collections. Multipart uploads are stored and served under /media/.
+package netboxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// BaseURL is the server URL used in fixtures and golden files. Responses use the real URL of the
+// test server, which Normalize turns back into BaseURL.
+const BaseURL = "http://netbox.test"
+
+// Version is the Netbox version reported by /api/status/.
+const Version = "4.1.3"
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+	// Token is the Authorization header of the request.
+	Token string
+}
+
+// Server is a fake Netbox server.
+type Server struct {
+	*httptest.Server
+	// Version is the Netbox version reported by /api/status/, Version unless a test changes it.
+	Version string
+	// Delay holds every API response back, to test timeouts and interruptions.
+	Delay       time.Duration
+	mu          sync.Mutex
+	collections Fixtures
+	requests    []Request
+	media       map[string][]byte
+}
+
+// NewServer starts a Server with a copy of fixtures; it is closed when the test ends. Endpoints
+// without a fixture serve a single generated object with ID 1.
+func NewServer(t testing.TB, fixtures Fixtures) *Server {
+	s := &Server{Version: Version, collections: fixtures.clone(), media: map[string][]byte{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Objects returns the objects currently stored at an API path such as /api/dcim/devices/.
+func (s *Server) Objects(path string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.collection(path)
+	return append([]map[string]interface{}(nil), c.Results...)
+}
+
+// Normalize replaces the URL of the running server in out with BaseURL, and its port, which shows
+// up on its own in errors about malformed URLs, with "<port>".
+func (s *Server) Normalize(out string) string {
+	out = strings.ReplaceAll(out, s.URL, BaseURL)
+	_, port, _ := net.SplitHostPort(s.Listener.Addr().String())
+	return strings.ReplaceAll(out, port, "<port>")
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if s.Delay > 0 && strings.HasPrefix(r.URL.Path, "/api/") && r.URL.Path != "/api/status/" {
+		select {
+		case <-time.After(s.Delay):
+		case <-r.Context().Done():
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	multipartErr := s.decodeMultipart(r, &body)
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body), Token: r.Header.Get("Authorization")})
+
+	switch {
+	case r.URL.Path == "/":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"api": BaseURL + "/api/"})
+		return
+	case strings.HasPrefix(r.URL.Path, "/media/") && r.Method == http.MethodGet:
+		s.serveMedia(w, r.URL.Path)
+		return
+	case !strings.HasPrefix(r.URL.Path, "/api/") && r.URL.Path != "/graphql/":
+		writeJSON(w, http.StatusNotFound, detail("Not found."))
+		return
+	case !strings.HasPrefix(r.Header.Get("Authorization"), "Token "):
+		writeJSON(w, http.StatusForbidden, detail("Authentication credentials were not provided."))
+		return
+	case multipartErr != nil:
+		writeJSON(w, http.StatusBadRequest, detail("Multipart form parse error - "+multipartErr.Error()))
+		return
+	case r.URL.Path == "/graphql/":
+		s.graphql(w, r, body)
+		return
+	case r.URL.Path == "/api/status/":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"django-version":     "5.0.9",
+			"installed-apps":     map[string]string{"django_filters": "24.3", "rest_framework": "3.15.2"},
+			"netbox-version":     s.Version,
+			"plugins":            map[string]string{},
+			"python-version":     "3.12.3",
+			"rq-workers-running": 1,
+		})
+		return
+	}
+
+	path, objectID, action, ok := splitPath(r.URL.Path)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, detail("Not found."))
+		return
+	}
+	c := s.collection(path)
+
+	if objectID == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, path, c)
+		case http.MethodPost:
+			s.create(w, path, c, body)
+		case http.MethodPatch, http.MethodPut:
+			s.bulkUpdate(w, c, body)
+		case http.MethodDelete:
+			s.bulkDelete(w, c, body)
+		case http.MethodOptions:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"name": path, "actions": map[string]interface{}{"POST": map[string]interface{}{}}})
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed, detail(fmt.Sprintf("Method \"%s\" not allowed.", r.Method)))
+		}
+		return
+	}
+
+	i := c.index(objectID)
+	if i < 0 {
+		writeJSON(w, http.StatusNotFound, detail("No "+resourceName(path)+" matches the given query."))
+		return
+	}
+	if action == "elevation" && path == "/api/dcim/racks/" {
+		s.elevation(w, r, c.Results[i])
+		return
+	}
+	if action != "" {
+		s.action(w, r, c, objectID, action)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.Results[i])
+	case http.MethodPatch, http.MethodPut:
+		changes, ok := decodeObject(w, body)
+		if !ok {
+			return
+		}
+		for key, value := range changes {
+			c.Results[i][key] = value
+		}
+		writeJSON(w, http.StatusOK, c.Results[i])
+	case http.MethodDelete:
+		if c.protected(objectID) {
+			writeJSON(w, http.StatusConflict, conflict(path, objectID))
+			return
+		}
+		c.Results = append(c.Results[:i], c.Results[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, detail(fmt.Sprintf("Method \"%s\" not allowed.", r.Method)))
+	}
+}
+
+// decodeMultipart turns the body of a multipart/form-data request into the JSON object create
+// expects. Each file is stored under /media/<resource>/<file name>, and its field set to the URL
+// of the file, with the size of images in <field>_width and <field>_height like Netbox does.
+func (s *Server) decodeMultipart(r *http.Request, body *[]byte) error {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil
+	}
+	form, err := multipart.NewReader(bytes.NewReader(*body), params["boundary"]).ReadForm(32 << 20)
+	if err != nil {
+		return err
+	}
+	object := map[string]interface{}{}
+	for key, values := range form.Value {
+		if id, err := strconv.Atoi(values[0]); err == nil {
+			object[key] = json.Number(strconv.Itoa(id))
+		} else {
+			object[key] = values[0]
+		}
+	}
+	dir := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for key, files := range form.File {
+		f, err := files[0].Open()
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		mediaPath := "/media/" + dir[len(dir)-1] + "/" + files[0].Filename
+		s.media[mediaPath] = content
+		object[key] = BaseURL + mediaPath
+		if config, _, err := image.DecodeConfig(bytes.NewReader(content)); err == nil {
+			object[key+"_width"] = json.Number(strconv.Itoa(config.Width))
+			object[key+"_height"] = json.Number(strconv.Itoa(config.Height))
+		}
+	}
+	*body, err = json.Marshal(object)
+	return err
+}
+
+// serveMedia answers GET on an uploaded file. The files of fixture objects, which were never
+// uploaded, are served as a 1x1 PNG.
+func (s *Server) serveMedia(w http.ResponseWriter, mediaPath string) {
+	content, ok := s.media[mediaPath]
+	if !ok {
+		for _, c := range s.collections {
+			for _, object := range c.Results {
+				if object["image"] == BaseURL+mediaPath {
+					content, ok = placeholderPNG, true
+				}
+			}
+		}
+	}
+	if !ok {
+		writeJSON(w, http.StatusNotFound, detail("Not found."))
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(content))
+	_, _ = w.Write(content)
+}
+
+// placeholderPNG is a 1x1 transparent PNG.
+var placeholderPNG = func() []byte {
+	var b bytes.Buffer
+	_ = png.Encode(&b, image.NewNRGBA(image.Rect(0, 0, 1, 1)))
+	return b.Bytes()
+}()
+
+// action answers GET on an action of an object from the Actions of its collection. The cable path
+// actions answer an empty path for objects without one, like Netbox does for unconnected ports.
+func (s *Server) action(w http.ResponseWriter, r *http.Request, c *Collection, objectID int, action string) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, detail(fmt.Sprintf("Method \"%s\" not allowed.", r.Method)))
+		return
+	}
+	if response, ok := c.Actions[action][strconv.Itoa(objectID)]; ok {
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+	if action == "trace" || action == "paths" {
+		writeJSON(w, http.StatusOK, []interface{}{})
+		return
+	}
+	writeJSON(w, http.StatusNotFound, detail("Not found."))
+}
+
+// elevation answers GET on the elevation of a rack, computed from the devices mounted in it: its
+// units from the top, each with the device in it on the requested face, full-depth devices on
+// both. Device heights come from their device type, 1U if it has none. With render=svg, a
+// drawing with the names of the devices is returned instead.
+func (s *Server) elevation(w http.ResponseWriter, r *http.Request, rack map[string]interface{}) {
+	face := r.URL.Query().Get("face")
+	if face == "" {
+		face = "front"
+	}
+	if face != "front" && face != "rear" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"face": []string{"\"" + face + "\" is not a valid choice."}})
+		return
+	}
+	height := queryInt(fmt.Sprint(rack["u_height"]), 42)
+	devices := make([]interface{}, height+1)
+	for _, device := range s.collection("/api/dcim/devices/").Results {
+		mounted, _ := device["rack"].(map[string]interface{})
+		if mounted == nil || fmt.Sprint(mounted["id"]) != fmt.Sprint(rack["id"]) || device["position"] == nil {
+			continue
+		}
+		uHeight, fullDepth := 1, true
+		if deviceType, ok := device["device_type"].(map[string]interface{}); ok {
+			types := s.collection("/api/dcim/device-types/")
+			if i := types.index(queryInt(fmt.Sprint(deviceType["id"]), 0)); i >= 0 {
+				uHeight = queryInt(fmt.Sprint(types.Results[i]["u_height"]), 1)
+				if full, ok := types.Results[i]["is_full_depth"].(bool); ok {
+					fullDepth = full
+				}
+			}
+		}
+		if !fullDepth && choiceValue(device["face"]) != face {
+			continue
+		}
+		// Netbox 4 returns positions as decimals, e.g. 40.0.
+		position, _ := strconv.ParseFloat(fmt.Sprint(device["position"]), 64)
+		for u := int(position); u < int(position)+uHeight && u <= height; u++ {
+			if u > 0 {
+				devices[u] = map[string]interface{}{"id": device["id"], "url": device["url"], "display": device["display"], "name": device["name"]}
+			}
+		}
+	}
+
+	if r.URL.Query().Get("render") == "svg" {
+		var svg strings.Builder
+		fmt.Fprintf(&svg, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"230\" height=\"%d\" class=\"rack\" data-rack=\"%v\" data-face=\"%s\">\n", height*22+40, rack["name"], face)
+		for u := height; u > 0; u-- {
+			if device, ok := devices[u].(map[string]interface{}); ok {
+				fmt.Fprintf(&svg, "  <text x=\"40\" y=\"%d\">%v</text>\n", (height-u)*22+35, device["name"])
+			}
+		}
+		svg.WriteString("</svg>\n")
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(svg.String()))
+		return
+	}
+	units := []map[string]interface{}{}
+	for u := height; u > 0; u-- {
+		units = append(units, map[string]interface{}{
+			"id":       u,
+			"name":     fmt.Sprintf("U%d", u),
+			"face":     map[string]interface{}{"value": face, "label": strings.ToUpper(face[:1]) + face[1:]},
+			"device":   devices[u],
+			"occupied": devices[u] != nil,
+			"display":  fmt.Sprintf("U%d", u),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(units), "next": nil, "previous": nil, "results": units})
+}
+
+// collection returns the collection at path, generating one for endpoints without a fixture.
+func (s *Server) collection(path string) *Collection {
+	c, ok := s.collections[path]
+	if !ok {
+		name := resourceName(path)
+		c = &Collection{Results: []map[string]interface{}{{
+			"id":      json.Number("1"),
+			"url":     BaseURL + path + "1/",
+			"display": name + " 1",
+			"name":    name + "-1",
+			"slug":    name + "-1",
+		}}, generated: true}
+		s.collections[path] = c
+	}
+	return c
+}
+
+// list answers GET on a collection with one page of the objects matching the query's filters.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, path string, c *Collection) {
+	query := r.URL.Query()
+	limit := queryInt(query.Get("limit"), 50)
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+	offset := queryInt(query.Get("offset"), 0)
+
+	filters := query
+	if !c.generated {
+		filters = knownFilters(c.Results, query)
+	}
+	var matches []map[string]interface{}
+	for _, object := range c.Results {
+		if matchesFilters(object, filters) {
+			matches = append(matches, object)
+		}
+	}
+
+	page := []map[string]interface{}{}
+	for i := offset; i < len(matches) && i < offset+limit; i++ {
+		object := matches[i]
+		if query.Get("brief") != "" {
+			object = brief(object)
+		}
+		if fields := query.Get("fields"); fields != "" {
+			object = only(object, strings.Split(fields, ","))
+		}
+		page = append(page, object)
+	}
+
+	pageURL := func(offset int) interface{} {
+		q := r.URL.Query()
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("offset", strconv.Itoa(offset))
+		return s.URL + path + "?" + q.Encode()
+	}
+	var next, previous interface{}
+	if offset+limit < len(matches) {
+		next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		previous = pageURL(0)
+		if offset > limit {
+			previous = pageURL(offset - limit)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(matches), "next": next, "previous": previous, "results": page})
+}
+
+// create answers POST on a collection, with a single object or a list of objects for a bulk create.
+func (s *Server) create(w http.ResponseWriter, path string, c *Collection, body []byte) {
+	payload, err := decode(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, detail("JSON parse error - "+err.Error()))
+		return
+	}
+
+	objects, bulk := toObjects(payload)
+	if objects == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"non_field_errors": []string{"Invalid data. Expected a dictionary, but got " + typeName(payload) + "."}})
+		return
+	}
+	var errs []map[string]interface{}
+	failed := false
+	for _, object := range objects {
+		missing := map[string]interface{}{}
+		for _, field := range c.Required {
+			if _, ok := object[field]; !ok {
+				missing[field] = []string{"This field is required."}
+			}
+		}
+		errs = append(errs, missing)
+		failed = failed || len(missing) > 0
+	}
+	if failed {
+		if bulk {
+			writeJSON(w, http.StatusBadRequest, errs)
+		} else {
+			writeJSON(w, http.StatusBadRequest, errs[0])
+		}
+		return
+	}
+
+	for _, object := range objects {
+		id := c.nextID()
+		object["id"] = json.Number(strconv.Itoa(id))
+		object["url"] = BaseURL + path + strconv.Itoa(id) + "/"
+		if _, ok := object["display"]; !ok {
+			object["display"] = displayName(object, resourceName(path)+" "+strconv.Itoa(id))
+		}
+		c.Results = append(c.Results, object)
+	}
+	if bulk {
+		writeJSON(w, http.StatusCreated, objects)
+	} else {
+		writeJSON(w, http.StatusCreated, objects[0])
+	}
+}
+
+// bulkUpdate answers PATCH on a collection with a list of objects, each identified by its id.
+func (s *Server) bulkUpdate(w http.ResponseWriter, c *Collection, body []byte) {
+	objects, ok := decodeBulk(w, c, body)
+	if !ok {
+		return
+	}
+	var updated []map[string]interface{}
+	for _, changes := range objects {
+		i := c.index(objectIDOf(changes))
+		for key, value := range changes {
+			c.Results[i][key] = value
+		}
+		updated = append(updated, c.Results[i])
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// bulkDelete answers DELETE on a collection with a list of objects, each identified by its id.
+// Nothing is deleted when one of the objects is protected.
+func (s *Server) bulkDelete(w http.ResponseWriter, c *Collection, body []byte) {
+	objects, ok := decodeBulk(w, c, body)
+	if !ok {
+		return
+	}
+	for _, object := range objects {
+		if id := objectIDOf(object); c.protected(id) {
+			writeJSON(w, http.StatusConflict, detail(fmt.Sprintf("Unable to delete object %d: dependent objects were found.", id)))
+			return
+		}
+	}
+	for _, object := range objects {
+		i := c.index(objectIDOf(object))
+		c.Results = append(c.Results[:i], c.Results[i+1:]...)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeBulk decodes the list of a bulk update or delete, answering 400 when it is not a list of
+// existing objects.
+func decodeBulk(w http.ResponseWriter, c *Collection, body []byte) ([]map[string]interface{}, bool) {
+	payload, err := decode(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, detail("JSON parse error - "+err.Error()))
+		return nil, false
+	}
+	objects, bulk := toObjects(payload)
+	if !bulk {
+		writeJSON(w, http.StatusBadRequest, detail("Bulk operations require a list of objects."))
+		return nil, false
+	}
+	for _, object := range objects {
+		id := objectIDOf(object)
+		if id == 0 {
+			writeJSON(w, http.StatusBadRequest, detail("Every object must specify an id."))
+			return nil, false
+		}
+		if c.index(id) < 0 {
+			writeJSON(w, http.StatusBadRequest, detail(fmt.Sprintf("Object with ID %d does not exist.", id)))
+			return nil, false
+		}
+	}
+	return objects, true
+}
+
+func decodeObject(w http.ResponseWriter, body []byte) (map[string]interface{}, bool) {
+	payload, err := decode(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, detail("JSON parse error - "+err.Error()))
+		return nil, false
+	}
+	object, ok := payload.(map[string]interface{})
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"non_field_errors": []string{"Invalid data. Expected a dictionary, but got " + typeName(payload) + "."}})
+		return nil, false
+	}
+	return object, true
+}
+
+func decode(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	var payload interface{}
+	err := decoder.Decode(&payload)
+	return payload, err
+}
+
+// toObjects returns the objects of a payload and whether it was a list.
+func toObjects(payload interface{}) ([]map[string]interface{}, bool) {
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{p}, false
+	case []interface{}:
+		objects := []map[string]interface{}{}
+		for _, item := range p {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, true
+			}
+			objects = append(objects, object)
+		}
+		return objects, true
+	}
+	return nil, false
+}
+
+// splitPath splits /api/dcim/devices/5/ into the collection path /api/dcim/devices/ and ID 5.
+// An action of the object, as in /api/dcim/interfaces/5/trace/, is returned as well.
+func splitPath(p string) (string, int, string, bool) {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	switch len(parts) {
+	case 3:
+		return "/" + strings.Join(parts, "/") + "/", 0, "", true
+	case 4, 5:
+		id, err := strconv.Atoi(parts[3])
+		if err != nil || id <= 0 {
+			return "", 0, "", false
+		}
+		action := ""
+		if len(parts) == 5 {
+			action = parts[4]
+		}
+		return "/" + strings.Join(parts[:3], "/") + "/", id, action, true
+	}
+	return "", 0, "", false
+}
+
+func resourceName(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	return strings.TrimSuffix(parts[len(parts)-1], "s")
+}
+
+// knownFilters returns the filters of query on fields the objects of a collection have. Like
+// Netbox, which ignores filters it does not know, a filter on a field no object has matches
+// every object: a lookup by slug on devices, which have no slug, finds them all.
+func knownFilters(objects []map[string]interface{}, query map[string][]string) map[string][]string {
+	known := map[string][]string{}
+	for key, values := range query {
+		for _, object := range objects {
+			if hasField(object, key) {
+				known[key] = values
+				break
+			}
+		}
+	}
+	return known
+}
+
+// hasField reports whether object has the field a filter key is on.
+func hasField(object map[string]interface{}, key string) bool {
+	switch key {
+	case "limit", "offset", "brief", "ordering", "format", "fields", "q":
+		return true
+	}
+	if name, ok := strings.CutPrefix(key, "cf_"); ok {
+		customFields, _ := object["custom_fields"].(map[string]interface{})
+		_, ok := customFields[name]
+		return ok
+	}
+	if _, ok := object[key]; ok {
+		return true
+	}
+	_, ok := object[strings.TrimSuffix(key, "_id")]
+	return ok
+}
+
+// matchesFilters reports whether object matches every filter in query. A filter matches a field
+// holding the value, or a related object with that id, slug or name; name__ic and q match substrings.
+// cf_<name> matches the custom field called name.
+func matchesFilters(object map[string]interface{}, query map[string][]string) bool {
+	for key, values := range query {
+		switch key {
+		case "limit", "offset", "brief", "ordering", "format", "fields":
+			continue
+		case "q":
+			if !containsFold(object, values[0]) {
+				return false
+			}
+			continue
+		}
+		if !matchesField(object, key, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesField(object map[string]interface{}, key string, values []string) bool {
+	if name, ok := strings.CutPrefix(key, "cf_"); ok {
+		customFields, _ := object["custom_fields"].(map[string]interface{})
+		return matchesField(customFields, name, values)
+	}
+	field, relatedID := key, false
+	if _, ok := object[key]; !ok && strings.HasSuffix(key, "_id") {
+		field, relatedID = strings.TrimSuffix(key, "_id"), true
+	}
+	for _, want := range values {
+		for _, got := range fieldValues(object[field], relatedID) {
+			if got == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldValues returns the strings a filter may match a field value by.
+func fieldValues(value interface{}, idOnly bool) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		if idOnly {
+			return []string{fmt.Sprint(v["id"])}
+		}
+		var out []string
+		for _, key := range []string{"id", "slug", "name", "value"} {
+			if attr, ok := v[key]; ok {
+				out = append(out, fmt.Sprint(attr))
+			}
+		}
+		return out
+	case []interface{}:
+		var out []string
+		for _, item := range v {
+			out = append(out, fieldValues(item, idOnly)...)
+		}
+		return out
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+func containsFold(object map[string]interface{}, s string) bool {
+	s = strings.ToLower(s)
+	for _, key := range []string{"display", "name", "serial", "description"} {
+		if v, ok := object[key].(string); ok && strings.Contains(strings.ToLower(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func brief(object map[string]interface{}) map[string]interface{} {
+	return only(object, []string{"id", "url", "display", "name", "slug", "model", "cid", "address", "description"})
+}
+
+// only returns the given fields of object, like the fields query parameter of Netbox 4.
+func only(object map[string]interface{}, fields []string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, key := range fields {
+		if v, ok := object[key]; ok {
+			out[key] = v
+		}
+	}
+	return out
+}
+
+func displayName(object map[string]interface{}, fallback string) string {
+	for _, key := range []string{"name", "model", "cid", "address", "ssid"} {
+		if v, ok := object[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return fallback
+}
+
+func objectIDOf(object map[string]interface{}) int {
+	id, _ := strconv.Atoi(fmt.Sprint(object["id"]))
+	return id
+}
+
+// choiceValue returns the value of a choice field, given as {value, label} or as its value.
+func choiceValue(v interface{}) string {
+	if choice, ok := v.(map[string]interface{}); ok {
+		return fmt.Sprint(choice["value"])
+	}
+	return fmt.Sprint(v)
+}
+
+func queryInt(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case []interface{}:
+		return "list"
+	case string:
+		return "str"
+	case json.Number:
+		return "int"
+	case bool:
+		return "bool"
+	}
+	return "NoneType"
+}
+
+func detail(message string) map[string]interface{} {
+	return map[string]interface{}{"detail": message}
+}
+
+func conflict(path string, id int) map[string]interface{} {
+	return detail(fmt.Sprintf("Unable to delete object. The following dependent objects were found: %s %d", resourceName(path), id))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package netboxtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func request(t *testing.T, srv *Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	var out map[string]interface{}
+	_ = json.Unmarshal(b, &out)
+	return resp.StatusCode, out
+}
+
+func TestPagination(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+
+	status, page := request(t, srv, "GET", "/api/dcim/devices/?limit=2", "")
+	if status != 200 || page["count"].(float64) != 5 || len(page["results"].([]interface{})) != 2 {
+		t.Fatalf("first page: %d %v", status, page)
+	}
+	if page["previous"] != nil {
+		t.Errorf("first page has previous link %v", page["previous"])
+	}
+
+	var names []string
+	for next := "/api/dcim/devices/?limit=2"; next != ""; {
+		_, page = request(t, srv, "GET", strings.TrimPrefix(next, srv.URL), "")
+		for _, r := range page["results"].([]interface{}) {
+			names = append(names, r.(map[string]interface{})["name"].(string))
+		}
+		next, _ = page["next"].(string)
+	}
+	if got := strings.Join(names, ","); got != "nyc1-leaf1,nyc1-leaf2,nyc1-spine1,nyc1-spine2,lax1-leaf1" {
+		t.Errorf("pages returned %s", got)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+
+	for query, want := range map[string]float64{
+		"site=nyc1":              4,
+		"site_id=2":              1,
+		"status=offline":         1,
+		"role=spine&site=nyc1":   2,
+		"name=nyc1-leaf1":        1,
+		"q=SPINE":                2,
+		"name=does-not-exist":    0,
+		"serial=JPE0005&brief=1": 1,
+		// Devices have no slug: like Netbox, the server ignores the filter.
+		"slug=nyc1-leaf1":           5,
+		"slug=nyc1-leaf1&site=nyc1": 4,
+	} {
+		_, page := request(t, srv, "GET", "/api/dcim/devices/?"+query, "")
+		if got := page["count"].(float64); got != want {
+			t.Errorf("%s: count %v, want %v", query, got, want)
+		}
+	}
+
+	_, page := request(t, srv, "GET", "/api/dcim/devices/?brief=true&name=nyc1-leaf1", "")
+	object := page["results"].([]interface{})[0].(map[string]interface{})
+	if _, ok := object["serial"]; ok {
+		t.Errorf("brief object has serial: %v", object)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+
+	tests := []struct {
+		method, path, body string
+		status             int
+	}{
+		{"GET", "/api/dcim/devices/99/", "", 404},
+		{"PATCH", "/api/dcim/devices/99/", `{"name": "x"}`, 404},
+		{"DELETE", "/api/dcim/devices/99/", "", 404},
+		{"DELETE", "/api/dcim/devices/3/", "", 409},
+		{"DELETE", "/api/dcim/devices/", `[{"id": 1}, {"id": 3}]`, 409},
+		{"POST", "/api/dcim/sites/", `{"name": "SFO1"}`, 400},
+		{"POST", "/api/dcim/sites/", `{"name": `, 400},
+		{"POST", "/api/dcim/sites/", `"SFO1"`, 400},
+		{"PATCH", "/api/dcim/devices/", `[{"name": "x"}]`, 400},
+		{"PATCH", "/api/dcim/devices/", `{"id": 1}`, 400},
+		{"DELETE", "/api/dcim/devices/", `[{"id": 99}]`, 400},
+		{"GET", "/api/dcim/devices/nope/", "", 404},
+	}
+	for _, tt := range tests {
+		if status, body := request(t, srv, tt.method, tt.path, tt.body); status != tt.status {
+			t.Errorf("%s %s %s: status %d, want %d: %v", tt.method, tt.path, tt.body, status, tt.status, body)
+		}
+	}
+	if n := len(srv.Objects("/api/dcim/devices/")); n != 5 {
+		t.Errorf("failed requests changed the devices: %d left", n)
+	}
+
+	req, _ := http.NewRequest("GET", srv.URL+"/api/dcim/devices/", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 403 {
+		t.Errorf("request without token: status %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestBulkOperations(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+
+	status, _ := request(t, srv, "POST", "/api/dcim/sites/", `[{"name": "SFO1", "slug": "sfo1"}, {"name": "SEA1", "slug": "sea1"}]`)
+	if status != 201 {
+		t.Fatalf("bulk create: status %d", status)
+	}
+	_, page := request(t, srv, "GET", "/api/dcim/sites/?slug=sea1", "")
+	if page["count"].(float64) != 1 || page["results"].([]interface{})[0].(map[string]interface{})["id"].(float64) != 4 {
+		t.Errorf("created site: %v", page)
+	}
+
+	if status, _ := request(t, srv, "PATCH", "/api/dcim/devices/", `[{"id": 1, "status": "offline"}, {"id": 2, "status": "offline"}]`); status != 200 {
+		t.Fatalf("bulk update: status %d", status)
+	}
+	if _, page := request(t, srv, "GET", "/api/dcim/devices/?status=offline", ""); page["count"].(float64) != 3 {
+		t.Errorf("offline devices after bulk update: %v", page["count"])
+	}
+
+	if status, _ := request(t, srv, "DELETE", "/api/dcim/devices/", `[{"id": 1}, {"id": 2}]`); status != 204 {
+		t.Fatalf("bulk delete: status %d", status)
+	}
+	if n := len(srv.Objects("/api/dcim/devices/")); n != 3 {
+		t.Errorf("%d devices left after bulk delete, want 3", n)
+	}
+
+	// Servers do not share changes.
+	if n := len(NewServer(t, DefaultFixtures()).Objects("/api/dcim/devices/")); n != 5 {
+		t.Errorf("new server has %d devices, want 5", n)
+	}
+}
+
+func TestGeneratedCollections(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+
+	status, object := request(t, srv, "GET", "/api/dcim/regions/1/", "")
+	if status != 200 || object["display"] != "region 1" {
+		t.Errorf("generated object: %d %v", status, object)
+	}
+	if status, _ := request(t, srv, "GET", "/api/status/", ""); status != 200 {
+		t.Errorf("status endpoint: %d", status)
+	}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// journalKey is the config key of the extras journal entries endpoint.
+const journalKey = "cmd.extras.extras_api_url.journal_entries"
+
+// journalKinds are the kinds of journal entry Netbox knows.
+var journalKinds = []string{"info", "success", "warning", "danger"}
+
+var journalEnv string
+
+var journalComments string
+
+// journalKind is the --kind of journal add and journalListKind the --kind of journal list, which
+// have different defaults.
+var journalKind string
+
+var journalListKind string
+
+var journalOutput string
+
+var journalObjects objectSelector
+
+// journalMessage and journalMessageKind are the --journal and --journal-kind global flags.
+var journalMessage string
+
+var journalMessageKind string
+
+// JournalCmd represents the journal command
+var JournalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Add and list journal entries of Netbox objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Add and list the journal entries of any kind of Netbox object:
+
+    abc-netbox.cli journal add dcim devices nyc1-leaf1 --kind warning --comments "PSU 2 failed, RMA 4471" --env production
+    abc-netbox.cli journal list dcim devices nyc1-leaf1 --env production
+
+  Every command that changes objects also accepts --journal "message", which adds a journal
+  entry to each object it creates or changes, so the reason for a change is kept in Netbox.`,
+}
+
+// JournalAddCmd represents the journal add command
+var JournalAddCmd = &cobra.Command{
+	Use:   "add <domain> <resource> [id|name]...",
+	Short: "Add a journal entry to objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Add a journal entry to objects given by ID or name as arguments, with --id, or with --filter to
+  select every object matching Netbox filters. The comments are markdown; --comments - reads
+  them from stdin.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := addJournalEntries(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("adding journal entries: %s", err)
+		}
+		return nil
+	},
+}
+
+// JournalListCmd represents the journal list command
+var JournalListCmd = &cobra.Command{
+	Use:   "list <domain> <resource> [id|name]...",
+	Short: "List the journal entries of objects",
+	Long: `
+ABC Netbox Automation Tools:
+  List the journal entries of objects given by ID or name as arguments, with --id or with
+  --filter, oldest first. Without objects, the entries of every object of the resource are listed.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeResource,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := listJournalEntries(cmd.Context(), args[0], args[1], args[2:]); err != nil {
+			return fmt.Errorf("listing journal entries: %s", err)
+		}
+		return nil
+	},
+}
+
+// journalRoot returns the root URL of the environment of the journal commands, after the SSL check.
+func journalRoot() (string, string, error) {
+	rootURL, err := session.RootURL(journalEnv)
+	if err != nil {
+		return "", "", fmt.Errorf("unrecognized environment: %s", journalEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return "", "", fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+	path, err := journalPath()
+	return rootURL, path, err
+}
+
+func journalPath() (string, error) {
+	config, err := session.Config()
+	if err != nil {
+		return "", err
+	}
+	path := strings.SplitN(config.GetString(journalKey), "?", 2)[0]
+	if path == "" {
+		return "", fmt.Errorf("no endpoint configured for %s", journalKey)
+	}
+	return path, nil
+}
+
+func validJournalKind(kind string) error {
+	for _, known := range journalKinds {
+		if kind == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown kind %q, use one of %s", kind, strings.Join(journalKinds, ", "))
+}
+
+// addJournalEntries adds a journal entry with the --comments to each selected object of a resource.
+func addJournalEntries(ctx context.Context, domain string, resource string, names []string) error {
+	if !journalObjects.selected(names) {
+		return fmt.Errorf("no objects selected: give IDs or names, --id or --filter")
+	}
+	if err := validJournalKind(journalKind); err != nil {
+		return err
+	}
+	comments := journalComments
+	if comments == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		comments = string(b)
+	}
+	if strings.TrimSpace(comments) == "" {
+		return fmt.Errorf("the comments are empty")
+	}
+	endpoint, err := lookupEndpoint(domain, resource)
+	if err != nil {
+		return err
+	}
+	rootURL, path, err := journalRoot()
+	if err != nil {
+		return err
+	}
+	objects, err := journalObjects.objects(ctx, journalEnv, rootURL, endpoint, names)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]interface{}, len(objects))
+	for i, o := range objects {
+		entries[i] = journalEntry(endpoint.objectType, o.Get("id"), journalKind, comments)
+	}
+	if _, err := sendJSON(ctx, journalEnv, "POST", rootURL+path, entries); err != nil {
+		return err
+	}
+	for _, o := range objects {
+		color.Cyan("  Added a journal entry to " + color.YellowString("%s", objectName(o)))
+	}
+	return nil
+}
+
+func journalEntry(objectType string, id interface{}, kind string, comments string) *output.Object {
+	return &output.Object{
+		Keys: []string{"assigned_object_type", "assigned_object_id", "kind", "comments"},
+		Values: map[string]interface{}{
+			"assigned_object_type": objectType,
+			"assigned_object_id":   id,
+			"kind":                 kind,
+			"comments":             comments,
+		},
+	}
+}
+
+// listJournalEntries prints the journal entries of the selected objects of a resource, or of all of them.
+func listJournalEntries(ctx context.Context, domain string, resource string, names []string) error {
+	if journalOutput == "" {
+		journalOutput = output.Default()
+	}
+	endpoint, err := lookupEndpoint(domain, resource)
+	if err != nil {
+		return err
+	}
+	rootURL, path, err := journalRoot()
+	if err != nil {
+		return err
+	}
+
+	filters := url.Values{"assigned_object_type": {endpoint.objectType}, "ordering": {"created"}}
+	if journalListKind != "" {
+		if err := validJournalKind(journalListKind); err != nil {
+			return err
+		}
+		filters.Set("kind", journalListKind)
+	}
+	var entries []interface{}
+	if !journalObjects.selected(names) {
+		if entries, err = listObjects(ctx, journalEnv, rootURL+path, filters); err != nil {
+			return err
+		}
+	} else {
+		objects, err := journalObjects.objects(ctx, journalEnv, rootURL, endpoint, names)
+		if err != nil {
+			return err
+		}
+		for _, o := range objects {
+			filters.Set("assigned_object_id", fmt.Sprint(o.Get("id")))
+			found, err := listObjects(ctx, journalEnv, rootURL+path, filters)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, found...)
+		}
+	}
+
+	if journalOutput != output.Text {
+		return output.Render(os.Stdout, entries, journalOutput)
+	}
+	if len(entries) == 0 {
+		color.Cyan("  No journal entries found.")
+		return nil
+	}
+	for _, item := range entries {
+		entry, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		printJournalEntry(entry)
+	}
+	return nil
+}
+
+// printJournalEntry prints an entry as a header line, with its object, time, kind and author,
+// followed by its comments.
+func printJournalEntry(entry *output.Object) {
+	object := fmt.Sprintf("%v #%v", entry.Get("assigned_object_type"), entry.Get("assigned_object_id"))
+	if assigned, ok := entry.Get("assigned_object").(*output.Object); ok {
+		object = objectName(assigned)
+	}
+	kind := "info"
+	if k, ok := entry.Get("kind").(*output.Object); ok {
+		kind = fmt.Sprint(k.Get("value"))
+	} else if k, ok := entry.Get("kind").(string); ok {
+		kind = k
+	}
+	author := "-"
+	switch user := entry.Get("created_by").(type) {
+	case nil:
+	case *output.Object:
+		author = objectName(user)
+	default:
+		author = fmt.Sprintf("user #%v", user)
+	}
+
+	kindColor := color.CyanString
+	switch kind {
+	case "success":
+		kindColor = color.GreenString
+	case "warning":
+		kindColor = color.YellowString
+	case "danger":
+		kindColor = color.RedString
+	}
+	fmt.Println(color.CyanString("\n  %s", object) + "  " + color.YellowString("%v", entry.Get("created")) + "  " + kindColor("%s", kind) + "  " + color.YellowString("%s", author))
+	for _, line := range strings.Split(strings.TrimRight(fmt.Sprint(entry.Get("comments")), "\n"), "\n") {
+		fmt.Println("\t" + line)
+	}
+}
+
+// addJournalFlag adds --journal and --journal-kind to every command below root.
+func addJournalFlag(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&journalMessage, "journal", "", "Add a journal entry with this message to every object the command creates or changes")
+	root.PersistentFlags().StringVar(&journalMessageKind, "journal-kind", "info", "Kind of the --journal entries ("+strings.Join(journalKinds, ", ")+")")
+	_ = root.RegisterFlagCompletionFunc("journal-kind", cobra.FixedCompletions(journalKinds, cobra.ShellCompDirectiveNoFileComp))
+
+	next := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		session.SetChangeHandler(nil)
+		if journalMessage != "" {
+			if err := validJournalKind(journalMessageKind); err != nil {
+				return fmt.Errorf("--journal-kind: %s", err)
+			}
+			session.SetChangeHandler(recordJournal)
+		}
+		if next != nil {
+			return next(cmd, args)
+		}
+		return nil
+	}
+}
+
+// recordJournal adds the --journal entry to the objects created or changed by a request. Deleted
+// objects have no journal left to write to. A failure is reported but does not fail the command,
+// whose change is already made.
+func recordJournal(change session.Change) {
+	path, err := journalPath()
+	if err != nil || change.Method == "DELETE" {
+		return
+	}
+	u, err := url.Parse(change.URL)
+	if err != nil || strings.HasPrefix(u.Path, path) {
+		return
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) == 4 {
+		if _, err := strconv.Atoi(parts[3]); err == nil {
+			parts = parts[:3]
+		}
+	}
+	if len(parts) != 3 || parts[0] != "api" {
+		return
+	}
+	objectType := customfields.ObjectType("/" + strings.Join(parts, "/") + "/")
+
+	body, err := output.Decode(bytes.NewReader(change.Body))
+	if err != nil {
+		return
+	}
+	results, ok := body.([]interface{})
+	if !ok {
+		results = []interface{}{body}
+	}
+	var entries []interface{}
+	for _, item := range results {
+		if o, ok := item.(*output.Object); ok && o.Get("id") != nil {
+			entries = append(entries, journalEntry(objectType, o.Get("id"), journalMessageKind, journalMessage))
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	resp, err := session.Client().R().
+		SetHeaders(map[string]string{
+			"Authorization": change.Authorization,
+			"Accept":        "application/json",
+			"Content-Type":  "application/json",
+		}).
+		SetBody(entries).
+		Post(u.Scheme + "://" + u.Host + path)
+	switch {
+	case err != nil:
+		color.Red("  Warning: the journal entry was not recorded: %s", err)
+	case !resp.IsSuccess():
+		color.Red("  Warning: the journal entry was not recorded: %s", resp.Status())
+	case len(entries) == 1:
+		color.Cyan("  Journal entry added to " + color.YellowString("%s #%v", objectType, entries[0].(*output.Object).Get("assigned_object_id")))
+	default:
+		color.Cyan("  Journal entries added to " + color.YellowString("%d %s", len(entries), parts[2]))
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{JournalAddCmd, JournalListCmd} {
+		c.Flags().StringVarP(&journalEnv, "env", "", "development", "Environment ('development' or 'production')")
+		_ = c.MarkFlagRequired("env")
+		journalObjects.addFlags(c)
+		JournalCmd.AddCommand(c)
+	}
+	JournalAddCmd.Flags().StringVar(&journalComments, "comments", "", "Markdown comments of the entry, or - to read them from stdin")
+	_ = JournalAddCmd.MarkFlagRequired("comments")
+	JournalAddCmd.Flags().StringVar(&journalKind, "kind", "info", "Kind of the entry ("+strings.Join(journalKinds, ", ")+")")
+	JournalListCmd.Flags().StringVar(&journalListKind, "kind", "", "Only list entries of this kind ("+strings.Join(journalKinds, ", ")+")")
+	JournalListCmd.Flags().StringVarP(&journalOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	for _, c := range []*cobra.Command{JournalAddCmd, JournalListCmd} {
+		_ = c.RegisterFlagCompletionFunc("kind", cobra.FixedCompletions(journalKinds, cobra.ShellCompDirectiveNoFileComp))
+	}
+	_ = JournalListCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
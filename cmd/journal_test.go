@@ -0,0 +1,112 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestJournal(t *testing.T) {
+	// sentJournal checks the body of the POST sent to the journal entries endpoint.
+	sentJournal := func(want string) func(t *testing.T, srv *netboxtest.Server) {
+		return func(t *testing.T, srv *netboxtest.Server) {
+			var posts []string
+			for _, r := range srv.Requests() {
+				if r.Method == "POST" && r.Path == "/api/extras/journal-entries/" {
+					posts = append(posts, r.Body)
+				}
+			}
+			if len(posts) != 1 || posts[0] != want {
+				t.Errorf("sent journal POST %q, want %s", posts, want)
+			}
+		}
+	}
+	noWrites := func(t *testing.T, srv *netboxtest.Server) {
+		for _, r := range srv.Requests() {
+			if r.Method != "GET" {
+				t.Errorf("sent %s %s", r.Method, r.Path)
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name:  "add",
+			args:  []string{"journal", "add", "dcim", "devices", "nyc1-leaf1", "--id", "2", "--kind", "warning", "--comments", "PSU 2 failed, RMA **4471**", "--env", "development"},
+			check: sentJournal(`[{"assigned_object_type":"dcim.device","assigned_object_id":1,"kind":"warning","comments":"PSU 2 failed, RMA **4471**"},{"assigned_object_type":"dcim.device","assigned_object_id":2,"kind":"warning","comments":"PSU 2 failed, RMA **4471**"}]`),
+		},
+		{
+			name:  "add_default_kind",
+			args:  []string{"journal", "add", "dcim", "devices", "nyc1-leaf1", "--comments", "Cabling checked", "--env", "development"},
+			check: sentJournal(`[{"assigned_object_type":"dcim.device","assigned_object_id":1,"kind":"info","comments":"Cabling checked"}]`),
+		},
+		{
+			name:  "add_unknown_kind",
+			args:  []string{"journal", "add", "dcim", "devices", "nyc1-leaf1", "--kind", "critical", "--comments", "down", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "list",
+			args:  []string{"journal", "list", "dcim", "devices", "nyc1-leaf1", "--env", "development", "-o", "text"},
+			check: noWrites,
+		},
+		{
+			name: "list_all_json",
+			args: []string{"journal", "list", "dcim", "devices", "--kind", "success", "--env", "development", "-o", "json"},
+		},
+		{
+			name:  "update",
+			args:  []string{"dcim", "devices", "update", "nyc1-leaf2", "--data", `{"serial":"SN-2002"}`, "--journal", "Replaced chassis", "--journal-kind", "success", "--env", "development"},
+			check: sentJournal(`[{"assigned_object_type":"dcim.device","assigned_object_id":2,"kind":"success","comments":"Replaced chassis"}]`),
+		},
+		{
+			name:  "bulk_update",
+			args:  []string{"dcim", "devices", "update", "--data", `[{"id":3,"serial":"SN-3003"},{"id":4,"serial":"SN-4004"}]`, "--journal", "Serials audited", "--env", "development"},
+			check: sentJournal(`[{"assigned_object_type":"dcim.device","assigned_object_id":3,"kind":"info","comments":"Serials audited"},{"assigned_object_type":"dcim.device","assigned_object_id":4,"kind":"info","comments":"Serials audited"}]`),
+		},
+		{
+			name:  "dry_run",
+			args:  []string{"dcim", "devices", "update", "nyc1-leaf2", "--data", `{"serial":"SN-2002"}`, "--journal", "Replaced chassis", "--dry-run", "--env", "development"},
+			check: noWrites,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "journal/"+tt.name, result.String())
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/spf13/cobra"
+)
+
+var verbosity int
+
+var debugLogging bool
+
+var logFile string
+
+var logFormat string
+
+// logOutput is the file opened for --log-file, kept open until the process exits.
+var logOutput *os.File
+
+// addLoggingFlags adds -v, -vv, --debug, --log-file and --log-format to every command below root.
+func addLoggingFlags(root *cobra.Command) {
+	root.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "Log every request to Netbox (-v), with its headers and bodies (-vv)")
+	root.PersistentFlags().BoolVar(&debugLogging, "debug", false, "Log every request to Netbox with its headers and bodies, like -vv")
+	root.PersistentFlags().StringVar(&logFile, "log-file", "", "Append the log to a file instead of stderr")
+	root.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format ('text' or 'json')")
+	_ = root.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+
+	next := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := startLogging(); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(cmd, args)
+		}
+		return nil
+	}
+}
+
+// startLogging sets the logger of the session from the logging flags. Without -v or --debug
+// nothing is logged.
+func startLogging() error {
+	level := slog.LevelInfo
+	switch {
+	case debugLogging || verbosity >= 2:
+		level = slog.LevelDebug
+	case verbosity == 0:
+		session.SetLogger(nil)
+		return nil
+	}
+
+	var w io.Writer = os.Stderr
+	if logFile != "" {
+		if logOutput == nil || logOutput.Name() != logFile {
+			f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				return fmt.Errorf("cannot open log file: %s", err)
+			}
+			logOutput = f
+		}
+		w = logOutput
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	switch logFormat {
+	case "text":
+		session.SetLogger(slog.New(slog.NewTextHandler(w, options)))
+	case "json":
+		session.SetLogger(slog.New(slog.NewJSONHandler(w, options)))
+	default:
+		return fmt.Errorf("unknown log format %q, use 'text' or 'json'", logFormat)
+	}
+	return nil
+}
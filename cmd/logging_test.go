@@ -0,0 +1,98 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestLogging(t *testing.T) {
+	token := strings.TrimPrefix(netboxtest.Token, "Token ")
+
+	t.Run("off", func(t *testing.T) {
+		result := run(t, netboxtest.NewServer(t, netboxtest.DefaultFixtures()), netboxtest.Options{}, "dcim", "sites", "get", "1", "--env", "production")
+		if strings.Contains(result.Stderr, "http request") {
+			t.Errorf("logged without -v:\n%s", result.Stderr)
+		}
+	})
+
+	t.Run("verbose_text", func(t *testing.T) {
+		result := run(t, netboxtest.NewServer(t, netboxtest.DefaultFixtures()), netboxtest.Options{}, "dcim", "sites", "get", "1", "--env", "production", "-v")
+		for _, want := range []string{"level=INFO", `msg="http request"`, "method=GET", "url=" + netboxtest.BaseURL + "/api/dcim/sites/1/", "status=200", "duration=", "bytes="} {
+			if !strings.Contains(result.Stderr, want) {
+				t.Errorf("log has no %s:\n%s", want, result.Stderr)
+			}
+		}
+		if strings.Contains(result.Stderr, "response_body") {
+			t.Errorf("-v logged bodies:\n%s", result.Stderr)
+		}
+	})
+
+	t.Run("debug_json_file", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "netbox.log")
+		srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+		result := run(t, srv, netboxtest.Options{}, "dcim", "sites", "get", "1", "--env", "production", "--debug", "--log-format", "json", "--log-file", logFile)
+		if result.ExitCode != 0 || strings.Contains(result.Stderr, "http request") {
+			t.Fatalf("logged to stderr instead of the file:\n%s", result)
+		}
+
+		f, err := os.Open(logFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		var entries []map[string]interface{}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), token) {
+				t.Errorf("log contains the API token: %s", scanner.Text())
+			}
+			var entry map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Fatalf("log line is not JSON: %s", scanner.Text())
+			}
+			if entry["msg"] == "http request" && strings.HasSuffix(entry["url"].(string), "/api/dcim/sites/1/") {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) != 1 {
+			t.Fatalf("%d log entries for the site request, want 1", len(entries))
+		}
+		entry := entries[0]
+		headers, _ := entry["request_headers"].(map[string]interface{})
+		if headers["Authorization"] != "[REDACTED]" {
+			t.Errorf("Authorization header logged as %v", headers["Authorization"])
+		}
+		if body, _ := entry["response_body"].(string); !strings.Contains(body, `"name":"NYC1"`) {
+			t.Errorf("response body not logged: %v", entry["response_body"])
+		}
+	})
+}
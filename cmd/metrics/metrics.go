@@ -0,0 +1,249 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package metrics exports Netbox inventory counts as Prometheus metrics.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+)
+
+// pageSize is the number of objects fetched per request when counting by label.
+const pageSize = 1000
+
+// inventory is a Netbox endpoint exported as a gauge. Without labels only its total count is
+// requested; with labels every object is fetched, restricted to the labelled fields, and counted
+// by their values.
+type inventory struct {
+	name      string
+	configKey string
+	labels    []string
+	gauge     *prometheus.GaugeVec
+}
+
+// Exporter refreshes the inventory gauges from a Netbox server and serves them on /metrics.
+type Exporter struct {
+	rootURL     string
+	token       string
+	config      *viper.Viper
+	registry    *prometheus.Registry
+	inventories []*inventory
+	duration    *prometheus.GaugeVec
+	errors      *prometheus.CounterVec
+	success     *prometheus.GaugeVec
+	lastRefresh prometheus.Gauge
+	mu          sync.Mutex
+}
+
+// NewExporter returns an Exporter for the Netbox server at rootURL, with the endpoints of config.
+func NewExporter(rootURL string, token string, config *viper.Viper) *Exporter {
+	e := &Exporter{
+		rootURL:  rootURL,
+		token:    token,
+		config:   config,
+		registry: prometheus.NewRegistry(),
+		duration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netbox_scrape_duration_seconds",
+			Help: "Time taken by the last refresh of an endpoint.",
+		}, []string{"endpoint"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "netbox_scrape_errors_total",
+			Help: "Failed refreshes of an endpoint.",
+		}, []string{"endpoint"}),
+		success: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netbox_scrape_success",
+			Help: "Whether the last refresh of an endpoint succeeded (1) or failed (0).",
+		}, []string{"endpoint"}),
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "netbox_last_refresh_timestamp_seconds",
+			Help: "Unix time of the last completed refresh.",
+		}),
+	}
+
+	e.inventories = []*inventory{
+		e.newInventory("devices", "cmd.dcim.dcim_api_url.devices_id", "Devices by site, role and status.", "site", "role", "status"),
+		e.newInventory("interfaces", "cmd.dcim.dcim_api_url.interfaces_id", "Device interfaces."),
+		e.newInventory("cables", "cmd.dcim.dcim_api_url.cables_id", "Cables by status.", "status"),
+		e.newInventory("circuits", "cmd.circuits.circuits_api_url.circuits_id", "Circuits by provider and status.", "provider", "status"),
+		e.newInventory("tunnels", "cmd.vpn.vpn_api_url.tunnels", "VPN tunnels by status.", "status"),
+	}
+	e.registry.MustRegister(e.duration, e.errors, e.success, e.lastRefresh)
+	return e
+}
+
+func (e *Exporter) newInventory(name, configKey, help string, labels ...string) *inventory {
+	inv := &inventory{
+		name:      name,
+		configKey: configKey,
+		labels:    labels,
+		gauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netbox_" + name,
+			Help: help,
+		}, labels),
+	}
+	e.registry.MustRegister(inv.gauge)
+	return inv
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Refresh updates every gauge. An endpoint that fails keeps its previous values; the failure shows
+// in netbox_scrape_errors_total and netbox_scrape_success. It returns the errors by endpoint.
+func (e *Exporter) Refresh() map[string]error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	failed := map[string]error{}
+	for _, inv := range e.inventories {
+		start := time.Now()
+		err := e.refresh(inv)
+		e.duration.WithLabelValues(inv.name).Set(time.Since(start).Seconds())
+		if err != nil {
+			failed[inv.name] = err
+			e.errors.WithLabelValues(inv.name).Inc()
+			e.success.WithLabelValues(inv.name).Set(0)
+			continue
+		}
+		e.errors.WithLabelValues(inv.name).Add(0)
+		e.success.WithLabelValues(inv.name).Set(1)
+	}
+	e.lastRefresh.SetToCurrentTime()
+	return failed
+}
+
+// Run refreshes the gauges every interval until stop is closed, reporting failures to report.
+func (e *Exporter) Run(interval time.Duration, stop <-chan struct{}, report func(map[string]error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report(e.Refresh())
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+type page struct {
+	Count   int                      `json:"count"`
+	Next    *string                  `json:"next"`
+	Results []map[string]interface{} `json:"results"`
+}
+
+func (e *Exporter) refresh(inv *inventory) error {
+	path := strings.SplitN(e.config.GetString(inv.configKey), "?", 2)[0]
+	if path == "" {
+		return fmt.Errorf("no endpoint configured for %s", inv.configKey)
+	}
+
+	query := url.Values{}
+	if len(inv.labels) == 0 {
+		// Counts only: a single object is enough to learn the total.
+		query.Set("brief", "true")
+		query.Set("limit", "1")
+		p, err := e.get(e.rootURL + path + "?" + query.Encode())
+		if err != nil {
+			return err
+		}
+		inv.gauge.WithLabelValues().Set(float64(p.Count))
+		return nil
+	}
+
+	query.Set("limit", fmt.Sprint(pageSize))
+	query.Set("fields", "id,"+strings.Join(inv.labels, ","))
+	counts := map[string]float64{}
+	next := e.rootURL + path + "?" + query.Encode()
+	for next != "" {
+		p, err := e.get(next)
+		if err != nil {
+			return err
+		}
+		for _, object := range p.Results {
+			values := make([]string, len(inv.labels))
+			for i, label := range inv.labels {
+				values[i] = labelValue(object[label])
+			}
+			counts[strings.Join(values, "\x00")]++
+		}
+		next = ""
+		if p.Next != nil {
+			next = *p.Next
+		}
+	}
+
+	inv.gauge.Reset()
+	for key, count := range counts {
+		inv.gauge.WithLabelValues(strings.Split(key, "\x00")...).Set(count)
+	}
+	return nil
+}
+
+func (e *Exporter) get(fullAPIPath string) (*page, error) {
+	resp, err := session.Client().R().
+		SetHeaders(map[string]string{
+			"Authorization": e.token,
+			"Accept":        "application/json",
+		}).
+		Get(fullAPIPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s returned %s", fullAPIPath, resp.Status())
+	}
+	p := new(page)
+	if err := json.Unmarshal(resp.Body(), p); err != nil {
+		return nil, fmt.Errorf("error while parsing the response bytes: %s", err)
+	}
+	return p, nil
+}
+
+// labelValue returns the label for a field: the slug or value of a related object or choice, or "".
+func labelValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		for _, key := range []string{"slug", "value", "name", "display"} {
+			if s, ok := value[key]; ok && s != nil {
+				return fmt.Sprint(s)
+			}
+		}
+		return ""
+	}
+	return fmt.Sprint(v)
+}
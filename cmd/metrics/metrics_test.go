@@ -0,0 +1,114 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+	"github.com/spf13/viper"
+)
+
+func testConfig() *viper.Viper {
+	vi := viper.New()
+	vi.Set("cmd.dcim.dcim_api_url.devices_id", "/api/dcim/devices/")
+	vi.Set("cmd.dcim.dcim_api_url.interfaces_id", "/api/dcim/interfaces/")
+	vi.Set("cmd.dcim.dcim_api_url.cables_id", "/api/dcim/cables/")
+	vi.Set("cmd.circuits.circuits_api_url.circuits_id", "/api/circuits/circuits/")
+	vi.Set("cmd.vpn.vpn_api_url.tunnels", "/api/vpn/tunnels/")
+	return vi
+}
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	b, _ := io.ReadAll(rec.Body)
+	return string(b)
+}
+
+func TestRefresh(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	e := NewExporter(srv.URL, netboxtest.Token, testConfig())
+	if failed := e.Refresh(); len(failed) != 0 {
+		t.Fatalf("refresh failed: %v", failed)
+	}
+
+	out := scrape(t, e)
+	for _, want := range []string{
+		`netbox_devices{role="leaf",site="nyc1",status="active"} 2`,
+		`netbox_devices{role="spine",site="nyc1",status="offline"} 1`,
+		`netbox_devices{role="leaf",site="lax1",status="planned"} 1`,
+		`netbox_interfaces 3`,
+		`netbox_cables{status="connected"} 1`,
+		`netbox_circuits{provider="zayo",status="provisioning"} 1`,
+		`netbox_tunnels{status="active"} 1`,
+		`netbox_scrape_success{endpoint="devices"} 1`,
+		`netbox_scrape_errors_total{endpoint="devices"} 0`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+
+	// Totals are counts-only requests; labelled counts ask only for their fields.
+	for _, r := range srv.Requests() {
+		switch r.Path {
+		case "/api/dcim/interfaces/":
+			if !strings.Contains(r.Query, "limit=1") {
+				t.Errorf("interfaces requested with %s", r.Query)
+			}
+		case "/api/dcim/devices/":
+			if !strings.Contains(r.Query, "fields=id%2Csite%2Crole%2Cstatus") {
+				t.Errorf("devices requested with %s", r.Query)
+			}
+		}
+	}
+}
+
+func TestRefreshErrors(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	e := NewExporter(srv.URL, netboxtest.Token, testConfig())
+	e.Refresh()
+
+	srv.Close()
+	failed := e.Refresh()
+	if len(failed) != 5 {
+		t.Fatalf("%d endpoints failed, want 5", len(failed))
+	}
+
+	out := scrape(t, e)
+	for _, want := range []string{
+		`netbox_scrape_success{endpoint="tunnels"} 0`,
+		`netbox_scrape_errors_total{endpoint="tunnels"} 1`,
+		// The last known counts are kept.
+		`netbox_devices{role="leaf",site="nyc1",status="active"} 2`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package namerange expands the name range patterns Netbox accepts when creating components in
+// bulk. A pattern holds one or more bracketed lists of values and ranges, so
+//
+//	{"device": "nyc1-leaf1", "name": "Ethernet1/[1-48]", "type": "10gbase-x-sfpp", "mtu": 9216}
+//
+// stands for 48 interfaces, Ethernet1/1 to Ethernet1/48, that all share the type and MTU.
+// Several brackets expand left to right: xe-0/0/[0-3]:[0-3] is xe-0/0/0:0, xe-0/0/0:1 and so
+// on up to xe-0/0/3:3. Ranges may be numeric, keeping the zero padding of their start as in
+// [01-24], or run over single letters as in [a-d]; lists mix both, as in [ge,xe] or [1,3,5-8].
+package namerange
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxObjects is the largest number of objects a pattern may expand into.
+const MaxObjects = 1000
+
+// fields are the payload fields that may hold a pattern. A pattern in label or
+// rear_port_position has to expand into as many values as the name, one for each object;
+// rear_port_position values are sent as numbers.
+var fields = []string{"name", "label", "rear_port_position"}
+
+var bracket = regexp.MustCompile(`\[([^\[\]]*)\]`)
+
+var alphanumeric = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// HasPattern reports whether s holds a bracketed pattern.
+func HasPattern(s string) bool {
+	return bracket.MatchString(s)
+}
+
+// Expand returns the values pattern stands for, in order. A string without brackets stands for
+// itself.
+func Expand(pattern string) ([]string, error) {
+	loc := bracket.FindStringSubmatchIndex(pattern)
+	if loc == nil {
+		if strings.ContainsAny(pattern, "[]") {
+			return nil, fmt.Errorf("unbalanced brackets in %q", pattern)
+		}
+		return []string{pattern}, nil
+	}
+	values, err := expandList(pattern[loc[2]:loc[3]])
+	if err != nil {
+		return nil, fmt.Errorf("%s in %q", err, pattern)
+	}
+	prefix := pattern[:loc[0]]
+	if strings.ContainsAny(prefix, "[]") {
+		return nil, fmt.Errorf("unbalanced brackets in %q", pattern)
+	}
+	rest, err := Expand(pattern[loc[1]:])
+	if err != nil {
+		return nil, err
+	}
+	if len(values)*len(rest) > MaxObjects {
+		return nil, fmt.Errorf("%q expands into more than %d values", pattern, MaxObjects)
+	}
+	expanded := make([]string, 0, len(values)*len(rest))
+	for _, value := range values {
+		for _, suffix := range rest {
+			expanded = append(expanded, prefix+value+suffix)
+		}
+	}
+	return expanded, nil
+}
+
+// expandList expands the comma-separated values and ranges inside a pair of brackets.
+func expandList(list string) ([]string, error) {
+	var values []string
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		start, end, isRange := strings.Cut(part, "-")
+		if !alphanumeric.MatchString(start) || isRange && !alphanumeric.MatchString(end) {
+			return nil, fmt.Errorf("invalid value %q", part)
+		}
+		if !isRange {
+			values = append(values, part)
+			continue
+		}
+		expanded, err := expandRange(start, end)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, expanded...)
+		if len(values) > MaxObjects {
+			return nil, fmt.Errorf("range %q has more than %d values", part, MaxObjects)
+		}
+	}
+	return values, nil
+}
+
+// expandRange returns the numbers or letters from start to end.
+func expandRange(start, end string) ([]string, error) {
+	first, err1 := strconv.Atoi(start)
+	last, err2 := strconv.Atoi(end)
+	if err1 == nil && err2 == nil {
+		if first > last {
+			return nil, fmt.Errorf("range %s-%s runs backwards", start, end)
+		}
+		if last-first >= MaxObjects {
+			return nil, fmt.Errorf("range %s-%s has more than %d values", start, end, MaxObjects)
+		}
+		width := 0
+		if len(start) > 1 && start[0] == '0' {
+			width = len(start)
+		}
+		var values []string
+		for n := first; n <= last; n++ {
+			values = append(values, fmt.Sprintf("%0*d", width, n))
+		}
+		return values, nil
+	}
+	if len(start) != 1 || len(end) != 1 || !sameCaseLetters(start[0], end[0]) {
+		return nil, fmt.Errorf("invalid range %s-%s: ranges run between two numbers or two letters of the same case", start, end)
+	}
+	if start[0] > end[0] {
+		return nil, fmt.Errorf("range %s-%s runs backwards", start, end)
+	}
+	var values []string
+	for c := start[0]; c <= end[0]; c++ {
+		values = append(values, string(c))
+	}
+	return values, nil
+}
+
+func sameCaseLetters(a, b byte) bool {
+	lower := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	upper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	return lower(a) && lower(b) || upper(a) && upper(b)
+}
+
+// ExpandData expands the patterns in the --data payload data, an object or a list of objects,
+// into one object per name. It returns the payload and the number of objects the patterns
+// expanded into, 0 when there was no pattern and data is returned as it is.
+func ExpandData(data string) (string, int, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return "", 0, fmt.Errorf("--data is not valid JSON: %s", err)
+	}
+	items, isList := payload.([]interface{})
+	if !isList {
+		items = []interface{}{payload}
+	}
+
+	var objects []interface{}
+	count := 0
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			if isList {
+				return "", 0, fmt.Errorf("item %d of --data is not an object", i)
+			}
+			return "", 0, fmt.Errorf("--data is not an object or a list of objects")
+		}
+		expanded, err := expandObject(object)
+		if err != nil {
+			return "", 0, err
+		}
+		if expanded != nil {
+			count += len(expanded)
+			objects = append(objects, expanded...)
+		} else {
+			objects = append(objects, object)
+		}
+	}
+	if count == 0 {
+		return data, 0, nil
+	}
+	if len(objects) > MaxObjects {
+		return "", 0, fmt.Errorf("--data expands into %d objects, more than %d", len(objects), MaxObjects)
+	}
+	out, err := json.Marshal(objects)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(out), count, nil
+}
+
+// expandObject returns a copy of object for every name its name pattern stands for, or nil
+// when the object has no pattern.
+func expandObject(object map[string]interface{}) ([]interface{}, error) {
+	name, _ := object["name"].(string)
+	if !HasPattern(name) {
+		for _, field := range fields[1:] {
+			if value, ok := object[field].(string); ok && HasPattern(value) {
+				return nil, fmt.Errorf("%s %q is a pattern but name %q is not", field, value, name)
+			}
+		}
+		return nil, nil
+	}
+
+	values := map[string][]string{}
+	for _, field := range fields {
+		value, ok := object[field].(string)
+		if !ok || !HasPattern(value) {
+			continue
+		}
+		expanded, err := Expand(value)
+		if err != nil {
+			return nil, err
+		}
+		if field == "rear_port_position" {
+			for _, position := range expanded {
+				if _, err := strconv.Atoi(position); err != nil {
+					return nil, fmt.Errorf("rear_port_position %q expands into %q, which is not a number", value, position)
+				}
+			}
+		}
+		if field != "name" && len(expanded) != len(values["name"]) {
+			return nil, fmt.Errorf("%s %q expands into %d values but name %q into %d; the counts must match", field, value, len(expanded), name, len(values["name"]))
+		}
+		values[field] = expanded
+	}
+
+	objects := make([]interface{}, len(values["name"]))
+	for i := range objects {
+		member := make(map[string]interface{}, len(object))
+		for key, value := range object {
+			member[key] = value
+		}
+		for field, expanded := range values {
+			member[field] = expanded[i]
+			if field == "rear_port_position" {
+				position, _ := strconv.Atoi(expanded[i])
+				member[field] = position
+			}
+		}
+		objects[i] = member
+	}
+	return objects, nil
+}
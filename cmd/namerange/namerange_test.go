@@ -0,0 +1,104 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package namerange
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	for pattern, want := range map[string][]string{
+		"Ethernet1":          {"Ethernet1"},
+		"Ethernet1/[1-3]":    {"Ethernet1/1", "Ethernet1/2", "Ethernet1/3"},
+		"xe-0/0/[0-1]:[0-1]": {"xe-0/0/0:0", "xe-0/0/0:1", "xe-0/0/1:0", "xe-0/0/1:1"},
+		"[ge,xe]-0/0/0":      {"ge-0/0/0", "xe-0/0/0"},
+		"port[1,3,5-6]":      {"port1", "port3", "port5", "port6"},
+		"PSU[a-c]":           {"PSUa", "PSUb", "PSUc"},
+		"Gi0/[08-11]":        {"Gi0/08", "Gi0/09", "Gi0/10", "Gi0/11"},
+	} {
+		got, err := Expand(pattern)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Expand(%q) = %q, %v, want %q", pattern, got, err, want)
+		}
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"Ethernet1/[1-48":       "unbalanced brackets",
+		"Ethernet1/]1-48[":      "unbalanced brackets",
+		"Ethernet1/[48-1]":      "runs backwards",
+		"Ethernet1/[a-D]":       "invalid range",
+		"Ethernet1/[1-b]":       "invalid range",
+		"Ethernet1/[]":          "invalid value",
+		"Ethernet1/[1,,2]":      "invalid value",
+		"Ethernet[1-40]/[1-40]": "more than 1000",
+	} {
+		if _, err := Expand(pattern); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expand(%q) error = %v, want %q", pattern, err, want)
+		}
+	}
+}
+
+func TestExpandData(t *testing.T) {
+	out, count, err := ExpandData(`{"device": 1, "name": "Ethernet1/[1-2]", "label": "port [1-2]", "type": "10gbase-x-sfpp", "mtu": 9216, "tags": [{"name": "core"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"device":1,"label":"port 1","mtu":9216,"name":"Ethernet1/1","tags":[{"name":"core"}],"type":"10gbase-x-sfpp"},` +
+		`{"device":1,"label":"port 2","mtu":9216,"name":"Ethernet1/2","tags":[{"name":"core"}],"type":"10gbase-x-sfpp"}]`
+	if out != want || count != 2 {
+		t.Errorf("ExpandData = %s, %d\nwant %s, 2", out, count, want)
+	}
+
+	out, count, err = ExpandData(`[{"device": 1, "name": "Ethernet1"}, {"device": 1, "rear_port": 4, "name": "front[1-2]", "rear_port_position": "[1-2]"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `[{"device":1,"name":"Ethernet1"},{"device":1,"name":"front1","rear_port":4,"rear_port_position":1},{"device":1,"name":"front2","rear_port":4,"rear_port_position":2}]`
+	if out != want || count != 2 {
+		t.Errorf("ExpandData = %s, %d\nwant %s, 2", out, count, want)
+	}
+
+	data := `{"device": 1, "name": "Ethernet1"}`
+	if out, count, err := ExpandData(data); out != data || count != 0 || err != nil {
+		t.Errorf("ExpandData without a pattern = %s, %d, %v", out, count, err)
+	}
+}
+
+func TestExpandDataErrors(t *testing.T) {
+	for data, want := range map[string]string{
+		`{"name": "Ethernet1/[1-4]", "label": "[1-2]"}`:     "the counts must match",
+		`{"name": "Ethernet1", "label": "[1-2]"}`:           "is a pattern but name",
+		`{"name": "f[1-2]", "rear_port_position": "[a-b]"}`: "not a number",
+		`["Ethernet1/[1-4]"]`:                               "item 0 of --data is not an object",
+		`"Ethernet1/[1-4]"`:                                 "not an object or a list of objects",
+		`{"name": `:                                         "not valid JSON",
+	} {
+		if _, _, err := ExpandData(data); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ExpandData(%s) error = %v, want %q", data, err, want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/spf13/cobra"
+)
+
+// objectEndpoint is the API endpoint of a resource of the noun-verb grammar, such as dcim devices.
+type objectEndpoint struct {
+	domain   string
+	resource string
+	// key is its netbox_config.yaml key, e.g. cmd.dcim.dcim_api_url.devices_id.
+	key string
+	// path is the API path of the collection, e.g. /api/dcim/devices/.
+	path string
+	// objectType is the model of its objects, e.g. dcim.device.
+	objectType string
+}
+
+// lookupEndpoint returns the endpoint of the resource named by domain and resource, as in
+// "dcim devices" or "ipam ip-addresses".
+func lookupEndpoint(domain string, resource string) (*objectEndpoint, error) {
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("cmd.%s.%s_api_url.%s_id", domain, domain, strings.ReplaceAll(resource, "-", "_"))
+	path := strings.SplitN(config.GetString(key), "?", 2)[0]
+	if path == "" {
+		return nil, fmt.Errorf("unknown resource %q, see %s --help for the resources of a domain", domain+" "+resource, "abc-netbox.cli "+domain)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return &objectEndpoint{domain: domain, resource: resource, key: key, path: path, objectType: customfields.ObjectType(path)}, nil
+}
+
+// completeResource completes the domain and resource arguments of commands that work on objects
+// of any resource, from the domains and resources of the noun-verb grammar.
+func completeResource(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var candidates []string
+	switch len(args) {
+	case 0:
+		for _, domain := range cmd.Root().Commands() {
+			if isDomainCommand(domain) {
+				candidates = append(candidates, domain.Name()+"\t"+strings.TrimSpace(domain.Short))
+			}
+		}
+	case 1:
+		domain, _, err := cmd.Root().Find(args[:1])
+		if err != nil || !isDomainCommand(domain) {
+			break
+		}
+		for _, resource := range domain.Commands() {
+			candidates = append(candidates, resource.Name())
+		}
+	}
+	sort.Strings(candidates)
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isDomainCommand reports whether c is a domain of the noun-verb grammar, such as dcim.
+func isDomainCommand(c *cobra.Command) bool {
+	for _, resource := range c.Commands() {
+		for _, verb := range resource.Commands() {
+			if _, ok := verb.Annotations[endpointAnnotation]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterConfirmLimit is the number of objects --filter may select for a command that changes them
+// without --yes. Netbox ignores filters it does not know, so a mistyped key selects every object.
+const filterConfirmLimit = 5
+
+// objectSelector selects the objects a command works on: objects given as arguments, by ID or
+// name, objects given with --id and every object matching the --filter filters.
+type objectSelector struct {
+	ids     []int
+	filters []string
+	// confirm is set for commands that change the objects; yes is their --yes.
+	confirm bool
+	yes     bool
+}
+
+func (s *objectSelector) addFlags(c *cobra.Command) {
+	c.Flags().IntSliceVar(&s.ids, "id", nil, "IDs of the objects, e.g. 1,2,3")
+	c.Flags().StringArrayVar(&s.filters, "filter", nil, "Select every object matching a Netbox filter, as key=value, e.g. site=nyc1 (repeatable, all must match)")
+}
+
+// addConfirmFlag adds --yes to a command that changes the selected objects, which is needed when
+// --filter selects more than filterConfirmLimit of them.
+func (s *objectSelector) addConfirmFlag(c *cobra.Command) {
+	s.confirm = true
+	c.Flags().BoolVar(&s.yes, "yes", false, fmt.Sprintf("Change the objects --filter selects even when there are more than %d", filterConfirmLimit))
+}
+
+// selected reports whether any object was selected, so that no command works on every object of
+// an endpoint by accident.
+func (s *objectSelector) selected(names []string) bool {
+	return len(names) > 0 || len(s.ids) > 0 || len(s.filters) > 0
+}
+
+// objects returns the selected objects of endpoint on the instance of profile, without duplicates.
+// A name must match exactly one object by the name, slug or serial its model has.
+func (s *objectSelector) objects(ctx context.Context, profile string, rootURL string, endpoint *objectEndpoint, names []string) ([]*output.Object, error) {
+	var objects []*output.Object
+	seen := map[string]bool{}
+	add := func(found []interface{}) {
+		for _, item := range found {
+			o, ok := item.(*output.Object)
+			if !ok {
+				continue
+			}
+			id := fmt.Sprint(o.Get("id"))
+			if !seen[id] {
+				seen[id] = true
+				objects = append(objects, o)
+			}
+		}
+	}
+
+	ids := s.ids
+	for _, name := range names {
+		if id, err := strconv.Atoi(name); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		field, ok := api.NameField(endpoint.key)
+		if !ok {
+			return nil, fmt.Errorf("%s cannot be selected by name, use IDs, --id or --filter", endpoint.resource)
+		}
+		found, err := listObjects(ctx, profile, rootURL+endpoint.path, url.Values{field: {name}})
+		if err != nil {
+			return nil, err
+		}
+		// Netbox ignores a filter it does not know; only objects that have the name count.
+		var matches []interface{}
+		for _, item := range found {
+			if o, ok := item.(*output.Object); ok && o.Get(field) == name {
+				matches = append(matches, o)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, fmt.Errorf("%d %s match %s %q, select them with --id or --filter", len(matches), endpoint.resource, field, name)
+		}
+		add(matches)
+	}
+	for _, id := range ids {
+		object, status, err := getJSON(ctx, profile, fmt.Sprintf("%s%s%d/", rootURL, endpoint.path, id))
+		if status == 404 {
+			return nil, fmt.Errorf("no %s has ID %d", endpoint.resource, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		add([]interface{}{object})
+	}
+	if len(s.filters) > 0 {
+		filters := url.Values{}
+		for _, pair := range s.filters {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("invalid filter %q: expected key=value", pair)
+			}
+			filters.Add(strings.TrimSpace(key), value)
+		}
+		found, err := listObjects(ctx, profile, rootURL+endpoint.path, filters)
+		if err != nil {
+			return nil, err
+		}
+		if s.confirm && !s.yes && len(found) > filterConfirmLimit {
+			return nil, fmt.Errorf("--filter %s selects %d %s; Netbox ignores filters it does not know, so check the keys and give --yes to change them all",
+				strings.Join(s.filters, " --filter "), len(found), endpoint.resource)
+		}
+		add(found)
+	}
+	return objects, nil
+}
+
+// listObjects returns every page of the objects at collectionURL matching filters.
+func listObjects(ctx context.Context, profile string, collectionURL string, filters url.Values) ([]interface{}, error) {
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("limit", "1000")
+	next := collectionURL + "?" + query.Encode()
+	var objects []interface{}
+	for next != "" {
+		page, _, err := getJSON(ctx, profile, next)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := page.(*output.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response from %s", next)
+		}
+		results, _ := list.Get("results").([]interface{})
+		objects = append(objects, results...)
+		next, _ = list.Get("next").(string)
+	}
+	return objects, nil
+}
+
+// sendJSON sends body with method to url on the instance of a profile and decodes the response.
+// Responses other than 200, 201 and 204 are returned as errors with the reason Netbox gave.
+func sendJSON(ctx context.Context, profile string, method string, url string, body interface{}) (interface{}, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := session.Client().R().
+		SetContext(ctx).
+		SetHeaders(map[string]string{
+			"Authorization": session.ProfileToken(profile),
+			"Accept":        "application/json",
+			"Content-Type":  "application/json",
+		}).
+		SetBody(payload).
+		Execute(method, url)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode() {
+	case 200, 201:
+	case 204:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status(), strings.TrimSpace(string(resp.Body())))
+	}
+	v, err := output.Decode(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing the response bytes: %s", err)
+	}
+	return v, nil
+}
+
+// toInt64 returns an ID decoded by output.Decode as a number.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// objectName returns the name an object is shown by in messages.
+func objectName(o *output.Object) string {
+	for _, key := range []string{"display", "name"} {
+		if name, ok := o.Get(key).(string); ok && name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("#%v", o.Get("id"))
+}
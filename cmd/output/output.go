@@ -0,0 +1,367 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package output renders command results as text, JSON, YAML or a table.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+// The output formats.
+const (
+	Text  = "text"
+	JSON  = "json"
+	YAML  = "yaml"
+	Table = "table"
+)
+
+// Formats lists the output formats, for flag help and completion.
+var Formats = []string{Text, JSON, YAML, Table}
+
+// Default returns the format set with cmd.output in netbox_config.yaml, or text.
+func Default() string {
+	config, err := session.Config()
+	if err != nil || config.GetString("cmd.output") == "" {
+		return Text
+	}
+	return config.GetString("cmd.output")
+}
+
+// Object is a JSON object that keeps its keys in the order they were received, so that results
+// print in the order a query asked for them.
+type Object struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// Get returns the value of key.
+func (o *Object) Get(key string) interface{} {
+	return o.Values[key]
+}
+
+// MarshalJSON encodes o with its keys in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.Keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(o.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalYAML encodes o with its keys in order.
+func (o *Object) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range o.Keys {
+		value := new(yaml.Node)
+		if err := value.Encode(yamlValue(o.Values[key])); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+	return node, nil
+}
+
+// yamlValue turns the json.Number values in v into numbers, which YAML would otherwise quote.
+func yamlValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = yamlValue(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			out[key] = yamlValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+// Decode reads a JSON value, decoding objects as *Object and numbers as json.Number.
+func Decode(r io.Reader) (interface{}, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decodeValue(decoder)
+}
+
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		o := &Object{Values: map[string]interface{}{}}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			k := key.(string)
+			if _, seen := o.Values[k]; !seen {
+				o.Keys = append(o.Keys, k)
+			}
+			o.Values[k] = value
+		}
+		_, err = decoder.Token()
+		return o, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// Render writes v to w in format.
+func Render(w io.Writer, v interface{}, format string) error {
+	switch format {
+	case JSON:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(yamlValue(v)); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case Table:
+		return renderTable(w, v)
+	case Text, "":
+		renderText(w, v, "  ")
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// renderText writes v as indented "Key: value" lines in the colors the commands use.
+func renderText(w io.Writer, v interface{}, indent string) {
+	switch value := v.(type) {
+	case *Object:
+		for _, key := range value.Keys {
+			renderTextField(w, key, value.Values[key], indent)
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			renderTextField(w, key, value[key], indent)
+		}
+	case []interface{}:
+		for i, item := range value {
+			if i > 0 {
+				_, _ = fmt.Fprintln(w)
+			}
+			_, _ = fmt.Fprintln(w, indent+color.CyanString("[%d]", i))
+			renderText(w, item, indent+"  ")
+		}
+	default:
+		_, _ = fmt.Fprintln(w, indent+color.YellowString("%s", scalar(v)))
+	}
+}
+
+func renderTextField(w io.Writer, key string, v interface{}, indent string) {
+	switch value := v.(type) {
+	case *Object, map[string]interface{}:
+		_, _ = fmt.Fprintln(w, indent+color.CyanString("%s:", key))
+		renderText(w, value, indent+"  ")
+	case []interface{}:
+		if len(value) == 0 {
+			_, _ = fmt.Fprintln(w, indent+color.CyanString("%s: ", key)+color.YellowString("[]"))
+			return
+		}
+		if items, ok := scalars(value); ok {
+			_, _ = fmt.Fprintln(w, indent+color.CyanString("%s: ", key)+color.YellowString("%s", strings.Join(items, ", ")))
+			return
+		}
+		_, _ = fmt.Fprintln(w, indent+color.CyanString("%s:", key))
+		renderText(w, value, indent+"  ")
+	default:
+		_, _ = fmt.Fprintln(w, indent+color.CyanString("%s: ", key)+color.YellowString("%s", scalar(v)))
+	}
+}
+
+// renderTable writes the rows of v as a table. The rows are v itself if it is a list, or the list
+// found by descending through objects holding a single field, e.g. {"device_list": [...]}.
+// Nested objects become dotted columns; lists show their length.
+func renderTable(w io.Writer, v interface{}) error {
+	rows, ok := tableRows(v)
+	if !ok {
+		return fmt.Errorf("the result is not a list, use another output format")
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	var cells []map[string]string
+	for _, row := range rows {
+		flat := map[string]string{}
+		flatten("", row, flat, func(column string) {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		})
+		cells = append(cells, flat)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, flat := range cells {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = flat[column]
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+func tableRows(v interface{}) ([]interface{}, bool) {
+	for {
+		switch value := v.(type) {
+		case []interface{}:
+			return value, true
+		case *Object:
+			if len(value.Keys) != 1 {
+				return []interface{}{value}, true
+			}
+			v = value.Values[value.Keys[0]]
+		case map[string]interface{}:
+			if len(value) != 1 {
+				return []interface{}{value}, true
+			}
+			for _, only := range value {
+				v = only
+			}
+		default:
+			return nil, false
+		}
+	}
+}
+
+func flatten(prefix string, v interface{}, out map[string]string, column func(string)) {
+	add := func(key string, value interface{}) {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch nested := value.(type) {
+		case *Object, map[string]interface{}:
+			flatten(name, nested, out, column)
+		case []interface{}:
+			column(name)
+			out[name] = fmt.Sprintf("[%d]", len(nested))
+		default:
+			column(name)
+			out[name] = scalar(value)
+		}
+	}
+	switch value := v.(type) {
+	case *Object:
+		for _, key := range value.Keys {
+			add(key, value.Values[key])
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			add(key, value[key])
+		}
+	default:
+		column("value")
+		out["value"] = scalar(v)
+	}
+}
+
+// scalars returns the items of list as strings, if none of them is an object or a list.
+func scalars(list []interface{}) ([]string, bool) {
+	items := make([]string, len(list))
+	for i, item := range list {
+		switch item.(type) {
+		case *Object, map[string]interface{}, []interface{}:
+			return nil, false
+		}
+		items[i] = scalar(item)
+	}
+	return items, true
+}
+
+func scalar(v interface{}) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+const devices = `{"device_list": [
+  {"name": "nyc1-leaf1", "id": 1, "site": {"name": "NYC1"}, "tags": ["a", "b"], "serial": null},
+  {"name": "nyc1-leaf2", "id": 2, "site": {"name": "NYC1"}, "tags": [], "serial": "X2"}
+]}`
+
+func render(t *testing.T, format string) string {
+	t.Helper()
+	v, err := Decode(strings.NewReader(devices))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := Render(&b, v, format); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestRender(t *testing.T) {
+	color.NoColor = true
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{JSON, `{
+  "device_list": [
+    {
+      "name": "nyc1-leaf1",
+      "id": 1,
+      "site": {
+        "name": "NYC1"
+      },
+      "tags": [
+        "a",
+        "b"
+      ],
+      "serial": null
+    },
+    {
+      "name": "nyc1-leaf2",
+      "id": 2,
+      "site": {
+        "name": "NYC1"
+      },
+      "tags": [],
+      "serial": "X2"
+    }
+  ]
+}
+`},
+		{YAML, `device_list:
+  - name: nyc1-leaf1
+    id: 1
+    site:
+      name: NYC1
+    tags:
+      - a
+      - b
+    serial: null
+  - name: nyc1-leaf2
+    id: 2
+    site:
+      name: NYC1
+    tags: []
+    serial: X2
+`},
+		{Table, `NAME        ID  SITE.NAME  TAGS  SERIAL
+nyc1-leaf1  1   NYC1       [2]   -
+nyc1-leaf2  2   NYC1       [0]   X2
+`},
+		{Text, `  device_list:
+    [0]
+      name: nyc1-leaf1
+      id: 1
+      site:
+        name: NYC1
+      tags: a, b
+      serial: -
+
+    [1]
+      name: nyc1-leaf2
+      id: 2
+      site:
+        name: NYC1
+      tags: []
+      serial: X2
+`},
+	}
+	for _, tt := range tests {
+		if got := render(t, tt.format); got != tt.want {
+			t.Errorf("%s output:\n%s\nwant:\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	if err := Render(&bytes.Buffer{}, "x", "xml"); err == nil {
+		t.Errorf("rendered an unknown format")
+	}
+	if err := Render(&bytes.Buffer{}, "x", Table); err == nil {
+		t.Errorf("rendered a scalar as a table")
+	}
+}
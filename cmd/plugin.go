@@ -0,0 +1,303 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the file name prefix of plugin executables: abc-netbox-cli-foo on PATH runs as abc-netbox.cli foo.
+const pluginPrefix = "abc-netbox-cli-"
+
+// plugin is an executable found on PATH.
+type plugin struct {
+	name string
+	path string
+	// shadowedBy names what hides the plugin: a built-in command or an earlier plugin of the same name on PATH.
+	shadowedBy string
+}
+
+// PluginCmd represents the plugin command
+var PluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage external plugin commands",
+	Long: `
+ABC Netbox Automation Tools:
+  Manage external plugin commands.
+
+  Any executable named abc-netbox-cli-<name> on PATH can be run as "abc-netbox.cli <name>".
+  Arguments are passed on unchanged, except --env, which selects the Netbox server the plugin
+  is given. Plugins receive the session through the environment:
+
+    ABC_NETBOX_ENV        environment ('development' or 'production')
+    ABC_NETBOX_URL        root URL of the Netbox server
+    ABC_NETBOX_TOKEN      API token without the "Token " prefix: cmd.plugins.<name>.token_key,
+                          empty when it is not set (see below)
+    ABC_NETBOX_OUTPUT     output format, cmd.plugins.<name>.output or cmd.output (default "text")
+    ABC_NETBOX_NO_COLOR   "1" when colored output is disabled
+    ABC_NETBOX_CONFIG     path of the netbox_config.yaml in use
+    ABC_NETBOX_PLUGIN     name the plugin was run as
+
+  Give each plugin a token of its own, with only the permissions it needs. A plugin without one
+  is given no token, unless profile_token hands it the full token of the profile given with
+  --env, which can do anything the profile can:
+
+    cmd:
+        plugins:
+            <name>:
+                token_key: "Token ..."
+            <trusted-name>:
+                profile_token: true
+
+  "plugin list" shows which token each plugin is given.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugins found on PATH",
+	Long: `
+ABC Netbox Automation Tools:
+  List the abc-netbox-cli-<name> executables found on PATH.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plugins := discoverPlugins(cmd.Root())
+		if len(plugins) == 0 {
+			color.Yellow("  No plugins found on PATH (looking for %s<name>)", pluginPrefix)
+			return
+		}
+		config, _ := session.Config()
+		for _, p := range plugins {
+			color.Cyan("  %s"+color.YellowString("\t%s"), p.name, p.path)
+			if p.shadowedBy != "" {
+				color.Red("    warning: not runnable, hidden by %s", p.shadowedBy)
+				continue
+			}
+			switch {
+			case config != nil && config.GetString("cmd.plugins."+p.name+".token_key") != "":
+				// A token of its own needs no warning.
+			case config != nil && config.GetBool("cmd.plugins."+p.name+".profile_token"):
+				color.Yellow("    given the profile token (cmd.plugins.%s.profile_token)", p.name)
+			default:
+				color.Yellow("    given no token, set cmd.plugins.%s.token_key to give it one", p.name)
+			}
+		}
+	},
+}
+
+// discoverPlugins returns the plugins on PATH sorted by name, marking those that cannot be run
+// because a built-in command or an earlier plugin already has their name.
+func discoverPlugins(root *cobra.Command) []plugin {
+	var plugins []plugin
+	seen := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+
+			p := plugin{name: name, path: path}
+			if c, _, err := root.Find([]string{name}); err == nil && c != root && !isPluginCommand(c) {
+				p.shadowedBy = "built-in command " + c.Name()
+			} else if first, ok := seen[name]; ok {
+				p.shadowedBy = first
+			} else {
+				seen[name] = path
+			}
+			plugins = append(plugins, p)
+		}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool { return plugins[i].name < plugins[j].name })
+	return plugins
+}
+
+// pluginName returns the command name of a plugin file, or false if file is not a plugin.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode()&0111 != 0
+}
+
+// isPluginCommand reports whether c was added by addPluginCommands.
+func isPluginCommand(c *cobra.Command) bool {
+	_, ok := c.Annotations["plugin"]
+	return ok
+}
+
+// addPluginCommands adds a command to root for every runnable plugin on PATH. Built-in commands
+// always win over plugins of the same name.
+func addPluginCommands(root *cobra.Command) {
+	for _, p := range discoverPlugins(root) {
+		if p.shadowedBy != "" {
+			continue
+		}
+		p := p
+		root.AddCommand(&cobra.Command{
+			Use:                p.name,
+			Short:              "Plugin " + p.path,
+			Annotations:        map[string]string{"plugin": p.path, ownSignalsAnnotation: ""},
+			DisableFlagParsing: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runPlugin(p, args)
+			},
+		})
+	}
+}
+
+// runPlugin runs a plugin with the session in its environment. A plugin that fails returns an
+// error carrying its exit code, which the process then exits with.
+func runPlugin(p plugin, args []string) error {
+	env, args := pluginEnvArg(args)
+
+	environ, err := pluginEnviron(p.name, env)
+	if err != nil {
+		return fmt.Errorf("preparing plugin %s: %s", p.name, err)
+	}
+
+	c := exec.Command(p.path, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(), environ...)
+
+	err = c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// The plugin has reported its own errors.
+		return &exitError{code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("running plugin %s: %s", p.name, err)
+	}
+	return nil
+}
+
+// pluginEnvArg removes --env from the plugin's arguments and returns its value. Without --env the
+// shell's environment is used, or 'development' like every other command.
+func pluginEnvArg(args []string) (string, []string) {
+	env := session.Env()
+	if env == "" {
+		env = "development"
+	}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return env, append(rest, args[i:]...)
+		case args[i] == "--env" && i+1 < len(args):
+			env = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--env="):
+			env = strings.TrimPrefix(args[i], "--env=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return env, rest
+}
+
+// pluginEnviron returns the ABC_NETBOX_* variables handed to a plugin.
+func pluginEnviron(name string, env string) ([]string, error) {
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	rootURL, err := session.RootURL(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// The profile token is only handed over when the configuration asks for it.
+	token := config.GetString("cmd.plugins." + name + ".token_key")
+	if token == "" && config.GetBool("cmd.plugins."+name+".profile_token") {
+		token = session.ProfileToken(env)
+	}
+	if token == "" {
+		_, _ = fmt.Fprintln(color.Error, color.YellowString("  Warning: plugin %s is given no token, set cmd.plugins.%s.token_key to give it one", name, name))
+	}
+	output := config.GetString("cmd.plugins." + name + ".output")
+	if output == "" {
+		output = config.GetString("cmd.output")
+	}
+	if output == "" {
+		output = "text"
+	}
+	noColor := "0"
+	if color.NoColor {
+		noColor = "1"
+	}
+	configFile, _ := filepath.Abs(config.ConfigFileUsed())
+
+	return []string{
+		"ABC_NETBOX_ENV=" + env,
+		"ABC_NETBOX_URL=" + rootURL,
+		"ABC_NETBOX_TOKEN=" + strings.TrimPrefix(token, "Token "),
+		"ABC_NETBOX_OUTPUT=" + output,
+		"ABC_NETBOX_NO_COLOR=" + noColor,
+		"ABC_NETBOX_CONFIG=" + configFile,
+		"ABC_NETBOX_PLUGIN=" + name,
+	}, nil
+}
+
+func init() {
+	PluginCmd.AddCommand(pluginListCmd)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+	"github.com/spf13/cobra"
+)
+
+func TestPluginName(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		ok   bool
+	}{
+		"abc-netbox-cli-audit":     {"audit", true},
+		"abc-netbox-cli-ip-report": {"ip-report", true},
+		"abc-netbox-cli-":          {"", false},
+		"abc-netbox.cli":           {"", false},
+		"kubectl-audit":            {"", false},
+	}
+	for file, want := range tests {
+		name, ok := pluginName(file)
+		if name != want.name || ok != want.ok {
+			t.Errorf("pluginName(%q) = %q, %v, want %q, %v", file, name, ok, want.name, want.ok)
+		}
+	}
+}
+
+func TestPluginEnvArg(t *testing.T) {
+	tests := []struct {
+		args []string
+		env  string
+		rest []string
+	}{
+		{nil, "development", nil},
+		{[]string{"--env", "production", "report", "-v"}, "production", []string{"report", "-v"}},
+		{[]string{"report", "--env=emea"}, "emea", []string{"report"}},
+		{[]string{"report", "--", "--env", "production"}, "development", []string{"report", "--", "--env", "production"}},
+		{[]string{"report", "--env"}, "development", []string{"report", "--env"}},
+	}
+	for _, tt := range tests {
+		env, rest := pluginEnvArg(tt.args)
+		if env != tt.env || !reflect.DeepEqual(rest, tt.rest) {
+			t.Errorf("pluginEnvArg(%q) = %q, %q, want %q, %q", tt.args, env, rest, tt.env, tt.rest)
+		}
+	}
+}
+
+// writePlugin writes an executable shell script named file into dir.
+func writePlugin(t *testing.T, dir string, file string, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	audit := writePlugin(t, first, "abc-netbox-cli-audit", "true")
+	shadowedAudit := writePlugin(t, second, "abc-netbox-cli-audit", "true")
+	status := writePlugin(t, second, "abc-netbox-cli-status", "true")
+	if err := os.WriteFile(filepath.Join(second, "abc-netbox-cli-notes"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+
+	root := &cobra.Command{Use: "abc-netbox.cli"}
+	root.AddCommand(&cobra.Command{Use: "status", Run: func(*cobra.Command, []string) {}})
+
+	got := discoverPlugins(root)
+	want := []plugin{
+		{name: "audit", path: audit},
+		{name: "audit", path: shadowedAudit, shadowedBy: audit},
+		{name: "status", path: status, shadowedBy: "built-in command status"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("discoverPlugins() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPluginExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "abc-netbox-cli-fail", `echo "env=$ABC_NETBOX_ENV args=$*"; echo "failed" >&2; exit 4`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	config := map[string]string{"cmd.plugins.fail.token_key": "Token plugin-token"}
+	result := run(t, srv, netboxtest.Options{Config: config}, "fail", "--env", "production", "report")
+	if result.ExitCode != 4 {
+		t.Errorf("exit status %d, want 4:\n%s", result.ExitCode, result)
+	}
+	if result.Stdout != "env=production args=report\n" || result.Stderr != "failed\n" {
+		t.Errorf("plugin output:\n%s", result)
+	}
+}
+
+// TestPluginToken checks that a plugin is only given the profile token when configured to.
+func TestPluginToken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "abc-netbox-cli-audit", `echo "token=$ABC_NETBOX_TOKEN"`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   string
+		warned bool
+	}{
+		{"own_token", map[string]string{"cmd.plugins.audit.token_key": "Token plugin-token"}, "token=plugin-token\n", false},
+		{"profile_token", map[string]string{"cmd.plugins.audit.profile_token": "true"}, "token=profile-token\n", false},
+		{"no_token", nil, "token=\n", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			config := map[string]string{"cmd.token_key": "Token profile-token"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+			result := run(t, srv, netboxtest.Options{Config: config}, "audit")
+			if result.ExitCode != 0 || result.Stdout != tt.want || strings.Contains(result.Stderr, "given no token") != tt.warned {
+				t.Errorf("plugin run:\n%s", result)
+			}
+		})
+	}
+}
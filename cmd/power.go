@@ -0,0 +1,579 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// powerBatchSize is the number of IDs asked for in one request.
+const powerBatchSize = 100
+
+// feedLegs are the legs of a three-phase feed, as outlets name them.
+var feedLegs = []string{"A", "B", "C"}
+
+var powerEnv string
+
+var powerSite string
+
+var powerLocation string
+
+var powerPanels []string
+
+var powerRacks []string
+
+var powerThreshold float64
+
+var powerOutput string
+
+// PowerCmd represents the power command
+var PowerCmd = &cobra.Command{
+	Use:   "power",
+	Short: "Report on the power budget of racks, panels and feeds",
+	Long: `
+ABC Netbox Automation Tools:
+  Report on the power modelled in Netbox: power panels, their feeds, and the PDUs and devices
+  drawing from them.`,
+}
+
+// PowerReportCmd represents the power report command
+var PowerReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report the power utilisation of feeds, panels and racks",
+	Long: `
+ABC Netbox Automation Tools:
+  Walk every power feed to the PDU power port it feeds, the outlets of the PDU and the device
+  power ports plugged into them, and sum their allocated and maximum draw against the power
+  available on the feed: voltage x amperage x max utilisation, x sqrt(3) for three-phase feeds,
+  whose legs are reported one by one. A PDU without outlets counts with its own draw.
+
+    abc-netbox.cli power report --site nyc1 --env production
+    abc-netbox.cli power report --rack R01 --threshold 80 --env production -o json
+
+  Feeds, legs, panels and racks whose allocated draw is over --threshold percent of their
+  available power are flagged. Panels and racks add up their feeds, as Netbox does.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runPowerReport(cmd.Context()); err != nil {
+			return fmt.Errorf("reporting power: %s", err)
+		}
+		return nil
+	},
+}
+
+// powerLoad is the draw on a power budget, in watts.
+type powerLoad struct {
+	name      string
+	allocated float64
+	maximum   float64
+	available float64
+}
+
+func (l *powerLoad) add(other *powerLoad) {
+	l.allocated += other.allocated
+	l.maximum += other.maximum
+	l.available += other.available
+}
+
+// percent returns draw as a percentage of the available power, 0 if there is none.
+func (l *powerLoad) percent(draw float64) float64 {
+	if l.available == 0 {
+		return 0
+	}
+	return math.Round(draw/l.available*1000) / 10
+}
+
+func (l *powerLoad) over() bool {
+	return l.available > 0 && l.percent(l.allocated) > powerThreshold
+}
+
+// feedReport is the load on a power feed, and on each of its legs if it is three-phase.
+type feedReport struct {
+	powerLoad
+	feed  *output.Object
+	panel string
+	rack  string
+	legs  []*powerLoad
+	pdus  []string
+}
+
+func runPowerReport(ctx context.Context) error {
+	if powerOutput == "" {
+		powerOutput = output.Default()
+	}
+	rootURL, err := session.RootURL(powerEnv)
+	if err != nil {
+		return fmt.Errorf("unrecognized environment: %s", powerEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+	endpoints := map[string]*objectEndpoint{}
+	for _, resource := range []string{"power-feeds", "power-panels", "power-ports", "power-outlets", "racks"} {
+		if endpoints[resource], err = lookupEndpoint("dcim", resource); err != nil {
+			return err
+		}
+	}
+
+	filters := url.Values{}
+	if powerSite != "" {
+		filters.Set("site", powerSite)
+	}
+	if powerLocation != "" {
+		filters.Set("location", powerLocation)
+	}
+	for _, selection := range []struct {
+		names    []string
+		resource string
+		filter   string
+	}{
+		{powerPanels, "power-panels", "power_panel_id"},
+		{powerRacks, "racks", "rack_id"},
+	} {
+		for _, name := range selection.names {
+			ids, err := idsByName(ctx, rootURL, endpoints[selection.resource], name)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				filters.Add(selection.filter, id)
+			}
+		}
+	}
+	feedList, err := listObjects(ctx, powerEnv, rootURL+endpoints["power-feeds"].path, filters)
+	if err != nil {
+		return err
+	}
+	if len(feedList) == 0 {
+		return fmt.Errorf("no power feeds found")
+	}
+
+	// The PDU power ports fed by each feed.
+	pduPorts := map[string][]string{}
+	var pduPortIDs []string
+	for _, item := range feedList {
+		feed, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		for _, port := range connectedEndpoints(feed, "dcim.powerport") {
+			id := fmt.Sprint(port.Get("id"))
+			pduPorts[fmt.Sprint(feed.Get("id"))] = append(pduPorts[fmt.Sprint(feed.Get("id"))], id)
+			pduPortIDs = append(pduPortIDs, id)
+		}
+	}
+	outletList, err := listObjectsByID(ctx, rootURL+endpoints["power-outlets"].path, "power_port_id", pduPortIDs)
+	if err != nil {
+		return err
+	}
+	// The outlets of each PDU power port, and the device power ports plugged into them.
+	outlets := map[string][]*output.Object{}
+	portIDs := append([]string(nil), pduPortIDs...)
+	for _, item := range outletList {
+		outlet, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		inlet, ok := outlet.Get("power_port").(*output.Object)
+		if !ok {
+			continue
+		}
+		outlets[fmt.Sprint(inlet.Get("id"))] = append(outlets[fmt.Sprint(inlet.Get("id"))], outlet)
+		for _, port := range connectedEndpoints(outlet, "dcim.powerport") {
+			portIDs = append(portIDs, fmt.Sprint(port.Get("id")))
+		}
+	}
+	portList, err := listObjectsByID(ctx, rootURL+endpoints["power-ports"].path, "id", portIDs)
+	if err != nil {
+		return err
+	}
+	ports := map[string]*output.Object{}
+	for _, item := range portList {
+		if port, ok := item.(*output.Object); ok {
+			ports[fmt.Sprint(port.Get("id"))] = port
+		}
+	}
+
+	var feeds []*feedReport
+	for _, item := range feedList {
+		feed, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		feeds = append(feeds, newFeedReport(feed, pduPorts[fmt.Sprint(feed.Get("id"))], outlets, ports))
+	}
+	panels := sumFeeds(feeds, func(f *feedReport) string { return f.panel })
+	racks := sumFeeds(feeds, func(f *feedReport) string { return f.rack })
+
+	if powerOutput != output.Text {
+		return output.Render(os.Stdout, powerReportObject(panels, feeds, racks), powerOutput)
+	}
+	renderPowerReport(panels, feeds, racks)
+	return nil
+}
+
+// newFeedReport sums the draw on a feed. The draw of a PDU power port is the sum of the device
+// power ports plugged into its outlets, split by the leg of the outlet; a PDU without outlets
+// draws its own allocated and maximum draw.
+func newFeedReport(feed *output.Object, pduPortIDs []string, outlets map[string][]*output.Object, ports map[string]*output.Object) *feedReport {
+	report := &feedReport{feed: feed, powerLoad: powerLoad{name: objectName(feed), available: feedAvailablePower(feed)}}
+	if panel, ok := feed.Get("power_panel").(*output.Object); ok {
+		report.panel = objectName(panel)
+	}
+	if rack, ok := feed.Get("rack").(*output.Object); ok {
+		report.rack = objectName(rack)
+	}
+	threePhase := choiceValue(feed.Get("phase")) == "three-phase"
+	legs := map[string]*powerLoad{}
+	if threePhase {
+		for _, leg := range feedLegs {
+			legs[leg] = &powerLoad{name: "leg " + leg, available: math.Round(report.available / 3)}
+			report.legs = append(report.legs, legs[leg])
+		}
+	}
+
+	for _, id := range pduPortIDs {
+		pdu, ok := ports[id]
+		if !ok {
+			continue
+		}
+		if device, ok := pdu.Get("device").(*output.Object); ok {
+			report.pdus = append(report.pdus, objectName(device)+":"+objectName(pdu))
+		}
+		if len(outlets[id]) == 0 {
+			report.allocated += drawOf(pdu, "allocated_draw")
+			report.maximum += drawOf(pdu, "maximum_draw")
+			continue
+		}
+		for _, outlet := range outlets[id] {
+			leg := legs[choiceValue(outlet.Get("feed_leg"))]
+			for _, endpoint := range connectedEndpoints(outlet, "dcim.powerport") {
+				port, ok := ports[fmt.Sprint(endpoint.Get("id"))]
+				if !ok {
+					continue
+				}
+				allocated, maximum := drawOf(port, "allocated_draw"), drawOf(port, "maximum_draw")
+				report.allocated += allocated
+				report.maximum += maximum
+				if leg != nil {
+					leg.allocated += allocated
+					leg.maximum += maximum
+				}
+			}
+		}
+	}
+	return report
+}
+
+// feedAvailablePower returns the power a feed may deliver, as Netbox computes it.
+func feedAvailablePower(feed *output.Object) float64 {
+	if available, ok := toFloat(feed.Get("available_power")); ok {
+		return available
+	}
+	voltage, _ := toFloat(feed.Get("voltage"))
+	amperage, _ := toFloat(feed.Get("amperage"))
+	utilization, ok := toFloat(feed.Get("max_utilization"))
+	if !ok {
+		utilization = 100
+	}
+	available := math.Abs(voltage) * amperage * utilization / 100
+	if choiceValue(feed.Get("phase")) == "three-phase" {
+		available *= math.Sqrt(3)
+	}
+	return math.Round(available)
+}
+
+func drawOf(port *output.Object, field string) float64 {
+	draw, _ := toFloat(port.Get(field))
+	return draw
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+	return f, err == nil
+}
+
+// connectedEndpoints returns the endpoints an object is connected to when they are of kind.
+func connectedEndpoints(o *output.Object, kind string) []*output.Object {
+	if t, _ := o.Get("connected_endpoints_type").(string); t != kind {
+		return nil
+	}
+	return objectList(o.Get("connected_endpoints"))
+}
+
+// sumFeeds adds up the feeds by the key returned by key, e.g. their panel, leaving out feeds
+// without one, in the order the keys come in.
+func sumFeeds(feeds []*feedReport, key func(f *feedReport) string) []*powerLoad {
+	var loads []*powerLoad
+	byName := map[string]*powerLoad{}
+	for _, f := range feeds {
+		name := key(f)
+		if name == "" {
+			continue
+		}
+		if byName[name] == nil {
+			byName[name] = &powerLoad{name: name}
+			loads = append(loads, byName[name])
+		}
+		byName[name].add(&f.powerLoad)
+	}
+	return loads
+}
+
+// listObjectsByID lists the objects at collectionURL whose filter is one of ids, in batches.
+func listObjectsByID(ctx context.Context, collectionURL string, filter string, ids []string) ([]interface{}, error) {
+	sort.Strings(ids)
+	var unique []string
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			unique = append(unique, id)
+		}
+	}
+	var objects []interface{}
+	for start := 0; start < len(unique); start += powerBatchSize {
+		end := start + powerBatchSize
+		if end > len(unique) {
+			end = len(unique)
+		}
+		found, err := listObjects(ctx, powerEnv, collectionURL, url.Values{filter: unique[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, found...)
+	}
+	return objects, nil
+}
+
+// idsByName returns the ID given, or the IDs of the objects of endpoint with the name given.
+func idsByName(ctx context.Context, rootURL string, endpoint *objectEndpoint, name string) ([]string, error) {
+	if _, err := strconv.Atoi(name); err == nil {
+		return []string{name}, nil
+	}
+	found, err := listObjects(ctx, powerEnv, rootURL+endpoint.path, url.Values{"name": {name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no %s named %q", endpoint.resource, name)
+	}
+	var ids []string
+	for _, item := range found {
+		if o, ok := item.(*output.Object); ok {
+			ids = append(ids, fmt.Sprint(o.Get("id")))
+		}
+	}
+	return ids, nil
+}
+
+// renderPowerReport prints the panels with their feeds and legs, then the racks, as tables with
+// the rows over the threshold in red, and a summary of those.
+func renderPowerReport(panels []*powerLoad, feeds []*feedReport, racks []*powerLoad) {
+	color.Cyan("  Power report, flagging allocated draw over %s%% of the available power\n", formatNumber(powerThreshold))
+	var flagged []string
+	flag := func(kind string, l *powerLoad) {
+		if l.over() {
+			flagged = append(flagged, fmt.Sprintf("%s %s at %s%%", kind, l.name, formatNumber(l.percent(l.allocated))))
+		}
+	}
+
+	var rows [][]string
+	var over []bool
+	addRow := func(name string, details string, l *powerLoad) {
+		rows = append(rows, []string{name, details, watts(l.allocated), watts(l.maximum), watts(l.available), formatNumber(l.percent(l.allocated)) + "%", formatNumber(l.percent(l.maximum)) + "%"})
+		over = append(over, l.over())
+	}
+	for _, panel := range panels {
+		addRow(panel.name, "", panel)
+		flag("panel", panel)
+		for _, f := range feeds {
+			if f.panel != panel.name {
+				continue
+			}
+			addRow("  "+f.name, feedDetails(f), &f.powerLoad)
+			flag("feed", &f.powerLoad)
+			for _, leg := range f.legs {
+				addRow("    "+leg.name, "", leg)
+				if leg.over() {
+					flagged = append(flagged, fmt.Sprintf("feed %s %s at %s%%", f.name, leg.name, formatNumber(leg.percent(leg.allocated))))
+				}
+			}
+		}
+	}
+	printPowerTable([]string{"PANEL / FEED", "RACK / PHASE", "ALLOCATED", "MAXIMUM", "AVAILABLE", "ALLOCATED %", "MAXIMUM %"}, rows, over)
+
+	if len(racks) > 0 {
+		rows, over = nil, nil
+		for _, rack := range racks {
+			addRow(rack.name, "", rack)
+			flag("rack", rack)
+		}
+		for i := range rows {
+			rows[i] = append(rows[i][:1], rows[i][2:]...)
+		}
+		printPowerTable([]string{"RACK", "ALLOCATED", "MAXIMUM", "AVAILABLE", "ALLOCATED %", "MAXIMUM %"}, rows, over)
+	}
+
+	fmt.Println()
+	if len(flagged) == 0 {
+		color.Green("  Nothing over %s%%.", formatNumber(powerThreshold))
+		return
+	}
+	color.Red("  %d over %s%%:", len(flagged), formatNumber(powerThreshold))
+	for _, line := range flagged {
+		color.Red("    %s", line)
+	}
+}
+
+func printPowerTable(header []string, rows [][]string, over []bool) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	fmt.Println()
+	for i, line := range lines {
+		line = "  " + strings.TrimRight(line, " ")
+		switch {
+		case i == 0:
+			color.Cyan(line)
+		case over[i-1]:
+			color.Red(line)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// feedDetails returns the rack and electrical details of a feed, e.g. R01 208V 30A three-phase.
+func feedDetails(f *feedReport) string {
+	details := []string{}
+	if f.rack != "" {
+		details = append(details, f.rack)
+	}
+	details = append(details, fmt.Sprintf("%vV %vA %s", f.feed.Get("voltage"), f.feed.Get("amperage"), choiceValue(f.feed.Get("phase"))))
+	return strings.Join(details, " ")
+}
+
+func watts(w float64) string {
+	return formatNumber(w) + " W"
+}
+
+// formatNumber formats n without a decimal part when it has none.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// powerReportObject returns the report for the structured output formats.
+func powerReportObject(panels []*powerLoad, feeds []*feedReport, racks []*powerLoad) *output.Object {
+	feedObjects := make([]interface{}, len(feeds))
+	for i, f := range feeds {
+		o := f.powerLoad.object()
+		o.Keys = append([]string{"name", "panel", "rack", "phase", "voltage", "amperage", "max_utilization"}, o.Keys[1:]...)
+		o.Values["panel"] = f.panel
+		o.Values["rack"] = f.rack
+		o.Values["phase"] = choiceValue(f.feed.Get("phase"))
+		o.Values["voltage"] = f.feed.Get("voltage")
+		o.Values["amperage"] = f.feed.Get("amperage")
+		o.Values["max_utilization"] = f.feed.Get("max_utilization")
+		pdus := make([]interface{}, len(f.pdus))
+		for j, pdu := range f.pdus {
+			pdus[j] = pdu
+		}
+		legs := make([]interface{}, len(f.legs))
+		for j, leg := range f.legs {
+			legs[j] = leg.object()
+		}
+		o.Keys = append(o.Keys, "pdus", "legs")
+		o.Values["pdus"] = pdus
+		o.Values["legs"] = legs
+		feedObjects[i] = o
+	}
+	loads := func(list []*powerLoad) []interface{} {
+		objects := make([]interface{}, len(list))
+		for i, l := range list {
+			objects[i] = l.object()
+		}
+		return objects
+	}
+	return &output.Object{
+		Keys: []string{"threshold", "panels", "feeds", "racks"},
+		Values: map[string]interface{}{
+			"threshold": powerThreshold,
+			"panels":    loads(panels),
+			"feeds":     feedObjects,
+			"racks":     loads(racks),
+		},
+	}
+}
+
+func (l *powerLoad) object() *output.Object {
+	return &output.Object{
+		Keys: []string{"name", "allocated_draw", "maximum_draw", "available_power", "allocated_percent", "maximum_percent", "over_threshold"},
+		Values: map[string]interface{}{
+			"name":              l.name,
+			"allocated_draw":    l.allocated,
+			"maximum_draw":      l.maximum,
+			"available_power":   l.available,
+			"allocated_percent": l.percent(l.allocated),
+			"maximum_percent":   l.percent(l.maximum),
+			"over_threshold":    l.over(),
+		},
+	}
+}
+
+func init() {
+	PowerReportCmd.Flags().StringVarP(&powerEnv, "env", "", "development", "Environment ('development' or 'production')")
+	_ = PowerReportCmd.MarkFlagRequired("env")
+	PowerReportCmd.Flags().StringVar(&powerSite, "site", "", "Only report the feeds of a site, by slug")
+	PowerReportCmd.Flags().StringVar(&powerLocation, "location", "", "Only report the feeds of a location, by slug")
+	PowerReportCmd.Flags().StringSliceVar(&powerPanels, "panel", nil, "Only report the feeds of power panels, by name or ID")
+	PowerReportCmd.Flags().StringSliceVar(&powerRacks, "rack", nil, "Only report the feeds of racks, by name or ID")
+	PowerReportCmd.Flags().Float64Var(&powerThreshold, "threshold", 100, "Flag allocated draw over this percentage of the available power")
+	PowerReportCmd.Flags().StringVarP(&powerOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	_ = PowerReportCmd.RegisterFlagCompletionFunc("site", completeObjects("cmd.dcim.dcim_api_url.sites_id", "slug"))
+	_ = PowerReportCmd.RegisterFlagCompletionFunc("location", completeObjects("cmd.dcim.dcim_api_url.locations_id", "slug"))
+	_ = PowerReportCmd.RegisterFlagCompletionFunc("panel", completeObjects("cmd.dcim.dcim_api_url.power_panels_id", "name"))
+	_ = PowerReportCmd.RegisterFlagCompletionFunc("rack", completeObjects("cmd.dcim.dcim_api_url.racks_id", "name"))
+	_ = PowerReportCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+	PowerCmd.AddCommand(PowerReportCmd)
+}
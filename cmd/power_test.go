@@ -0,0 +1,52 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestPowerReport(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "default", args: []string{"power", "report", "--env", "development", "-o", "text"}},
+		{name: "threshold", args: []string{"power", "report", "--threshold", "80", "--env", "development", "-o", "text"}},
+		{name: "rack", args: []string{"power", "report", "--rack", "R02", "--env", "development", "-o", "text"}},
+		{name: "panel_json", args: []string{"power", "report", "--panel", "NYC1-PP-B", "--env", "development", "-o", "json"}},
+		{name: "unknown_rack", args: []string{"power", "report", "--rack", "R99", "--env", "development"}},
+		{name: "no_feeds", args: []string{"power", "report", "--rack", "3", "--env", "development"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "power/"+tt.name, result.String())
+		})
+	}
+}
@@ -0,0 +1,492 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// rackCellWidth bounds the width of the device names in an elevation column.
+const (
+	rackCellMinWidth = 20
+	rackCellMaxWidth = 36
+)
+
+var rackEnv string
+
+var rackFace string
+
+var rackLocation string
+
+var rackSite string
+
+var rackSVG string
+
+var rackOutput string
+
+var rackObjects objectSelector
+
+// RackCmd represents the rack command
+var RackCmd = &cobra.Command{
+	Use:   "rack",
+	Short: "Show what is mounted in racks",
+	Long: `
+ABC Netbox Automation Tools:
+  Show what is mounted in racks, as elevations drawn in the terminal or as the SVG drawings of
+  Netbox.`,
+}
+
+// RackElevationCmd represents the rack elevation command
+var RackElevationCmd = &cobra.Command{
+	Use:   "elevation [id|name]...",
+	Short: "Draw the elevations of racks",
+	Long: `
+ABC Netbox Automation Tools:
+  Draw the front or rear elevation of racks, unit by unit from the top, with the devices mounted
+  in them, reserved units and free units. Several racks are drawn side by side:
+
+    abc-netbox.cli rack elevation R01 --env production
+    abc-netbox.cli rack elevation --location row-a --face both --env production
+    abc-netbox.cli rack elevation R01 --svg r01.svg --env production
+
+  Racks are given by ID or name as arguments, with --id, with --filter, or all the racks of a
+  --location or --site, by slug. --svg saves the drawings of Netbox instead: to a file for a single
+  rack face, otherwise to a directory, as <rack>-<face>.svg.`,
+	ValidArgsFunction: completeObjects("cmd.dcim.dcim_api_url.racks_id", "name"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runRackElevation(cmd.Context(), args); err != nil {
+			return fmt.Errorf("drawing rack elevations: %s", err)
+		}
+		return nil
+	},
+}
+
+// rackElevation is a face of a rack with what is mounted in each of its units.
+type rackElevation struct {
+	rack *output.Object
+	face string
+	// units are listed from the top, as Netbox draws them.
+	units []rackUnit
+	// nonRacked are the devices assigned to the rack without a position, such as 0U PDUs.
+	nonRacked []string
+}
+
+type rackUnit struct {
+	number      int
+	device      *output.Object
+	reservation *output.Object
+}
+
+func runRackElevation(ctx context.Context, names []string) error {
+	if rackOutput == "" {
+		rackOutput = output.Default()
+	}
+	var faces []string
+	switch rackFace {
+	case "front", "rear":
+		faces = []string{rackFace}
+	case "both":
+		faces = []string{"front", "rear"}
+	default:
+		return fmt.Errorf("unknown face %q, use front, rear or both", rackFace)
+	}
+	// --location and --site are filters too; they go on a copy so the flag values stay as given.
+	selector := rackObjects
+	selector.filters = append([]string(nil), rackObjects.filters...)
+	if rackLocation != "" {
+		selector.filters = append(selector.filters, "location="+rackLocation)
+	}
+	if rackSite != "" {
+		selector.filters = append(selector.filters, "site="+rackSite)
+	}
+	if !selector.selected(names) {
+		return fmt.Errorf("no racks selected: give IDs or names, --id, --filter, --location or --site")
+	}
+	endpoint, err := lookupEndpoint("dcim", "racks")
+	if err != nil {
+		return err
+	}
+	rootURL, err := session.RootURL(rackEnv)
+	if err != nil {
+		return fmt.Errorf("unrecognized environment: %s", rackEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+	racks, err := selector.objects(ctx, rackEnv, rootURL, endpoint, names)
+	if err != nil {
+		return err
+	}
+	if len(racks) == 0 {
+		return fmt.Errorf("no racks match %s", strings.Join(selector.filters, ", "))
+	}
+
+	if rackSVG != "" {
+		return saveRackSVGs(ctx, rootURL, endpoint, racks, faces)
+	}
+	var elevations []*rackElevation
+	for _, rack := range racks {
+		more, err := fetchRackElevations(ctx, rootURL, endpoint, rack, faces)
+		if err != nil {
+			return err
+		}
+		elevations = append(elevations, more...)
+	}
+	if rackOutput != output.Text {
+		list := make([]interface{}, len(elevations))
+		for i, e := range elevations {
+			list[i] = e.object()
+		}
+		return output.Render(os.Stdout, list, rackOutput)
+	}
+	renderRackElevations(elevations)
+	return nil
+}
+
+// fetchRackElevations reads the faces of a rack from its elevation, with the devices and
+// reservations of the rack.
+func fetchRackElevations(ctx context.Context, rootURL string, endpoint *objectEndpoint, rack *output.Object, faces []string) ([]*rackElevation, error) {
+	rackID := fmt.Sprint(rack.Get("id"))
+	devicesEndpoint, err := lookupEndpoint("dcim", "devices")
+	if err != nil {
+		return nil, err
+	}
+	reservationsEndpoint, err := lookupEndpoint("dcim", "rack-reservations")
+	if err != nil {
+		return nil, err
+	}
+	deviceList, err := listObjects(ctx, rackEnv, rootURL+devicesEndpoint.path, url.Values{"rack_id": {rackID}})
+	if err != nil {
+		return nil, err
+	}
+	devices := map[string]*output.Object{}
+	var nonRacked []string
+	for _, item := range deviceList {
+		device, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		devices[fmt.Sprint(device.Get("id"))] = device
+		if device.Get("position") == nil {
+			nonRacked = append(nonRacked, objectName(device))
+		}
+	}
+	reservationList, err := listObjects(ctx, rackEnv, rootURL+reservationsEndpoint.path, url.Values{"rack_id": {rackID}})
+	if err != nil {
+		return nil, err
+	}
+	reservations := map[int]*output.Object{}
+	for _, item := range reservationList {
+		reservation, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		units, _ := reservation.Get("units").([]interface{})
+		for _, u := range units {
+			if n, ok := toInt64(u); ok {
+				reservations[int(n)] = reservation
+			}
+		}
+	}
+
+	var elevations []*rackElevation
+	for _, face := range faces {
+		units, err := listObjects(ctx, rackEnv, fmt.Sprintf("%s%s%s/elevation/", rootURL, endpoint.path, rackID), url.Values{"face": {face}, "expand_devices": {"true"}})
+		if err != nil {
+			return nil, err
+		}
+		e := &rackElevation{rack: rack, face: face, nonRacked: nonRacked}
+		for _, item := range units {
+			unit, ok := item.(*output.Object)
+			if !ok {
+				continue
+			}
+			// Half units of Netbox 4 are listed as decimals, e.g. 12.5; they are drawn with their unit.
+			n, err := strconv.ParseFloat(fmt.Sprint(unit.Get("id")), 64)
+			if err != nil || n != float64(int(n)) {
+				continue
+			}
+			ru := rackUnit{number: int(n), reservation: reservations[int(n)]}
+			if device, ok := unit.Get("device").(*output.Object); ok {
+				ru.device = device
+				if full, ok := devices[fmt.Sprint(device.Get("id"))]; ok {
+					ru.device = full
+				}
+			}
+			e.units = append(e.units, ru)
+		}
+		elevations = append(elevations, e)
+	}
+	return elevations, nil
+}
+
+// saveRackSVGs saves the SVG drawings Netbox renders of the faces of racks.
+func saveRackSVGs(ctx context.Context, rootURL string, endpoint *objectEndpoint, racks []*output.Object, faces []string) error {
+	single := strings.EqualFold(filepath.Ext(rackSVG), ".svg")
+	if single && len(racks)*len(faces) > 1 {
+		return fmt.Errorf("%d rack faces selected, give --svg a directory to save them to", len(racks)*len(faces))
+	}
+	if !single {
+		if err := os.MkdirAll(rackSVG, 0o755); err != nil {
+			return err
+		}
+	}
+	for _, rack := range racks {
+		for _, face := range faces {
+			resp, err := session.Client().R().
+				SetContext(ctx).
+				SetHeaders(map[string]string{
+					"Authorization": session.ProfileToken(rackEnv),
+					"Accept":        "image/svg+xml",
+				}).
+				SetQueryParams(map[string]string{"face": face, "render": "svg"}).
+				Get(fmt.Sprintf("%s%s%v/elevation/", rootURL, endpoint.path, rack.Get("id")))
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode() != 200 {
+				return fmt.Errorf("the elevation of %s returned %s", objectName(rack), resp.Status())
+			}
+			file := rackSVG
+			if !single {
+				file = filepath.Join(rackSVG, strings.ReplaceAll(objectName(rack), "/", "_")+"-"+face+".svg")
+			}
+			if err := os.WriteFile(file, resp.Body(), 0o644); err != nil {
+				return err
+			}
+			color.Cyan("  Saved the %s of "+color.YellowString("%s", objectName(rack))+" to %s", face, file)
+		}
+	}
+	return nil
+}
+
+// freeUnits returns the units without a device, from the bottom.
+func (e *rackElevation) freeUnits() []int {
+	var free []int
+	for i := len(e.units) - 1; i >= 0; i-- {
+		if e.units[i].device == nil {
+			free = append(free, e.units[i].number)
+		}
+	}
+	return free
+}
+
+// title returns the rack name and face heading a column.
+func (e *rackElevation) title() string {
+	return fmt.Sprintf("%s (%s)", objectName(e.rack), e.face)
+}
+
+// cell returns the text of the unit at index i of the elevation and the color to print it in.
+// A device is named in its top unit; its other units are marked as taken.
+func (e *rackElevation) cell(i int) (string, func(format string, a ...interface{}) string) {
+	unit := e.units[i]
+	switch {
+	case unit.device != nil:
+		if i > 0 && e.units[i-1].device != nil && fmt.Sprint(e.units[i-1].device.Get("id")) == fmt.Sprint(unit.device.Get("id")) {
+			return " :", color.YellowString
+		}
+		name := objectName(unit.device)
+		if face := choiceValue(unit.device.Get("face")); face != "" && face != e.face {
+			name += " (" + face + ")"
+		}
+		return name, color.YellowString
+	case unit.reservation != nil:
+		description, _ := unit.reservation.Get("description").(string)
+		return "reserved: " + description, color.MagentaString
+	}
+	return "", fmt.Sprintf
+}
+
+// renderRackElevations draws the elevations side by side, as many as fit the terminal on a row,
+// aligned on their bottom unit, then the free units of each.
+func renderRackElevations(elevations []*rackElevation) {
+	width := rackCellMinWidth
+	for _, e := range elevations {
+		if len(e.title()) > width {
+			width = len(e.title())
+		}
+		for i := range e.units {
+			if text, _ := e.cell(i); len(text)+2 > width {
+				width = len(text) + 2
+			}
+		}
+	}
+	if width > rackCellMaxWidth {
+		width = rackCellMaxWidth
+	}
+	columnWidth := width + 6
+	perRow := (terminalColumns() + 2) / (columnWidth + 2)
+	if perRow < 1 {
+		perRow = 1
+	}
+
+	for start := 0; start < len(elevations); start += perRow {
+		end := start + perRow
+		if end > len(elevations) {
+			end = len(elevations)
+		}
+		row := elevations[start:end]
+		height := 0
+		for _, e := range row {
+			if len(e.units) > height {
+				height = len(e.units)
+			}
+		}
+		columns := make([][]string, len(row))
+		for i, e := range row {
+			columns[i] = e.lines(width, height)
+		}
+		for line := range columns[0] {
+			parts := make([]string, len(columns))
+			for i := range columns {
+				parts[i] = columns[i][line]
+			}
+			fmt.Println(strings.TrimRight("  "+strings.Join(parts, "  "), " "))
+		}
+		fmt.Println()
+	}
+
+	for _, e := range elevations {
+		free := e.freeUnits()
+		fmt.Println(color.CyanString("  %s: ", e.title()) + color.GreenString("%d of %d U free", len(free), len(e.units)) + color.CyanString(" %s", unitRanges(free)))
+		if len(e.nonRacked) > 0 {
+			fmt.Println(color.CyanString("    Non-racked devices: ") + color.YellowString("%s", strings.Join(e.nonRacked, ", ")))
+		}
+	}
+}
+
+// lines draws the elevation as lines of the same visible width: its title, the units padded
+// above to height, and a frame.
+func (e *rackElevation) lines(width int, height int) []string {
+	blank := strings.Repeat(" ", width+6)
+	border := "    +" + strings.Repeat("-", width) + "+"
+	lines := []string{color.CyanString("%-*s", width+6, "    "+truncate(e.title(), width+2))}
+	for i := len(e.units); i < height; i++ {
+		lines = append(lines, blank)
+	}
+	lines = append(lines, border)
+	for i, unit := range e.units {
+		text, paint := e.cell(i)
+		lines = append(lines, fmt.Sprintf("%3d |", unit.number)+paint("%s", fmt.Sprintf(" %-*s", width-1, truncate(text, width-2)))+"|")
+	}
+	return append(lines, border)
+}
+
+// object returns the elevation for the structured output formats.
+func (e *rackElevation) object() *output.Object {
+	units := make([]interface{}, len(e.units))
+	for i, unit := range e.units {
+		var device, reservation interface{}
+		if unit.device != nil {
+			device = objectName(unit.device)
+		}
+		if unit.reservation != nil {
+			reservation = unit.reservation.Get("description")
+		}
+		units[i] = &output.Object{
+			Keys:   []string{"unit", "device", "reservation"},
+			Values: map[string]interface{}{"unit": unit.number, "device": device, "reservation": reservation},
+		}
+	}
+	free := make([]interface{}, 0)
+	for _, u := range e.freeUnits() {
+		free = append(free, u)
+	}
+	nonRacked := make([]interface{}, len(e.nonRacked))
+	for i, name := range e.nonRacked {
+		nonRacked[i] = name
+	}
+	return &output.Object{
+		Keys: []string{"rack", "face", "units", "free", "non_racked"},
+		Values: map[string]interface{}{
+			"rack":       objectName(e.rack),
+			"face":       e.face,
+			"units":      units,
+			"free":       free,
+			"non_racked": nonRacked,
+		},
+	}
+}
+
+// unitRanges returns ascending units as ranges, e.g. (1-19, 22, 25-30).
+func unitRanges(units []int) string {
+	if len(units) == 0 {
+		return ""
+	}
+	var ranges []string
+	for i := 0; i < len(units); {
+		j := i
+		for j+1 < len(units) && units[j+1] == units[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(units[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", units[i], units[j]))
+		}
+		i = j + 1
+	}
+	return "(" + strings.Join(ranges, ", ") + ")"
+}
+
+func truncate(s string, width int) string {
+	if len(s) <= width || width < 4 {
+		return s
+	}
+	return s[:width-3] + "..."
+}
+
+// terminalColumns returns the width of the terminal from $COLUMNS, or 120.
+func terminalColumns() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 120
+}
+
+func init() {
+	RackElevationCmd.Flags().StringVarP(&rackEnv, "env", "", "development", "Environment ('development' or 'production')")
+	_ = RackElevationCmd.MarkFlagRequired("env")
+	rackObjects.addFlags(RackElevationCmd)
+	RackElevationCmd.Flags().StringVar(&rackFace, "face", "front", "Face to draw: front, rear or both")
+	RackElevationCmd.Flags().StringVar(&rackLocation, "location", "", "Draw every rack of a location, by slug")
+	RackElevationCmd.Flags().StringVar(&rackSite, "site", "", "Draw every rack of a site, by slug")
+	RackElevationCmd.Flags().StringVar(&rackSVG, "svg", "", "Save the SVG drawings of Netbox to a .svg file or a directory")
+	RackElevationCmd.Flags().StringVarP(&rackOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	_ = RackElevationCmd.RegisterFlagCompletionFunc("face", cobra.FixedCompletions([]string{"front", "rear", "both"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = RackElevationCmd.RegisterFlagCompletionFunc("location", completeObjects("cmd.dcim.dcim_api_url.locations_id", "slug"))
+	_ = RackElevationCmd.RegisterFlagCompletionFunc("site", completeObjects("cmd.dcim.dcim_api_url.sites_id", "slug"))
+	_ = RackElevationCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+	RackCmd.AddCommand(RackElevationCmd)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestRackElevation(t *testing.T) {
+	svgs := t.TempDir()
+
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T)
+	}{
+		{name: "front", args: []string{"rack", "elevation", "R01", "--env", "development", "-o", "text"}},
+		{name: "both_faces", args: []string{"rack", "elevation", "--id", "1", "--face", "both", "--env", "development", "-o", "text"}},
+		{name: "location", args: []string{"rack", "elevation", "--location", "row-a", "--env", "development", "-o", "text"}},
+		{name: "json", args: []string{"rack", "elevation", "R02", "--env", "development", "-o", "json"}},
+		{
+			name: "svg_directory",
+			args: []string{"rack", "elevation", "--location", "row-a", "--svg", svgs, "--env", "development"},
+			check: func(t *testing.T) {
+				b, err := os.ReadFile(filepath.Join(svgs, "R01-front.svg"))
+				if err != nil || !strings.Contains(string(b), "nyc1-srv1") {
+					t.Errorf("R01-front.svg: %s %v", b, err)
+				}
+			},
+		},
+		{name: "svg_file_many_faces", args: []string{"rack", "elevation", "R01", "--face", "both", "--svg", "r01.svg", "--env", "development"}},
+		{name: "no_racks", args: []string{"rack", "elevation", "--env", "development"}},
+		{name: "unknown_face", args: []string{"rack", "elevation", "R01", "--face", "top", "--env", "development"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fixtures := netboxtest.DefaultFixtures()
+			for _, deviceType := range fixtures["/api/dcim/device-types/"].Results {
+				deviceType["u_height"] = json.Number("1")
+				deviceType["is_full_depth"] = false
+			}
+			fixtures["/api/dcim/device-types/"].Results = append(fixtures["/api/dcim/device-types/"].Results, map[string]interface{}{
+				"id": json.Number("3"), "display": "PowerEdge R750", "model": "PowerEdge R750", "slug": "poweredge-r750", "u_height": json.Number("2"), "is_full_depth": true,
+			})
+			// R01 also holds a 2U full-depth server and a PDU without a position.
+			devices := fixtures["/api/dcim/devices/"]
+			server := map[string]interface{}{}
+			pdu := map[string]interface{}{}
+			for key, value := range devices.Results[0] {
+				server[key], pdu[key] = value, value
+			}
+			server["id"], server["name"], server["display"], server["position"] = json.Number("6"), "nyc1-srv1", "nyc1-srv1", json.Number("20")
+			server["device_type"] = map[string]interface{}{"id": json.Number("3"), "display": "PowerEdge R750", "model": "PowerEdge R750"}
+			pdu["id"], pdu["name"], pdu["display"], pdu["position"], pdu["face"] = json.Number("7"), "nyc1-pdu1", "nyc1-pdu1", nil, nil
+			devices.Results = append(devices.Results, server, pdu)
+
+			srv := netboxtest.NewServer(t, fixtures)
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "rack/"+tt.name, strings.ReplaceAll(result.String(), svgs, "<svgs>"))
+			if tt.check != nil {
+				tt.check(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/spf13/cobra"
+)
+
+var recordFile string
+
+var replayFile string
+
+// addCassetteFlags adds --record and --replay to every command below root.
+func addCassetteFlags(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&recordFile, "record", "", "Record every request to Netbox and its response to a cassette file, with tokens and secrets redacted")
+	root.PersistentFlags().StringVar(&replayFile, "replay", "", "Answer every request from a cassette file written by --record instead of the Netbox server")
+	root.MarkFlagsMutuallyExclusive("record", "replay")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return startCassette()
+	}
+}
+
+// startCassette starts recording or replaying when --record or --replay is given.
+func startCassette() error {
+	switch {
+	case recordFile != "":
+		if err := session.Record(recordFile); err != nil {
+			return fmt.Errorf("cannot record to %s: %s", recordFile, err)
+		}
+	case replayFile != "":
+		if err := session.Replay(replayFile); err != nil {
+			return fmt.Errorf("cannot replay %s: %s", replayFile, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestRecordReplay(t *testing.T) {
+	fixtures := netboxtest.DefaultFixtures()
+	cassette := filepath.Join(t.TempDir(), "session.json")
+	args := []string{"dcim", "devices", "get", "nyc1-leaf2", "--env", "production"}
+
+	recorded := run(t, netboxtest.NewServer(t, fixtures), netboxtest.Options{}, append(args, "--record", cassette)...)
+	if recorded.ExitCode != 0 {
+		t.Fatalf("recording failed:\n%s", recorded)
+	}
+	b, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token := strings.TrimPrefix(netboxtest.Token, "Token "); strings.Contains(string(b), token) {
+		t.Errorf("cassette contains the API token")
+	}
+
+	srv := netboxtest.NewServer(t, fixtures)
+	replayed := run(t, srv, netboxtest.Options{}, append(args, "--replay", cassette)...)
+	if replayed.String() != recorded.String() {
+		t.Errorf("replay differs from recording\n--- recorded\n%s\n--- replayed\n%s", recorded, replayed)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("replay sent %d requests to the server", n)
+	}
+
+	missing := run(t, srv, netboxtest.Options{}, "dcim", "devices", "get", "1", "--env", "production", "--replay", cassette)
+	if missing.ExitCode == 0 && !strings.Contains(missing.Stderr+missing.Stdout, "no recorded response") {
+		t.Errorf("replaying an unrecorded request:\n%s", missing)
+	}
+}
@@ -0,0 +1,460 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package refs resolves references to related objects inside a --data payload. Instead of
+// numeric IDs a payload may name related objects by their attributes, e.g.
+//
+//	{"site": {"slug": "nyc1"}, "device_type": "Arista/DCS-7050SX-64", "role": "leaf", "tags": ["core"]}
+//
+// and Resolve rewrites it to the IDs Netbox expects before it is sent.
+package refs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/customfields"
+	"github.com/decassidy/abc-netbox-cli/cmd/namerange"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+)
+
+// fieldEndpoints maps a payload field to the config key of the endpoint its references live in.
+var fieldEndpoints = map[string]string{
+	"cluster":            "cmd.virtualization.virtualization_api_url.clusters",
+	"circuit":            "cmd.circuits.circuits_api_url.circuits_id",
+	"config_template":    "cmd.extras.extras_api_url.config_templates",
+	"device":             "cmd.dcim.dcim_api_url.devices_id",
+	"device_role":        "cmd.dcim.dcim_api_url.device_roles_id",
+	"device_type":        "cmd.dcim.dcim_api_url.device_types_id",
+	"location":           "cmd.dcim.dcim_api_url.locations_id",
+	"manufacturer":       "cmd.dcim.dcim_api_url.manufacturers_id",
+	"module_type":        "cmd.dcim.dcim_api_url.module_types_id",
+	"platform":           "cmd.dcim.dcim_api_url.platforms_id",
+	"power_panel":        "cmd.dcim.dcim_api_url.power_panels_id",
+	"power_port":         "cmd.dcim.dcim_api_url.power_ports_id",
+	"primary_ip4":        "cmd.ipam.ipam_api_url.ip_addresses_id",
+	"primary_ip6":        "cmd.ipam.ipam_api_url.ip_addresses_id",
+	"provider":           "cmd.circuits.circuits_api_url.providers_id",
+	"provider_account":   "cmd.circuits.circuits_api_url.provider_accounts_id",
+	"provider_network":   "cmd.circuits.circuits_api_url.provider_networks_id",
+	"rack":               "cmd.dcim.dcim_api_url.racks_id",
+	"rear_port":          "cmd.dcim.dcim_api_url.rear_ports_id",
+	"region":             "cmd.dcim.dcim_api_url.regions_id",
+	"role":               "cmd.dcim.dcim_api_url.device_roles_id",
+	"site":               "cmd.dcim.dcim_api_url.sites_id",
+	"site_group":         "cmd.dcim.dcim_api_url.site_groups_id",
+	"tagged_vlans":       "cmd.ipam.ipam_api_url.vlans_id",
+	"tags":               "cmd.extras.extras_api_url.tags",
+	"tenant":             "cmd.tenancy.tenancy_api_url.tenants",
+	"tenant_group":       "cmd.tenancy.tenancy_api_url.tenant_groups",
+	"untagged_vlan":      "cmd.ipam.ipam_api_url.vlans_id",
+	"virtual_chassis":    "cmd.dcim.dcim_api_url.virtual_chassis_id",
+	"vlan":               "cmd.ipam.ipam_api_url.vlans_id",
+	"vrf":                "cmd.ipam.ipam_api_url.vrfs_id",
+	"wireless_lan":       "cmd.wireless.wireless_api_url.wireless_lans",
+	"wireless_lan_group": "cmd.wireless.wireless_api_url.wireless_lan_groups",
+}
+
+// endpointFields overrides fieldEndpoints for fields whose meaning depends on the endpoint written to,
+// keyed by the resource part of the endpoint's config key.
+var endpointFields = map[string]map[string]string{
+	"racks":           {"role": "cmd.dcim.dcim_api_url.rack_roles_id"},
+	"inventory_items": {"role": "cmd.dcim.dcim_api_url.inventory_item_roles_id", "parent": "cmd.dcim.dcim_api_url.inventory_items_id"},
+	"interfaces":      {"parent": "cmd.dcim.dcim_api_url.interfaces_id", "lag": "cmd.dcim.dcim_api_url.interfaces_id", "bridge": "cmd.dcim.dcim_api_url.interfaces_id"},
+	"locations":       {"parent": "cmd.dcim.dcim_api_url.locations_id"},
+	"regions":         {"parent": "cmd.dcim.dcim_api_url.regions_id"},
+	"site_groups":     {"parent": "cmd.dcim.dcim_api_url.site_groups_id"},
+	"sites":           {"group": "cmd.dcim.dcim_api_url.site_groups_id"},
+	"circuits":        {"type": "cmd.circuits.circuits_api_url.circuit_types_id"},
+	"devices":         {"parent_device": "cmd.dcim.dcim_api_url.devices_id"},
+}
+
+// naturalKeys lists, per endpoint, how a plain string reference is split into filters. A key such as
+// "manufacturer/model" means "Arista/DCS-7050SX-64" is looked up with manufacturer=Arista and model=DCS-7050SX-64.
+var naturalKeys = map[string]string{
+	"cmd.dcim.dcim_api_url.device_types_id":     "manufacturer/model",
+	"cmd.dcim.dcim_api_url.module_types_id":     "manufacturer/model",
+	"cmd.dcim.dcim_api_url.racks_id":            "site/name",
+	"cmd.dcim.dcim_api_url.locations_id":        "site/name",
+	"cmd.dcim.dcim_api_url.interfaces_id":       "device:name",
+	"cmd.dcim.dcim_api_url.power_ports_id":      "device:name",
+	"cmd.dcim.dcim_api_url.rear_ports_id":       "device:name",
+	"cmd.circuits.circuits_api_url.circuits_id": "cid",
+	"cmd.ipam.ipam_api_url.ip_addresses_id":     "address",
+	"cmd.ipam.ipam_api_url.vlans_id":            "name",
+	// Objects without a slug: Netbox ignores filters on fields a model lacks, so looking them up
+	// by slug would match every object.
+	"cmd.dcim.dcim_api_url.devices_id":                   "name",
+	"cmd.dcim.dcim_api_url.virtual_chassis_id":           "name",
+	"cmd.dcim.dcim_api_url.power_panels_id":              "name",
+	"cmd.circuits.circuits_api_url.provider_accounts_id": "name",
+	"cmd.circuits.circuits_api_url.provider_networks_id": "name",
+	"cmd.virtualization.virtualization_api_url.clusters": "name",
+	"cmd.wireless.wireless_api_url.wireless_lans":        "ssid",
+}
+
+// nameRangeEndpoints are the config keys of the endpoints whose POST payloads may name their
+// objects with a name range pattern such as Ethernet1/[1-48].
+var nameRangeEndpoints = map[string]bool{
+	"cmd.dcim.dcim_api_url.console_port_templates_id": true,
+	"cmd.dcim.dcim_api_url.console_ports_id":          true,
+	"cmd.dcim.dcim_api_url.console_server_ports_id":   true,
+	"cmd.dcim.dcim_api_url.front_port_templates_id":   true,
+	"cmd.dcim.dcim_api_url.front_ports_id":            true,
+	"cmd.dcim.dcim_api_url.interface_templates_id":    true,
+	"cmd.dcim.dcim_api_url.interfaces_id":             true,
+	"cmd.dcim.dcim_api_url.power_outlet_templates_id": true,
+	"cmd.dcim.dcim_api_url.power_outlets_id":          true,
+	"cmd.dcim.dcim_api_url.power_port_templates_id":   true,
+	"cmd.dcim.dcim_api_url.power_ports_id":            true,
+	"cmd.dcim.dcim_api_url.rear_port_templates_id":    true,
+	"cmd.dcim.dcim_api_url.rear_ports_id":             true,
+}
+
+// ResolveReferences prepares the data of a write with method to the endpoint with config key
+// endpointKey: component names posted as a range pattern, such as Ethernet1/[1-48], are expanded
+// into one object per name, the customFields name=value pairs are merged into its custom_fields
+// and references to related objects, such as {"site": {"slug": "nyc1"}} or
+// "device_type": "Arista/DCS-7050SX-64", are replaced with their IDs. It returns the data and the
+// number of objects the name ranges expanded into, 0 without any.
+func ResolveReferences(ctx context.Context, rootURL string, endpointKey string, method string, data string, customFields []string) (string, int, error) {
+	expanded := 0
+	if method == "POST" && nameRangeEndpoints[endpointKey] && data != "" {
+		var err error
+		if data, expanded, err = namerange.ExpandData(data); err != nil {
+			return "", 0, fmt.Errorf("expanding name ranges in --data: %s", err)
+		}
+	}
+
+	if len(customFields) > 0 {
+		merged, err := customfields.Apply(rootURL, endpointKey, data, customFields)
+		if err != nil {
+			return "", 0, fmt.Errorf("setting custom fields: %s", err)
+		}
+		data = merged
+	}
+
+	resolver, err := NewResolver(ctx, rootURL, endpointKey)
+	if err != nil {
+		return "", 0, fmt.Errorf("resolving references in --data: %s", err)
+	}
+	resolved, _, err := resolver.Resolve(data)
+	if err != nil {
+		return "", 0, fmt.Errorf("resolving references in --data: %s", err)
+	}
+	return resolved, expanded, nil
+}
+
+// Resolver rewrites references for writes to a single endpoint. Lookups are cached, so a bulk
+// payload naming the same site a hundred times costs a single request.
+type Resolver struct {
+	ctx      context.Context
+	RootURL  string
+	Token    string
+	Endpoint string
+	lookup   func(key string) string
+	cache    map[string]int64
+}
+
+// NewResolver returns a Resolver for writes to the endpoint with config key endpointKey. Its
+// lookups are made with ctx.
+func NewResolver(ctx context.Context, rootURL string, endpointKey string) (*Resolver, error) {
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	return &Resolver{
+		ctx:      ctx,
+		RootURL:  rootURL,
+		Token:    config.GetString("cmd.token_key"),
+		Endpoint: endpointKey,
+		lookup:   config.GetString,
+		cache:    map[string]int64{},
+	}, nil
+}
+
+// Resolve returns data with every reference replaced by an ID. Payloads without references are
+// returned unchanged, byte for byte.
+func (r *Resolver) Resolve(data string) (string, bool, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return "", false, fmt.Errorf("--data is not valid JSON: %s", err)
+	}
+
+	changed := false
+	var err error
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		changed, err = r.resolveObject(p)
+	case []interface{}:
+		for i, item := range p {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			c, itemErr := r.resolveObject(object)
+			if itemErr != nil {
+				return "", false, fmt.Errorf("item %d: %s", i, itemErr)
+			}
+			changed = changed || c
+		}
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if !changed {
+		return data, false, nil
+	}
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return "", false, err
+	}
+	return string(out), true, nil
+}
+
+// Indent renders a payload for --dry-run output.
+func Indent(data string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(data), "    ", "  "); err != nil {
+		return data
+	}
+	return "    " + out.String()
+}
+
+func (r *Resolver) resolveObject(object map[string]interface{}) (bool, error) {
+	changed := false
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, field := range keys {
+		endpoint := r.endpointFor(field)
+		if endpoint == "" {
+			continue
+		}
+		switch value := object[field].(type) {
+		case []interface{}:
+			for i, item := range value {
+				id, ok, err := r.resolveValue(field, endpoint, item)
+				if err != nil {
+					return false, err
+				}
+				if ok {
+					value[i] = id
+					changed = true
+				}
+			}
+		default:
+			id, ok, err := r.resolveValue(field, endpoint, value)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				object[field] = id
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// endpointFor returns the config key of the endpoint referenced by field, or "" if field holds no reference.
+func (r *Resolver) endpointFor(field string) string {
+	resource := r.Endpoint[strings.LastIndex(r.Endpoint, ".")+1:]
+	resource = strings.TrimSuffix(resource, "_id")
+	if overrides, ok := endpointFields[resource]; ok {
+		if endpoint, ok := overrides[field]; ok {
+			return endpoint
+		}
+	}
+	return fieldEndpoints[field]
+}
+
+// resolveValue resolves a single reference. It reports false for values that already are IDs.
+func (r *Resolver) resolveValue(field string, endpoint string, value interface{}) (int64, bool, error) {
+	filters := url.Values{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, ok := v["id"]; ok {
+			return 0, false, nil
+		}
+		for key, attr := range v {
+			filters.Set(key, fmt.Sprintf("%v", attr))
+		}
+	case string:
+		filters = r.naturalKeyFilters(endpoint, v)
+	default:
+		return 0, false, nil
+	}
+	if len(filters) == 0 {
+		return 0, false, fmt.Errorf("%s: empty reference", field)
+	}
+
+	id, err := r.find(endpoint, filters)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %s", field, err)
+	}
+	return id, true, nil
+}
+
+// naturalKeyFilters turns a plain string reference into filters using the endpoint's natural key.
+// Endpoints without one have a slug: they are looked up by slug, falling back to name in find.
+func (r *Resolver) naturalKeyFilters(endpoint string, value string) url.Values {
+	filters := url.Values{}
+	key, ok := naturalKeys[endpoint]
+	if !ok {
+		filters.Set("slug", value)
+		return filters
+	}
+	for _, sep := range []string{"/", ":"} {
+		if parent, child, ok := strings.Cut(key, sep); ok {
+			if p, c, ok := strings.Cut(value, sep); ok {
+				// The parents before "/" (site, manufacturer) are filtered by slug, the devices
+				// before ":" by their exact name.
+				if sep == "/" {
+					p = Slugify(p)
+				}
+				filters.Set(parent, p)
+				filters.Set(child, c)
+			} else {
+				filters.Set(child, value)
+			}
+			return filters
+		}
+	}
+	filters.Set(key, value)
+	return filters
+}
+
+// find returns the ID of the single object of endpoint matching filters.
+func (r *Resolver) find(endpoint string, filters url.Values) (int64, error) {
+	cacheKey := endpoint + "?" + filters.Encode()
+	if id, ok := r.cache[cacheKey]; ok {
+		return id, nil
+	}
+
+	matches, err := r.query(endpoint, filters)
+	if err != nil {
+		return 0, err
+	}
+	// A bare string is tried as a slug first; objects without slugs, or named differently, by name.
+	if len(matches.Results) == 0 && len(filters) == 1 && filters.Get("slug") != "" {
+		byName := url.Values{}
+		byName.Set("name", filters.Get("slug"))
+		if matches, err = r.query(endpoint, byName); err != nil {
+			return 0, err
+		}
+	}
+
+	switch {
+	case matches.Count == 1:
+		r.cache[cacheKey] = matches.Results[0].Id
+		return matches.Results[0].Id, nil
+	case matches.Count == 0 && len(filters) == 1 && filters.Get("slug") != "":
+		return 0, fmt.Errorf("no object has slug or name %q", filters.Get("slug"))
+	case matches.Count == 0:
+		return 0, fmt.Errorf("no object matches %s", describe(filters))
+	default:
+		var candidates []string
+		for _, m := range matches.Results {
+			candidates = append(candidates, fmt.Sprintf("%d (%s)", m.Id, m.Display))
+		}
+		return 0, fmt.Errorf("%d objects match %s: %s", matches.Count, describe(filters), strings.Join(candidates, ", "))
+	}
+}
+
+type briefMatches struct {
+	Count   int `json:"count"`
+	Results []struct {
+		Id      int64  `json:"id"`
+		Display string `json:"display"`
+	} `json:"results"`
+}
+
+func (r *Resolver) query(endpoint string, filters url.Values) (*briefMatches, error) {
+	path := strings.SplitN(r.lookup(endpoint), "?", 2)[0]
+	if path == "" {
+		return nil, fmt.Errorf("no endpoint configured for %s", endpoint)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("brief", "true")
+	query.Set("limit", "10")
+	fullAPIPath := r.RootURL + path + "?" + query.Encode()
+
+	resp, err := session.Client().R().
+		SetContext(r.ctx).
+		SetHeaders(map[string]string{
+			"Authorization": r.Token,
+			"Accept":        "application/json",
+		}).
+		Get(fullAPIPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("lookup %s returned %s", fullAPIPath, resp.Status())
+	}
+	matches := new(briefMatches)
+	if err := json.Unmarshal(resp.Body(), matches); err != nil {
+		return nil, fmt.Errorf("error while parsing the response bytes: %s", err)
+	}
+	return matches, nil
+}
+
+func describe(filters url.Values) string {
+	var pairs []string
+	for key := range filters {
+		pairs = append(pairs, key+"="+filters.Get(key))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+var (
+	slugInvalid   = regexp.MustCompile(`[^\-.\w\s]`)
+	slugSeparator = regexp.MustCompile(`[\-.\s]+`)
+)
+
+// Slugify converts a display name such as "Dell EMC / R740" into the slug Netbox derives from it,
+// dell-emc-r740: other characters than word characters, whitespace, "-" and "." are dropped and
+// every run of the rest becomes a single "-".
+func Slugify(s string) string {
+	s = strings.TrimSpace(slugInvalid.ReplaceAllString(s, ""))
+	return strings.ToLower(slugSeparator.ReplaceAllString(s, "-"))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package refs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNaturalKeyFilters(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		value    string
+		want     string
+	}{
+		{"cmd.dcim.dcim_api_url.sites_id", "nyc1", "slug=nyc1"},
+		{"cmd.dcim.dcim_api_url.devices_id", "nyc1-leaf1", "name=nyc1-leaf1"},
+		{"cmd.dcim.dcim_api_url.virtual_chassis_id", "nyc1-stack1", "name=nyc1-stack1"},
+		{"cmd.virtualization.virtualization_api_url.clusters", "nyc1-vmw", "name=nyc1-vmw"},
+		{"cmd.circuits.circuits_api_url.provider_accounts_id", "Zayo NYC", "name=Zayo+NYC"},
+		{"cmd.wireless.wireless_api_url.wireless_lans", "ABC-Corp", "ssid=ABC-Corp"},
+		{"cmd.dcim.dcim_api_url.device_types_id", "Arista Networks/DCS-7050SX-64", "manufacturer=arista-networks&model=DCS-7050SX-64"},
+		{"cmd.dcim.dcim_api_url.device_types_id", "DCS-7050SX-64", "model=DCS-7050SX-64"},
+		{"cmd.dcim.dcim_api_url.interfaces_id", "nyc1-leaf1:Ethernet1/1", "device=nyc1-leaf1&name=Ethernet1%2F1"},
+		{"cmd.dcim.dcim_api_url.power_ports_id", "Core1.lab:PSU1", "device=Core1.lab&name=PSU1"},
+	}
+	r := &Resolver{}
+	for _, tt := range tests {
+		if got := r.naturalKeyFilters(tt.endpoint, tt.value).Encode(); got != tt.want {
+			t.Errorf("naturalKeyFilters(%s, %q) = %s, want %s", tt.endpoint, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Arista Networks": "arista-networks",
+		"Juniper":         "juniper",
+		"  Palo Alto  ":   "palo-alto",
+		"Cisco_Systems":   "cisco_systems",
+		"Dell EMC / R740": "dell-emc-r740",
+	}
+	for in, want := range tests {
+		if got := Slugify(in); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// newTestResolver returns a Resolver for writes to interfaces, backed by a server that, like
+// Netbox, ignores the filters on fields a model does not have.
+func newTestResolver(t *testing.T) (*Resolver, *[]string) {
+	t.Helper()
+	objects := map[string][]map[string]interface{}{
+		"/api/dcim/devices/": {
+			{"id": 1, "display": "nyc1-leaf1", "name": "nyc1-leaf1"},
+			{"id": 2, "display": "nyc1-leaf2", "name": "nyc1-leaf2"},
+		},
+		"/api/dcim/sites/": {
+			{"id": 1, "display": "NYC1", "name": "NYC1", "slug": "nyc1"},
+		},
+		"/api/extras/tags/": {
+			{"id": 3, "display": "core", "name": "core", "slug": "core"},
+		},
+	}
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/status/" {
+			_, _ = w.Write([]byte(`{"netbox-version": "4.1.3"}`))
+			return
+		}
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		var results []map[string]interface{}
+		for _, object := range objects[r.URL.Path] {
+			matches := true
+			for key, values := range r.URL.Query() {
+				if value, ok := object[key]; ok && value != values[0] {
+					matches = false
+				}
+			}
+			if matches {
+				results = append(results, object)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+	t.Cleanup(srv.Close)
+
+	paths := map[string]string{
+		"cmd.dcim.dcim_api_url.devices_id": "/api/dcim/devices/",
+		"cmd.dcim.dcim_api_url.sites_id":   "/api/dcim/sites/",
+		"cmd.extras.extras_api_url.tags":   "/api/extras/tags/",
+	}
+	return &Resolver{
+		ctx:      context.Background(),
+		RootURL:  srv.URL,
+		Endpoint: "cmd.dcim.dcim_api_url.interfaces",
+		lookup:   func(key string) string { return paths[key] },
+		cache:    map[string]int64{},
+	}, &queries
+}
+
+func TestResolve(t *testing.T) {
+	r, queries := newTestResolver(t)
+	got, changed, err := r.Resolve(`[{"device": "nyc1-leaf2", "name": "Ethernet1", "tags": ["core"]}, {"device": "nyc1-leaf2", "name": "Ethernet2", "tags": [{"id": 3}]}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("Resolve reported no change")
+	}
+	var objects []map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &objects); err != nil {
+		t.Fatalf("%s: %s", got, err)
+	}
+	if objects[0]["device"] != 2.0 || objects[1]["device"] != 2.0 {
+		t.Errorf("devices resolved to %v and %v, want 2", objects[0]["device"], objects[1]["device"])
+	}
+	if tags, _ := objects[0]["tags"].([]interface{}); len(tags) != 1 || tags[0] != 3.0 {
+		t.Errorf("tags resolved to %v, want [3]", objects[0]["tags"])
+	}
+	// The second device reference comes from the cache.
+	if want := []string{"/api/dcim/devices/?brief=true&limit=10&name=nyc1-leaf2", "/api/extras/tags/?brief=true&limit=10&slug=core"}; strings.Join(*queries, " ") != strings.Join(want, " ") {
+		t.Errorf("queries %v, want %v", *queries, want)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"device": "nyc9-leaf1"}`, `device: no object matches name=nyc9-leaf1`},
+		{`{"device": {"site": "nyc1"}}`, `device: 2 objects match site=nyc1`},
+		{`{"site": "sfo1"}`, `site: no object has slug or name "sfo1"`},
+		{`{"device"`, `--data is not valid JSON`},
+	}
+	for _, tt := range tests {
+		r, _ := newTestResolver(t)
+		if _, _, err := r.Resolve(tt.data); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Resolve(%s) returned %v, want %q", tt.data, err, tt.want)
+		}
+	}
+}
+
+func TestResolveUnchanged(t *testing.T) {
+	r, queries := newTestResolver(t)
+	data := `{"device": 1, "name": "Ethernet1", "site": {"id": 1}}`
+	got, changed, err := r.Resolve(data)
+	if err != nil || changed || got != data {
+		t.Errorf("Resolve(%s) = %s, %v, %v; want it unchanged", data, got, changed, err)
+	}
+	if len(*queries) != 0 {
+		t.Errorf("IDs were looked up: %v", *queries)
+	}
+}
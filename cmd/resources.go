@@ -0,0 +1,363 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// endpointAnnotation records on a generated command the netbox_config.yaml key of the object
+// endpoint it works on, for commands whose name does not say so (see endpointKeyForCommand).
+const endpointAnnotation = "netbox-endpoint"
+
+// objectFlags are the flags that select a single object; setting one picks the by-ID command of a verb.
+var objectFlags = []string{"id", "name", "slug", "serial", "lookup"}
+
+// legacySearches maps the search commands that do not follow the <method><Domain><Resource>[ById]
+// naming to their resource and verb. getDcimDeviceIdBySerialNumber is left out: dcim devices get
+// --serial does the same.
+var legacySearches = map[string][2]string{
+	"getDcimDeviceByQuery":     {"devices", "find"},
+	"getDcimInterfacesByQuery": {"interfaces", "find"},
+	"getDcimFrontPortsByQuery": {"front-ports", "find"},
+	"getDcimSitesByQuery":      {"sites", "find"},
+}
+
+// resourceVerb is one verb of a resource, backed by the command for a single object, the command
+// for the whole collection, or both (update and delete work on one object or in bulk).
+type resourceVerb struct {
+	name   string
+	object *cobra.Command
+	many   *cobra.Command
+}
+
+// resource is a Netbox API endpoint in the noun-verb grammar, e.g. "dcim devices".
+type resource struct {
+	domain   string
+	name     string
+	endpoint string
+	verbs    map[string]*resourceVerb
+}
+
+// resourceRegistry holds every resource by domain and name. It is built from the per-endpoint
+// commands, so a new command file is picked up by the noun-verb grammar without further changes.
+type resourceRegistry struct {
+	domains   map[string]*cobra.Command
+	resources map[string]map[string]*resource
+}
+
+// addResourceCommands adds the noun-verb grammar below root:
+//
+//	abc-netbox.cli dcim devices list --env production
+//	abc-netbox.cli dcim devices get 5 --env production
+//	abc-netbox.cli dcim devices update core1 --data '{"status": "offline"}' --env production
+//
+// The palette commands (DCIM, DcimGet, ...) and the commands below them stay available, hidden,
+// so existing scripts keep working.
+func addResourceCommands(root *cobra.Command) {
+	registry := &resourceRegistry{
+		domains:   map[string]*cobra.Command{},
+		resources: map[string]map[string]*resource{},
+	}
+	for _, palette := range root.Commands() {
+		if palette.HasSubCommands() {
+			registry.collect(palette, palette)
+		}
+	}
+	registry.mergeSingulars()
+
+	domains := make([]string, 0, len(registry.resources))
+	for domain := range registry.resources {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	for _, domain := range domains {
+		domainCmd := registry.domains[domain]
+		for _, r := range registry.resources[domain] {
+			domainCmd.AddCommand(r.command())
+		}
+		root.AddCommand(domainCmd)
+	}
+}
+
+// collect registers the commands below c, which belongs to the top-level palette.
+func (reg *resourceRegistry) collect(palette *cobra.Command, c *cobra.Command) {
+	for _, child := range c.Commands() {
+		if child.HasSubCommands() {
+			reg.collect(palette, child)
+			continue
+		}
+		reg.add(palette, child)
+	}
+}
+
+// add registers a single per-endpoint command under its resource and verb.
+func (reg *resourceRegistry) add(palette *cobra.Command, c *cobra.Command) {
+	m := commandNamePattern.FindStringSubmatch(c.Name())
+	if m == nil {
+		return
+	}
+	domain := strings.ToLower(m[2])
+	name := strings.ReplaceAll(toSnakeCase(m[3]), "_", "-")
+	byID := m[4] != ""
+
+	var verb string
+	switch m[1] {
+	case "get":
+		verb = "list"
+		if byID {
+			verb = "get"
+		}
+	case "post":
+		verb = "create"
+	case "patch", "put":
+		verb = "update"
+	case "delete":
+		verb = "delete"
+	}
+	if search, ok := legacySearches[c.Name()]; ok {
+		name, verb = search[0], search[1]
+	} else if strings.Contains(m[3], "By") {
+		return
+	}
+
+	if reg.resources[domain] == nil {
+		reg.resources[domain] = map[string]*resource{}
+		reg.domains[domain] = &cobra.Command{
+			Use:   domain,
+			Short: palette.Short,
+			Long:  palette.Long,
+		}
+	}
+	r := reg.resources[domain][name]
+	if r == nil {
+		r = &resource{domain: domain, name: name, verbs: map[string]*resourceVerb{}}
+		reg.resources[domain][name] = r
+	}
+	if r.endpoint == "" {
+		r.endpoint, _ = endpointKeyForCommand(c.Name())
+	}
+	v := r.verbs[verb]
+	if v == nil {
+		v = &resourceVerb{name: verb}
+		r.verbs[verb] = v
+	}
+	if byID && v.object == nil {
+		v.object = c
+	} else if !byID && v.many == nil {
+		v.many = c
+	}
+}
+
+// mergeSingulars folds resources named in the singular by a stray command, such as
+// getCoreDataFileByID, into their plural resource.
+func (reg *resourceRegistry) mergeSingulars() {
+	for _, resources := range reg.resources {
+		for name, r := range resources {
+			plural, ok := resources[name+"s"]
+			if !ok {
+				continue
+			}
+			for verbName, v := range r.verbs {
+				pv := plural.verbs[verbName]
+				if pv == nil {
+					plural.verbs[verbName] = v
+					continue
+				}
+				if pv.object == nil {
+					pv.object = v.object
+				}
+				if pv.many == nil {
+					pv.many = v.many
+				}
+			}
+			delete(resources, name)
+		}
+	}
+}
+
+// command returns the "<resource>" command holding one subcommand per verb.
+func (r *resource) command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   r.name,
+		Short: fmt.Sprintf("ABC Netbox %s %s APIs.", strings.ToUpper(r.domain), r.name),
+		Long: fmt.Sprintf(`
+ABC Netbox Automation Tools:
+  ABC Netbox %s %s APIs.`, strings.ToUpper(r.domain), r.name),
+	}
+	for _, v := range r.verbs {
+		c.AddCommand(r.verbCommand(v))
+	}
+	return c
+}
+
+// verbCommand returns the command for a verb. It carries the flags of the commands behind the verb
+// and passes them on, together with the object given as argument, to the one that fits: the by-ID
+// command when an object is given, the collection command otherwise.
+func (r *resource) verbCommand(v *resourceVerb) *cobra.Command {
+	legacy := v.object
+	if legacy == nil {
+		legacy = v.many
+	}
+	c := &cobra.Command{
+		Use:         v.name,
+		Short:       legacy.Short,
+		Long:        legacy.Long,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{endpointAnnotation: r.endpoint},
+		RunE: func(c *cobra.Command, args []string) error {
+			if fanOutRequested(c) {
+				return fanOut(c, r.endpoint, args)
+			}
+			return v.run(c, args)
+		},
+	}
+	if v.object != nil {
+		c.Use = v.name + " <id|name>"
+		if v.many != nil {
+			c.Use = v.name + " [id|name]"
+		}
+		c.Args = cobra.MaximumNArgs(1)
+		if r.endpoint != "" {
+			c.ValidArgsFunction = completeObjects(r.endpoint, "id")
+		}
+	}
+
+	// The flags share their values with the flags of the commands behind the verb, but not their
+	// required annotations: which flags are required depends on the command picked in run.
+	for _, target := range []*cobra.Command{v.object, v.many} {
+		if target == nil {
+			continue
+		}
+		target.Flags().VisitAll(func(f *pflag.Flag) {
+			if c.Flags().Lookup(f.Name) != nil {
+				return
+			}
+			flag := *f
+			flag.Annotations = nil
+			if flag.Shorthand != "" && c.Flags().ShorthandLookup(flag.Shorthand) != nil {
+				flag.Shorthand = ""
+			}
+			c.Flags().AddFlag(&flag)
+		})
+	}
+	switch v.name {
+	case "list", "get", "find":
+		addFanOutFlags(c)
+	}
+	return c
+}
+
+// copyFlag sets the flag to of a command to the value of from. Slice flags such as --cf are
+// copied element by element: their String form is not something Set can parse back.
+func copyFlag(flags *pflag.FlagSet, to *pflag.Flag, from *pflag.Flag) error {
+	if to.Value == from.Value {
+		to.Changed = true
+		return nil
+	}
+	src, srcOK := from.Value.(pflag.SliceValue)
+	dst, dstOK := to.Value.(pflag.SliceValue)
+	if srcOK && dstOK {
+		if err := dst.Replace(src.GetSlice()); err != nil {
+			return err
+		}
+		to.Changed = true
+		return nil
+	}
+	return flags.Set(to.Name, from.Value.String())
+}
+
+// run picks the command behind the verb, hands it the flags set on c and runs it.
+func (v *resourceVerb) run(c *cobra.Command, args []string) error {
+	target := v.many
+	if v.object != nil && (len(args) == 1 || target == nil || changedAny(c, objectFlags)) {
+		target = v.object
+	}
+
+	var err error
+	c.Flags().Visit(func(f *pflag.Flag) {
+		// Global flags such as --record are read from their own variables.
+		if c.InheritedFlags().Lookup(f.Name) != nil {
+			return
+		}
+		tf := target.Flags().Lookup(f.Name)
+		if tf == nil {
+			err = fmt.Errorf("--%s cannot be used with %s", f.Name, target.Name())
+			return
+		}
+		if setErr := copyFlag(target.Flags(), tf, f); setErr != nil {
+			err = setErr
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		// A name is looked up by the first identifier the model has.
+		flag := ""
+		for _, field := range []string{"name", "slug", "serial"} {
+			if target.Flags().Lookup(field) != nil {
+				flag = field
+				break
+			}
+		}
+		if _, convErr := strconv.Atoi(args[0]); convErr == nil {
+			flag = "id"
+		}
+		if flag == "" || target.Flags().Lookup(flag) == nil {
+			return fmt.Errorf("%s cannot look up objects by name, use --id or --lookup", c.CommandPath())
+		}
+		if err := target.Flags().Set(flag, args[0]); err != nil {
+			return err
+		}
+	}
+
+	if err := target.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	if err := target.ValidateFlagGroups(); err != nil {
+		return err
+	}
+	if target.RunE != nil {
+		return target.RunE(target, nil)
+	}
+	target.Run(target, nil)
+	return nil
+}
+
+// changedAny reports whether any of the named flags was set on c.
+func changedAny(c *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if c.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
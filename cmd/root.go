@@ -47,6 +47,9 @@ var rootCmd = &cobra.Command{
 ABC Netbox Automation Tools - ABC Netbox APIs.
 Copyright (c) 2024 ABC Technologies, Inc. All Rights Reserved.`,
 	Version: "v0.1.0",
+	// Errors are printed by reportError, which prints the usage only for errors in the command line.
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
@@ -71,9 +74,13 @@ var versionCmd = &cobra.Command{
 }
 
 var CompletionCmd = &cobra.Command{
-	Use:                   "completion [bash|zsh|fish|powershell]",
-	Short:                 "Generate completion script",
-	Long:                  "To load completions",
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate completion script",
+	Long: `To load completions
+
+Besides commands and flags, the generated scripts complete --id, --env and slug and enum
+flags with live values from the Netbox server selected with --env. Results are cached
+on disk for a short time (cmd.completion_cache_ttl, default 2m) to keep completion fast.`,
 	DisableFlagsInUseLine: true,
 	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
 	Args:                  cobra.ExactArgs(1),
@@ -104,8 +111,9 @@ var CompletionCmd = &cobra.Command{
 }
 
 var CircuitsCmd = &cobra.Command{
-	Use:   "Circuits",
-	Short: "ABC Netbox Circuit Management APIs.",
+	Use:    "Circuits",
+	Hidden: true,
+	Short:  "ABC Netbox Circuit Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Circuit Management APIs.`,
@@ -159,8 +167,9 @@ ABC Netbox Automation Tools:
 }
 
 var CoreCmd = &cobra.Command{
-	Use:   "Core",
-	Short: "ABC Netbox Core Management APIs.",
+	Use:    "Core",
+	Hidden: true,
+	Short:  "ABC Netbox Core Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Core Management APIs.`,
@@ -214,8 +223,9 @@ ABC Netbox Automation Tools:
 }
 
 var DcimCmd = &cobra.Command{
-	Use:   "DCIM",
-	Short: "ABC Netbox DCIM Management APIs.",
+	Use:    "DCIM",
+	Hidden: true,
+	Short:  "ABC Netbox DCIM Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox DCIM Management APIs.`,
@@ -269,8 +279,9 @@ ABC Netbox Automation Tools:
 }
 
 var ExtrasCmd = &cobra.Command{
-	Use:   "Extras",
-	Short: "ABC Netbox Extras Management APIs.",
+	Use:    "Extras",
+	Hidden: true,
+	Short:  "ABC Netbox Extras Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Extras Management APIs.`,
@@ -324,8 +335,9 @@ ABC Netbox Automation Tools:
 }
 
 var IpamCmd = &cobra.Command{
-	Use:   "IPAM",
-	Short: "ABC Netbox IPAM Management APIs.",
+	Use:    "IPAM",
+	Hidden: true,
+	Short:  "ABC Netbox IPAM Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox IPAM Management APIs.`,
@@ -379,8 +391,9 @@ ABC Netbox Automation Tools:
 }
 
 var TenancyCmd = &cobra.Command{
-	Use:   "Tenancy",
-	Short: "ABC Netbox Tenancy Management APIs.",
+	Use:    "Tenancy",
+	Hidden: true,
+	Short:  "ABC Netbox Tenancy Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Tenancy Management APIs.`,
@@ -434,8 +447,9 @@ ABC Netbox Automation Tools:
 }
 
 var UsersCmd = &cobra.Command{
-	Use:   "Users",
-	Short: "ABC Netbox Users Management APIs.",
+	Use:    "Users",
+	Hidden: true,
+	Short:  "ABC Netbox Users Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Users Management APIs.`,
@@ -489,8 +503,9 @@ ABC Netbox Automation Tools:
 }
 
 var VirtualizationCmd = &cobra.Command{
-	Use:   "Virtualization",
-	Short: "ABC Netbox Virtualization Management APIs.",
+	Use:    "Virtualization",
+	Hidden: true,
+	Short:  "ABC Netbox Virtualization Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Virtualization Management APIs.`,
@@ -544,8 +559,9 @@ ABC Netbox Automation Tools:
 }
 
 var VpnCmd = &cobra.Command{
-	Use:   "VPN",
-	Short: "ABC Netbox VPN Management APIs.",
+	Use:    "VPN",
+	Hidden: true,
+	Short:  "ABC Netbox VPN Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox VPN Management APIs.`,
@@ -599,8 +615,9 @@ ABC Netbox Automation Tools:
 }
 
 var WirelessCmd = &cobra.Command{
-	Use:   "Wireless",
-	Short: "ABC Netbox Wireless Management APIs.",
+	Use:    "Wireless",
+	Hidden: true,
+	Short:  "ABC Netbox Wireless Management APIs.",
 	Long: `
 ABC Netbox Automation Tools:
   ABC Netbox Wireless Management APIs.`,
@@ -656,10 +673,26 @@ ABC Netbox Automation Tools:
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	if status := executeRoot(); status != 0 {
+		os.Exit(status)
+	}
+}
+
+// executeRoot runs the command line, reports the error of the command, if any, and returns the
+// exit status of the process.
+func executeRoot() int {
+	// The commands of the tag, journal and other files are added by their own init functions, so
+	// their RunE can only be wrapped, and their flags completed, once every init has run.
+	commandTreeOnce.Do(func() {
+		markRunErrors(rootCmd)
+		registerDynamicCompletions(rootCmd)
+	})
+	cmd, err := runRoot()
 	if err != nil {
-		os.Exit(1)
+		reportError(cmd, err)
+		return exitCode(err)
 	}
+	return 0
 }
 
 func addCircuitsSubcommandPalettes() {
@@ -1521,6 +1554,26 @@ func init() {
 	addVirtualizationSubcommandPalettes()
 	addVpnSubcommandPalettes()
 	addWirelessSubcommandPalettes()
+	addResourceCommands(rootCmd)
+	addCassetteFlags(rootCmd)
+	addLoggingFlags(rootCmd)
+	addTimeoutFlag(rootCmd)
+	addJournalFlag(rootCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(CompletionCmd)
+	rootCmd.AddCommand(TuiCmd)
+	rootCmd.AddCommand(ShellCmd)
+	rootCmd.AddCommand(PluginCmd)
+	rootCmd.AddCommand(ServeCmd)
+	rootCmd.AddCommand(GraphqlCmd)
+	rootCmd.AddCommand(StatusCmd)
+	rootCmd.AddCommand(TagCmd)
+	rootCmd.AddCommand(JournalCmd)
+	rootCmd.AddCommand(AttachmentCmd)
+	rootCmd.AddCommand(TraceCmd)
+	rootCmd.AddCommand(RackCmd)
+	rootCmd.AddCommand(PowerCmd)
+	rootCmd.AddCommand(CableCmd)
+	rootCmd.AddCommand(ImportCmd)
+	addPluginCommands(rootCmd)
 }
@@ -0,0 +1,145 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/metrics"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var serveEnv string
+
+var metricsListen string
+
+var metricsInterval time.Duration
+
+// ServeCmd represents the serve command
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run long-running services backed by Netbox",
+	Long: `
+ABC Netbox Automation Tools:
+  Run long-running services backed by Netbox.`,
+}
+
+var serveMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export Netbox inventory counts as Prometheus metrics",
+	Long: `
+ABC Netbox Automation Tools:
+  Export Netbox inventory counts as Prometheus metrics on /metrics.
+
+  The counts are refreshed every --interval from the list endpoints:
+
+    netbox_devices{site,role,status}       devices
+    netbox_interfaces                      device interfaces
+    netbox_cables{status}                  cables
+    netbox_circuits{provider,status}       circuits
+    netbox_tunnels{status}                 VPN tunnels
+
+  and every endpoint reports netbox_scrape_duration_seconds, netbox_scrape_errors_total and
+  netbox_scrape_success. Totals are fetched with counts-only requests; labelled counts page
+  through the endpoint asking only for the labelled fields.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := session.Config()
+		if err != nil {
+			return fmt.Errorf("reading config file: %s", err)
+		}
+		rootURL, err := session.RootURL(serveEnv)
+		if err != nil {
+			return fmt.Errorf("unrecognized environment: %s", serveEnv)
+		}
+		if metricsInterval < time.Second {
+			return fmt.Errorf("refresh interval too short: %s", metricsInterval)
+		}
+
+		exporter := metrics.NewExporter(rootURL, session.Token(), config)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", exporter.Handler())
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte("<html><body><a href=\"/metrics\">Netbox metrics</a></body></html>\n"))
+		})
+		server := &http.Server{Addr: metricsListen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		stop := make(chan struct{})
+		go exporter.Run(metricsInterval, stop, reportRefresh)
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			close(stop)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(ctx)
+		}()
+
+		color.Cyan("  Serving metrics for %s on "+color.YellowString("%s/metrics", metricsListen)+color.CyanString(", refreshing every %s", metricsInterval), rootURL)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serving metrics: %s", err)
+		}
+		return nil
+	},
+}
+
+// reportRefresh logs the endpoints a refresh failed for.
+func reportRefresh(failed map[string]error) {
+	endpoints := make([]string, 0, len(failed))
+	for endpoint := range failed {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		log.Printf("Error refreshing %s: %s\n", endpoint, failed[endpoint])
+	}
+}
+
+func init() {
+	serveMetricsCmd.Flags().StringVarP(&serveEnv, "env", "", "development", "Environment ('development' or 'production')")
+	err := serveMetricsCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for serveMetricsCmd", err)
+	}
+	serveMetricsCmd.Flags().StringVarP(&metricsListen, "listen", "", ":9464", "Address to serve /metrics on")
+	serveMetricsCmd.Flags().DurationVarP(&metricsInterval, "interval", "", 5*time.Minute, "How often to refresh the counts from Netbox")
+
+	serveMetricsCmd.Annotations = map[string]string{ownSignalsAnnotation: ""}
+	ServeCmd.AddCommand(serveMetricsCmd)
+}
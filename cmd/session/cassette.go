@@ -0,0 +1,374 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces tokens and secrets in a cassette.
+const Redacted = "[REDACTED]"
+
+// redactedHeaders are the headers whose values never go into a cassette.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Csrftoken"}
+
+// secretFieldPattern matches the JSON fields whose values never go into a cassette.
+var secretFieldPattern = regexp.MustCompile(`(?i)^(password|secret|token|key|api_key|private_key|psk|auth_psk|preshared_key|community|.*_(password|secret|token))$`)
+
+// Cassette is a recorded session: every request sent to Netbox and the response it got.
+type Cassette struct {
+	Version    int    `json:"version"`
+	RecordedAt string `json:"recorded_at"`
+	// SSLChecks holds the result of each certificate check, "" for a valid certificate.
+	SSLChecks    map[string]string `json:"ssl_checks"`
+	Interactions []Interaction     `json:"interactions"`
+}
+
+// Interaction is one request and its response, or the error that prevented a response.
+type Interaction struct {
+	Request  RecordedRequest   `json:"request"`
+	Response *RecordedResponse `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status     string            `json:"status"`
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+var (
+	cassetteMu sync.Mutex
+	// recording is the cassette written by --record, replaying the one read by --replay.
+	recording     *Cassette
+	recordingPath string
+	replaying     *Cassette
+	replayed      []bool
+	replayingPath string
+)
+
+// Record starts writing every exchange with Netbox to the cassette at path, with tokens and
+// secrets redacted. The file is rewritten after each exchange, so it is complete even when a
+// command exits early.
+func Record(path string) error {
+	cassetteMu.Lock()
+	recording = &Cassette{Version: 1, RecordedAt: time.Now().UTC().Format(time.RFC3339), SSLChecks: map[string]string{}, Interactions: []Interaction{}}
+	recordingPath = path
+	err := saveCassette()
+	cassetteMu.Unlock()
+	resetClient()
+	return err
+}
+
+// Replay serves every request from the cassette at path instead of the network. Requests are
+// matched by method, path and query, so a cassette recorded against one server replays with any
+// configuration.
+func Replay(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	c := new(Cassette)
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("%s is not a cassette: %s", path, err)
+	}
+
+	cassetteMu.Lock()
+	replaying = c
+	replayed = make([]bool, len(c.Interactions))
+	replayingPath = path
+	cassetteMu.Unlock()
+	resetClient()
+	return nil
+}
+
+// resetClient drops the shared client, so that the next one is built with the cassette transport.
+func resetClient() {
+	mu.Lock()
+	client = nil
+	checked = map[string]error{}
+	mu.Unlock()
+}
+
+// cassetteTransport returns the transport of the active cassette, or nil without one.
+func cassetteTransport() http.RoundTripper {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	switch {
+	case replaying != nil:
+		return roundTripFunc(replay)
+	case recording != nil:
+		return roundTripFunc(record)
+	}
+	return nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// cassetteCheckSSL answers a certificate check from the cassette, or runs and records it.
+func cassetteCheckSSL(url string, check func(string) error) error {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	if replaying != nil {
+		msg, ok := replaying.SSLChecks[redactString(url)]
+		// Replaying with another configuration: a cassette usually talks to a single server.
+		if !ok && len(replaying.SSLChecks) == 1 {
+			for _, only := range replaying.SSLChecks {
+				msg, ok = only, true
+			}
+		}
+		switch {
+		case !ok:
+			return fmt.Errorf("no certificate check for %s in %s", url, replayingPath)
+		case msg != "":
+			return errors.New(msg)
+		}
+		return nil
+	}
+
+	err := check(url)
+	if recording != nil {
+		msg := ""
+		if err != nil {
+			msg = redactString(err.Error())
+		}
+		recording.SSLChecks[redactString(url)] = msg
+		_ = saveCassette()
+	}
+	return err
+}
+
+func record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{Request: RecordedRequest{
+		Method:  req.Method,
+		URL:     redactString(req.URL.String()),
+		Headers: redactHeaders(req.Header),
+		Body:    redactBody(reqBody),
+	}}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		interaction.Error = redactString(err.Error())
+	} else {
+		respBody, readErr := readBody(&resp.Body)
+		if readErr != nil {
+			return nil, readErr
+		}
+		interaction.Response = &RecordedResponse{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       redactBody(respBody),
+		}
+	}
+
+	cassetteMu.Lock()
+	recording.Interactions = append(recording.Interactions, interaction)
+	saveErr := saveCassette()
+	cassetteMu.Unlock()
+	if saveErr != nil {
+		return nil, fmt.Errorf("recording to %s: %s", recordingPath, saveErr)
+	}
+	return resp, err
+}
+
+func replay(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	match := -1
+	for i, interaction := range replaying.Interactions {
+		if replayed[i] || interaction.Request.Method != req.Method || !samePathAndQuery(interaction.Request.URL, req.URL.RequestURI()) {
+			continue
+		}
+		if match < 0 {
+			match = i
+		}
+		// Of several identical requests, prefer the one sent with the same body.
+		if interaction.Request.Body == redactBody(reqBody) {
+			match = i
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response in %s for %s %s", replayingPath, req.Method, req.URL.RequestURI())
+	}
+	replayed[match] = true
+
+	interaction := replaying.Interactions[match]
+	if interaction.Response == nil {
+		return nil, errors.New(interaction.Error)
+	}
+	header := http.Header{}
+	for key, value := range interaction.Response.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        interaction.Response.Status,
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// samePathAndQuery reports whether a recorded URL has the request URI uri.
+func samePathAndQuery(recorded string, uri string) bool {
+	i := strings.Index(recorded, "://")
+	if i < 0 {
+		return recorded == uri
+	}
+	rest := recorded[i+3:]
+	if j := strings.IndexAny(rest, "/?"); j >= 0 {
+		return rest[j:] == uri
+	}
+	return uri == "/"
+}
+
+// readBody reads a request or response body and replaces it with a copy, so it can still be sent or read.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	b, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return string(b), err
+}
+
+func saveCassette() error {
+	b, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(recordingPath, append(b, '\n'), 0600)
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := map[string]string{}
+	for key := range h {
+		out[key] = redactString(h.Get(key))
+	}
+	for _, key := range redactedHeaders {
+		if h.Get(key) != "" {
+			out[http.CanonicalHeaderKey(key)] = Redacted
+		}
+	}
+	return out
+}
+
+// redactBody blanks the secret fields of a JSON body and the configured token anywhere in it.
+func redactBody(body string) string {
+	if body == "" {
+		return ""
+	}
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return redactString(body)
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redactString(body)
+	}
+	return redactString(string(b))
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, isString := field.(string); isString && secretFieldPattern.MatchString(key) {
+				value[key] = Redacted
+				continue
+			}
+			value[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+// redactString removes the configured API tokens from s.
+func redactString(s string) string {
+	for _, key := range tokensForRedaction() {
+		token := strings.TrimSpace(strings.TrimPrefix(key, "Token "))
+		if len(token) >= 8 {
+			s = strings.ReplaceAll(s, token, Redacted)
+		}
+	}
+	return s
+}
+
+// tokensForRedaction returns the configured tokens, cmd.token_key and the token_key of every
+// profile and plugin, without taking mu, which may be held by the caller.
+func tokensForRedaction() []string {
+	if config == nil {
+		return nil
+	}
+	tokens := []string{config.GetString("cmd.token_key")}
+	for _, section := range []string{"cmd.profiles", "cmd.plugins"} {
+		for name := range config.GetStringMap(section) {
+			tokens = append(tokens, config.GetString(section+"."+name+".token_key"))
+		}
+	}
+	return tokens
+}
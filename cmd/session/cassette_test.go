@@ -0,0 +1,150 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const testToken = "Token 0123456789abcdef0123456789abcdef01234567"
+
+func useTestConfig(t *testing.T) {
+	vi := viper.New()
+	vi.Set("cmd.token_key", testToken)
+	mu.Lock()
+	config = vi
+	mu.Unlock()
+	t.Cleanup(func() {
+		cassetteMu.Lock()
+		recording, replaying = nil, nil
+		cassetteMu.Unlock()
+		Reset()
+	})
+}
+
+func get(t *testing.T, url string, body string) (int, string, error) {
+	t.Helper()
+	resp, err := Client().R().SetHeader("Authorization", testToken).SetBody(body).Post(url)
+	if err != nil {
+		return 0, "", err
+	}
+	return resp.StatusCode(), resp.String(), nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	useTestConfig(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "sessionid=abc")
+		w.WriteHeader(201)
+		_, _ = io.WriteString(w, `{"id": 7, "path": "`+r.URL.RequestURI()+`", "sent": `+string(b)+`, "key": "0123456789abcdef0123456789abcdef01234567"}`)
+	}))
+	defer srv.Close()
+
+	cassette := filepath.Join(t.TempDir(), "session.json")
+	if err := Record(cassette); err != nil {
+		t.Fatal(err)
+	}
+	_, first, err := get(t, srv.URL+"/api/users/tokens/?user=1", `{"user": 1, "password": "hunter2"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, second, _ := get(t, srv.URL+"/api/users/tokens/?user=1", `{"user": 2}`)
+
+	b, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"0123456789abcdef", "hunter2", "sessionid"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, b)
+		}
+	}
+
+	srv.Close()
+	cassetteMu.Lock()
+	recording = nil
+	cassetteMu.Unlock()
+	if err := Replay(cassette); err != nil {
+		t.Fatal(err)
+	}
+
+	// Identical requests are matched by body, so they replay in any order.
+	status, replayedSecond, err := get(t, "https://netbox.example.com/api/users/tokens/?user=1", `{"user": 2}`)
+	if err != nil || status != 201 {
+		t.Fatalf("replay: %d %v", status, err)
+	}
+	if strings.Contains(replayedSecond, "0123456789abcdef") || replayedSecond != redactBody(second) {
+		t.Errorf("replayed %s, recorded %s", replayedSecond, second)
+	}
+	if _, replayedFirst, _ := get(t, "https://netbox.example.com/api/users/tokens/?user=1", `{"user": 1, "password": "hunter2"}`); replayedFirst != redactBody(first) {
+		t.Errorf("replayed %s, recorded %s", replayedFirst, first)
+	}
+
+	if _, _, err := get(t, "https://netbox.example.com/api/users/tokens/?user=1", `{}`); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("replaying a request twice: %v", err)
+	}
+}
+
+func TestReplaySSLChecks(t *testing.T) {
+	useTestConfig(t)
+	cassette := filepath.Join(t.TempDir(), "session.json")
+	content := `{"version": 1, "ssl_checks": {"https://good.example.com": "", "https://bad.example.com": "x509: certificate has expired"}, "interactions": []}`
+	if err := os.WriteFile(cassette, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replay(cassette); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckSSL("https://good.example.com"); err != nil {
+		t.Errorf("good: %v", err)
+	}
+	if err := CheckSSL("https://bad.example.com"); err == nil || err.Error() != "x509: certificate has expired" {
+		t.Errorf("bad: %v", err)
+	}
+	if err := CheckSSL("https://other.example.com"); err == nil {
+		t.Error("unrecorded check succeeded")
+	}
+}
+
+func TestRedactProfileTokens(t *testing.T) {
+	useTestConfig(t)
+	mu.Lock()
+	config.Set("cmd.profiles.emea.token_key", "Token fedcba9876543210fedcba9876543210fedcba98")
+	config.Set("cmd.plugins.audit.token_key", "Token 00112233445566778899aabbccddeeff00112233")
+	mu.Unlock()
+
+	got := redactString("emea fedcba9876543210fedcba9876543210fedcba98 audit 00112233445566778899aabbccddeeff00112233 default 0123456789abcdef0123456789abcdef01234567")
+	if want := "emea " + Redacted + " audit " + Redacted + " default " + Redacted; got != want {
+		t.Errorf("redactString = %q, want %q", got, want)
+	}
+}
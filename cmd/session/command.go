@@ -0,0 +1,299 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrTimeout is the cause of a command stopped by --timeout.
+var ErrTimeout = errors.New("timed out")
+
+// errEnded is the cause of a command context cancelled because the command ended.
+var errEnded = errors.New("command ended")
+
+// Interrupted is the cause of a command stopped by a signal.
+type Interrupted struct {
+	Signal os.Signal
+}
+
+func (e *Interrupted) Error() string {
+	return "interrupted by " + e.Signal.String()
+}
+
+// Stopped is the error of a command interrupted by a signal or stopped by --timeout, with the
+// report of what it did.
+type Stopped struct {
+	Cause  error
+	Report Report
+}
+
+func (e *Stopped) Error() string {
+	return "stopped, " + e.Cause.Error()
+}
+
+func (e *Stopped) Unwrap() error {
+	return e.Cause
+}
+
+// Request is a request of the current command, for the report of an interrupted command.
+type Request struct {
+	Method string
+	URL    string
+	// Status is the HTTP status of the response, or 0 without one.
+	Status int
+	// Objects is the number of objects in the body of a bulk request, 1 otherwise.
+	Objects int
+}
+
+// Report describes what an interrupted command did.
+type Report struct {
+	// Completed is the number of requests that got a response.
+	Completed int
+	// Applied and Rejected are the changes answered with a success and an error status.
+	Applied  []Request
+	Rejected []Request
+	// InFlight are the changes sent but not answered: Netbox may or may not have applied them.
+	InFlight []Request
+	// NotSent are the changes stopped before they were sent.
+	NotSent []Request
+}
+
+// Change is a change made by the current command that Netbox answered with a success status.
+type Change struct {
+	Method string
+	URL    string
+	// Authorization is the header the change was sent with.
+	Authorization string
+	// Body is the body of the response.
+	Body []byte
+}
+
+// command is the state of the command being run.
+type command struct {
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	stop     func()
+	inFlight map[*resty.Request]Request
+	report   Report
+}
+
+var (
+	commandMu sync.Mutex
+	current   = newCommand(context.Background())
+	// changeHandler is called after every change made by a command.
+	changeHandler func(change Change)
+)
+
+func newCommand(ctx context.Context) *command {
+	c := &command{inFlight: map[*resty.Request]Request{}}
+	c.ctx, c.cancel = context.WithCancelCause(ctx)
+	c.stop = func() {}
+	return c
+}
+
+// Context returns the context of the current command. Requests made without a context of their
+// own are made with it, so that they stop when the command is interrupted or times out.
+func Context() context.Context {
+	commandMu.Lock()
+	defer commandMu.Unlock()
+	return current.ctx
+}
+
+// SetChangeHandler sets the function called after every change a command makes, e.g. to record
+// it in the journal of the objects changed. A nil handler removes it.
+func SetChangeHandler(handler func(change Change)) {
+	commandMu.Lock()
+	defer commandMu.Unlock()
+	changeHandler = handler
+}
+
+// StartCommand starts a command that stops after timeout, if not zero, and on SIGINT or SIGTERM
+// when handleSignals is set. Stopping the command cancels the context it returns: the requests
+// fail and the command returns. The previous command, if any, is ended.
+func StartCommand(timeout time.Duration, handleSignals bool) context.Context {
+	EndCommand()
+
+	c := newCommand(context.Background())
+	var stops []func()
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			c.cancel(fmt.Errorf("%w after %s", ErrTimeout, timeout))
+		})
+		stops = append(stops, func() { timer.Stop() })
+	}
+	if handleSignals {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			select {
+			case sig := <-signals:
+				c.cancel(&Interrupted{Signal: sig})
+			case <-c.ctx.Done():
+			}
+		}()
+		stops = append(stops, func() { signal.Stop(signals) })
+	}
+	c.stop = func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+	commandMu.Lock()
+	current = c
+	commandMu.Unlock()
+	return c.ctx
+}
+
+// EndCommand ends the current command, releasing its timer and signal handler. It returns a
+// *Stopped if the command was interrupted or timed out, whatever error the command returned.
+func EndCommand() error {
+	commandMu.Lock()
+	c := current
+	current = newCommand(context.Background())
+	cause := context.Cause(c.ctx)
+	report := c.snapshot()
+	commandMu.Unlock()
+	c.stop()
+	c.cancel(errEnded)
+	if cause == nil || errors.Is(cause, errEnded) {
+		return nil
+	}
+	return &Stopped{Cause: cause, Report: report}
+}
+
+// snapshot returns the report of c. commandMu must be held.
+func (c *command) snapshot() Report {
+	report := c.report
+	report.Applied = append([]Request(nil), report.Applied...)
+	report.Rejected = append([]Request(nil), report.Rejected...)
+	report.NotSent = append([]Request(nil), report.NotSent...)
+	for _, r := range c.inFlight {
+		if isChange(r.Method) {
+			report.InFlight = append(report.InFlight, r)
+		}
+	}
+	return report
+}
+
+// withCommandContext is a request middleware of the client. It makes the request with the
+// context of the current command, unless it has its own, and refuses to send it once the
+// command is stopped.
+func withCommandContext(_ *resty.Client, req *resty.Request) error {
+	commandMu.Lock()
+	c := current
+	if req.Context() == context.Background() {
+		req.SetContext(c.ctx)
+	}
+	r := Request{Method: req.Method, URL: redactString(req.URL), Objects: countObjects(req)}
+	if err := context.Cause(c.ctx); err != nil && !errors.Is(err, errEnded) {
+		if isChange(r.Method) {
+			c.report.NotSent = append(c.report.NotSent, r)
+		}
+		commandMu.Unlock()
+		return err
+	}
+	c.inFlight[req] = r
+	commandMu.Unlock()
+	return nil
+}
+
+// trackResponse is a response middleware of the client, recording the outcome of a request.
+func trackResponse(_ *resty.Client, resp *resty.Response) error {
+	commandMu.Lock()
+	defer commandMu.Unlock()
+	c := current
+	r, ok := c.inFlight[resp.Request]
+	if !ok {
+		return nil
+	}
+	delete(c.inFlight, resp.Request)
+	r.Status = resp.StatusCode()
+	c.report.Completed++
+	switch {
+	case !isChange(r.Method):
+	case resp.IsSuccess():
+		c.report.Applied = append(c.report.Applied, r)
+	default:
+		c.report.Rejected = append(c.report.Rejected, r)
+	}
+	return nil
+}
+
+// notifyChange is a response middleware of the client, passing the changes Netbox applied to the
+// change handler. The handler may send requests of its own.
+func notifyChange(_ *resty.Client, resp *resty.Response) error {
+	if !isChange(resp.Request.Method) || !resp.IsSuccess() {
+		return nil
+	}
+	commandMu.Lock()
+	handler := changeHandler
+	commandMu.Unlock()
+	if handler != nil {
+		handler(Change{
+			Method:        resp.Request.Method,
+			URL:           resp.Request.URL,
+			Authorization: resp.Request.Header.Get("Authorization"),
+			Body:          resp.Body(),
+		})
+	}
+	return nil
+}
+
+// trackError is an error hook of the client. A request that failed because its command was
+// stopped stays in flight, as Netbox may have received it.
+func trackError(req *resty.Request, err error) {
+	commandMu.Lock()
+	c := current
+	if context.Cause(c.ctx) == nil {
+		delete(c.inFlight, req)
+	}
+	commandMu.Unlock()
+}
+
+func isChange(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+	return true
+}
+
+// countObjects returns the number of objects in the body of a bulk request, 1 otherwise.
+func countObjects(req *resty.Request) int {
+	var list []json.RawMessage
+	if err := json.Unmarshal([]byte(requestBodyString(req)), &list); err == nil {
+		return len(list)
+	}
+	return 1
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// stopCommand runs a command against a server that answers writes slowly, and returns the cause
+// and report of the *Stopped that ends it.
+func stopCommand(t *testing.T, timeout time.Duration, stop func()) (error, Report) {
+	t.Helper()
+	useTestConfig(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/status/":
+			_, _ = w.Write([]byte(`{"netbox-version": "4.1.3"}`))
+		case r.Method == "GET":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case r.Method == "PATCH":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			<-release
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	t.Cleanup(func() { _ = EndCommand() })
+
+	StartCommand(timeout, stop != nil)
+	if _, err := Client().R().Get(srv.URL + "/api/dcim/sites/1/"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Client().R().SetBody(`{"id": 1}`).Patch(srv.URL + "/api/dcim/sites/"); err != nil {
+		t.Fatal(err)
+	}
+	if stop != nil {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			stop()
+		}()
+	}
+	if _, err := Client().R().SetBody(`[{"name": "a"}, {"name": "b"}]`).Post(srv.URL + "/api/dcim/sites/"); err == nil {
+		t.Errorf("the request was not stopped")
+	}
+	if _, err := Client().R().Delete(srv.URL + "/api/dcim/sites/1/"); err == nil {
+		t.Errorf("a request was sent after the command was stopped")
+	}
+
+	var stopped *Stopped
+	if err := EndCommand(); !errors.As(err, &stopped) {
+		t.Fatalf("the command ended with %v, want a *Stopped", err)
+	}
+	return stopped.Cause, stopped.Report
+}
+
+func TestCommandTimeout(t *testing.T) {
+	cause, report := stopCommand(t, 300*time.Millisecond, nil)
+	if !errors.Is(cause, ErrTimeout) {
+		t.Errorf("cause %v, want a timeout", cause)
+	}
+	if report.Completed != 3 {
+		t.Errorf("%d completed requests, want 3: the status, the GET and the PATCH", report.Completed)
+	}
+	if len(report.Applied) != 0 || len(report.Rejected) != 1 || report.Rejected[0].Status != 400 {
+		t.Errorf("applied %v, rejected %v", report.Applied, report.Rejected)
+	}
+	if len(report.InFlight) != 1 || report.InFlight[0].Method != "POST" || report.InFlight[0].Objects != 2 {
+		t.Errorf("in flight %v, want the bulk POST", report.InFlight)
+	}
+}
+
+func TestCommandInterrupted(t *testing.T) {
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Skip(err)
+	}
+	cause, report := stopCommand(t, 0, func() {
+		if err := self.Signal(os.Interrupt); err != nil {
+			t.Errorf("cannot interrupt the test: %s", err)
+		}
+	})
+	var interrupted *Interrupted
+	if !errors.As(cause, &interrupted) || interrupted.Signal != os.Interrupt {
+		t.Errorf("cause %v, want an interrupt", cause)
+	}
+	if len(report.InFlight) != 1 {
+		t.Errorf("in flight %v, want the bulk POST", report.InFlight)
+	}
+}
+
+func TestCommandEnded(t *testing.T) {
+	StartCommand(time.Minute, false)
+	if err := EndCommand(); err != nil {
+		t.Errorf("a command that ran to its end returned %v", err)
+	}
+}
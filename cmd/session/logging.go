@@ -0,0 +1,127 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxLoggedBody is the number of bytes of a request or response body kept in a debug log entry.
+const maxLoggedBody = 64 << 10
+
+var logger atomic.Pointer[slog.Logger]
+
+// discardLogger is the logger of a session without logging.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+
+func init() {
+	logger.Store(discardLogger)
+}
+
+// Logger returns the logger of the session, which discards everything until SetLogger is called.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// SetLogger sets the logger of the session. Every request to Netbox is logged at info level with
+// its method, URL, status, duration and response size, and at debug level with its headers and
+// bodies. Tokens and secret fields are redacted. A nil logger turns logging off.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = discardLogger
+	}
+	logger.Store(l)
+}
+
+// logResponse is a response middleware of the client.
+func logResponse(_ *resty.Client, resp *resty.Response) error {
+	l := Logger()
+	ctx := context.Background()
+	if !l.Enabled(ctx, slog.LevelInfo) {
+		return nil
+	}
+	req := resp.Request
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactString(req.URL)),
+		slog.Int("status", resp.StatusCode()),
+		slog.Duration("duration", resp.Time()),
+		slog.Int("bytes", len(resp.Body())),
+	}
+	if l.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs,
+			slog.Any("request_headers", redactHeaders(req.Header)),
+			slog.String("request_body", truncateBody(redactBody(requestBodyString(req)))),
+			slog.Any("response_headers", redactHeaders(resp.Header())),
+			slog.String("response_body", truncateBody(redactBody(resp.String()))),
+		)
+	}
+	l.LogAttrs(ctx, slog.LevelInfo, "http request", attrs...)
+	return nil
+}
+
+// logError is an error hook of the client, for requests that got no response.
+func logError(req *resty.Request, err error) {
+	var elapsed time.Duration
+	if !req.Time.IsZero() {
+		elapsed = time.Since(req.Time)
+	}
+	Logger().LogAttrs(context.Background(), slog.LevelWarn, "http request failed",
+		slog.String("method", req.Method),
+		slog.String("url", redactString(req.URL)),
+		slog.Duration("duration", elapsed),
+		slog.String("error", redactString(err.Error())),
+	)
+}
+
+func requestBodyString(req *resty.Request) string {
+	switch body := req.Body.(type) {
+	case nil:
+		return ""
+	case string:
+		return body
+	case []byte:
+		return string(body)
+	default:
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Sprintf("%v", body)
+		}
+		return string(b)
+	}
+}
+
+func truncateBody(body string) string {
+	if len(body) <= maxLoggedBody {
+		return body
+	}
+	return body[:maxLoggedBody] + fmt.Sprintf("... (%d bytes)", len(body))
+}
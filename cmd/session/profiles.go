@@ -0,0 +1,92 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Profiles returns the names of the Netbox instances in netbox_config.yaml: development and
+// production, then every instance under cmd.profiles in alphabetical order:
+//
+//	cmd:
+//	    profiles:
+//	        emea:
+//	            root_url: "https://netbox-emea.ABC.io"
+//	            token_key: "Token ..."
+//
+// A profile without token_key uses cmd.token_key. A profile name is accepted wherever an
+// environment is.
+func Profiles() ([]string, error) {
+	vi, err := Config()
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for _, env := range []string{"development", "production"} {
+		if url, _ := RootURL(env); url != "" {
+			profiles = append(profiles, env)
+		}
+	}
+	var named []string
+	for name := range vi.GetStringMap("cmd.profiles") {
+		if name != "development" && name != "production" {
+			named = append(named, name)
+		}
+	}
+	sort.Strings(named)
+	return append(profiles, named...), nil
+}
+
+// ProfileToken returns the value of the Authorization header sent to the instance of a profile.
+func ProfileToken(profile string) string {
+	vi, err := Config()
+	if err != nil {
+		return ""
+	}
+	if token := vi.GetString("cmd.profiles." + profile + ".token_key"); token != "" {
+		return token
+	}
+	return vi.GetString("cmd.token_key")
+}
+
+// useProfileToken is a request middleware of the client. Requests to the instance of a profile
+// with its own token_key are sent with that token, whichever token the command set.
+func useProfileToken(_ *resty.Client, req *resty.Request) error {
+	vi, err := Config()
+	if err != nil || req.Header.Get("Authorization") == "" {
+		return nil
+	}
+	for name := range vi.GetStringMap("cmd.profiles") {
+		root := strings.TrimSuffix(vi.GetString("cmd.profiles."+name+".root_url"), "/")
+		token := vi.GetString("cmd.profiles." + name + ".token_key")
+		if root != "" && token != "" && (req.URL == root || strings.HasPrefix(req.URL, root+"/")) {
+			req.SetHeader("Authorization", token)
+			return nil
+		}
+	}
+	return nil
+}
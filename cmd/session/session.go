@@ -0,0 +1,209 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package session holds the state shared by every command of a single abc-netbox.cli process:
+// the parsed netbox_config.yaml, one HTTP client, the result of the SSL check and the detected
+// Netbox version per server, and the context of the command being run.
+// A one-shot invocation uses it exactly once; the interactive shell keeps it alive between commands.
+package session
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/viper"
+)
+
+var (
+	mu      sync.Mutex
+	config  *viper.Viper
+	client  *resty.Client
+	checked = map[string]error{}
+	env     string
+	// versions holds the Netbox version of each server, by root URL.
+	versions = map[string]string{}
+	// versionErrors holds the error of each server whose version could not be detected, so a
+	// failing /api/status/ is requested once per session rather than before every request.
+	versionErrors = map[string]error{}
+)
+
+// Config returns netbox_config.yaml, reading it on first use.
+func Config() (*viper.Viper, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if config != nil {
+		return config, nil
+	}
+
+	vi := viper.New()
+	vi.SetConfigName("netbox_config")
+	vi.SetConfigType("yaml")
+	vi.AddConfigPath(".")
+	vi.AutomaticEnv()
+
+	if err := vi.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	config = vi
+	return config, nil
+}
+
+// RootURL returns the root URL of the Netbox API for an environment ('development' or 'production')
+// or a profile (see Profiles).
+func RootURL(environment string) (string, error) {
+	vi, err := Config()
+	if err != nil {
+		return "", err
+	}
+	switch environment {
+	case "development":
+		return vi.GetString("cmd.netbox_dev_root_url"), nil
+	case "production":
+		return vi.GetString("cmd.netbox_prod_root_url"), nil
+	default:
+		if url := vi.GetString("cmd.profiles." + environment + ".root_url"); url != "" {
+			return url, nil
+		}
+		return "", fmt.Errorf("unrecognized environment: %s", environment)
+	}
+}
+
+// Token returns the value of the Authorization header sent to Netbox.
+func Token() string {
+	vi, err := Config()
+	if err != nil {
+		return ""
+	}
+	return vi.GetString("cmd.token_key")
+}
+
+// Client returns the HTTP client shared by every request of the session.
+func Client() *resty.Client {
+	mu.Lock()
+	defer mu.Unlock()
+	if client == nil {
+		client = resty.New()
+		client.SetTimeout(requestTimeout())
+		client.OnBeforeRequest(withCommandContext)
+		client.OnBeforeRequest(useProfileToken)
+		client.OnBeforeRequest(adaptToVersion)
+		client.OnAfterResponse(trackResponse)
+		client.OnAfterResponse(logResponse)
+		client.OnAfterResponse(notifyChange)
+		client.OnError(logError)
+		client.OnError(trackError)
+		if transport := cassetteTransport(); transport != nil {
+			client.SetTransport(transport)
+		}
+	}
+	return client
+}
+
+// defaultRequestTimeout is the time a request may take, unless cmd.request_timeout says otherwise.
+const defaultRequestTimeout = 2 * time.Minute
+
+// requestTimeout returns the time a single request to Netbox may take, from cmd.request_timeout.
+// mu must be held.
+func requestTimeout() time.Duration {
+	if config == nil {
+		return defaultRequestTimeout
+	}
+	if timeout := config.GetDuration("cmd.request_timeout"); timeout > 0 {
+		return timeout
+	}
+	return defaultRequestTimeout
+}
+
+// Env returns the environment the session is pinned to, or an empty string outside the shell.
+func Env() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return env
+}
+
+// SetEnv pins the session to an environment. Commands run by the shell default to it.
+func SetEnv(environment string) {
+	mu.Lock()
+	defer mu.Unlock()
+	env = environment
+}
+
+// Reset forgets the cached configuration, client and SSL checks, so the next command starts afresh.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	config = nil
+	client = nil
+	checked = map[string]error{}
+	versions = map[string]string{}
+	versionErrors = map[string]error{}
+}
+
+// CheckSSL verifies that url serves a valid certificate. The result is remembered for the
+// rest of the session, so the shell only pays for the check once per server.
+func CheckSSL(url string) error {
+	mu.Lock()
+	err, ok := checked[url]
+	mu.Unlock()
+	if ok {
+		return err
+	}
+
+	err = cassetteCheckSSL(url, checkSSL)
+
+	mu.Lock()
+	checked[url] = err
+	mu.Unlock()
+	return err
+}
+
+func checkSSL(url string) error {
+	// Create a new http client with the custom transport.
+	c := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: false},
+		},
+		Timeout: time.Second * 10,
+	}
+
+	// Request the URL
+	resp, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Check if the status code is okay
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	// No problem
+	return nil
+}
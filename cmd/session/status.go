@@ -0,0 +1,300 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/go-resty/resty/v2"
+)
+
+// The range of Netbox releases the commands are written against, as major.minor.
+const (
+	MinVersion = "3.5"
+	MaxVersion = "4.1"
+)
+
+// statusPath is the API endpoint reporting the versions of a Netbox server.
+const statusPath = "/api/status/"
+
+// versionCacheTTL is how long a detected server version is trusted before it is detected again.
+const versionCacheTTL = 24 * time.Hour
+
+// Status is the response of /api/status/.
+type Status struct {
+	NetboxVersion     string            `json:"netbox-version"`
+	NetboxFullVersion string            `json:"netbox-full-version,omitempty"`
+	DjangoVersion     string            `json:"django-version"`
+	PythonVersion     string            `json:"python-version"`
+	InstalledApps     map[string]string `json:"installed-apps"`
+	Plugins           map[string]string `json:"plugins"`
+	RQWorkersRunning  int               `json:"rq-workers-running"`
+}
+
+// FetchStatus requests /api/status/ from the server at rootURL and remembers its version.
+func FetchStatus(rootURL string) (*Status, error) {
+	rootURL = strings.TrimSuffix(rootURL, "/")
+	resp, err := Client().R().
+		SetHeader("Authorization", Token()).
+		SetHeader("Accept", "application/json").
+		Get(rootURL + statusPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s: %s", resp.Status(), strings.TrimSpace(resp.String()))
+	}
+	status := new(Status)
+	if err := json.Unmarshal(resp.Body(), status); err != nil {
+		return nil, fmt.Errorf("parsing %s: %s", statusPath, err)
+	}
+
+	Logger().Debug("detected Netbox version", "url", rootURL, "version", status.NetboxVersion)
+	mu.Lock()
+	versions[rootURL] = status.NetboxVersion
+	mu.Unlock()
+	writeVersionCache(rootURL, status.NetboxVersion)
+	return status, nil
+}
+
+// ServerVersion returns the Netbox version of the server at rootURL. It is detected once per
+// server and kept for a day in the user's cache directory, so that commands only pay for the
+// request on first use of a profile. A failed detection is remembered for the session only. A
+// newly detected version outside the supported range is reported on stderr.
+func ServerVersion(rootURL string) (string, error) {
+	rootURL = strings.TrimSuffix(rootURL, "/")
+	mu.Lock()
+	version, ok := versions[rootURL]
+	versionErr := versionErrors[rootURL]
+	mu.Unlock()
+	if ok {
+		return version, nil
+	}
+	if versionErr != nil {
+		return "", versionErr
+	}
+	if version, ok := readVersionCache(rootURL); ok {
+		mu.Lock()
+		versions[rootURL] = version
+		mu.Unlock()
+		return version, nil
+	}
+
+	status, err := FetchStatus(rootURL)
+	if err != nil {
+		mu.Lock()
+		versionErrors[rootURL] = err
+		mu.Unlock()
+		return "", err
+	}
+	if warning := VersionWarning(status.NetboxVersion); warning != "" {
+		_, _ = fmt.Fprintln(os.Stderr, color.YellowString("  Warning: %s runs Netbox %s, %s. Some fields may be missing or ignored.", rootURL, status.NetboxVersion, warning))
+	}
+	return status.NetboxVersion, nil
+}
+
+// VersionWarning describes how version falls outside the supported range, or returns an empty
+// string when it is supported.
+func VersionWarning(version string) string {
+	switch {
+	case version == "":
+		return "the version is unknown"
+	case CompareVersions(version, MinVersion) < 0:
+		return fmt.Sprintf("older than %s, the oldest release supported", MinVersion)
+	case CompareVersions(version, MaxVersion+".999") > 0:
+		return fmt.Sprintf("newer than %s, the newest release supported", MaxVersion)
+	}
+	return ""
+}
+
+// CompareVersions compares two Netbox versions such as 4.1.3 or v4.2.0-beta1 by their major,
+// minor and patch numbers, returning -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) [3]int {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	for i, field := range strings.SplitN(version, ".", 3) {
+		end := 0
+		for end < len(field) && field[end] >= '0' && field[end] <= '9' {
+			end++
+		}
+		parts[i], _ = strconv.Atoi(field[:end])
+	}
+	return parts
+}
+
+// adaptToVersion is a request middleware of the client. It detects the version of the server on
+// first use and rewrites request bodies for fields renamed between releases: the role of a
+// device was device_role before Netbox 3.6, and only role from Netbox 4.0.
+func adaptToVersion(_ *resty.Client, req *resty.Request) error {
+	rootURL, path, ok := splitAPIURL(req.URL)
+	if !ok || path == statusPath {
+		return nil
+	}
+	version, err := ServerVersion(rootURL)
+	if err != nil || (req.Method != "POST" && req.Method != "PATCH" && req.Method != "PUT") || !strings.HasPrefix(path, "/api/dcim/devices/") {
+		return nil
+	}
+
+	var from, to string
+	switch {
+	case CompareVersions(version, "4.0") >= 0:
+		from, to = "device_role", "role"
+	case CompareVersions(version, "3.6") < 0:
+		from, to = "role", "device_role"
+	default:
+		return nil
+	}
+	body, ok := requestBody(req)
+	if !ok {
+		return nil
+	}
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	if renameField(payload, from, to) {
+		if adapted, err := json.Marshal(payload); err == nil {
+			req.SetBody(string(adapted))
+		}
+	}
+	return nil
+}
+
+// splitAPIURL splits the URL of a request to the REST or GraphQL API into the root URL of the
+// server and the path under it, e.g. https://netbox/api/dcim/devices/5/ into https://netbox and
+// /api/dcim/devices/5/.
+func splitAPIURL(rawURL string) (string, string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	for _, marker := range []string{"/api/", "/graphql/"} {
+		if i := strings.Index(u.Path, marker); i >= 0 {
+			return u.Scheme + "://" + u.Host + u.Path[:i], u.Path[i:], true
+		}
+	}
+	return "", "", false
+}
+
+func requestBody(req *resty.Request) ([]byte, bool) {
+	switch body := req.Body.(type) {
+	case string:
+		return []byte(body), true
+	case []byte:
+		return body, true
+	}
+	return nil, false
+}
+
+// renameField renames from to to in an object or the objects of a list, unless to is already set.
+func renameField(payload interface{}, from, to string) bool {
+	renamed := false
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		if value, ok := p[from]; ok {
+			if _, exists := p[to]; !exists {
+				p[to] = value
+			}
+			delete(p, from)
+			renamed = true
+		}
+	case []interface{}:
+		for _, item := range p {
+			renamed = renameField(item, from, to) || renamed
+		}
+	}
+	return renamed
+}
+
+type versionCacheEntry struct {
+	URL        string    `json:"url"`
+	Version    string    `json:"netbox-version"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// versionCachePath returns the cache file of rootURL, or an empty string when there is no cache
+// directory or a cassette is active, which must see the same requests on every run.
+func versionCachePath(rootURL string) string {
+	if cassetteTransport() != nil {
+		return ""
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum([]byte(rootURL))
+	return filepath.Join(dir, "abc-netbox.cli", "status", hex.EncodeToString(sum[:])+".json")
+}
+
+func readVersionCache(rootURL string) (string, bool) {
+	path := versionCachePath(rootURL)
+	if path == "" {
+		return "", false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry versionCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.URL != rootURL || time.Since(entry.DetectedAt) > versionCacheTTL {
+		return "", false
+	}
+	return entry.Version, true
+}
+
+func writeVersionCache(rootURL, version string) {
+	path := versionCachePath(rootURL)
+	if path == "" {
+		return
+	}
+	b, err := json.Marshal(versionCacheEntry{URL: rootURL, Version: version, DetectedAt: time.Now()})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+		_ = os.WriteFile(path, b, 0o600)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package session
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"4.1.3", "4.1.3", 0},
+		{"4.1", "4.1.0", 0},
+		{"v4.2.0-beta1", "4.1.999", 1},
+		{"3.5.9", "3.6", -1},
+		{"4.0.0-Docker-2.9.1", "4.0", 0},
+		{"10.0", "9.9", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVersionWarning(t *testing.T) {
+	for version, supported := range map[string]bool{"3.4.10": false, "3.5.0": true, "4.1.11": true, "4.2.0": false, "": false} {
+		if got := VersionWarning(version) == ""; got != supported {
+			t.Errorf("VersionWarning(%q) = %q", version, VersionWarning(version))
+		}
+	}
+}
+
+// TestAdaptToVersion checks that the role of a device is sent under the name the server knows.
+func TestAdaptToVersion(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		version string
+		body    string
+		want    string
+	}{
+		{"3.5.2", `{"name":"a","role":1}`, `{"device_role":1,"name":"a"}`},
+		{"3.7.8", `{"name":"a","role":1}`, `{"name":"a","role":1}`},
+		{"4.1.3", `[{"name":"a","device_role":1}]`, `[{"name":"a","role":1}]`},
+		{"4.1.3", `{"name":"a","device_role":1,"role":2}`, `{"name":"a","role":2}`},
+	}
+	for _, tt := range tests {
+		useTestConfig(t)
+		var mu sync.Mutex
+		var statusRequests int
+		var got string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			defer mu.Unlock()
+			if r.URL.Path == "/api/status/" {
+				statusRequests++
+				_, _ = w.Write([]byte(`{"netbox-version": "` + tt.version + `"}`))
+				return
+			}
+			got = string(b)
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		for i := 0; i < 2; i++ {
+			if _, err := Client().R().SetBody(tt.body).Post(srv.URL + "/api/dcim/devices/"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		srv.Close()
+		if strings.TrimSpace(got) != tt.want {
+			t.Errorf("Netbox %s received %s, want %s", tt.version, got, tt.want)
+		}
+		if statusRequests != 1 {
+			t.Errorf("Netbox %s: status requested %d times, want 1", tt.version, statusRequests)
+		}
+		Reset()
+	}
+}
+
+// TestServerVersionFailure checks that a server whose status cannot be read is asked only once.
+func TestServerVersionFailure(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	useTestConfig(t)
+	defer Reset()
+	var mu sync.Mutex
+	var statusRequests, posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/status/" {
+			statusRequests++
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		posts++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := Client().R().SetBody(`{"name":"a","role":1}`).Post(srv.URL + "/api/dcim/devices/"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if statusRequests != 1 || posts != 3 {
+		t.Errorf("status requested %d times and %d devices posted, want 1 and 3", statusRequests, posts)
+	}
+}
@@ -0,0 +1,572 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/google/shlex"
+	"github.com/peterh/liner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var shellEnv string
+
+// shellBuiltins lists the commands handled by the shell itself rather than by a subcommand.
+var shellBuiltins = []string{"help", "exit", "quit", "env", "get", "vars", "unset", "reload"}
+
+// assignmentPattern matches "$name = <command line>".
+var assignmentPattern = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.+)$`)
+
+// variablePattern matches a variable reference such as $dev, $dev.id or $devs.0.site.slug.
+var variablePattern = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)((?:\.[A-Za-z0-9_]+)*)`)
+
+// shellHelp is the help text of the shell command, also printed by the help built-in.
+const shellHelp = `
+ABC Netbox Automation Tools:
+  Interactive shell that keeps one Netbox session open.
+
+  Every abc-netbox.cli command can be run at the prompt without the program name. The
+  configuration, HTTP client and SSL check are shared by all of them and --env defaults
+  to the environment of the shell. Flags are reset after every command.
+
+  Built-in commands:
+    get <resource> [key=value ...]   query a resource, e.g. "get device name=core1"
+    $name = <command line>           store the result of get, or the output of any command
+    vars                             list variables
+    unset <name>                     delete a variable
+    env [development|production]     show or switch the environment
+    reload                           re-read netbox_config.yaml
+    help                             show this help
+    exit, quit                       leave the shell
+
+  Variables expand anywhere on a command line. $dev expands to the ID of the stored object,
+  $dev.name or $dev.site.slug to one of its fields and $devs.0.id indexes into a list:
+
+    $dev = get device name=core1
+    DCIM DcimGet getDcimDevicesById --id $dev`
+
+// ShellCmd represents the shell command
+var ShellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Interactive shell that keeps one Netbox session open",
+	Long:  shellHelp, RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := session.RootURL(shellEnv); err != nil {
+			return err
+		}
+		session.SetEnv(shellEnv)
+		newShell().run()
+		return nil
+	},
+}
+
+// shell is the state of one interactive session.
+type shell struct {
+	vars map[string]interface{}
+}
+
+func newShell() *shell {
+	return &shell{vars: map[string]interface{}{}}
+}
+
+func (s *shell) run() {
+	line := liner.NewLiner()
+	defer func(line *liner.State) {
+		err := line.Close()
+		if err != nil {
+			log.Printf("Error closing the terminal: %s", err)
+		}
+	}(line)
+	line.SetCtrlCAborts(true)
+	line.SetTabCompletionStyle(liner.TabPrints)
+	line.SetCompleter(s.complete)
+
+	history := shellHistoryPath()
+	if f, err := os.Open(history); err == nil {
+		_, _ = line.ReadHistory(f)
+		_ = f.Close()
+	}
+
+	color.Cyan("\n  ABC Netbox shell - environment: " + color.YellowString("%s", session.Env()))
+	color.Cyan("  Type 'help' for built-in commands, 'exit' to leave.\n")
+
+	for {
+		input, err := line.Prompt(fmt.Sprintf("netbox(%s)> ", session.Env()))
+		if errors.Is(err, liner.ErrPromptAborted) {
+			continue
+		}
+		if err != nil {
+			break
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+		line.AppendHistory(input)
+		if !s.exec(input) {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(history), 0o700); err == nil {
+		if f, err := os.Create(history); err == nil {
+			_, _ = line.WriteHistory(f)
+			_ = f.Close()
+		}
+	}
+}
+
+// exec runs one line of input and reports whether the shell should keep going.
+func (s *shell) exec(input string) bool {
+	target := ""
+	if m := assignmentPattern.FindStringSubmatch(input); m != nil {
+		target, input = m[1], m[2]
+	}
+
+	words, err := shlex.Split(input)
+	if err != nil {
+		color.Red("  %s", err)
+		return true
+	}
+	for i, w := range words {
+		if words[i], err = s.expand(w); err != nil {
+			color.Red("  %s", err)
+			return true
+		}
+	}
+	if len(words) == 0 {
+		return true
+	}
+
+	var result interface{}
+	switch words[0] {
+	case "exit", "quit":
+		return false
+	case "help":
+		fmt.Println(shellHelp)
+		return true
+	case "vars":
+		s.printVars()
+		return true
+	case "unset":
+		for _, name := range words[1:] {
+			delete(s.vars, strings.TrimPrefix(name, "$"))
+		}
+		return true
+	case "reload":
+		session.Reset()
+		color.Green("  Configuration reloaded.")
+		return true
+	case "env":
+		if len(words) > 1 {
+			if _, err := session.RootURL(words[1]); err != nil {
+				color.Red("  %s", err)
+				return true
+			}
+			session.SetEnv(words[1])
+		}
+		color.Cyan("  Environment: " + color.YellowString("%s", session.Env()))
+		return true
+	case "get":
+		result, err = s.get(words[1:])
+		if err != nil {
+			color.Red("  %s", err)
+			return true
+		}
+	default:
+		if target == "" {
+			s.runCommand(words)
+			return true
+		}
+		result = strings.TrimSpace(captureOutput(func() { s.runCommand(words) }))
+		fmt.Println(result)
+	}
+
+	if target != "" {
+		s.vars[target] = result
+		color.Cyan("  $%s = %s", target, color.YellowString("%s", describeValue(result)))
+	}
+	return true
+}
+
+// runCommand executes an abc-netbox.cli command line inside the shell's session.
+func (s *shell) runCommand(words []string) {
+	c, _, err := rootCmd.Find(words)
+	if err != nil || c == rootCmd {
+		color.Red("  Unknown command: %s", words[0])
+		return
+	}
+	if c.Name() == "shell" {
+		color.Red("  Already in the shell.")
+		return
+	}
+	// The palette commands (DCIM, DcimGet, ...) only group subcommands; show what they contain.
+	if c.HasSubCommands() {
+		_ = c.Help()
+		return
+	}
+	if c.Flags().Lookup("env") != nil && !containsFlag(words, "env") {
+		words = append(words, "--env", session.Env())
+	}
+
+	resetFlags(rootCmd)
+	defer resetFlags(rootCmd)
+	rootCmd.SetArgs(words)
+	if cmd, err := runRoot(); err != nil {
+		reportError(cmd, err)
+	}
+}
+
+// get queries a resource by its short name, e.g. "device" or "dcim.interfaces", with Netbox filters.
+func (s *shell) get(args []string) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: get <resource> [key=value ...]")
+	}
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	path, err := resourcePath(config.AllKeys(), config.GetString, args[0])
+	if err != nil {
+		return nil, err
+	}
+	rootURL, err := session.RootURL(session.Env())
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	values.Set("limit", "1000")
+	for _, pair := range args[1:] {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("filters must be key=value: %s", pair)
+		}
+		values.Add(key, value)
+	}
+	fullAPIPath := rootURL + path + "?" + values.Encode()
+
+	resp, err := session.Client().R().
+		SetHeaders(map[string]string{
+			"Authorization": session.Token(),
+			"Accept":        "application/json",
+		}).
+		Get(fullAPIPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s returned %s", fullAPIPath, resp.Status())
+	}
+
+	list := struct {
+		Count   int                      `json:"count"`
+		Results []map[string]interface{} `json:"results"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), &list); err != nil {
+		return nil, fmt.Errorf("error while parsing the response bytes: %s", err)
+	}
+
+	color.Cyan("  %s: "+color.YellowString("%d")+" found", args[0], list.Count)
+	for _, object := range list.Results {
+		color.Cyan("\tID: "+color.YellowString("%v", formatScalar(object["id"]))+"  %s", formatScalar(object["display"]))
+	}
+	if len(list.Results) == 1 {
+		return list.Results[0], nil
+	}
+	results := make([]interface{}, len(list.Results))
+	for i, object := range list.Results {
+		results[i] = object
+	}
+	return results, nil
+}
+
+// resourcePath finds the API path of a resource given as "device", "devices", "dcim.device" or "front-ports".
+func resourcePath(keys []string, get func(string) string, name string) (string, error) {
+	domain := ""
+	if d, n, ok := strings.Cut(name, "."); ok {
+		domain, name = d, n
+	}
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+
+	var matches []string
+	for _, candidate := range []string{name, name + "s", name + "es"} {
+		for _, key := range keys {
+			if strings.HasSuffix(key, "_api_url."+candidate+"_id") && (domain == "" || strings.HasPrefix(key, "cmd."+domain+".")) {
+				matches = append(matches, key)
+			}
+		}
+		if len(matches) > 0 {
+			break
+		}
+	}
+	sort.Strings(matches)
+	switch {
+	case len(matches) == 0:
+		return "", fmt.Errorf("unknown resource: %s", name)
+	case len(matches) > 1 && domain == "":
+		// Prefer dcim for names shared with other domains, e.g. interfaces.
+		for _, key := range matches {
+			if strings.HasPrefix(key, "cmd.dcim.") {
+				return strings.SplitN(get(key), "?", 2)[0], nil
+			}
+		}
+	}
+	return strings.SplitN(get(matches[0]), "?", 2)[0], nil
+}
+
+// expand replaces the variable references in word with their values.
+func (s *shell) expand(word string) (string, error) {
+	var err error
+	out := variablePattern.ReplaceAllStringFunc(word, func(ref string) string {
+		m := variablePattern.FindStringSubmatch(ref)
+		value, ok := s.vars[m[1]]
+		if !ok {
+			err = fmt.Errorf("undefined variable: $%s", m[1])
+			return ref
+		}
+		for _, field := range strings.Split(strings.TrimPrefix(m[2], "."), ".") {
+			if field == "" {
+				continue
+			}
+			switch v := value.(type) {
+			case map[string]interface{}:
+				value = v[field]
+			case []interface{}:
+				i, convErr := strconv.Atoi(field)
+				if convErr != nil || i < 0 || i >= len(v) {
+					err = fmt.Errorf("$%s%s: no element %s", m[1], m[2], field)
+					return ref
+				}
+				value = v[i]
+			default:
+				err = fmt.Errorf("$%s%s: %s has no fields", m[1], m[2], field)
+				return ref
+			}
+		}
+		return formatValue(value)
+	})
+	return out, err
+}
+
+func (s *shell) printVars() {
+	var names []string
+	for name := range s.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		color.Cyan("  $%s = %s", name, color.YellowString("%s", describeValue(s.vars[name])))
+	}
+}
+
+// complete offers completions for the shell line using the same machinery as the generated
+// shell completion scripts, so object IDs and slugs are completed from the server as well.
+func (s *shell) complete(line string) []string {
+	words, err := shlex.Split(line)
+	if err != nil {
+		return nil
+	}
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+	last := words[len(words)-1]
+	prefix := line
+	if last != "" {
+		prefix = line[:strings.LastIndex(line, last)]
+	}
+
+	var candidates []string
+	if len(words) == 1 {
+		for _, b := range shellBuiltins {
+			if strings.HasPrefix(b, last) {
+				candidates = append(candidates, b)
+			}
+		}
+	}
+
+	// Complete against the shell's environment unless the line picks one itself.
+	at := len(words) - 1
+	if at > 0 && strings.HasPrefix(words[at-1], "-") && !strings.Contains(words[at-1], "=") {
+		at--
+	}
+	if c, _, err := rootCmd.Find(words[:at]); err == nil && c.Flags().Lookup("env") != nil && !containsFlag(words, "env") {
+		words = append(append(append([]string{}, words[:at]...), "--env", session.Env()), words[at:]...)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, words...))
+	_ = rootCmd.Execute()
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	resetFlags(rootCmd)
+
+	for _, l := range strings.Split(buf.String(), "\n") {
+		if l == "" || strings.HasPrefix(l, ":") {
+			break
+		}
+		value, _, _ := strings.Cut(l, "\t")
+		candidates = append(candidates, value)
+	}
+
+	for i, c := range candidates {
+		candidates[i] = prefix + c
+	}
+	return candidates
+}
+
+// resetFlags restores every flag below c to its default value, so that one command run in the
+// shell does not leak --id, --data or --query into the next one.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, child := range c.Commands() {
+		resetFlags(child)
+	}
+}
+
+// containsFlag reports whether words set the flag called name.
+func containsFlag(words []string, name string) bool {
+	for _, w := range words {
+		if w == "--"+name || strings.HasPrefix(w, "--"+name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// captureOutput runs fn and returns what it printed, without colors.
+func captureOutput(fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		fn()
+		return ""
+	}
+	stdout, colorOutput, noColor := os.Stdout, color.Output, color.NoColor
+	os.Stdout, color.Output, color.NoColor = w, w, true
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+
+	fn()
+
+	_ = w.Close()
+	os.Stdout, color.Output, color.NoColor = stdout, colorOutput, noColor
+	return <-done
+}
+
+// formatValue renders a variable value for use on a command line. Objects expand to their
+// ID and lists to a comma separated list of IDs.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return formatScalar(v["id"])
+	case []interface{}:
+		var ids []string
+		for _, item := range v {
+			ids = append(ids, formatValue(item))
+		}
+		return strings.Join(ids, ",")
+	default:
+		return formatScalar(v)
+	}
+}
+
+// formatScalar renders JSON numbers without a trailing ".0".
+func formatScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// describeValue summarises a variable for the vars listing.
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return fmt.Sprintf("object %s (ID %s)", formatScalar(v["display"]), formatScalar(v["id"]))
+	case []interface{}:
+		return fmt.Sprintf("list of %d objects", len(v))
+	case string:
+		if first, _, more := strings.Cut(v, "\n"); more {
+			return fmt.Sprintf("%q ...", first)
+		}
+		return fmt.Sprintf("%q", v)
+	default:
+		return formatScalar(v)
+	}
+}
+
+func shellHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".abc-netbox.cli_history"
+	}
+	return filepath.Join(home, ".abc-netbox.cli", "shell_history")
+}
+
+func init() {
+
+	// Here you will define your flags and configuration settings.
+	ShellCmd.Annotations = map[string]string{ownSignalsAnnotation: ""}
+	ShellCmd.Flags().StringVarP(&shellEnv, "env", "", "development", "Environment ('development' or 'production')")
+	err := ShellCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for ShellCmd", err)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+// TestShellCarriesOnAfterError runs a failing command in the shell, then commands that need the
+// state the shell kept: its variables and the flags of the next command.
+func TestShellCarriesOnAfterError(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	result := run(t, srv, netboxtest.Options{Stdin: strings.Join([]string{
+		"$site = get site slug=nyc1",
+		"dcim devices get nyc9-leaf1",
+		"dcim sites get $site",
+		"exit",
+	}, "\n")}, "shell", "--env", "development")
+	if result.ExitCode != 0 {
+		t.Fatalf("the shell exited with status %d:\n%s", result.ExitCode, result)
+	}
+	if !strings.Contains(result.Stderr, "Error: no object found on Netbox server for: name=nyc9-leaf1") {
+		t.Errorf("the failing command reported no error:\n%s", result)
+	}
+	if !strings.Contains(result.Stdout, "/api/dcim/sites/1/") {
+		t.Errorf("the command after the error did not run:\n%s", result)
+	}
+}
+
+// TestShellCarriesOnAfterTimeout stops a command of the shell with --timeout: the shell reports
+// what the command did and runs the next one.
+func TestShellCarriesOnAfterTimeout(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	srv.Delay = time.Second
+	result := run(t, srv, netboxtest.Options{Stdin: strings.Join([]string{
+		`dcim sites create --timeout 200ms --data '{"name": "SFO1", "slug": "sfo1"}'`,
+		"dcim sites get 1",
+		"exit",
+	}, "\n")}, "shell", "--env", "development")
+	if result.ExitCode != 0 {
+		t.Fatalf("the shell exited with status %d:\n%s", result.ExitCode, result)
+	}
+	if !strings.Contains(result.Stderr, "Stopped, timed out after 200ms") {
+		t.Errorf("the stopped command was not reported:\n%s", result)
+	}
+	if !strings.Contains(result.Stdout, "ABC Site: NYC1") {
+		t.Errorf("the command after the timeout did not run:\n%s", result)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var statusEnv string
+
+var statusOutput string
+
+// StatusCmd represents the status command
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the versions, plugins and workers of a Netbox server",
+	Long: `
+ABC Netbox Automation Tools:
+  Show the Netbox, Django and Python versions, the installed plugins and the number of running
+  background workers reported by /api/status/, and whether the Netbox release is one the
+  commands support (` + session.MinVersion + ` to ` + session.MaxVersion + `).
+
+  The version of each server is also detected on first use by every command and remembered for
+  a day; commands warn when it is outside the supported range.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statusOutput == "" {
+			statusOutput = output.Default()
+		}
+		rootURL, err := session.RootURL(statusEnv)
+		if err != nil {
+			return fmt.Errorf("unrecognized environment: %s", statusEnv)
+		}
+		if err := session.CheckSSL(rootURL); err != nil {
+			return fmt.Errorf("checking SSL certificate: %s", err)
+		}
+		status, err := session.FetchStatus(rootURL)
+		if err != nil {
+			return fmt.Errorf("getting Netbox status: %s", err)
+		}
+
+		if statusOutput != output.Text {
+			if err := output.Render(os.Stdout, statusObject(rootURL, status), statusOutput); err != nil {
+				return fmt.Errorf("printing status: %s", err)
+			}
+			return nil
+		}
+
+		color.Cyan("\n  Netbox status for " + color.YellowString("%s", rootURL))
+		version := status.NetboxVersion
+		if status.NetboxFullVersion != "" {
+			version = status.NetboxFullVersion
+		}
+		if warning := session.VersionWarning(status.NetboxVersion); warning != "" {
+			color.Cyan("\tNetbox version: " + color.YellowString("%s ", version) + color.RedString("(%s)", warning))
+		} else {
+			color.Cyan("\tNetbox version: " + color.YellowString("%s ", version) + color.GreenString("(supported)"))
+		}
+		color.Cyan("\tDjango version: " + color.YellowString("%s", status.DjangoVersion))
+		color.Cyan("\tPython version: " + color.YellowString("%s", status.PythonVersion))
+		if status.RQWorkersRunning > 0 {
+			color.Cyan("\tRQ workers running: " + color.YellowString("%d", status.RQWorkersRunning))
+		} else {
+			color.Cyan("\tRQ workers running: " + color.RedString("0 (background jobs will not run)"))
+		}
+		if len(status.Plugins) == 0 {
+			color.Cyan("\tPlugins: " + color.YellowString("none"))
+			return nil
+		}
+		color.Cyan("\tPlugins:")
+		for _, name := range sortedStrings(status.Plugins) {
+			color.Cyan("\t  %s: "+color.YellowString("%s", status.Plugins[name]), name)
+		}
+		return nil
+	},
+}
+
+// statusObject returns status with the server URL and support verdict, for the JSON and YAML output.
+func statusObject(rootURL string, status *session.Status) *output.Object {
+	supported := session.VersionWarning(status.NetboxVersion) == ""
+	o := &output.Object{Values: map[string]interface{}{
+		"url":                rootURL,
+		"netbox-version":     status.NetboxVersion,
+		"supported":          supported,
+		"django-version":     status.DjangoVersion,
+		"python-version":     status.PythonVersion,
+		"rq-workers-running": status.RQWorkersRunning,
+		"plugins":            stringMap(status.Plugins),
+	}}
+	o.Keys = []string{"url", "netbox-version", "supported", "django-version", "python-version", "rq-workers-running", "plugins"}
+	if status.NetboxFullVersion != "" {
+		o.Keys = append(o.Keys[:2], append([]string{"netbox-full-version"}, o.Keys[2:]...)...)
+		o.Values["netbox-full-version"] = status.NetboxFullVersion
+	}
+	return o
+}
+
+func stringMap(m map[string]string) *output.Object {
+	o := &output.Object{Keys: sortedStrings(m), Values: map[string]interface{}{}}
+	for key, value := range m {
+		o.Values[key] = value
+	}
+	return o
+}
+
+func sortedStrings(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	StatusCmd.Flags().StringVarP(&statusEnv, "env", "", "development", "Environment ('development' or 'production')")
+	err := StatusCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for StatusCmd", err)
+	}
+	StatusCmd.Flags().StringVarP(&statusOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	_ = StatusCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		args    []string
+	}{
+		{
+			name: "text",
+			args: []string{"status", "--env", "production"},
+		},
+		{
+			name: "json",
+			args: []string{"status", "--env", "production", "-o", "json"},
+		},
+		{
+			name:    "unsupported",
+			version: "3.4.10",
+			args:    []string{"status", "--env", "production"},
+		},
+		{
+			name:    "command_warning",
+			version: "4.3.0",
+			args:    []string{"dcim", "sites", "get", "1", "--env", "production"},
+		},
+	}
+
+	fixtures := netboxtest.DefaultFixtures()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, fixtures)
+			if tt.version != "" {
+				srv.Version = tt.version
+			}
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "status/"+tt.name, result.String())
+		})
+	}
+}
+
+func TestVersionDetectedOnce(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	result := run(t, srv, netboxtest.Options{}, "dcim", "devices", "list", "--env", "production")
+	if result.ExitCode != 0 {
+		t.Fatalf("listing devices failed:\n%s", result)
+	}
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "/api/status/" {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("status requested %d times, want 1", n)
+	}
+	if strings.Contains(result.Stderr, "Warning") {
+		t.Errorf("warning for a supported version:\n%s", result.Stderr)
+	}
+}
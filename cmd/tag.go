@@ -0,0 +1,363 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/refs"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// tagsKey is the config key of the extras tags endpoint.
+const tagsKey = "cmd.extras.extras_api_url.tags"
+
+// tagBatchSize is the number of objects changed by one bulk PATCH.
+const tagBatchSize = 100
+
+var tagEnv string
+
+var tagNames []string
+
+var tagCreate bool
+
+var tagDryRun bool
+
+var tagObjects objectSelector
+
+// TagCmd represents the tag command
+var TagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add, remove or replace the tags of Netbox objects",
+	Long: `
+ABC Netbox Automation Tools:
+  Add, remove or replace the tags of any kind of Netbox object, for many objects at once:
+
+    abc-netbox.cli tag add dcim devices nyc1-leaf1 nyc1-leaf2 --tags core,monitored --env production
+    abc-netbox.cli tag remove dcim interfaces --filter device=nyc1-leaf1 --tags legacy --env production
+    abc-netbox.cli tag set circuits circuits --id 4,7 --tags carrier-a --env production
+
+  The current tags of each object are read first, so add and remove keep the other tags. Tags are
+  given by slug or name; --create creates those missing from Netbox. The changes are sent in bulk.`,
+}
+
+// tagOperation changes the tags of an object: current holds the tag IDs it has, wanted the tags given.
+type tagOperation func(current []int64, wanted []int64) []int64
+
+var tagOperations = map[string]struct {
+	short string
+	apply tagOperation
+}{
+	"add": {"Add tags to objects, keeping their other tags", func(current, wanted []int64) []int64 {
+		return appendMissing(current, wanted)
+	}},
+	"remove": {"Remove tags from objects, keeping their other tags", func(current, wanted []int64) []int64 {
+		var kept []int64
+		for _, id := range current {
+			if !containsID(wanted, id) {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}},
+	"set": {"Replace the tags of objects", func(current, wanted []int64) []int64 {
+		return appendMissing(nil, wanted)
+	}},
+}
+
+// tagSubcommand returns the tag subcommand running operation.
+func tagSubcommand(name string) *cobra.Command {
+	operation := tagOperations[name]
+	c := &cobra.Command{
+		Use:   name + " <domain> <resource> [id|name]...",
+		Short: operation.short,
+		Long: `
+ABC Netbox Automation Tools:
+  ` + operation.short + `. The objects are given by ID or name as arguments,
+  with --id, or with --filter to select every object matching Netbox filters.`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeResource,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runTag(cmd.Context(), name, args[0], args[1], args[2:]); err != nil {
+				return fmt.Errorf("changing tags: %s", err)
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVarP(&tagEnv, "env", "", "development", "Environment ('development' or 'production')")
+	_ = c.MarkFlagRequired("env")
+	c.Flags().StringSliceVar(&tagNames, "tags", nil, "Tags by slug or name, e.g. core,monitored")
+	_ = c.MarkFlagRequired("tags")
+	c.Flags().BoolVar(&tagCreate, "create", false, "Create the tags missing from Netbox")
+	c.Flags().BoolVar(&tagDryRun, "dry-run", false, "Print the changes instead of sending them")
+	tagObjects.addFlags(c)
+	tagObjects.addConfirmFlag(c)
+	return c
+}
+
+// runTag applies the operation called name to the tags of the selected objects of a resource.
+func runTag(ctx context.Context, name string, domain string, resource string, names []string) error {
+	operation := tagOperations[name].apply
+	if !tagObjects.selected(names) {
+		return fmt.Errorf("no objects selected: give IDs or names, --id or --filter")
+	}
+	endpoint, err := lookupEndpoint(domain, resource)
+	if err != nil {
+		return err
+	}
+	rootURL, err := session.RootURL(tagEnv)
+	if err != nil {
+		return fmt.Errorf("unrecognized environment: %s", tagEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+
+	// Removing a tag Netbox does not have changes nothing.
+	tags, err := resolveTags(ctx, rootURL, tagNames, tagCreate && !tagDryRun, name == "remove")
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		color.Green("\n  No tags changed.")
+		return nil
+	}
+	wanted := make([]int64, len(tags))
+	for i, tag := range tags {
+		wanted[i] = tag.id
+	}
+	objects, err := tagObjects.objects(ctx, tagEnv, rootURL, endpoint, names)
+	if err != nil {
+		return err
+	}
+
+	tagNamesByID := map[int64]string{}
+	for _, tag := range tags {
+		tagNamesByID[tag.id] = tag.name
+	}
+	var changes []interface{}
+	for _, o := range objects {
+		current := objectTags(o, tagNamesByID)
+		updated := operation(current, wanted)
+		if sameIDs(current, updated) {
+			color.Cyan("  %s: "+color.YellowString("%s", tagList(current, tagNamesByID))+color.GreenString(" (unchanged)"), objectName(o))
+			continue
+		}
+		color.Cyan("  %s: "+color.YellowString("%s", tagList(updated, tagNamesByID)), objectName(o))
+		references := make([]interface{}, len(updated))
+		for i, id := range updated {
+			references[i] = tagReference(id, tagNamesByID)
+		}
+		changes = append(changes, &output.Object{
+			Keys:   []string{"id", "tags"},
+			Values: map[string]interface{}{"id": o.Get("id"), "tags": references},
+		})
+	}
+
+	if len(changes) == 0 {
+		color.Green("\n  No tags changed on %d %s.", len(objects), endpoint.resource)
+		return nil
+	}
+	if tagDryRun {
+		payload, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		color.Cyan("\n  Dry run, not sending: " + color.YellowString("PATCH %s%s", rootURL, endpoint.path))
+		fmt.Println(refs.Indent(string(payload)))
+		return nil
+	}
+	for start := 0; start < len(changes); start += tagBatchSize {
+		end := start + tagBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		if _, err := sendJSON(ctx, tagEnv, "PATCH", rootURL+endpoint.path, changes[start:end]); err != nil {
+			return err
+		}
+	}
+	color.Green("\n  Changed the tags of %d of %d %s.", len(changes), len(objects), endpoint.resource)
+	return nil
+}
+
+// tag is a tag of the Netbox server. Tags that --dry-run would create have no ID; they are
+// numbered from -1 down.
+type tag struct {
+	id   int64
+	name string
+	slug string
+}
+
+// resolveTags returns the tags named by slug or name in names, creating the missing ones if create
+// is set. Missing tags are an error unless create is set, --dry-run is given with --create or
+// skipMissing is set, which leaves them out.
+func resolveTags(ctx context.Context, rootURL string, names []string, create bool, skipMissing bool) ([]tag, error) {
+	config, err := session.Config()
+	if err != nil {
+		return nil, err
+	}
+	path := strings.SplitN(config.GetString(tagsKey), "?", 2)[0]
+	if path == "" {
+		return nil, fmt.Errorf("no endpoint configured for %s", tagsKey)
+	}
+
+	var tags []tag
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found, err := findTag(ctx, rootURL, path, name)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case found != nil:
+			tags = append(tags, *found)
+		case create:
+			created, err := sendJSON(ctx, tagEnv, "POST", rootURL+path, map[string]string{"name": name, "slug": refs.Slugify(name)})
+			if err != nil {
+				return nil, fmt.Errorf("creating tag %q: %s", name, err)
+			}
+			t := tagFromObject(created)
+			color.Green("  Created tag %s (ID %d)", t.name, t.id)
+			tags = append(tags, t)
+		case skipMissing:
+			color.Cyan("  No tag has slug or name " + color.YellowString("%s", name) + color.CyanString(", nothing to remove"))
+		case tagCreate && tagDryRun:
+			color.Cyan("  Would create tag " + color.YellowString("%s", name))
+			tags = append(tags, tag{id: -int64(len(tags) + 1), name: name, slug: refs.Slugify(name)})
+		default:
+			return nil, fmt.Errorf("no tag has slug or name %q, use --create to create it", name)
+		}
+	}
+	return tags, nil
+}
+
+// findTag looks a tag up by slug, then by name. It returns nil if there is none.
+func findTag(ctx context.Context, rootURL string, path string, name string) (*tag, error) {
+	for _, field := range []string{"slug", "name"} {
+		found, err := listObjects(ctx, tagEnv, rootURL+path, url.Values{field: {name}})
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 0 {
+			t := tagFromObject(found[0])
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func tagFromObject(v interface{}) tag {
+	o, _ := v.(*output.Object)
+	if o == nil {
+		return tag{}
+	}
+	t := tag{}
+	t.id, _ = toInt64(o.Get("id"))
+	t.name, _ = o.Get("name").(string)
+	t.slug, _ = o.Get("slug").(string)
+	return t
+}
+
+// objectTags returns the IDs of the tags of o, adding their names to names.
+func objectTags(o *output.Object, names map[int64]string) []int64 {
+	list, _ := o.Get("tags").([]interface{})
+	var ids []int64
+	for _, item := range list {
+		t := tagFromObject(item)
+		if t.id == 0 {
+			continue
+		}
+		if _, ok := names[t.id]; !ok {
+			names[t.id] = t.name
+		}
+		ids = append(ids, t.id)
+	}
+	return ids
+}
+
+// tagReference is how a tag is sent in the tags of an object: by ID, or by name when --dry-run
+// stands in for a tag it would create.
+func tagReference(id int64, names map[int64]string) *output.Object {
+	if id < 0 {
+		return &output.Object{Keys: []string{"name"}, Values: map[string]interface{}{"name": names[id]}}
+	}
+	return &output.Object{Keys: []string{"id"}, Values: map[string]interface{}{"id": id}}
+}
+
+func tagList(ids []int64, names map[int64]string) string {
+	if len(ids) == 0 {
+		return "no tags"
+	}
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = names[id]
+	}
+	return strings.Join(list, ", ")
+}
+
+func appendMissing(ids []int64, more []int64) []int64 {
+	for _, id := range more {
+		if !containsID(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sameIDs reports whether a and b hold the same IDs, in any order.
+func sameIDs(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !containsID(b, id) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	for _, name := range []string{"add", "remove", "set"} {
+		TagCmd.AddCommand(tagSubcommand(name))
+	}
+}
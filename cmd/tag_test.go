@@ -0,0 +1,158 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestTag(t *testing.T) {
+	// sentPatch checks the body of the bulk PATCH sent to the devices endpoint.
+	sentPatch := func(want string) func(t *testing.T, srv *netboxtest.Server) {
+		return func(t *testing.T, srv *netboxtest.Server) {
+			var patches []string
+			for _, r := range srv.Requests() {
+				if r.Method == "PATCH" {
+					patches = append(patches, r.Body)
+				}
+			}
+			if len(patches) != 1 || patches[0] != want {
+				t.Errorf("sent PATCH %q, want %s", patches, want)
+			}
+		}
+	}
+	noWrites := func(t *testing.T, srv *netboxtest.Server) {
+		for _, r := range srv.Requests() {
+			if r.Method != "GET" {
+				t.Errorf("sent %s %s", r.Method, r.Path)
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, srv *netboxtest.Server)
+	}{
+		{
+			name:  "add",
+			args:  []string{"tag", "add", "dcim", "devices", "nyc1-leaf1", "2", "--tags", "core,Monitored", "--env", "development"},
+			check: sentPatch(`[{"id":1,"tags":[{"id":3},{"id":1},{"id":2}]},{"id":2,"tags":[{"id":1},{"id":2}]}]`),
+		},
+		{
+			name:  "remove_by_filter",
+			args:  []string{"tag", "remove", "dcim", "devices", "--filter", "site=nyc1", "--tags", "legacy", "--env", "development"},
+			check: sentPatch(`[{"id":1,"tags":[{"id":1}]}]`),
+		},
+		{
+			name:  "unchanged",
+			args:  []string{"tag", "add", "dcim", "devices", "--id", "1", "--tags", "legacy", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "set_dry_run_create",
+			args:  []string{"tag", "set", "dcim", "devices", "nyc1-leaf1", "--tags", "core,Edge Routers", "--create", "--dry-run", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name: "create",
+			args: []string{"tag", "add", "dcim", "devices", "--id", "5", "--tags", "Edge Routers", "--create", "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				tags := srv.Objects("/api/extras/tags/")
+				if len(tags) != 4 || tags[3]["slug"] != "edge-routers" {
+					t.Errorf("tags after --create: %v", tags)
+				}
+				sentPatch(`[{"id":5,"tags":[{"id":4}]}]`)(t, srv)
+			},
+		},
+		{
+			name:  "missing_tag",
+			args:  []string{"tag", "add", "dcim", "devices", "nyc1-leaf1", "--tags", "edge", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "remove_missing_tag",
+			args:  []string{"tag", "remove", "dcim", "devices", "nyc1-leaf1", "--tags", "edge", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			// Netbox ignores the unknown key, so the filter selects all nine power ports.
+			name:  "filter_needs_yes",
+			args:  []string{"tag", "add", "dcim", "power-ports", "--filter", "sitee=nyc1", "--tags", "core", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name: "filter_yes",
+			args: []string{"tag", "add", "dcim", "power-ports", "--filter", "sitee=nyc1", "--tags", "core", "--yes", "--env", "development"},
+			check: func(t *testing.T, srv *netboxtest.Server) {
+				var patches int
+				for _, r := range srv.Requests() {
+					if r.Method == "PATCH" {
+						patches++
+					}
+				}
+				if patches != 1 {
+					t.Errorf("sent %d PATCH requests, want 1", patches)
+				}
+			},
+		},
+		{
+			// Cables have no name, so the name must not select them.
+			name:  "cable_by_name",
+			args:  []string{"tag", "add", "dcim", "cables", "nyc1-leaf1", "--tags", "core", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "no_objects",
+			args:  []string{"tag", "add", "dcim", "devices", "--tags", "core", "--env", "development"},
+			check: noWrites,
+		},
+		{
+			name:  "unknown_resource",
+			args:  []string{"tag", "add", "dcim", "routers", "r1", "--tags", "core", "--env", "development"},
+			check: noWrites,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fixtures := netboxtest.DefaultFixtures()
+			// nyc1-leaf1 is tagged legacy and core.
+			fixtures["/api/dcim/devices/"].Results[0]["tags"] = []interface{}{
+				map[string]interface{}{"id": json.Number("3"), "url": netboxtest.BaseURL + "/api/extras/tags/3/", "display": "Legacy", "name": "Legacy", "slug": "legacy", "color": "9e9e9e"},
+				map[string]interface{}{"id": json.Number("1"), "url": netboxtest.BaseURL + "/api/extras/tags/1/", "display": "Core", "name": "Core", "slug": "core", "color": "2196f3"},
+			}
+			srv := netboxtest.NewServer(t, fixtures)
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "tag/"+tt.name, result.String())
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
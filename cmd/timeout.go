@@ -0,0 +1,104 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// ownSignalsAnnotation marks the commands that handle SIGINT and SIGTERM themselves: long-running
+// and interactive commands, and plugins, which receive the signals of the terminal directly.
+const ownSignalsAnnotation = "own-signals"
+
+var commandTimeout time.Duration
+
+// addTimeoutFlag adds --timeout to every command below root, and stops commands on SIGINT and
+// SIGTERM. A stopped command ends with a *session.Stopped, which reportError prints as a report
+// of the changes the command made.
+func addTimeoutFlag(root *cobra.Command) {
+	root.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Stop the command if it runs longer than this, e.g. 30s or 5m (default no limit)")
+
+	next := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		_, ownSignals := cmd.Annotations[ownSignalsAnnotation]
+		cmd.SetContext(session.StartCommand(commandTimeout, !ownSignals))
+		if next != nil {
+			return next(cmd, args)
+		}
+		return nil
+	}
+}
+
+// reportStopped tells what a stopped command did.
+func reportStopped(stopped *session.Stopped) {
+	cause, report := stopped.Cause, stopped.Report
+	w := color.Error
+	_, _ = fmt.Fprintln(w, color.RedString("\n  Stopped, %s, after %d completed requests.", cause, report.Completed))
+
+	listRequests := func(title string, requests []session.Request) {
+		if len(requests) == 0 {
+			return
+		}
+		_, _ = fmt.Fprintln(w, color.CyanString("  %s:", title))
+		for _, r := range requests {
+			line := r.Method + " " + r.URL
+			if r.Status != 0 {
+				line += fmt.Sprintf(" (%d)", r.Status)
+			}
+			if r.Objects > 1 {
+				line += fmt.Sprintf(", %d objects", r.Objects)
+			}
+			_, _ = fmt.Fprintln(w, color.YellowString("    %s", line))
+		}
+	}
+	listRequests("Applied", report.Applied)
+	listRequests("Rejected by Netbox", report.Rejected)
+	listRequests("Sent but not answered, check whether Netbox applied them", report.InFlight)
+	listRequests("Not sent", report.NotSent)
+	if len(report.Applied) == 0 && len(report.InFlight) == 0 {
+		_, _ = fmt.Fprintln(w, color.CyanString("  No changes were made."))
+	}
+}
+
+// stoppedExitCode is the exit status of a stopped command: 124 on timeout, like timeout(1), and
+// 128 plus the signal number on a signal, like a shell.
+func stoppedExitCode(stopped *session.Stopped) int {
+	var interrupted *session.Interrupted
+	switch {
+	case errors.As(stopped.Cause, &interrupted):
+		if sig, ok := interrupted.Signal.(syscall.Signal); ok {
+			return 128 + int(sig)
+		}
+		return 130
+	case errors.Is(stopped.Cause, session.ErrTimeout):
+		return 124
+	}
+	return 1
+}
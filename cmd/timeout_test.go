@@ -0,0 +1,62 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestTimeout(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	srv.Delay = 5 * time.Second
+	start := time.Now()
+	result := run(t, srv, netboxtest.Options{}, "dcim", "sites", "create", "--env", "development", "--timeout", "300ms",
+		"--data", `[{"name": "SFO1", "slug": "sfo1"}, {"name": "SEA1", "slug": "sea1"}]`)
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("the command ran for %s", elapsed)
+	}
+	if result.ExitCode != 124 {
+		t.Errorf("exit status %d, want 124", result.ExitCode)
+	}
+	for _, want := range []string{
+		"Stopped, timed out after 300ms",
+		"Sent but not answered",
+		"POST " + netboxtest.BaseURL + "/api/dcim/sites/, 2 objects",
+	} {
+		if !strings.Contains(result.Stderr, want) {
+			t.Errorf("stderr has no %q:\n%s", want, result)
+		}
+	}
+}
+
+func TestTimeoutNotReached(t *testing.T) {
+	srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+	result := run(t, srv, netboxtest.Options{}, "dcim", "sites", "get", "1", "--env", "development", "--timeout", "1m")
+	if result.ExitCode != 0 || strings.Contains(result.Stderr, "Stopped") {
+		t.Errorf("the command was stopped:\n%s", result)
+	}
+}
@@ -0,0 +1,453 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/output"
+	"github.com/decassidy/abc-netbox-cli/cmd/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// traceResources are the resources trace follows the cable path of, by domain and API action:
+// path endpoints have their path traced by /trace/, pass-through ports list the paths running
+// through them with /paths/.
+var traceResources = map[string]struct{ domain, action string }{
+	"interfaces":           {"dcim", "trace"},
+	"console-ports":        {"dcim", "trace"},
+	"console-server-ports": {"dcim", "trace"},
+	"power-ports":          {"dcim", "trace"},
+	"power-outlets":        {"dcim", "trace"},
+	"power-feeds":          {"dcim", "trace"},
+	"front-ports":          {"dcim", "paths"},
+	"rear-ports":           {"dcim", "paths"},
+	"circuit-terminations": {"circuits", "paths"},
+}
+
+var traceEnv string
+
+var traceDevice string
+
+var traceOutput string
+
+// TraceCmd represents the trace command
+var TraceCmd = &cobra.Command{
+	Use:   "trace <resource> <id|name>",
+	Short: "Trace the cable path of a port or circuit termination",
+	Long: `
+ABC Netbox Automation Tools:
+  Follow the cables from a port through patch panels and circuits, hop by hop, as Netbox traces
+  them:
+
+    abc-netbox.cli trace interfaces eth0 --device nyc1-srv12 --env production
+    abc-netbox.cli trace rear-ports 88 --env production -o json
+    abc-netbox.cli trace circuit-terminations ZYO-100234:A --env production
+
+  Each line is one cable, with the ports at both ends as device:port and the cable as
+  [label type length]. Interfaces, console ports, console server ports, power ports, power outlets
+  and power feeds are traced to the end of their path; for front ports, rear ports and circuit
+  terminations every path running through them is shown. Ports are given by ID, or by name with
+  --device; circuit terminations by ID, or as circuit:side.`,
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: traceResourceNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runTrace(cmd.Context(), args[0], args[1]); err != nil {
+			return fmt.Errorf("tracing: %s", err)
+		}
+		return nil
+	},
+}
+
+func traceResourceNames() []string {
+	var names []string
+	for name := range traceResources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cablePath is a path of cables from an origin port, one segment per cable.
+type cablePath struct {
+	segments []cableSegment
+	complete bool
+}
+
+// cableSegment is a cable and the terminations at its ends. The far end of the last segment of
+// an incomplete path may be empty, as may its cable.
+type cableSegment struct {
+	near  []*output.Object
+	cable *output.Object
+	far   []*output.Object
+}
+
+func runTrace(ctx context.Context, resource string, ref string) error {
+	if traceOutput == "" {
+		traceOutput = output.Default()
+	}
+	traced, ok := traceResources[resource]
+	if !ok {
+		return fmt.Errorf("cannot trace %s, use one of %s", resource, strings.Join(traceResourceNames(), ", "))
+	}
+	endpoint, err := lookupEndpoint(traced.domain, resource)
+	if err != nil {
+		return err
+	}
+	rootURL, err := session.RootURL(traceEnv)
+	if err != nil {
+		return fmt.Errorf("unrecognized environment: %s", traceEnv)
+	}
+	if err := session.CheckSSL(rootURL); err != nil {
+		return fmt.Errorf("checking %s: %s", rootURL, err)
+	}
+
+	port, err := tracedPort(ctx, rootURL, endpoint, ref)
+	if err != nil {
+		return err
+	}
+	response, _, err := getJSON(ctx, traceEnv, fmt.Sprintf("%s%s%v/%s/", rootURL, endpoint.path, port.Get("id"), traced.action))
+	if err != nil {
+		return err
+	}
+	var paths []cablePath
+	if traced.action == "trace" {
+		if path, ok := traceToPath(response); ok {
+			paths = append(paths, path)
+		}
+	} else {
+		paths = pathsToPaths(response)
+	}
+
+	if traceOutput != output.Text {
+		list := make([]interface{}, len(paths))
+		for i, path := range paths {
+			list[i] = path.object()
+		}
+		return output.Render(os.Stdout, list, traceOutput)
+	}
+	if len(paths) == 0 {
+		color.Yellow("  %s is not connected.", terminationName(port))
+		return nil
+	}
+	color.Cyan("  Cable trace of " + color.YellowString("%s", terminationName(port)))
+	for i, path := range paths {
+		if len(paths) > 1 {
+			color.Cyan("\n  Path %d of %d", i+1, len(paths))
+		}
+		fmt.Println()
+		path.render()
+	}
+	return nil
+}
+
+// tracedPort returns the port given by ID, by name and --device, or for a circuit termination,
+// which has no name, as circuit:side.
+func tracedPort(ctx context.Context, rootURL string, endpoint *objectEndpoint, ref string) (*output.Object, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		object, status, err := getJSON(ctx, traceEnv, fmt.Sprintf("%s%s%d/", rootURL, endpoint.path, id))
+		if status == 404 {
+			return nil, fmt.Errorf("no %s has ID %d", endpoint.resource, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		port, ok := object.(*output.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response for %s %d", endpoint.resource, id)
+		}
+		return port, nil
+	}
+	if endpoint.resource == "circuit-terminations" {
+		return tracedCircuitTermination(ctx, rootURL, endpoint, ref)
+	}
+	filters := url.Values{"name": {ref}}
+	if traceDevice != "" {
+		filters.Set("device", traceDevice)
+	}
+	found, err := listObjects(ctx, traceEnv, rootURL+endpoint.path, filters)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(found) == 0 && traceDevice != "":
+		return nil, fmt.Errorf("%s has no %s named %q", traceDevice, endpoint.resource, ref)
+	case len(found) == 0:
+		return nil, fmt.Errorf("no %s is named %q", endpoint.resource, ref)
+	case len(found) > 1:
+		return nil, fmt.Errorf("%d %s match name %q, narrow it down with --device or give the ID", len(found), endpoint.resource, ref)
+	}
+	port, ok := found[0].(*output.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response for %s %q", endpoint.resource, ref)
+	}
+	return port, nil
+}
+
+// tracedCircuitTermination returns the circuit termination given as circuit:side, e.g. ZYO-100234:A.
+func tracedCircuitTermination(ctx context.Context, rootURL string, endpoint *objectEndpoint, ref string) (*output.Object, error) {
+	if traceDevice != "" {
+		return nil, fmt.Errorf("--device does not apply to circuit terminations, give them as circuit:side")
+	}
+	var filters url.Values
+	if cid, side, ok := strings.Cut(ref, ":"); ok {
+		var err error
+		if filters, err = circuitTerminationFilters(ctx, traceEnv, rootURL, cid, side); err != nil {
+			return nil, err
+		}
+	}
+	if filters == nil {
+		return nil, fmt.Errorf("no circuit termination is %q, give it by ID or as circuit:side, e.g. ZYO-100234:A", ref)
+	}
+	found, err := listObjects(ctx, traceEnv, rootURL+endpoint.path, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("%d circuit terminations match %s, give the ID", len(found), ref)
+	}
+	termination, ok := found[0].(*output.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response for %s %q", endpoint.resource, ref)
+	}
+	return termination, nil
+}
+
+// traceToPath reads the response of /trace/, a list of [near ends, cable, far ends] segments.
+func traceToPath(response interface{}) (cablePath, bool) {
+	steps, _ := response.([]interface{})
+	var path cablePath
+	for _, step := range steps {
+		parts, ok := step.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		cable, _ := parts[1].(*output.Object)
+		path.segments = append(path.segments, cableSegment{near: objectList(parts[0]), cable: cable, far: objectList(parts[2])})
+	}
+	if len(path.segments) == 0 {
+		return path, false
+	}
+	last := path.segments[len(path.segments)-1]
+	path.complete = last.cable != nil && len(last.far) > 0
+	return path, true
+}
+
+// pathsToPaths reads the response of /paths/, a list of cable paths whose path is a list of steps
+// alternating terminations, cables and terminations, which Netbox groups by three into segments.
+func pathsToPaths(response interface{}) []cablePath {
+	items, _ := response.([]interface{})
+	var paths []cablePath
+	for _, item := range items {
+		p, ok := item.(*output.Object)
+		if !ok {
+			continue
+		}
+		steps, _ := p.Get("path").([]interface{})
+		path := cablePath{complete: p.Get("is_complete") == true}
+		for i := 0; i < len(steps); i += 3 {
+			segment := cableSegment{near: objectList(steps[i])}
+			if i+1 < len(steps) {
+				if cables := objectList(steps[i+1]); len(cables) > 0 {
+					segment.cable = cables[0]
+				}
+			}
+			if i+2 < len(steps) {
+				segment.far = objectList(steps[i+2])
+			}
+			path.segments = append(path.segments, segment)
+		}
+		if len(path.segments) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func objectList(v interface{}) []*output.Object {
+	items, _ := v.([]interface{})
+	var objects []*output.Object
+	for _, item := range items {
+		if o, ok := item.(*output.Object); ok {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// render prints the path one cable per line, as device:port ─[cable]─ device:port.
+func (p cablePath) render() {
+	cables := 0
+	for _, segment := range p.segments {
+		line := color.YellowString("%s", terminationNames(segment.near))
+		switch {
+		case segment.cable != nil:
+			cables++
+			line += color.CyanString(" ─[%s]─ ", cableSummary(segment.cable))
+			if len(segment.far) > 0 {
+				line += color.YellowString("%s", terminationNames(segment.far))
+			} else {
+				line += color.RedString("?")
+			}
+		case len(segment.far) > 0:
+			line += color.CyanString(" ─── ") + color.YellowString("%s", terminationNames(segment.far))
+		default:
+			line += color.RedString(" ─ not connected")
+		}
+		fmt.Println("  " + line)
+	}
+	noun := "cables"
+	if cables == 1 {
+		noun = "cable"
+	}
+	if p.complete {
+		color.Green("\n  Path complete: %d %s", cables, noun)
+	} else {
+		color.Yellow("\n  Path incomplete: %d %s", cables, noun)
+	}
+}
+
+// object returns the path for the structured output formats, with the terminations and cables
+// as Netbox returned them.
+func (p cablePath) object() *output.Object {
+	segments := make([]interface{}, len(p.segments))
+	for i, segment := range p.segments {
+		var cable interface{}
+		if segment.cable != nil {
+			cable = segment.cable
+		}
+		segments[i] = &output.Object{
+			Keys:   []string{"near", "cable", "far"},
+			Values: map[string]interface{}{"near": objectsToList(segment.near), "cable": cable, "far": objectsToList(segment.far)},
+		}
+	}
+	origin, destination := "", ""
+	if len(p.segments) > 0 {
+		origin = terminationNames(p.segments[0].near)
+		destination = terminationNames(p.segments[len(p.segments)-1].far)
+	}
+	return &output.Object{
+		Keys: []string{"origin", "destination", "complete", "segments"},
+		Values: map[string]interface{}{
+			"origin":      origin,
+			"destination": destination,
+			"complete":    p.complete,
+			"segments":    segments,
+		},
+	}
+}
+
+func objectsToList(objects []*output.Object) []interface{} {
+	list := make([]interface{}, len(objects))
+	for i, o := range objects {
+		list[i] = o
+	}
+	return list
+}
+
+func terminationNames(terminations []*output.Object) string {
+	names := make([]string, len(terminations))
+	for i, t := range terminations {
+		names[i] = terminationName(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// terminationName returns a cable termination as parent:port, e.g. nyc1-leaf1:Ethernet1 or
+// ZYO-100234:A, with the side of front and rear ports.
+func terminationName(t *output.Object) string {
+	name := objectName(t)
+	if port, ok := t.Get("name").(string); ok && port != "" {
+		name = port
+	}
+	for _, key := range []string{"device", "power_panel", "circuit"} {
+		parent, ok := t.Get(key).(*output.Object)
+		if !ok {
+			continue
+		}
+		parentName := objectName(parent)
+		if cid, ok := parent.Get("cid").(string); ok {
+			parentName = cid
+		} else if n, ok := parent.Get("name").(string); ok && n != "" {
+			parentName = n
+		}
+		if side, ok := t.Get("term_side").(string); ok && key == "circuit" {
+			name = side
+		}
+		name = parentName + ":" + name
+		break
+	}
+	u, _ := t.Get("url").(string)
+	switch {
+	case strings.Contains(u, "/front-ports/"):
+		name += " (front)"
+	case strings.Contains(u, "/rear-ports/"):
+		name += " (rear)"
+	}
+	return name
+}
+
+// cableSummary returns the label, type and length of a cable, e.g. PP-0042 cat6 3 m.
+func cableSummary(cable *output.Object) string {
+	label, _ := cable.Get("label").(string)
+	if label == "" {
+		label = fmt.Sprintf("#%v", cable.Get("id"))
+	}
+	parts := []string{label}
+	if t := choiceValue(cable.Get("type")); t != "" {
+		parts = append(parts, t)
+	}
+	if length := cable.Get("length"); length != nil {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%v %s", length, choiceValue(cable.Get("length_unit")))))
+	}
+	return strings.Join(parts, " ")
+}
+
+// choiceValue returns the value of a Netbox choice field, given as a string or as {value, label}.
+func choiceValue(v interface{}) string {
+	switch c := v.(type) {
+	case string:
+		return c
+	case *output.Object:
+		if value, ok := c.Get("value").(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func init() {
+	TraceCmd.Flags().StringVarP(&traceEnv, "env", "", "development", "Environment ('development' or 'production')")
+	_ = TraceCmd.MarkFlagRequired("env")
+	TraceCmd.Flags().StringVar(&traceDevice, "device", "", "Device of the port given by name")
+	_ = TraceCmd.RegisterFlagCompletionFunc("device", completeObjects("cmd.dcim.dcim_api_url.devices_id", "name"))
+	TraceCmd.Flags().StringVarP(&traceOutput, "output", "o", "", "Output format ("+strings.Join(output.Formats, ", ")+"), default cmd.output or text")
+	_ = TraceCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/decassidy/abc-netbox-cli/cmd/internal/netboxtest"
+)
+
+func TestTrace(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"direct", []string{"trace", "interfaces", "1", "--env", "development", "-o", "text"}},
+		{"patch_panels_by_name", []string{"trace", "interfaces", "Management1", "--device", "nyc1-leaf1", "--env", "development", "-o", "text"}},
+		{"not_connected", []string{"trace", "interfaces", "2", "--env", "development", "-o", "text"}},
+		{"rear_port_paths", []string{"trace", "rear-ports", "1", "--env", "development", "-o", "text"}},
+		{"circuit_termination_incomplete", []string{"trace", "circuit-terminations", "1", "--env", "development", "-o", "text"}},
+		{"circuit_termination_by_side", []string{"trace", "circuit-terminations", "ZYO-100234:a", "--env", "development", "-o", "text"}},
+		{"circuit_termination_by_name", []string{"trace", "circuit-terminations", "ZYO-100234", "--env", "development"}},
+		{"json", []string{"trace", "interfaces", "1", "--env", "development", "-o", "json"}},
+		{"unknown_name", []string{"trace", "interfaces", "Ethernet9", "--device", "nyc1-leaf1", "--env", "development"}},
+		{"unknown_id", []string{"trace", "interfaces", "99", "--env", "development"}},
+		{"unknown_resource", []string{"trace", "cables", "1", "--env", "development"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := netboxtest.NewServer(t, netboxtest.DefaultFixtures())
+			result := run(t, srv, netboxtest.Options{}, tt.args...)
+			netboxtest.Golden(t, "trace/"+tt.name, result.String())
+		})
+	}
+}
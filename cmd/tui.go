@@ -0,0 +1,466 @@
+/*
+Copyright © 2024 Derrick Cassidy.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
+	"github.com/decassidy/abc-netbox-cli/cmd/browse"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+)
+
+var tuiEnv string
+
+var tuiResource string
+
+// TuiCmd represents the tui command
+var TuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse Netbox objects in an interactive terminal UI",
+	Long: `
+ABC Netbox Automation Tools:
+  Browse Netbox objects in an interactive terminal UI.
+
+  Keys:
+    /          fuzzy search the loaded objects
+    f          filter with Netbox query parameters, e.g. "site=nyc1 status=active"
+    1-9        follow a related object link shown in the detail pane
+    b          go back to the previous list
+    o          open the selected object in the Netbox web UI
+    y          copy the selected object's ID to the clipboard
+    r          reload the current list
+    tab        move between the resource list, the object list and the detail pane
+    q          quit
+
+  Resources: dcim/devices, dcim/interfaces, dcim/cables, dcim/sites, dcim/racks,
+  circuits/circuits, circuits/circuit-terminations and circuits/providers. The core, vpn and
+  wireless objects cannot be browsed yet.
+
+  A list loads at most ` + fmt.Sprint(api.BrowseMaxPages*api.BrowsePageSize) + ` objects; the status line says when more match,
+  narrow the list with a filter to see them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		parts := strings.SplitN(tuiResource, "/", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("resource must be given as <domain>/<name>, e.g. dcim/devices: %s", tuiResource)
+		}
+		if _, ok := browse.Lookup(parts[0], parts[1]); !ok {
+			return fmt.Errorf("unknown resource: %s", tuiResource)
+		}
+
+		b := newBrowser(cmd.Context(), tuiEnv)
+		b.open(parts[0], parts[1], "", false)
+		defer func() {
+			if b.cancelLoad != nil {
+				b.cancelLoad()
+			}
+		}()
+		if err := b.app.Run(); err != nil {
+			return fmt.Errorf("running terminal UI: %s", err)
+		}
+		return nil
+	},
+}
+
+// browserView is one entry of the browser's navigation history.
+type browserView struct {
+	domain   string
+	resource string
+	query    string
+	selected int
+}
+
+// browser holds the widgets and state of the tui command.
+type browser struct {
+	// ctx is the context of the command; cancelLoad stops the list loading in the background.
+	ctx        context.Context
+	cancelLoad context.CancelFunc
+	env        string
+	app        *tview.Application
+	resources  *tview.List
+	search     *tview.InputField
+	filter     *tview.InputField
+	objects    *tview.List
+	detail     *tview.TextView
+	status     *tview.TextView
+
+	current  browserView
+	history  []browserView
+	loaded   []browse.Object
+	visible  []browse.Object
+	selected *browse.Object
+}
+
+func newBrowser(ctx context.Context, env string) *browser {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	b := &browser{
+		ctx:       ctx,
+		env:       env,
+		app:       tview.NewApplication(),
+		resources: tview.NewList().ShowSecondaryText(false),
+		search:    tview.NewInputField().SetLabel("Search: "),
+		filter:    tview.NewInputField().SetLabel("Filter: "),
+		objects:   tview.NewList().ShowSecondaryText(false),
+		detail:    tview.NewTextView().SetDynamicColors(true).SetWrap(true),
+		status:    tview.NewTextView().SetDynamicColors(true),
+	}
+
+	b.resources.SetBorder(true).SetTitle(" Resources ")
+	b.objects.SetBorder(true).SetTitle(" Objects ")
+	b.detail.SetBorder(true).SetTitle(" Detail ")
+
+	for _, r := range browse.Resources() {
+		r := r
+		b.resources.AddItem(r.Domain+"/"+r.Name, "", 0, func() {
+			b.open(r.Domain, r.Name, "", true)
+			b.app.SetFocus(b.objects)
+		})
+	}
+
+	b.objects.SetChangedFunc(func(index int, _ string, _ string, _ rune) {
+		b.showDetail(index)
+	})
+	b.objects.SetSelectedFunc(func(index int, _ string, _ string, _ rune) {
+		b.showDetail(index)
+		b.app.SetFocus(b.detail)
+	})
+
+	b.search.SetChangedFunc(func(text string) {
+		b.applySearch(text)
+	})
+	b.search.SetDoneFunc(func(key tcell.Key) {
+		b.app.SetFocus(b.objects)
+	})
+	b.filter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			b.open(b.current.domain, b.current.resource, filterToQuery(b.filter.GetText()), false)
+		}
+		b.app.SetFocus(b.objects)
+	})
+
+	center := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(b.search, 1, 0, false).
+		AddItem(b.filter, 1, 0, false).
+		AddItem(b.objects, 0, 1, true)
+	main := tview.NewFlex().
+		AddItem(b.resources, 30, 0, false).
+		AddItem(center, 0, 1, true).
+		AddItem(b.detail, 0, 1, false)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(main, 0, 1, true).
+		AddItem(b.status, 1, 0, false)
+
+	b.app.SetInputCapture(b.handleKey)
+	b.app.SetRoot(layout, true).SetFocus(b.objects)
+	return b
+}
+
+// handleKey implements the global key bindings. Keys typed into the search and filter
+// fields are passed through untouched.
+func (b *browser) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	focus := b.app.GetFocus()
+	if focus == b.search || focus == b.filter {
+		if event.Key() == tcell.KeyEscape {
+			b.app.SetFocus(b.objects)
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyTab:
+		switch focus {
+		case b.resources:
+			b.app.SetFocus(b.objects)
+		case b.objects:
+			b.app.SetFocus(b.detail)
+		default:
+			b.app.SetFocus(b.resources)
+		}
+		return nil
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		b.back()
+		return nil
+	case tcell.KeyRune:
+	default:
+		return event
+	}
+
+	switch r := event.Rune(); {
+	case r == 'q':
+		b.app.Stop()
+	case r == '/':
+		b.app.SetFocus(b.search)
+	case r == 'f':
+		b.app.SetFocus(b.filter)
+	case r == 'b':
+		b.back()
+	case r == 'r':
+		b.open(b.current.domain, b.current.resource, b.current.query, false)
+	case r == 'o':
+		b.openWebUrl()
+	case r == 'y':
+		b.copyId()
+	case r >= '1' && r <= '9':
+		b.follow(int(r - '1'))
+	default:
+		return event
+	}
+	return nil
+}
+
+// open loads a resource list. When push is true the current view is remembered for 'b'.
+func (b *browser) open(domain, name, query string, push bool) {
+	resource, ok := browse.Lookup(domain, name)
+	if !ok {
+		b.setStatus("[red]No browser support for %s/%s yet", domain, name)
+		return
+	}
+	if push && b.current.resource != "" {
+		b.current.selected = b.objects.GetCurrentItem()
+		b.history = append(b.history, b.current)
+	}
+	b.current = browserView{domain: domain, resource: name, query: query}
+	b.load(resource, 0)
+}
+
+// load fetches the current view in the background and fills the object list when done.
+func (b *browser) load(resource browse.Resource, selected int) {
+	view := b.current
+	b.objects.SetTitle(fmt.Sprintf(" %s/%s ", view.domain, view.resource))
+	b.filter.SetText(queryToFilter(view.query))
+	b.setStatus("[yellow]Loading %s/%s %s ...", view.domain, view.resource, view.query)
+
+	// A list still loading for the previous view is of no use any more.
+	if b.cancelLoad != nil {
+		b.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(b.ctx)
+	b.cancelLoad = cancel
+
+	go func() {
+		list, err := resource.Fetch(ctx, b.env, view.query)
+		b.app.QueueUpdateDraw(func() {
+			if b.current != view || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				b.setStatus("[red]Error loading %s/%s: %s", view.domain, view.resource, err)
+				return
+			}
+			b.loaded = list.Objects
+			b.applySearch(b.search.GetText())
+			if selected < b.objects.GetItemCount() {
+				b.objects.SetCurrentItem(selected)
+			}
+			count := fmt.Sprintf("%d", len(list.Objects))
+			if list.Truncated() {
+				count = fmt.Sprintf("[yellow]first %d of %d, filter to see the rest,[green]", len(list.Objects), list.Count)
+			}
+			b.setStatus("[green]%s %s/%s objects[white]  / search  f filter  1-9 related  b back  o open  y copy ID  q quit",
+				count, view.domain, view.resource)
+		})
+	}()
+}
+
+// applySearch narrows the loaded objects to those fuzzy matching text, best matches first.
+func (b *browser) applySearch(text string) {
+	type scored struct {
+		object browse.Object
+		score  int
+	}
+	var matches []scored
+	for _, o := range b.loaded {
+		if ok, score := browse.FuzzyMatch(text, o.Display); ok {
+			matches = append(matches, scored{o, score})
+		}
+	}
+	if text != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+
+	b.visible = b.visible[:0]
+	b.objects.Clear()
+	for _, m := range matches {
+		b.visible = append(b.visible, m.object)
+		b.objects.AddItem(fmt.Sprintf("%-8d %s", m.object.Id, m.object.Display), "", 0, nil)
+	}
+	b.showDetail(b.objects.GetCurrentItem())
+}
+
+func (b *browser) showDetail(index int) {
+	b.detail.Clear()
+	if index < 0 || index >= len(b.visible) {
+		b.selected = nil
+		return
+	}
+	o := b.visible[index]
+	b.selected = &o
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow::b]%s[-:-:-]\n\n", tview.Escape(o.Display))
+	fmt.Fprintf(&sb, "[aqua]ID:[-] %d\n", o.Id)
+	fmt.Fprintf(&sb, "[aqua]URL:[-] %s\n", tview.Escape(o.WebUrl()))
+	for _, f := range o.Fields {
+		if f.Value == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "[aqua]%s:[-] %s\n", f.Label, tview.Escape(f.Value))
+	}
+	if len(o.Related) > 0 {
+		sb.WriteString("\n[yellow]Related[-]\n")
+		for i, link := range o.Related {
+			if i >= 9 {
+				break
+			}
+			fmt.Fprintf(&sb, "  [green][%d][-] %s\n", i+1, tview.Escape(link.Label))
+		}
+	}
+	b.detail.SetText(sb.String()).ScrollToBeginning()
+}
+
+func (b *browser) follow(n int) {
+	if b.selected == nil || n >= len(b.selected.Related) {
+		return
+	}
+	link := b.selected.Related[n]
+	if link.Resource == "" {
+		b.setStatus("[red]%s cannot be browsed", link.Label)
+		return
+	}
+	b.search.SetText("")
+	b.open(link.Domain, link.Resource, link.Query, true)
+	b.app.SetFocus(b.objects)
+}
+
+func (b *browser) back() {
+	if len(b.history) == 0 {
+		return
+	}
+	prev := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+	resource, ok := browse.Lookup(prev.domain, prev.resource)
+	if !ok {
+		return
+	}
+	b.search.SetText("")
+	b.current = browserView{domain: prev.domain, resource: prev.resource, query: prev.query}
+	b.load(resource, prev.selected)
+}
+
+func (b *browser) openWebUrl() {
+	if b.selected == nil {
+		return
+	}
+	link := b.selected.WebUrl()
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", link)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		c = exec.Command("xdg-open", link)
+	}
+	if err := c.Start(); err != nil {
+		b.setStatus("[red]Could not open %s: %s", link, err)
+		return
+	}
+	b.setStatus("[green]Opened %s", link)
+}
+
+func (b *browser) copyId() {
+	if b.selected == nil {
+		return
+	}
+	if err := clipboard.WriteAll(fmt.Sprintf("%d", b.selected.Id)); err != nil {
+		b.setStatus("[red]Could not copy ID: %s", err)
+		return
+	}
+	b.setStatus("[green]Copied ID %d to the clipboard", b.selected.Id)
+}
+
+func (b *browser) setStatus(format string, args ...interface{}) {
+	b.status.SetText(fmt.Sprintf(format, args...))
+}
+
+// filterToQuery turns the space separated key=value pairs typed into the filter field into a query string.
+func filterToQuery(filter string) string {
+	values := url.Values{}
+	for _, pair := range strings.Fields(filter) {
+		key, value, _ := strings.Cut(pair, "=")
+		values.Add(key, value)
+	}
+	return values.Encode()
+}
+
+// queryToFilter is the inverse of filterToQuery, used to show the active filter.
+func queryToFilter(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	var pairs []string
+	for key, list := range values {
+		for _, value := range list {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func init() {
+
+	// Here you will define your flags and configuration settings.
+	TuiCmd.Annotations = map[string]string{ownSignalsAnnotation: ""}
+	TuiCmd.Flags().StringVarP(&tuiEnv, "env", "", "development", "Environment ('development' or 'production')")
+	err := TuiCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for TuiCmd", err)
+	}
+
+	TuiCmd.Flags().StringVarP(&tuiResource, "resource", "r", "dcim/devices", "Resource to open first, as <domain>/<name>")
+	err = TuiCmd.RegisterFlagCompletionFunc("resource", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, r := range browse.Resources() {
+			names = append(names, r.Domain+"/"+r.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Fatalf("Error registering resource flag completion: %s - for TuiCmd", err)
+	}
+}
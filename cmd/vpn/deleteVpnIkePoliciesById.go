@@ -23,29 +23,38 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteVpnIkePoliciesByIdCmd represents the deleteVpnIkePoliciesById command
 var DeleteVpnIkePoliciesByIdCmd = &cobra.Command{
 	Use:   "deleteVpnIkePoliciesById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteVpnIkePoliciesById called")
+	Short: "DELETE an IKE policy object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE an IKE policy object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.vpn.vpn_api_url.ike_policies"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteVpnIkePoliciesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteVpnIkePoliciesByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteVpnIkePoliciesByIdCmd", err)
+	}
+
+	DeleteVpnIkePoliciesByIdCmd.Flags().IntP("id", "", 0, "ID of the IKE policy object to be deleted")
+	api.AddLookupFlags(DeleteVpnIkePoliciesByIdCmd, "cmd.vpn.vpn_api_url.ike_policies")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,38 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteVpnL2vpnTerminationsByIdCmd represents the deleteVpnL2vpnTerminationsById command
 var DeleteVpnL2vpnTerminationsByIdCmd = &cobra.Command{
 	Use:   "deleteVpnL2vpnTerminationsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteVpnL2vpnTerminationsById called")
+	Short: "DELETE an L2VPN termination object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE an L2VPN termination object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.vpn.vpn_api_url.l2vpn_terminations"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteVpnL2vpnTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteVpnL2vpnTerminationsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteVpnL2vpnTerminationsByIdCmd", err)
+	}
+
+	DeleteVpnL2vpnTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the L2VPN termination object to be deleted")
+	api.AddLookupFlags(DeleteVpnL2vpnTerminationsByIdCmd, "cmd.vpn.vpn_api_url.l2vpn_terminations")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
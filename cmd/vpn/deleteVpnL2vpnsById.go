@@ -23,29 +23,38 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteVpnL2vpnsByIdCmd represents the deleteVpnL2vpnsById command
 var DeleteVpnL2vpnsByIdCmd = &cobra.Command{
 	Use:   "deleteVpnL2vpnsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteVpnL2vpnsById called")
+	Short: "DELETE an L2VPN object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE an L2VPN object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.vpn.vpn_api_url.l2vpns"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteVpnL2vpnsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteVpnL2vpnsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteVpnL2vpnsByIdCmd", err)
+	}
+
+	DeleteVpnL2vpnsByIdCmd.Flags().IntP("id", "", 0, "ID of the L2VPN object to be deleted")
+	api.AddLookupFlags(DeleteVpnL2vpnsByIdCmd, "cmd.vpn.vpn_api_url.l2vpns")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
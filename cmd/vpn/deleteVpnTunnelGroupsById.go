@@ -23,29 +23,38 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteVpnTunnelGroupsByIdCmd represents the deleteVpnTunnelGroupsById command
 var DeleteVpnTunnelGroupsByIdCmd = &cobra.Command{
 	Use:   "deleteVpnTunnelGroupsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteVpnTunnelGroupsById called")
+	Short: "DELETE a tunnel group object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE a tunnel group object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.vpn.vpn_api_url.tunnel-groups"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteVpnTunnelGroupsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteVpnTunnelGroupsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteVpnTunnelGroupsByIdCmd", err)
+	}
+
+	DeleteVpnTunnelGroupsByIdCmd.Flags().IntP("id", "", 0, "ID of the tunnel group object to be deleted")
+	api.AddLookupFlags(DeleteVpnTunnelGroupsByIdCmd, "cmd.vpn.vpn_api_url.tunnel-groups")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnIkePoliciesByIdCmd represents the patchVpnIkePoliciesById command
 var PatchVpnIkePoliciesByIdCmd = &cobra.Command{
 	Use:   "patchVpnIkePoliciesById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnIkePoliciesById called")
+	Short: "PATCH an IKE policy object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an IKE policy object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.ike_policies"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnIkePoliciesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnIkePoliciesByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnIkePoliciesByIdCmd", err)
+	}
+
+	PatchVpnIkePoliciesByIdCmd.Flags().IntP("id", "", 0, "ID of the IKE policy object to patch")
+	api.AddLookupFlags(PatchVpnIkePoliciesByIdCmd, "cmd.vpn.vpn_api_url.ike_policies")
+
+	PatchVpnIkePoliciesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnIkePoliciesByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnIkePoliciesByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnIkePoliciesByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnIkePoliciesByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
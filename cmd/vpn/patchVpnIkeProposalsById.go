@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnIkeProposalsByIdCmd represents the patchVpnIkeProposalsById command
 var PatchVpnIkeProposalsByIdCmd = &cobra.Command{
 	Use:   "patchVpnIkeProposalsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnIkeProposalsById called")
+	Short: "PATCH an IKE proposal object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an IKE proposal object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.ike_proposals"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnIkeProposalsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnIkeProposalsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnIkeProposalsByIdCmd", err)
+	}
+
+	PatchVpnIkeProposalsByIdCmd.Flags().IntP("id", "", 0, "ID of the IKE proposal object to patch")
+	api.AddLookupFlags(PatchVpnIkeProposalsByIdCmd, "cmd.vpn.vpn_api_url.ike_proposals")
+
+	PatchVpnIkeProposalsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnIkeProposalsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnIkeProposalsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnIkeProposalsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnIkeProposalsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnIpsecPoliciesByIdCmd represents the patchVpnIpsecPoliciesById command
 var PatchVpnIpsecPoliciesByIdCmd = &cobra.Command{
 	Use:   "patchVpnIpsecPoliciesById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnIpsecPoliciesById called")
+	Short: "PATCH an IPSec policy object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an IPSec policy object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.ipsec_policies"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnIpsecPoliciesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnIpsecPoliciesByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnIpsecPoliciesByIdCmd", err)
+	}
+
+	PatchVpnIpsecPoliciesByIdCmd.Flags().IntP("id", "", 0, "ID of the IPSec policy object to patch")
+	api.AddLookupFlags(PatchVpnIpsecPoliciesByIdCmd, "cmd.vpn.vpn_api_url.ipsec_policies")
+
+	PatchVpnIpsecPoliciesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnIpsecPoliciesByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnIpsecPoliciesByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnIpsecPoliciesByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnIpsecPoliciesByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
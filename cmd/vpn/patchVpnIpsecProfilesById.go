@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnIpsecProfilesByIdCmd represents the patchVpnIpsecProfilesById command
 var PatchVpnIpsecProfilesByIdCmd = &cobra.Command{
 	Use:   "patchVpnIpsecProfilesById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnIpsecProfilesById called")
+	Short: "PATCH an IPSec profile object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an IPSec profile object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.ipsec_profiles"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnIpsecProfilesByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnIpsecProfilesByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnIpsecProfilesByIdCmd", err)
+	}
+
+	PatchVpnIpsecProfilesByIdCmd.Flags().IntP("id", "", 0, "ID of the IPSec profile object to patch")
+	api.AddLookupFlags(PatchVpnIpsecProfilesByIdCmd, "cmd.vpn.vpn_api_url.ipsec_profiles")
+
+	PatchVpnIpsecProfilesByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnIpsecProfilesByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnIpsecProfilesByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnIpsecProfilesByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnIpsecProfilesByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
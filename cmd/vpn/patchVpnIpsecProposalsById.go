@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnIpsecProposalsByIdCmd represents the patchVpnIpsecProposalsById command
 var PatchVpnIpsecProposalsByIdCmd = &cobra.Command{
 	Use:   "patchVpnIpsecProposalsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnIpsecProposalsById called")
+	Short: "PATCH an IPSec proposal object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an IPSec proposal object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.ipsec_proposals"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnIpsecProposalsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnIpsecProposalsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnIpsecProposalsByIdCmd", err)
+	}
+
+	PatchVpnIpsecProposalsByIdCmd.Flags().IntP("id", "", 0, "ID of the IPSec proposal object to patch")
+	api.AddLookupFlags(PatchVpnIpsecProposalsByIdCmd, "cmd.vpn.vpn_api_url.ipsec_proposals")
+
+	PatchVpnIpsecProposalsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnIpsecProposalsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnIpsecProposalsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnIpsecProposalsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnIpsecProposalsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
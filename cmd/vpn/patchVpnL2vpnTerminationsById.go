@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnL2vpnTerminationsByIdCmd represents the patchVpnL2vpnTerminationsById command
 var PatchVpnL2vpnTerminationsByIdCmd = &cobra.Command{
 	Use:   "patchVpnL2vpnTerminationsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnL2vpnTerminationsById called")
+	Short: "PATCH an L2VPN termination object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an L2VPN termination object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.l2vpn_terminations"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnL2vpnTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnL2vpnTerminationsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnL2vpnTerminationsByIdCmd", err)
+	}
+
+	PatchVpnL2vpnTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the L2VPN termination object to patch")
+	api.AddLookupFlags(PatchVpnL2vpnTerminationsByIdCmd, "cmd.vpn.vpn_api_url.l2vpn_terminations")
+
+	PatchVpnL2vpnTerminationsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnL2vpnTerminationsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnL2vpnTerminationsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnL2vpnTerminationsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnL2vpnTerminationsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
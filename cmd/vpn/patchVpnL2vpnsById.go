@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnL2vpnsByIdCmd represents the patchVpnL2vpnsById command
 var PatchVpnL2vpnsByIdCmd = &cobra.Command{
 	Use:   "patchVpnL2vpnsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnL2vpnsById called")
+	Short: "PATCH an L2VPN object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH an L2VPN object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.l2vpns"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnL2vpnsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnL2vpnsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnL2vpnsByIdCmd", err)
+	}
+
+	PatchVpnL2vpnsByIdCmd.Flags().IntP("id", "", 0, "ID of the L2VPN object to patch")
+	api.AddLookupFlags(PatchVpnL2vpnsByIdCmd, "cmd.vpn.vpn_api_url.l2vpns")
+
+	PatchVpnL2vpnsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnL2vpnsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnL2vpnsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnL2vpnsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnL2vpnsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnTunnelGroupsByIdCmd represents the patchVpnTunnelGroupsById command
 var PatchVpnTunnelGroupsByIdCmd = &cobra.Command{
 	Use:   "patchVpnTunnelGroupsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnTunnelGroupsById called")
+	Short: "PATCH a tunnel group object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH a tunnel group object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.tunnel-groups"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnTunnelGroupsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnTunnelGroupsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnTunnelGroupsByIdCmd", err)
+	}
+
+	PatchVpnTunnelGroupsByIdCmd.Flags().IntP("id", "", 0, "ID of the tunnel group object to patch")
+	api.AddLookupFlags(PatchVpnTunnelGroupsByIdCmd, "cmd.vpn.vpn_api_url.tunnel-groups")
+
+	PatchVpnTunnelGroupsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnTunnelGroupsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnTunnelGroupsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnTunnelGroupsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnTunnelGroupsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnTunnelTerminationsByIdCmd represents the patchVpnTunnelTerminationsById command
 var PatchVpnTunnelTerminationsByIdCmd = &cobra.Command{
 	Use:   "patchVpnTunnelTerminationsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnTunnelTerminationsById called")
+	Short: "PATCH a tunnel termination object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH a tunnel termination object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.tunnel-terminations"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnTunnelTerminationsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnTunnelTerminationsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnTunnelTerminationsByIdCmd", err)
+	}
+
+	PatchVpnTunnelTerminationsByIdCmd.Flags().IntP("id", "", 0, "ID of the tunnel termination object to patch")
+	api.AddLookupFlags(PatchVpnTunnelTerminationsByIdCmd, "cmd.vpn.vpn_api_url.tunnel-terminations")
+
+	PatchVpnTunnelTerminationsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnTunnelTerminationsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnTunnelTerminationsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnTunnelTerminationsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnTunnelTerminationsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
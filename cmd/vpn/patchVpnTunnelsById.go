@@ -23,29 +23,47 @@ THE SOFTWARE.
 package vpn
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchVpnTunnelsByIdCmd represents the patchVpnTunnelsById command
 var PatchVpnTunnelsByIdCmd = &cobra.Command{
 	Use:   "patchVpnTunnelsById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchVpnTunnelsById called")
+	Short: "PATCH a tunnel object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH a tunnel object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.vpn.vpn_api_url.tunnels"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchVpnTunnelsByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchVpnTunnelsByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchVpnTunnelsByIdCmd", err)
+	}
+
+	PatchVpnTunnelsByIdCmd.Flags().IntP("id", "", 0, "ID of the tunnel object to patch")
+	api.AddLookupFlags(PatchVpnTunnelsByIdCmd, "cmd.vpn.vpn_api_url.tunnels")
+
+	PatchVpnTunnelsByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchVpnTunnelsByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchVpnTunnelsByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchVpnTunnelsByIdCmd)
+	api.AddCustomFieldFlag(PatchVpnTunnelsByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,38 @@ THE SOFTWARE.
 package wireless
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // DeleteWirelessWirelessLinksByIdCmd represents the deleteWirelessWirelessLinksById command
 var DeleteWirelessWirelessLinksByIdCmd = &cobra.Command{
 	Use:   "deleteWirelessWirelessLinksById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("deleteWirelessWirelessLinksById called")
+	Short: "DELETE a wireless link object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  DELETE a wireless link object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionDeleteID(cmd, "cmd.wireless.wireless_api_url.wireless_links"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	DeleteWirelessWirelessLinksByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := DeleteWirelessWirelessLinksByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for DeleteWirelessWirelessLinksByIdCmd", err)
+	}
+
+	DeleteWirelessWirelessLinksByIdCmd.Flags().IntP("id", "", 0, "ID of the wireless link object to be deleted")
+	api.AddLookupFlags(DeleteWirelessWirelessLinksByIdCmd, "cmd.wireless.wireless_api_url.wireless_links")
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
@@ -23,29 +23,47 @@ THE SOFTWARE.
 package wireless
 
 import (
-	"fmt"
+	"log"
 
+	"github.com/decassidy/abc-netbox-cli/cmd/api"
 	"github.com/spf13/cobra"
 )
 
 // PatchWirelessWirelessLinksByIdCmd represents the patchWirelessWirelessLinksById command
 var PatchWirelessWirelessLinksByIdCmd = &cobra.Command{
 	Use:   "patchWirelessWirelessLinksById",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("patchWirelessWirelessLinksById called")
+	Short: "PATCH a wireless link object by ID",
+	Long: `
+ABC Netbox Automation Tools:
+  PATCH a wireless link object by ID`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := apiConnectionPatchID(cmd, "cmd.wireless.wireless_api_url.wireless_links"); err != nil {
+			return err
+		}
+		return nil
 	},
 }
 
 func init() {
 
 	// Here you will define your flags and configuration settings.
+	PatchWirelessWirelessLinksByIdCmd.Flags().StringP("env", "", "development", "Environment ('development' or 'production')")
+	err := PatchWirelessWirelessLinksByIdCmd.MarkFlagRequired("env")
+	if err != nil {
+		log.Fatalf("Error marking env flag as required: %s - for PatchWirelessWirelessLinksByIdCmd", err)
+	}
+
+	PatchWirelessWirelessLinksByIdCmd.Flags().IntP("id", "", 0, "ID of the wireless link object to patch")
+	api.AddLookupFlags(PatchWirelessWirelessLinksByIdCmd, "cmd.wireless.wireless_api_url.wireless_links")
+
+	PatchWirelessWirelessLinksByIdCmd.Flags().StringP("data", "", "", "JSON data to be patched (required)")
+	err = PatchWirelessWirelessLinksByIdCmd.MarkFlagRequired("data")
+	if err != nil {
+		log.Fatalf("Error marking data flag as required: %s - for PatchWirelessWirelessLinksByIdCmd", err)
+	}
+
+	api.AddDryRunFlag(PatchWirelessWirelessLinksByIdCmd)
+	api.AddCustomFieldFlag(PatchWirelessWirelessLinksByIdCmd)
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.: